@@ -239,40 +239,31 @@ func (c *Client) SupportsDevcontainers() bool {
 	return true
 }
 
-// GenerateConfig generates Claude Code MCP configuration from manifests
-func (c *Client) GenerateConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) error {
+// buildConfig builds the Claude Code MCP configuration for manifests. mcpServers is
+// written even when empty, which pkg.MCPConfig would omit.
+func (c *Client) buildConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) map[string]interface{} {
 	// Build MCP servers configuration
 	servers := make(map[string]pkg.MCPServerConfig)
 
 	for _, manifest := range manifests {
-		if manifest.Server.Command != "" {
-			serverConfig := pkg.MCPServerConfig{
-				Command: manifest.Server.Command,
-				Args:    make([]string, len(manifest.Server.Args)),
-			}
-
-			// Copy and expand args
-			for i, arg := range manifest.Server.Args {
-				serverConfig.Args[i] = client.ExpandSecretsInString(arg, secretsProvider)
-			}
-
-			// Build environment with secret expansion
-			if len(manifest.Server.Environment) > 0 {
-				serverConfig.Environment = make(map[string]string)
-				for key, value := range manifest.Server.Environment {
-					expandedValue := client.ExpandSecretsInString(value, secretsProvider)
-					serverConfig.Environment[key] = expandedValue
-				}
-			}
-
+		if serverConfig, ok := client.BuildMCPServerConfig(manifest, secretsProvider); ok {
 			servers[manifest.Name] = serverConfig
 		}
 	}
 
-	// Create MCP configuration
-	mcpConfig := &pkg.MCPConfig{
-		Servers: servers,
+	return map[string]interface{}{
+		"mcpServers": servers,
 	}
+}
+
+// RenderConfig returns the .mcp.json content GenerateConfig would write
+func (c *Client) RenderConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) ([]byte, error) {
+	return client.RenderJSON(c.buildConfig(manifests, secretsProvider))
+}
+
+// GenerateConfig generates Claude Code MCP configuration from manifests
+func (c *Client) GenerateConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) error {
+	mcpConfig := c.buildConfig(manifests, secretsProvider)
 
 	// Write to .mcp.json (Claude Code format)
 	configPath, err := client.ExpandPath(".mcp.json")
@@ -280,6 +271,21 @@ func (c *Client) GenerateConfig(manifests []pkg.ServoDefinition, secretsProvider
 		return fmt.Errorf("failed to get Claude Code config path: %w", err)
 	}
 
-	return client.WriteJSONFile(configPath, mcpConfig)
+	return client.WriteGeneratedConfig(configPath, mcpConfig, "mcpServers")
+}
+
+
+// GlobalConfigPath returns ~/.claude.json, where Claude Code keeps user-scoped servers
+func (c *Client) GlobalConfigPath() (string, error) {
+	return client.ExpandPath("~/.claude.json")
 }
 
+// MergeGlobalConfig merges servers into the user-scoped mcpServers of ~/.claude.json.
+// The file also holds Claude Code's own settings, which are kept untouched.
+func (c *Client) MergeGlobalConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) error {
+	configPath, err := c.GlobalConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to get Claude Code global config path: %w", err)
+	}
+	return client.MergeServersIntoFile(configPath, "mcpServers", client.BuildServers(manifests, secretsProvider))
+}
@@ -343,6 +343,67 @@ func TestClient_GenerateConfig(t *testing.T) {
 	}
 }
 
+func TestClient_GenerateConfig_RemoteTransport(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(tmpDir)
+
+	client := New()
+
+	manifests := []pkg.ServoDefinition{
+		{
+			Name: "remote-server",
+			Server: pkg.Server{
+				Transport: "sse",
+				URL:       "https://mcp.example.com/sse",
+				Headers: map[string]string{
+					"Authorization": "Bearer ${token}",
+				},
+			},
+		},
+	}
+
+	secretsProvider := func(key string) (string, error) {
+		return "", nil
+	}
+
+	if err := client.GenerateConfig(manifests, secretsProvider); err != nil {
+		t.Fatalf("GenerateConfig failed: %v", err)
+	}
+
+	configData, err := os.ReadFile(".mcp.json")
+	if err != nil {
+		t.Fatalf("Failed to read generated config: %v", err)
+	}
+
+	var config map[string]map[string]map[string]interface{}
+	if err := json.Unmarshal(configData, &config); err != nil {
+		t.Fatalf("Failed to parse generated config: %v", err)
+	}
+
+	remote, exists := config["mcpServers"]["remote-server"]
+	if !exists {
+		t.Fatalf("remote-server not found in generated config")
+	}
+
+	if remote["url"] != "https://mcp.example.com/sse" {
+		t.Errorf("Expected url entry, got '%v'", remote["url"])
+	}
+	if remote["type"] != "sse" {
+		t.Errorf("Expected type 'sse', got '%v'", remote["type"])
+	}
+	if _, hasCommand := remote["command"]; hasCommand {
+		t.Errorf("Remote server should not have a command entry, got '%v'", remote["command"])
+	}
+
+	headers, ok := remote["headers"].(map[string]interface{})
+	if !ok || headers["Authorization"] != "Bearer ${token}" {
+		t.Errorf("Expected unresolved header placeholder to be preserved, got %v", remote["headers"])
+	}
+}
+
 func TestClient_GenerateConfig_EmptyManifests(t *testing.T) {
 	tmpDir := t.TempDir()
 	
@@ -378,9 +439,9 @@ func TestClient_GenerateConfig_EmptyManifests(t *testing.T) {
 		t.Fatalf("Failed to parse generated config: %v", err)
 	}
 
-	// The mcpServers field might be omitted when empty due to omitempty tag
+	// mcpServers is written even when there are no servers
 	servers, exists := config["mcpServers"].(map[string]interface{})
-	if exists && len(servers) != 0 {
-		t.Errorf("Expected empty servers map or no mcpServers field, got %d servers", len(servers))
+	if !exists || len(servers) != 0 {
+		t.Errorf("Expected an empty mcpServers object, got %v", config)
 	}
 }
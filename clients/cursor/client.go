@@ -226,42 +226,31 @@ func (c *Client) SupportsDevcontainers() bool {
 	return true
 }
 
-// GenerateConfig generates Cursor MCP configuration from manifests
-func (c *Client) GenerateConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) error {
+// buildConfig builds the Cursor MCP configuration for manifests
+func (c *Client) buildConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) map[string]interface{} {
 	// Build MCP servers configuration in Cursor format
-	cursorConfig := map[string]interface{}{
-		"mcpServers": make(map[string]interface{}),
-	}
-
-	mcpServers := cursorConfig["mcpServers"].(map[string]interface{})
+	servers := make(map[string]pkg.MCPServerConfig)
 
 	for _, manifest := range manifests {
-		if manifest.Server.Command != "" {
-			serverData := map[string]interface{}{
-				"command": manifest.Server.Command,
-			}
-
-			if len(manifest.Server.Args) > 0 {
-				args := make([]interface{}, len(manifest.Server.Args))
-				for i, arg := range manifest.Server.Args {
-					args[i] = client.ExpandSecretsInString(arg, secretsProvider)
-				}
-				serverData["args"] = args
-			}
-
-			if len(manifest.Server.Environment) > 0 {
-				env := make(map[string]interface{})
-				for key, value := range manifest.Server.Environment {
-					expandedValue := client.ExpandSecretsInString(value, secretsProvider)
-					env[key] = expandedValue
-				}
-				serverData["env"] = env
-			}
-
-			mcpServers[manifest.Name] = serverData
+		if serverConfig, ok := client.BuildMCPServerConfig(manifest, secretsProvider); ok {
+			servers[manifest.Name] = serverConfig
 		}
 	}
 
+	return map[string]interface{}{
+		"mcpServers": servers,
+	}
+}
+
+// RenderConfig returns the .cursor/mcp.json content GenerateConfig would write
+func (c *Client) RenderConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) ([]byte, error) {
+	return client.RenderJSON(c.buildConfig(manifests, secretsProvider))
+}
+
+// GenerateConfig generates Cursor MCP configuration from manifests
+func (c *Client) GenerateConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) error {
+	cursorConfig := c.buildConfig(manifests, secretsProvider)
+
 	// Write to .cursor/mcp.json
 	configPath, err := c.getLocalConfigPath()
 	if err != nil {
@@ -273,6 +262,21 @@ func (c *Client) GenerateConfig(manifests []pkg.ServoDefinition, secretsProvider
 		return fmt.Errorf("failed to create .cursor directory: %w", err)
 	}
 
-	return client.WriteJSONFile(configPath, cursorConfig)
+	return client.WriteGeneratedConfig(configPath, cursorConfig, "mcpServers")
 }
 
+
+// GlobalConfigPath returns Cursor's user-level ~/.cursor/mcp.json
+func (c *Client) GlobalConfigPath() (string, error) {
+	return client.ExpandPath("~/.cursor/mcp.json")
+}
+
+// MergeGlobalConfig merges servers into ~/.cursor/mcp.json, keeping the servers
+// configured there by hand
+func (c *Client) MergeGlobalConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) error {
+	configPath, err := c.GlobalConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to get Cursor global config path: %w", err)
+	}
+	return client.MergeServersIntoFile(configPath, "mcpServers", client.BuildServers(manifests, secretsProvider))
+}
@@ -398,3 +398,57 @@ func TestClient_GenerateConfig(t *testing.T) {
 		t.Errorf("Expected DEBUG to be 'true', got '%v'", env["DEBUG"])
 	}
 }
+
+func TestClient_GenerateConfig_RemoteTransport(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(tmpDir)
+
+	client := New()
+
+	manifests := []pkg.ServoDefinition{
+		{
+			Name: "remote-server",
+			Server: pkg.Server{
+				Transport: "sse",
+				URL:       "https://mcp.example.com/sse",
+				Headers: map[string]string{
+					"Authorization": "Bearer ${token}",
+				},
+			},
+		},
+	}
+
+	if err := client.GenerateConfig(manifests, func(string) (string, error) { return "", nil }); err != nil {
+		t.Fatalf("GenerateConfig failed: %v", err)
+	}
+
+	configData, err := os.ReadFile(".cursor/mcp.json")
+	if err != nil {
+		t.Fatalf("Failed to read generated config: %v", err)
+	}
+
+	var config map[string]map[string]map[string]interface{}
+	if err := json.Unmarshal(configData, &config); err != nil {
+		t.Fatalf("Failed to parse generated config: %v", err)
+	}
+
+	remote, exists := config["mcpServers"]["remote-server"]
+	if !exists {
+		t.Fatalf("remote-server not found in generated config")
+	}
+
+	if remote["url"] != "https://mcp.example.com/sse" {
+		t.Errorf("Expected url entry, got '%v'", remote["url"])
+	}
+	if _, hasCommand := remote["command"]; hasCommand {
+		t.Errorf("Remote server should not have a command entry, got '%v'", remote["command"])
+	}
+
+	headers, ok := remote["headers"].(map[string]interface{})
+	if !ok || headers["Authorization"] != "Bearer ${token}" {
+		t.Errorf("Expected unresolved header placeholder to be preserved, got %v", remote["headers"])
+	}
+}
@@ -2,6 +2,7 @@ package vscode
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -273,42 +274,55 @@ func (c *Client) SupportsDevcontainers() bool {
 	return true
 }
 
-// GenerateConfig generates VSCode MCP configuration from manifests
-func (c *Client) GenerateConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) error {
+// buildConfig builds the VSCode MCP configuration for manifests
+func (c *Client) buildConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) map[string]interface{} {
 	// Build MCP servers configuration
 	servers := make(map[string]pkg.MCPServerConfig)
 
 	for _, manifest := range manifests {
-		if manifest.Server.Command != "" {
-			serverConfig := pkg.MCPServerConfig{
-				Command: manifest.Server.Command,
-				Args:    make([]string, len(manifest.Server.Args)),
-			}
-
-			// Copy and expand args
-			for i, arg := range manifest.Server.Args {
-				serverConfig.Args[i] = client.ExpandSecretsInString(arg, secretsProvider)
-			}
-
-			// Build environment with secret expansion
-			if len(manifest.Server.Environment) > 0 {
-				serverConfig.Environment = make(map[string]string)
-				for key, value := range manifest.Server.Environment {
-					expandedValue := client.ExpandSecretsInString(value, secretsProvider)
-					serverConfig.Environment[key] = expandedValue
-				}
-			}
-
+		if serverConfig, ok := client.BuildMCPServerConfig(manifest, secretsProvider); ok {
 			servers[manifest.Name] = serverConfig
 		}
 	}
 
 	// Create VSCode MCP configuration using correct format
-	vscodeConfig := map[string]interface{}{
+	return map[string]interface{}{
 		"servers": servers,
 	}
+}
+
+// RenderConfig returns the .vscode/mcp.json content GenerateConfig would write
+func (c *Client) RenderConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) ([]byte, error) {
+	return client.RenderJSON(c.buildConfig(manifests, secretsProvider))
+}
+
+// GenerateConfig generates VSCode MCP configuration from manifests
+func (c *Client) GenerateConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) error {
+	return c.writeConfig(c.buildConfig(manifests, secretsProvider))
+}
+
+// GenerateConfigWithEnvFile generates .vscode/mcp.json with secret-referencing
+// environment variables moved to .vscode/mcp.env, which each affected server loads
+// through envFile
+func (c *Client) GenerateConfigWithEnvFile(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) error {
+	configPath, err := c.getLocalConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to get VSCode config path: %w", err)
+	}
+	envPath := filepath.Join(filepath.Dir(configPath), "mcp.env")
 
-	// Write to .vscode/mcp.json
+	vscodeConfig := c.buildConfig(manifests, secretsProvider)
+	servers := vscodeConfig["servers"].(map[string]pkg.MCPServerConfig)
+	refs := client.MoveSecretEnvToFile(servers, manifests, client.WorkspaceFileRef(envPath))
+
+	if err := client.WriteEnvFile(envPath, refs); err != nil {
+		return fmt.Errorf("failed to write VSCode env file: %w", err)
+	}
+	return c.writeConfig(vscodeConfig)
+}
+
+// writeConfig writes the VSCode MCP configuration to .vscode/mcp.json
+func (c *Client) writeConfig(vscodeConfig map[string]interface{}) error {
 	configPath, err := c.getLocalConfigPath()
 	if err != nil {
 		return fmt.Errorf("failed to get VSCode config path: %w", err)
@@ -319,6 +333,24 @@ func (c *Client) GenerateConfig(manifests []pkg.ServoDefinition, secretsProvider
 		return fmt.Errorf("failed to create .vscode directory: %w", err)
 	}
 
-	return client.WriteJSONFile(configPath, vscodeConfig)
+	return client.WriteGeneratedConfig(configPath, vscodeConfig, "servers")
 }
 
+// GlobalConfigPath returns the mcp.json of the VS Code user profile
+func (c *Client) GlobalConfigPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user config directory: %w", err)
+	}
+	return filepath.Join(configDir, "Code", "User", "mcp.json"), nil
+}
+
+// MergeGlobalConfig merges servers into the user profile mcp.json, keeping the servers
+// and inputs configured there by hand
+func (c *Client) MergeGlobalConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) error {
+	configPath, err := c.GlobalConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to get VS Code global config path: %w", err)
+	}
+	return client.MergeServersIntoFile(configPath, "servers", client.BuildServers(manifests, secretsProvider))
+}
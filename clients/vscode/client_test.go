@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/servo/servo/internal/utils"
@@ -521,3 +522,130 @@ func TestClient_GenerateConfig_NoCommand(t *testing.T) {
 		t.Error("valid-server should have been included")
 	}
 }
+
+func TestClient_GenerateConfigWithEnvFile(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	manifests := []pkg.ServoDefinition{
+		{
+			Name: "search",
+			Server: pkg.Server{
+				Command: "search-mcp",
+				Environment: map[string]string{
+					"SEARCH_API_KEY": "${api_key}",
+					"LOG_LEVEL":      "debug",
+				},
+			},
+		},
+		{
+			Name:   "plain",
+			Server: pkg.Server{Command: "plain-mcp"},
+		},
+	}
+	// A provider that resolves values must still never leak them into either file
+	secretsProvider := func(string) (string, error) { return "sk-live-secret", nil }
+
+	if err := New().GenerateConfigWithEnvFile(manifests, secretsProvider); err != nil {
+		t.Fatalf("GenerateConfigWithEnvFile failed: %v", err)
+	}
+
+	envData, err := os.ReadFile(".vscode/mcp.env")
+	if err != nil {
+		t.Fatalf("Expected .vscode/mcp.env: %v", err)
+	}
+	if !strings.Contains(string(envData), "SEARCH_API_KEY=${api_key}\n") || strings.Contains(string(envData), "LOG_LEVEL") {
+		t.Errorf("Unexpected env file:\n%s", envData)
+	}
+
+	var config struct {
+		Servers map[string]pkg.MCPServerConfig `json:"servers"`
+	}
+	configData, _ := os.ReadFile(".vscode/mcp.json")
+	if err := json.Unmarshal(configData, &config); err != nil {
+		t.Fatalf("Invalid mcp.json: %v", err)
+	}
+	search := config.Servers["search"]
+	if search.EnvFile != "${workspaceFolder}/.vscode/mcp.env" {
+		t.Errorf("Expected search to load the env file, got %q", search.EnvFile)
+	}
+	if _, ok := search.Environment["SEARCH_API_KEY"]; ok || search.Environment["LOG_LEVEL"] != "debug" {
+		t.Errorf("Expected only non-secret env inline, got %v", search.Environment)
+	}
+	if config.Servers["plain"].EnvFile != "" {
+		t.Error("Servers without secret env should not load the env file")
+	}
+
+	for name, data := range map[string][]byte{"mcp.json": configData, "mcp.env": envData} {
+		if strings.Contains(string(data), "sk-live-secret") {
+			t.Errorf("%s contains a secret value", name)
+		}
+	}
+
+	// With no secret env left the env file is removed
+	if err := New().GenerateConfigWithEnvFile(manifests[1:], secretsProvider); err != nil {
+		t.Fatalf("GenerateConfigWithEnvFile failed: %v", err)
+	}
+	if _, err := os.Stat(".vscode/mcp.env"); !os.IsNotExist(err) {
+		t.Error("Expected stale env file to be removed")
+	}
+}
+
+func TestClient_GenerateConfig_RemoteTransport(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(tmpDir)
+
+	client := New()
+
+	manifests := []pkg.ServoDefinition{
+		{
+			Name: "remote-server",
+			Server: pkg.Server{
+				Transport: "http",
+				URL:       "https://mcp.example.com/mcp",
+				Headers: map[string]string{
+					"Authorization": "Bearer ${token}",
+				},
+			},
+		},
+	}
+
+	if err := client.GenerateConfig(manifests, func(string) (string, error) { return "", nil }); err != nil {
+		t.Fatalf("GenerateConfig failed: %v", err)
+	}
+
+	configData, err := os.ReadFile(".vscode/mcp.json")
+	if err != nil {
+		t.Fatalf("Failed to read generated config: %v", err)
+	}
+
+	// VS Code lists servers under "servers" and tells transports apart by "type"
+	var config map[string]map[string]map[string]interface{}
+	if err := json.Unmarshal(configData, &config); err != nil {
+		t.Fatalf("Failed to parse generated config: %v", err)
+	}
+
+	remote, exists := config["servers"]["remote-server"]
+	if !exists {
+		t.Fatalf("remote-server not found under servers in generated config")
+	}
+
+	if remote["type"] != "http" {
+		t.Errorf("Expected type 'http', got '%v'", remote["type"])
+	}
+	if remote["url"] != "https://mcp.example.com/mcp" {
+		t.Errorf("Expected url entry, got '%v'", remote["url"])
+	}
+	if _, hasCommand := remote["command"]; hasCommand {
+		t.Errorf("Remote server should not have a command entry, got '%v'", remote["command"])
+	}
+
+	headers, ok := remote["headers"].(map[string]interface{})
+	if !ok || headers["Authorization"] != "Bearer ${token}" {
+		t.Errorf("Expected unresolved header placeholder to be preserved, got %v", remote["headers"])
+	}
+}
@@ -0,0 +1,309 @@
+// Package bundle packs a servo project's shareable configuration into a single
+// archive and unpacks it into a fresh directory.
+//
+// A bundle holds project.yaml, project-level config overrides and every session's
+// manifests and config overrides. Secret values, volumes and logs never leave the
+// project; required secrets travel only as the definitions in project.yaml.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+	"gopkg.in/yaml.v3"
+)
+
+// FormatVersion is the bundle layout version written by Export
+const FormatVersion = 1
+
+// metadataFile is the archive entry describing the bundle
+const metadataFile = "bundle.yaml"
+
+// projectContents lists the project-level entries included in a bundle
+var projectContents = []string{"project.yaml", ".gitignore", "config"}
+
+// sessionContents lists the per-session entries included in a bundle
+var sessionContents = []string{"manifests", "config", "config.yaml"}
+
+// SessionInfo describes a session recreated on import
+type SessionInfo struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Profiles    []string `yaml:"profiles,omitempty"`
+}
+
+// Metadata describes the contents of a bundle
+type Metadata struct {
+	Version         int                      `yaml:"version"`
+	CreatedAt       time.Time                `yaml:"created_at"`
+	Sessions        []SessionInfo            `yaml:"sessions"`
+	RequiredSecrets []project.RequiredSecret `yaml:"required_secrets,omitempty"`
+}
+
+// Export writes the project in servoDir to w as a gzipped tar archive
+func Export(servoDir string, w io.Writer) (*Metadata, error) {
+	projectData, err := os.ReadFile(filepath.Join(servoDir, "project.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project file: %w", err)
+	}
+	proj, err := project.ParseProject(projectData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project configuration: %w", err)
+	}
+
+	sessions, err := session.NewManager(servoDir).List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	meta := &Metadata{
+		Version:         FormatVersion,
+		CreatedAt:       time.Now(),
+		RequiredSecrets: proj.RequiredSecrets,
+	}
+	for _, s := range sessions {
+		meta.Sessions = append(meta.Sessions, SessionInfo{Name: s.Name, Description: s.Description, Profiles: s.Profiles})
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	metaData, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle metadata: %w", err)
+	}
+	if err := writeEntry(tw, metadataFile, metaData); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range projectContents {
+		if err := addPath(tw, servoDir, entry); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range sessions {
+		for _, entry := range sessionContents {
+			if err := addPath(tw, servoDir, path.Join("sessions", s.Name, entry)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	return meta, nil
+}
+
+// Import unpacks a bundle into servoDir, which must not already hold a project.
+// Sessions are recreated with fresh volume paths and the default session is activated.
+func Import(r io.Reader, servoDir string) (*Metadata, error) {
+	if _, err := os.Stat(filepath.Join(servoDir, "project.yaml")); err == nil {
+		return nil, fmt.Errorf("a servo project already exists in %s", servoDir)
+	}
+
+	meta, files, err := readArchive(r)
+	if err != nil {
+		return nil, err
+	}
+
+	proj, err := project.ParseProject(files["project.yaml"])
+	if err != nil {
+		return nil, fmt.Errorf("bundle has invalid project.yaml: %w", err)
+	}
+
+	allowed := make(map[string]bool)
+	for _, s := range meta.Sessions {
+		if s.Name == "" || s.Name == "." || s.Name == ".." || strings.ContainsAny(s.Name, `/\`) {
+			return nil, fmt.Errorf("bundle has invalid session name '%s'", s.Name)
+		}
+		allowed[s.Name] = true
+	}
+	for name := range files {
+		if !isBundledPath(name, allowed) {
+			return nil, fmt.Errorf("bundle has unexpected entry %s", name)
+		}
+	}
+
+	_, statErr := os.Stat(servoDir)
+	createdDir := os.IsNotExist(statErr)
+	if err := os.MkdirAll(servoDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", servoDir, err)
+	}
+
+	if err := unpack(servoDir, meta, files); err != nil {
+		if createdDir {
+			os.RemoveAll(servoDir)
+		}
+		return nil, err
+	}
+
+	sessionManager := session.NewManager(servoDir)
+	if exists, _ := sessionManager.Exists(proj.DefaultSession); exists {
+		if err := sessionManager.Activate(proj.DefaultSession); err != nil {
+			return nil, fmt.Errorf("failed to activate session '%s': %w", proj.DefaultSession, err)
+		}
+	}
+
+	return meta, nil
+}
+
+// unpack recreates the bundle's sessions and writes its files under servoDir
+func unpack(servoDir string, meta *Metadata, files map[string][]byte) error {
+	sessionManager := session.NewManager(servoDir)
+	for _, info := range meta.Sessions {
+		s, err := sessionManager.Create(info.Name, info.Description, "")
+		if err != nil {
+			return fmt.Errorf("failed to create session '%s': %w", info.Name, err)
+		}
+		if len(info.Profiles) > 0 {
+			s.Profiles = info.Profiles
+			if err := sessionManager.SaveSession(s); err != nil {
+				return fmt.Errorf("failed to save session '%s': %w", info.Name, err)
+			}
+		}
+	}
+
+	for name, data := range files {
+		dst := filepath.Join(servoDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", name, err)
+		}
+		if err := os.WriteFile(dst, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// readArchive reads the bundle metadata and every file entry from a gzipped tar
+func readArchive(r io.Reader) (*Metadata, map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a servo bundle: %w", err)
+	}
+	defer gz.Close()
+
+	var meta *Metadata
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return nil, nil, fmt.Errorf("bundle entry %s is not a regular file", hdr.Name)
+		}
+		if !filepath.IsLocal(hdr.Name) || path.Clean(hdr.Name) != hdr.Name {
+			return nil, nil, fmt.Errorf("bundle has unsafe entry %s", hdr.Name)
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", hdr.Name, err)
+		}
+
+		if hdr.Name == metadataFile {
+			meta = &Metadata{}
+			if err := yaml.Unmarshal(data, meta); err != nil {
+				return nil, nil, fmt.Errorf("invalid bundle metadata: %w", err)
+			}
+			continue
+		}
+		files[hdr.Name] = data
+	}
+
+	if meta == nil {
+		return nil, nil, fmt.Errorf("not a servo bundle: missing %s", metadataFile)
+	}
+	if meta.Version > FormatVersion {
+		return nil, nil, fmt.Errorf("bundle format version %d is newer than supported version %d", meta.Version, FormatVersion)
+	}
+	if _, ok := files["project.yaml"]; !ok {
+		return nil, nil, fmt.Errorf("bundle is missing project.yaml")
+	}
+
+	return meta, files, nil
+}
+
+// isBundledPath reports whether an archive entry is one Export could have written
+func isBundledPath(name string, sessions map[string]bool) bool {
+	parts := strings.SplitN(name, "/", 4)
+	switch {
+	case name == "project.yaml" || name == ".gitignore":
+		return true
+	case parts[0] == "config":
+		return len(parts) > 1
+	case parts[0] == "sessions" && len(parts) >= 3 && sessions[parts[1]]:
+		entry := parts[2]
+		if entry == "config.yaml" {
+			return len(parts) == 3
+		}
+		return (entry == "manifests" || entry == "config") && len(parts) == 4
+	}
+	return false
+}
+
+// addPath adds a file, or every regular file beneath a directory, to the archive.
+// Missing paths are skipped.
+func addPath(tw *tar.Writer, servoDir, rel string) error {
+	root := filepath.Join(servoDir, filepath.FromSlash(rel))
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		name, err := filepath.Rel(servoDir, p)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		return writeEntry(tw, filepath.ToSlash(name), data)
+	})
+}
+
+// writeEntry writes a single regular file entry to the archive
+func writeEntry(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+	return nil
+}
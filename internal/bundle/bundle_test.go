@@ -0,0 +1,138 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/session"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+// setupProject creates a project with two sessions, secrets and volume data
+func setupProject(t *testing.T) string {
+	t.Helper()
+	servoDir := filepath.Join(t.TempDir(), ".servo")
+
+	writeTestFile(t, filepath.Join(servoDir, "project.yaml"), "default_session: dev\nclients: [vscode]\nrequired_secrets:\n  - name: api_key\n    description: API key\n")
+	writeTestFile(t, filepath.Join(servoDir, "secrets.yaml"), "secrets:\n  api_key: encrypted\n")
+	writeTestFile(t, filepath.Join(servoDir, "config", "docker-compose.yml"), "services: {}\n")
+
+	manager := session.NewManager(servoDir)
+	for _, name := range []string{"dev", "prod"} {
+		s, err := manager.Create(name, name+" session", "")
+		if err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		s.Profiles = []string{"database"}
+		manager.SaveSession(s)
+
+		sessionDir := manager.GetSessionDir(name)
+		writeTestFile(t, filepath.Join(sessionDir, "manifests", "api.servo"), "name: api\n")
+		writeTestFile(t, filepath.Join(sessionDir, "config", "devcontainer.json"), "{}\n")
+		writeTestFile(t, filepath.Join(sessionDir, "volumes", "api", "data.db"), "volume data")
+	}
+
+	return servoDir
+}
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	servoDir := setupProject(t)
+
+	var buf bytes.Buffer
+	meta, err := Export(servoDir, &buf)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(meta.Sessions) != 2 || len(meta.RequiredSecrets) != 1 || meta.RequiredSecrets[0].Name != "api_key" {
+		t.Errorf("Unexpected metadata: %+v", meta)
+	}
+
+	targetDir := filepath.Join(t.TempDir(), ".servo")
+	if _, err := Import(bytes.NewReader(buf.Bytes()), targetDir); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	for _, rel := range []string{
+		"project.yaml",
+		"config/docker-compose.yml",
+		"sessions/dev/manifests/api.servo",
+		"sessions/prod/config/devcontainer.json",
+	} {
+		if _, err := os.Stat(filepath.Join(targetDir, rel)); err != nil {
+			t.Errorf("Expected %s to be imported: %v", rel, err)
+		}
+	}
+	for _, rel := range []string{"secrets.yaml", "sessions/dev/volumes/api/data.db"} {
+		if _, err := os.Stat(filepath.Join(targetDir, rel)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be excluded from the bundle", rel)
+		}
+	}
+
+	manager := session.NewManager(targetDir)
+	active, err := manager.GetActive()
+	if err != nil || active == nil || active.Name != "dev" {
+		t.Errorf("Expected default session dev to be active, got %v (%v)", active, err)
+	}
+	prod, err := manager.Get("prod")
+	if err != nil {
+		t.Fatalf("Failed to get imported session: %v", err)
+	}
+	if prod.Description != "prod session" || len(prod.Profiles) != 1 {
+		t.Errorf("Session details not preserved: %+v", prod)
+	}
+	if !strings.HasPrefix(prod.VolumePath, targetDir) {
+		t.Errorf("Expected fresh volume path under %s, got %s", targetDir, prod.VolumePath)
+	}
+
+	// Importing over an existing project is refused
+	if _, err := Import(bytes.NewReader(buf.Bytes()), targetDir); err == nil {
+		t.Error("Expected import into an existing project to fail")
+	}
+}
+
+func TestImport_RejectsUnsafeEntries(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry string
+	}{
+		{name: "path traversal", entry: "../evil.txt"},
+		{name: "secrets file", entry: "secrets.yaml"},
+		{name: "unknown session", entry: "sessions/other/manifests/api.servo"},
+		{name: "session volumes", entry: "sessions/dev/volumes/data.db"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			tw := tar.NewWriter(gz)
+			writeEntry(tw, metadataFile, []byte("version: 1\nsessions:\n  - name: dev\n"))
+			writeEntry(tw, "project.yaml", []byte("default_session: dev\n"))
+			writeEntry(tw, tt.entry, []byte("data"))
+			tw.Close()
+			gz.Close()
+
+			targetDir := filepath.Join(t.TempDir(), ".servo")
+			if _, err := Import(&buf, targetDir); err == nil {
+				t.Errorf("Expected entry %s to be rejected", tt.entry)
+			}
+			if _, err := os.Stat(filepath.Join(targetDir, "project.yaml")); !os.IsNotExist(err) {
+				t.Error("Rejected bundle should not leave a project behind")
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package catalog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/servo/servo/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultStorePath is where added catalogs are kept, relative to the home directory
+const DefaultStorePath = ".servo/catalogs.yaml"
+
+// entryNameRegex matches the server names an index may list, the same shape
+// manifests require of their name
+var entryNameRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*[a-z0-9]$`)
+
+// Entry is one server listed by a catalog index
+type Entry struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Source      string `yaml:"source" json:"source"`
+}
+
+// Catalog is an index and the URL it was fetched from
+type Catalog struct {
+	URL       string    `yaml:"url"`
+	FetchedAt time.Time `yaml:"fetched_at"`
+	Entries   []Entry   `yaml:"entries"`
+}
+
+// Store holds every added catalog, in the order they were added
+type Store struct {
+	Catalogs []Catalog `yaml:"catalogs"`
+}
+
+// Match is a catalog entry together with the index that lists it
+type Match struct {
+	Entry
+	CatalogURL string
+}
+
+// ParseIndex parses and validates a catalog index: a YAML or JSON list of
+// {name, description, source} entries with unique names
+func ParseIndex(data []byte) ([]Entry, error) {
+	var entries []Entry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("index must be a list of {name, description, source} entries: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("index lists no servers")
+	}
+
+	seen := make(map[string]bool, len(entries))
+	for i, entry := range entries {
+		if entry.Name == "" {
+			return nil, fmt.Errorf("entry %d: name is required", i+1)
+		}
+		if !entryNameRegex.MatchString(entry.Name) {
+			return nil, fmt.Errorf("entry %d: name must be lowercase with hyphens only: %s", i+1, entry.Name)
+		}
+		if seen[entry.Name] {
+			return nil, fmt.Errorf("entry %d: duplicate name '%s'", i+1, entry.Name)
+		}
+		seen[entry.Name] = true
+		if strings.TrimSpace(entry.Source) == "" {
+			return nil, fmt.Errorf("entry %d (%s): source is required", i+1, entry.Name)
+		}
+	}
+	return entries, nil
+}
+
+// StorePath returns the catalog store in the current user's home directory
+func StorePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, DefaultStorePath), nil
+}
+
+// Load reads the catalog store at path. A missing store has no catalogs.
+func Load(path string) (*Store, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Store{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog store %s: %w", path, err)
+	}
+
+	var store Store
+	if err := yaml.Unmarshal(data, &store); err != nil {
+		return nil, fmt.Errorf("failed to parse catalog store %s: %w", path, err)
+	}
+	return &store, nil
+}
+
+// Save writes the catalog store to path
+func (s *Store) Save(path string) error {
+	return utils.WriteYAMLFile(path, s)
+}
+
+// Add stores the entries of the index at url, replacing the entries previously
+// fetched from the same url. It reports whether the catalog was already present.
+func (s *Store) Add(url string, entries []Entry) bool {
+	catalog := Catalog{URL: url, FetchedAt: time.Now().UTC(), Entries: entries}
+	for i := range s.Catalogs {
+		if s.Catalogs[i].URL == url {
+			s.Catalogs[i] = catalog
+			return true
+		}
+	}
+	s.Catalogs = append(s.Catalogs, catalog)
+	return false
+}
+
+// Search returns the entries whose name or description contains term, ignoring
+// case. An empty term matches every entry.
+func (s *Store) Search(term string) []Match {
+	term = strings.ToLower(term)
+
+	var matches []Match
+	for _, catalog := range s.Catalogs {
+		for _, entry := range catalog.Entries {
+			if strings.Contains(strings.ToLower(entry.Name), term) || strings.Contains(strings.ToLower(entry.Description), term) {
+				matches = append(matches, Match{Entry: entry, CatalogURL: catalog.URL})
+			}
+		}
+	}
+	return matches
+}
+
+// Find returns the entry named name. When several catalogs list the name, the
+// catalog added first wins.
+func (s *Store) Find(name string) (*Match, bool) {
+	for _, catalog := range s.Catalogs {
+		for _, entry := range catalog.Entries {
+			if entry.Name == name {
+				return &Match{Entry: entry, CatalogURL: catalog.URL}, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,106 @@
+package catalog
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseIndex(t *testing.T) {
+	entries, err := ParseIndex([]byte(`- name: postgres
+  description: PostgreSQL database tools
+  source: github:acme/postgres-mcp
+- name: notes
+  source: https://example.com/notes.servo
+`))
+	if err != nil {
+		t.Fatalf("ParseIndex() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Name != "postgres" || entries[1].Source != "https://example.com/notes.servo" {
+		t.Errorf("ParseIndex() = %+v", entries)
+	}
+
+	// JSON is valid YAML
+	if _, err := ParseIndex([]byte(`[{"name": "search", "source": "github:acme/search"}]`)); err != nil {
+		t.Errorf("ParseIndex() JSON error = %v", err)
+	}
+
+	tests := map[string]struct {
+		index string
+		want  string
+	}{
+		"not a list":      {index: "name: postgres\n", want: "must be a list"},
+		"empty":           {index: "[]\n", want: "lists no servers"},
+		"missing name":    {index: "- source: github:acme/x\n", want: "name is required"},
+		"invalid name":    {index: "- name: Postgres\n  source: github:acme/x\n", want: "lowercase"},
+		"duplicate name":  {index: "- name: db\n  source: a\n- name: db\n  source: b\n", want: "duplicate name 'db'"},
+		"missing source":  {index: "- name: db\n  description: no source\n", want: "source is required"},
+		"blank source":    {index: "- name: db\n  source: \"  \"\n", want: "source is required"},
+		"unparseable yml": {index: "- name: [db\n", want: "must be a list"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseIndex([]byte(tt.index))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseIndex() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalogs.yaml")
+
+	store, err := Load(path)
+	if err != nil || len(store.Catalogs) != 0 {
+		t.Fatalf("Load() of a missing store = %+v, %v; want empty", store, err)
+	}
+
+	if store.Add("https://one.example.com/index.yaml", []Entry{
+		{Name: "postgres", Description: "PostgreSQL database tools", Source: "github:one/postgres"},
+		{Name: "notes", Source: "github:one/notes"},
+	}) {
+		t.Error("Add() reported a new catalog as refreshed")
+	}
+	store.Add("https://two.example.com/index.yaml", []Entry{
+		{Name: "postgres", Source: "github:two/postgres"},
+		{Name: "search", Description: "Full-text search over notes", Source: "github:two/search"},
+	})
+	if err := store.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	store, err = Load(path)
+	if err != nil || len(store.Catalogs) != 2 {
+		t.Fatalf("Load() = %+v, %v; want both catalogs", store, err)
+	}
+
+	// The first catalog added wins a shared name
+	match, ok := store.Find("postgres")
+	if !ok || match.Source != "github:one/postgres" || match.CatalogURL != "https://one.example.com/index.yaml" {
+		t.Errorf("Find(postgres) = %+v, %v", match, ok)
+	}
+	if _, ok := store.Find("missing"); ok {
+		t.Error("Find() matched a name no catalog lists")
+	}
+
+	// Name and description both match, case-insensitively
+	var names []string
+	for _, m := range store.Search("NOTES") {
+		names = append(names, m.Name)
+	}
+	if strings.Join(names, ",") != "notes,search" {
+		t.Errorf("Search(NOTES) = %v, want [notes search]", names)
+	}
+	if got := len(store.Search("")); got != 4 {
+		t.Errorf("Search(\"\") matched %d entries, want 4", got)
+	}
+
+	// Adding a URL again replaces its entries
+	if !store.Add("https://two.example.com/index.yaml", []Entry{{Name: "search", Source: "github:two/search"}}) {
+		t.Error("Add() of a known URL should report a refresh")
+	}
+	if len(store.Catalogs) != 2 || len(store.Catalogs[1].Entries) != 1 {
+		t.Errorf("refresh should replace the catalog in place, got %+v", store.Catalogs)
+	}
+}
@@ -3,14 +3,21 @@ package cli
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
 	"github.com/servo/servo/internal/cli/commands"
+	"github.com/servo/servo/internal/logging"
 	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/override"
 	"github.com/servo/servo/internal/project"
 	"github.com/servo/servo/internal/registry"
 	"github.com/servo/servo/internal/session"
+	"github.com/servo/servo/internal/utils"
+	"github.com/servo/servo/pkg"
 )
 
 // NewApp creates a new CLI application using urfave/cli
@@ -26,11 +33,17 @@ func NewApp(version string) (*cli.App, error) {
 	parser := mcp.NewParser()
 	validator := mcp.NewValidator()
 
+	sessionFlagValues := map[string]completionSource{"--session": sessionNames, "-s": sessionNames}
+	clients := clientNames(clientRegistry)
+	clientTargets := withAllClients(clients)
+
 	app := &cli.App{
-		Name:        "servo",
-		Usage:       "MCP Server Project Manager",
-		Version:     version,
-		Description: "Servo provides project-focused tool for managing Model Context Protocol (MCP) servers in isolated, containerized development environments. Each project maintains its own MCP servers, dependencies, and configuration while supporting team collaboration through git-friendly configs.",
+		Name:                 "servo",
+		Usage:                "MCP Server Project Manager",
+		Version:              version,
+		HideVersion:          true,
+		EnableBashCompletion: true,
+		Description:          "Servo provides project-focused tool for managing Model Context Protocol (MCP) servers in isolated, containerized development environments. Each project maintains its own MCP servers, dependencies, and configuration while supporting team collaboration through git-friendly configs.",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:    "no-interactive",
@@ -38,14 +51,74 @@ func NewApp(version string) (*cli.App, error) {
 				Aliases: []string{"n"},
 				EnvVars: []string{"SERVO_NON_INTERACTIVE"},
 			},
+			&cli.StringFlag{
+				Name:    "env",
+				Usage:   "Environment whose .servo/config/docker-compose.<env>.yml override is applied",
+				EnvVars: []string{"SERVO_ENV"},
+			},
+			&cli.BoolFlag{
+				Name:    "verbose",
+				Usage:   "Log diagnostics to stderr; repeat (-v -v) for debug detail. SERVO_LOG_LEVEL sets the level when absent",
+				Aliases: []string{"v"},
+			},
+			// -v is taken by --verbose, so the app hides the default version flag and
+			// declares this long-only one, handled by Action
+			&cli.BoolFlag{
+				Name:               "version",
+				Usage:              "print the version",
+				DisableDefaultText: true,
+			},
 		},
 		Before: func(c *cli.Context) error {
+			verbosity := c.Count("verbose")
+			level := logging.VerbosityLevel(verbosity)
+			if value := os.Getenv(logging.LevelEnvVar); verbosity == 0 && value != "" {
+				parsed, err := logging.ParseLevel(value)
+				if err != nil {
+					return fmt.Errorf("%s: %w", logging.LevelEnvVar, err)
+				}
+				level = parsed
+			}
+			logging.Configure(c.App.ErrWriter, level)
+
 			// Set global environment variable if flag is set
 			if c.Bool("no-interactive") {
 				os.Setenv("SERVO_NON_INTERACTIVE", "1")
 			}
-			return nil
+			if env := c.String("env"); env != "" {
+				if err := override.ValidateEnvironment(env); err != nil {
+					return err
+				}
+				os.Setenv("SERVO_ENV", env)
+			}
+
+			if !projectIndependentCommands[c.Args().First()] {
+				if err := enterProjectRoot(); err != nil {
+					return err
+				}
+				// Unreadable projects are left for the command itself to report
+				if proj, err := projectManager.Get(); err == nil {
+					if err := project.CheckServoVersion(proj, version); err != nil {
+						return err
+					}
+				}
+			}
+
+			// Read from the project root, or the current directory outside a project
+			return applyServorc(servorcFile, c.App.Commands)
+		},
+		// Errors go back to main, which prints them and exits with ExitCode
+		Action: func(c *cli.Context) error {
+			if c.Bool("version") {
+				cli.ShowVersion(c)
+				return nil
+			}
+			if c.Args().Present() {
+				return cli.ShowCommandHelp(c, c.Args().First())
+			}
+			return cli.ShowAppHelp(c)
 		},
+		ExitErrHandler: func(*cli.Context, error) {},
 		Commands: []*cli.Command{
 			// Project management commands
 			{
@@ -63,6 +136,14 @@ func NewApp(version string) (*cli.App, error) {
 						Usage:   "Comma-separated list of MCP clients (vscode,claude-code,cursor)",
 						Aliases: []string{"c"},
 					},
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Reinitialize an existing project, keeping sessions and manifests",
+					},
+					&cli.BoolFlag{
+						Name:  "reset",
+						Usage: "Remove the existing project entirely before initializing (requires --force)",
+					},
 				},
 				Action: func(c *cli.Context) error {
 					sessionName := c.String("session")
@@ -75,16 +156,26 @@ func NewApp(version string) (*cli.App, error) {
 
 					// Initialize project structure
 					projectManager := project.NewManager()
-					project, err := projectManager.Init(sessionName, clients)
+					project, err := projectManager.InitWithOptions(sessionName, clients, project.InitOptions{
+						Force:        c.Bool("force"),
+						Reset:        c.Bool("reset"),
+						ServoVersion: version,
+					})
 					if err != nil {
 						return fmt.Errorf("failed to initialize project: %w", err)
 					}
 
-					// Create default session using session manager
+					// Create default session using session manager, reusing it on reinitialization
 					sessionManager := session.NewManager(".servo")
-					_, err = sessionManager.Create(sessionName, fmt.Sprintf("Default session for project"), "")
+					exists, err := sessionManager.Exists(sessionName)
 					if err != nil {
-						return fmt.Errorf("failed to create default session: %w", err)
+						return fmt.Errorf("failed to check default session: %w", err)
+					}
+					if !exists {
+						_, err = sessionManager.Create(sessionName, fmt.Sprintf("Default session for project"), "")
+						if err != nil {
+							return fmt.Errorf("failed to create default session: %w", err)
+						}
 					}
 
 					// Activate the session
@@ -107,8 +198,12 @@ func NewApp(version string) (*cli.App, error) {
 			{
 				Name:        "install",
 				Usage:       "Install MCP server from source",
-				Description: "Install MCP server from .servo file, git repository, or local directory",
-				ArgsUsage:   "<source>",
+				Description: "Install MCP servers from .servo files, git repositories, or local directories",
+				ArgsUsage:   "<source>...",
+				BashComplete: completer{flags: map[string]completionSource{
+					"--session": sessionNames, "-s": sessionNames,
+					"--clients": clientTargets, "-c": clientTargets,
+				}}.complete,
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:    "session",
@@ -117,7 +212,7 @@ func NewApp(version string) (*cli.App, error) {
 					},
 					&cli.StringSliceFlag{
 						Name:    "clients",
-						Usage:   "Target MCP clients",
+						Usage:   "Target MCP clients, or 'all' for every enabled client",
 						Aliases: []string{"c"},
 					},
 					&cli.BoolFlag{
@@ -125,6 +220,51 @@ func NewApp(version string) (*cli.App, error) {
 						Usage:   "Update server if it already exists",
 						Aliases: []string{"u"},
 					},
+					&cli.BoolFlag{
+						Name:  "no-update",
+						Usage: "Fail if the server exists, even when config.install_update_default is set",
+					},
+					&cli.BoolFlag{
+						Name:  "keep-going",
+						Usage: "With several sources, install them all and summarize the failures instead of stopping at the first",
+					},
+					&cli.BoolFlag{
+						Name:  "skip-system-checks",
+						Usage: "Install even if requirements.system check commands fail",
+					},
+					&cli.StringFlag{
+						Name:  "path",
+						Usage: "Manifest file to read, relative to the repository or directory root",
+					},
+					&cli.BoolFlag{
+						Name:  "dev",
+						Usage: "Treat <source> as a local checkout and bind-mount it into the workspace instead of cloning",
+					},
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Install even when servers listed in the manifest's depends_on are not installed in the session",
+					},
+					&cli.IntFlag{
+						Name:  "search-depth",
+						Usage: "Directory levels below a local or cloned source to search for a manifest (0 searches only the source directory)",
+						Value: mcp.DefaultManifestSearchDepth,
+					},
+					&cli.BoolFlag{
+						Name:  "no-devcontainer",
+						Usage: "Generate MCP client configurations only, without devcontainer or docker-compose files",
+					},
+					&cli.BoolFlag{
+						Name:  "create-session",
+						Usage: "Create the --session target if it does not exist",
+					},
+					&cli.StringFlag{
+						Name:  "session-description",
+						Usage: "Description for a session created by --create-session",
+					},
+					&cli.BoolFlag{
+						Name:  "global",
+						Usage: "Install into the user profile (~/.servo/global) and the user-level client configs instead of the project",
+					},
 					// Git authentication flags
 					&cli.StringFlag{
 						Name:    "ssh-key",
@@ -156,6 +296,19 @@ func NewApp(version string) (*cli.App, error) {
 					if c.NArg() == 0 {
 						return fmt.Errorf("source required")
 					}
+					if c.Bool("update") && c.Bool("no-update") {
+						return fmt.Errorf("--update and --no-update cannot be used together")
+					}
+					if c.Bool("create-session") && c.String("session") == "" {
+						return fmt.Errorf("--create-session requires --session")
+					}
+					if c.IsSet("session-description") && !c.Bool("create-session") {
+						return fmt.Errorf("--session-description requires --create-session")
+					}
+
+					if err := setSearchDepth(c, parser); err != nil {
+						return err
+					}
 
 					// Configure parser with authentication credentials
 					parser.SSHKeyPath = c.String("ssh-key")
@@ -165,14 +318,105 @@ func NewApp(version string) (*cli.App, error) {
 					parser.HTTPPassword = c.String("http-password")
 
 					installCmd := commands.NewInstallCommand(parser, validator)
+					installCmd.SkipSystemChecks = c.Bool("skip-system-checks")
+					installCmd.ManifestPath = c.String("path")
+					installCmd.Dev = c.Bool("dev")
+					installCmd.Force = c.Bool("force")
+					installCmd.KeepGoing = c.Bool("keep-going")
+					installCmd.NoDevcontainer = c.Bool("no-devcontainer")
+					installCmd.NoUpdate = c.Bool("no-update")
+					installCmd.CreateSession = c.Bool("create-session")
+					installCmd.SessionDescription = c.String("session-description")
+					installCmd.Global = c.Bool("global")
 
 					// Pass arguments and options directly
-					args := []string{c.Args().First()}
 					clients := c.StringSlice("clients")
 					session := c.String("session")
 					update := c.Bool("update")
 
-					return installCmd.ExecuteWithOptions(args, clients, session, update)
+					sources, err := sourceArgs(c)
+					if err != nil {
+						return err
+					}
+					for i, source := range sources {
+						sources[i] = userSource(source)
+					}
+					return installCmd.ExecuteSources(sources, clients, session, update)
+				},
+			},
+
+			{
+				Name:         "uninstall",
+				Usage:        "Remove MCP server from a session",
+				Description:  "Remove an MCP server from the active session, a named session, or every session, and regenerate configurations",
+				ArgsUsage:    "<server>",
+				BashComplete: completer{args: serverNames(projectManager), flags: sessionFlagValues}.complete,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "session",
+						Usage:   "Remove from specific session (default: active session)",
+						Aliases: []string{"s"},
+					},
+					&cli.BoolFlag{
+						Name:  "all-sessions",
+						Usage: "Remove from every session that has the server installed",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() == 0 {
+						return fmt.Errorf("server name required")
+					}
+
+					uninstallCmd := commands.NewUninstallCommand()
+					return uninstallCmd.ExecuteWithOptions([]string{c.Args().First()}, c.String("session"), c.Bool("all-sessions"))
+				},
+			},
+
+			{
+				Name:        "import-clients",
+				Usage:       "Import servers from existing MCP client configurations",
+				Description: "Read .mcp.json, .vscode/mcp.json and .cursor/mcp.json and write minimal .servo manifests into the active session",
+				Action: func(c *cli.Context) error {
+					importCmd := commands.NewImportClientsCommand()
+					return importCmd.Execute([]string{})
+				},
+			},
+
+			{
+				Name:        "doctor",
+				Usage:       "Check system requirements of installed servers",
+				Description: "Verify the active session's files and manifests, then run the requirements.system check commands of every server in it",
+				Action: func(c *cli.Context) error {
+					doctorCmd := commands.NewDoctorCommand()
+					return doctorCmd.Execute([]string{})
+				},
+			},
+
+			{
+				Name:        "export",
+				Usage:       "Export project configuration to a bundle",
+				Description: "Bundle project.yaml, config overrides and every session's manifests into a single archive. Secret values and volumes are never included",
+				ArgsUsage:   "<file>",
+				Action: func(c *cli.Context) error {
+					if c.NArg() == 0 {
+						return fmt.Errorf("output file required")
+					}
+					exportCmd := commands.NewExportCommand()
+					return exportCmd.Execute([]string{userPath(c.Args().First())})
+				},
+			},
+
+			{
+				Name:        "import",
+				Usage:       "Import project configuration from a bundle",
+				Description: "Recreate a project exported with 'servo export' in the current directory and list the secrets to set",
+				ArgsUsage:   "<file>",
+				Action: func(c *cli.Context) error {
+					if c.NArg() == 0 {
+						return fmt.Errorf("bundle file required")
+					}
+					importCmd := commands.NewImportCommand()
+					return importCmd.Execute([]string{c.Args().First()})
 				},
 			},
 
@@ -187,25 +431,178 @@ func NewApp(version string) (*cli.App, error) {
 			},
 
 			{
-				Name:        "configure",
-				Usage:       "Generate MCP client configurations",
-				Description: "Generate configuration files for MCP clients based on installed servers",
+				Name:         "list",
+				Usage:        "List installed MCP servers",
+				Description:  "List the MCP servers installed in the active or a named session with their versions and tags",
+				BashComplete: completer{flags: sessionFlagValues}.complete,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "session",
+						Usage:   "Session to list (default: active session)",
+						Aliases: []string{"s"},
+					},
+					&cli.StringFlag{
+						Name:    "tag",
+						Usage:   "Only servers whose manifest has this metadata tag",
+						Aliases: []string{"t"},
+					},
+				},
+				Action: func(c *cli.Context) error {
+					listCmd := commands.NewListCommand()
+					return listCmd.ExecuteWithOptions(c.String("session"), c.String("tag"))
+				},
+			},
+
+			{
+				Name:         "open",
+				Usage:        "Open an MCP server's homepage in the browser",
+				Description:  "Open the metadata.homepage of an installed server, or its metadata.repository when it has no homepage",
+				ArgsUsage:    "<server>",
+				BashComplete: completer{args: serverNames(projectManager), flags: sessionFlagValues}.complete,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "session",
+						Usage:   "Session the server is installed in (default: active session)",
+						Aliases: []string{"s"},
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() == 0 {
+						return fmt.Errorf("server name required")
+					}
+
+					openCmd := commands.NewOpenCommand()
+					return openCmd.ExecuteWithOptions([]string{c.Args().First()}, c.String("session"))
+				},
+			},
+
+			{
+				Name:         "configure",
+				Usage:        "Generate MCP client configurations",
+				Description:  "Generate configuration files for MCP clients based on installed servers. With --client only the named clients are regenerated; --client all names every enabled client.",
+				BashComplete: completer{flags: map[string]completionSource{"--client": clientTargets, "--clients": clientTargets, "-c": clientTargets}}.complete,
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:    "client",
+						Aliases: []string{"c", "clients"},
+						Usage:   "Regenerate only this client's configuration (repeatable, or 'all' for every enabled client); devcontainer and docker-compose files are left alone",
+					},
+					&cli.BoolFlag{
+						Name:  "include-devcontainer",
+						Usage: "With --client, also regenerate the devcontainer and docker-compose files",
+					},
+					&cli.BoolFlag{
+						Name:  "no-devcontainer",
+						Usage: "Generate MCP client configurations only, without devcontainer or docker-compose files",
+					},
+					&cli.BoolFlag{
+						Name:  "keep-going",
+						Usage: "Generate every client configuration and summarize the failures instead of stopping at the first",
+					},
+					&cli.BoolFlag{
+						Name:  "skip-secret-validation",
+						Usage: "Generate even when required secrets are missing, leaving placeholders unresolved and marking the output incomplete",
+					},
+				},
 				Action: func(c *cli.Context) error {
 					configureCmd := commands.NewConfigureCommand()
+					configureCmd.NoDevcontainer = c.Bool("no-devcontainer")
+					configureCmd.KeepGoing = c.Bool("keep-going")
+					configureCmd.SkipSecretValidation = c.Bool("skip-secret-validation")
+					configureCmd.Clients = c.StringSlice("client")
+					configureCmd.IncludeDevcontainer = c.Bool("include-devcontainer")
 					return configureCmd.Execute([]string{})
 				},
 			},
 
 			{
-				Name:        "work",
-				Usage:       "Start development environment with MCP servers",
-				Description: "Launch the development environment with devcontainer support",
+				Name:         "show-config",
+				Usage:        "Print the MCP configuration a client would receive",
+				ArgsUsage:    "<client>",
+				BashComplete: completer{args: clients, flags: sessionFlagValues}.complete,
+				Description:  "Render the configuration servo would write for one client (vscode, claude-code or cursor) from the session manifests, without touching disk. Secret placeholders are left unexpanded.",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "session",
+						Aliases: []string{"s"},
+						Usage:   "Session to render (defaults to the active session)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() < 1 {
+						return fmt.Errorf("client name is required")
+					}
+					showCmd := commands.NewShowConfigCommand()
+					return showCmd.ExecuteWithOptions(c.Args().First(), c.String("session"))
+				},
+			},
+
+			{
+				Name:        "override",
+				Usage:       "Inspect configuration overrides",
+				Description: "Inspect how the project, environment and session override files combine with the session manifests",
+				Subcommands: []*cli.Command{
+					{
+						Name:         "show",
+						Usage:        "Show the merged override result for a session",
+						BashComplete: completer{flags: sessionFlagValues}.complete,
+						Description:  "Print the effective docker-compose.yml and devcontainer.json the session generates, merged exactly as configure merges them, with each value commented with the layer that set it (manifest, project, env or session). Nothing is written and secrets stay as references.",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:    "session",
+								Aliases: []string{"s"},
+								Usage:   "Session to show (defaults to the active session)",
+							},
+						},
+						Action: func(c *cli.Context) error {
+							showCmd := commands.NewOverrideShowCommand()
+							return showCmd.ExecuteWithOptions(c.String("session"))
+						},
+					},
+				},
+			},
+
+			{
+				Name:        "reconfigure",
+				Usage:       "Rebuild generated configuration from scratch",
+				Description: "Delete servo-generated devcontainer, docker-compose and bundle files, then regenerate all configuration from the session manifests and overrides. Refuses to run when generated files were edited by hand unless --force is given.",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Discard hand edits to generated files",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					reconfigureCmd := commands.NewReconfigureCommand()
+					reconfigureCmd.Force = c.Bool("force")
+					return reconfigureCmd.Execute([]string{})
+				},
+			},
+
+			{
+				Name:         "work",
+				Usage:        "Start development environment with MCP servers",
+				Description:  "Launch the development environment with devcontainer support",
+				BashComplete: completer{flags: map[string]completionSource{"--client": clients, "-c": clients}}.complete,
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:    "client",
 						Usage:   "Target client for development",
 						Aliases: []string{"c"},
 					},
+					&cli.BoolFlag{
+						Name:  "wait",
+						Usage: "Start dependency services and wait until their healthchecks pass",
+					},
+					&cli.DurationFlag{
+						Name:  "wait-timeout",
+						Usage: "Maximum time to wait for services with --wait",
+						Value: 2 * time.Minute,
+					},
+					&cli.StringSliceFlag{
+						Name:  "service",
+						Usage: "Start only this service and its dependencies (repeatable)",
+					},
 				},
 				Action: func(c *cli.Context) error {
 					workCmd := commands.NewWorkCommand()
@@ -214,6 +611,12 @@ func NewApp(version string) (*cli.App, error) {
 					if client := c.String("client"); client != "" {
 						args = append(args, "--client", client)
 					}
+					if c.Bool("wait") {
+						args = append(args, "--wait", "--wait-timeout", c.Duration("wait-timeout").String())
+					}
+					for _, service := range c.StringSlice("service") {
+						args = append(args, "--service", service)
+					}
 
 					return workCmd.Execute(args)
 				},
@@ -259,33 +662,94 @@ func NewApp(version string) (*cli.App, error) {
 					{
 						Name:  "list",
 						Usage: "List all sessions",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "since",
+								Usage: "Only sessions created or used within this window (e.g. 12h, 7d, 2w)",
+							},
+							&cli.BoolFlag{
+								Name:  "active-only",
+								Usage: "Only the active session",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format: table, plain (tab-separated) or json; defaults to a bulleted list",
+							},
+							&cli.StringFlag{
+								Name:  "sort",
+								Usage: "Order sessions by name (default), created (newest first) or last-used (most recent first)",
+							},
+						},
+						BashComplete: completer{flags: map[string]completionSource{
+							"--format": func() []string { return commands.SessionListFormats },
+							"--sort":   func() []string { return session.SortOrders },
+						}}.complete,
 						Action: func(c *cli.Context) error {
+							format := c.String("format")
+							if _, err := commands.FormatSessions(nil, format); err != nil {
+								return err
+							}
+							sortBy, err := session.ParseSortBy(c.String("sort"))
+							if err != nil {
+								return err
+							}
+
+							filter := session.ListFilter{ActiveOnly: c.Bool("active-only")}
+							if c.IsSet("since") {
+								since, err := session.ParseSince(c.String("since"))
+								if err != nil {
+									return fmt.Errorf("invalid --since: %w", err)
+								}
+								filter.Since = since
+							}
+
 							sessionManager := session.NewManager(".servo")
-							sessions, err := sessionManager.List()
+							sessions, err := sessionManager.ListSorted(sortBy)
 							if err != nil {
 								return fmt.Errorf("failed to list sessions: %w", err)
 							}
 
-							if len(sessions) == 0 {
+							// Scriptable formats print an empty result instead of a message
+							if len(sessions) == 0 && format == "" {
 								fmt.Println("No sessions found")
 								return nil
 							}
 
-							fmt.Println("Sessions:")
-							for _, sess := range sessions {
-								status := ""
-								if sess.Active {
-									status = " (active)"
-								}
-								fmt.Printf("  • %s%s - %s\n", sess.Name, status, sess.Description)
+							sessions = filter.Filter(sessions, time.Now())
+							if len(sessions) == 0 && format == "" {
+								fmt.Println("No sessions match the filters")
+								return nil
 							}
+
+							output, err := commands.FormatSessions(sessions, format)
+							if err != nil {
+								return err
+							}
+							fmt.Print(output)
 							return nil
 						},
 					},
 					{
-						Name:      "activate",
-						Usage:     "Activate a session",
-						ArgsUsage: "<session-name>",
+						Name:         "activate",
+						Usage:        "Activate a session",
+						ArgsUsage:    "<session-name>",
+						BashComplete: completer{args: sessionNames}.complete,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "scope",
+								Usage: "Activation scope: project (default) or here for the current subdirectory only",
+								Value: "project",
+							},
+							&cli.BoolFlag{
+								Name:  "create",
+								Usage: "Create the session first if it does not exist",
+							},
+							&cli.StringFlag{
+								Name:    "description",
+								Usage:   "Description for a session created by --create",
+								Aliases: []string{"d"},
+							},
+						},
 						Action: func(c *cli.Context) error {
 							if c.NArg() == 0 {
 								return fmt.Errorf("session name required")
@@ -293,19 +757,55 @@ func NewApp(version string) (*cli.App, error) {
 
 							sessionName := c.Args().First()
 							sessionManager := session.NewManager(".servo")
-							err := sessionManager.Activate(sessionName)
-							if err != nil {
-								return fmt.Errorf("failed to activate session: %w", err)
+
+							if c.Bool("create") {
+								description := c.String("description")
+								if description == "" {
+									description = fmt.Sprintf("Session: %s", sessionName)
+								}
+								created, err := sessionManager.CreateIfMissing(sessionName, description)
+								if err != nil {
+									return fmt.Errorf("failed to create session: %w", err)
+								}
+								if created {
+									fmt.Printf("✅ Created session '%s'\n", sessionName)
+								}
+							} else if c.IsSet("description") {
+								return fmt.Errorf("--description requires --create")
 							}
 
-							fmt.Printf("✅ Activated session '%s'\n", sessionName)
+							switch c.String("scope") {
+							case "", "project":
+								if err := sessionManager.Activate(sessionName); err != nil {
+									return fmt.Errorf("failed to activate session: %w", err)
+								}
+								fmt.Printf("✅ Activated session '%s'\n", sessionName)
+							case "here":
+								if err := sessionManager.ActivateHere(sessionName); err != nil {
+									return fmt.Errorf("failed to activate session: %w", err)
+								}
+								if sessionManager.Scope() == "" {
+									fmt.Printf("✅ Activated session '%s'\n", sessionName)
+								} else {
+									fmt.Printf("✅ Activated session '%s' for %s\n", sessionName, sessionManager.Scope())
+								}
+							default:
+								return fmt.Errorf("unsupported scope '%s' (supported: project, here)", c.String("scope"))
+							}
 							return nil
 						},
 					},
 					{
-						Name:      "delete",
-						Usage:     "Delete a session",
-						ArgsUsage: "<session-name>",
+						Name:         "delete",
+						Usage:        "Delete a session",
+						ArgsUsage:    "<session-name>",
+						BashComplete: completer{args: sessionNames}.complete,
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "force",
+								Usage: "Delete without confirmation even when other sessions adopt its volumes",
+							},
+						},
 						Action: func(c *cli.Context) error {
 							if c.NArg() == 0 {
 								return fmt.Errorf("session name required")
@@ -313,7 +813,23 @@ func NewApp(version string) (*cli.App, error) {
 
 							sessionName := c.Args().First()
 							sessionManager := session.NewManager(".servo")
-							err := sessionManager.Delete(sessionName)
+
+							adopters, err := sessionManager.Adopters(sessionName)
+							if err != nil {
+								return fmt.Errorf("failed to check volume adoption: %w", err)
+							}
+							if len(adopters) > 0 && !c.Bool("force") {
+								fmt.Printf("⚠️  Session(s) %s adopt the volumes of '%s'. Volumes kept in its session directory are deleted with it, and those sessions go back to their own.\n", strings.Join(adopters, ", "), sessionName)
+								confirmed, err := utils.PromptForConfirmation("Delete the session anyway?", false)
+								if err != nil {
+									return err
+								}
+								if !confirmed {
+									return fmt.Errorf("session '%s' not deleted; use --force to delete it", sessionName)
+								}
+							}
+
+							err = sessionManager.Delete(sessionName)
 							if err != nil {
 								return fmt.Errorf("failed to delete session: %w", err)
 							}
@@ -323,9 +839,10 @@ func NewApp(version string) (*cli.App, error) {
 						},
 					},
 					{
-						Name:      "rename",
-						Usage:     "Rename a session",
-						ArgsUsage: "<old-name> <new-name>",
+						Name:         "rename",
+						Usage:        "Rename a session",
+						ArgsUsage:    "<old-name> <new-name>",
+						BashComplete: completer{args: sessionNames}.complete,
 						Action: func(c *cli.Context) error {
 							if c.NArg() != 2 {
 								return fmt.Errorf("both old and new session names required")
@@ -343,21 +860,315 @@ func NewApp(version string) (*cli.App, error) {
 							return nil
 						},
 					},
+					{
+						Name:        "copy-manifest",
+						Usage:       "Copy one server from a session to another",
+						ArgsUsage:   "<server> <from-session> <to-session>",
+						Description: "Copy a single installed server's manifest into another session without merging anything else",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "overwrite",
+								Usage: "Replace the server if the target session already has it",
+							},
+							&cli.BoolFlag{
+								Name:  "reconfigure",
+								Usage: "Rebuild generated configuration when the target session is active",
+							},
+						},
+						Action: func(c *cli.Context) error {
+							if c.NArg() != 3 {
+								return fmt.Errorf("server, source session and target session required")
+							}
+
+							copyCmd := commands.NewCopyManifestCommand()
+							copyCmd.Overwrite = c.Bool("overwrite")
+							copyCmd.Reconfigure = c.Bool("reconfigure")
+							return copyCmd.Execute(c.Args().Slice())
+						},
+					},
+					{
+						Name:         "verify",
+						Usage:        "Check a session's files, volume path and manifests",
+						ArgsUsage:    "<session-name>",
+						Description:  "Check that session.yaml parses, the volume path is accessible, and every installed manifest parses and validates. The first problem found is reported.",
+						BashComplete: completer{args: sessionNames}.complete,
+						Action: func(c *cli.Context) error {
+							if c.NArg() != 1 {
+								return fmt.Errorf("session name required")
+							}
+
+							sessionName := c.Args().First()
+							sessionManager := session.NewManager(".servo")
+							if err := sessionManager.RequireSessions(sessionName); err != nil {
+								return err
+							}
+							if err := sessionManager.Verify(sessionName); err != nil {
+								return fmt.Errorf("session '%s' failed verification: %w", sessionName, err)
+							}
+
+							fmt.Printf("✅ Session '%s' is healthy\n", sessionName)
+							return nil
+						},
+					},
+					{
+						Name:         "info",
+						Usage:        "Show a session's details and installed servers",
+						ArgsUsage:    "[session-name]",
+						Description:  "Show the session's description, timestamps, clients, profiles and tags, and each installed server with the capabilities its manifest advertises. Defaults to the active session.",
+						BashComplete: completer{args: sessionNames}.complete,
+						Action: func(c *cli.Context) error {
+							return commands.NewSessionInfoCommand().Execute(c.Args().Slice())
+						},
+					},
+					{
+						Name:         "save-template",
+						Usage:        "Save a session's manifests and config as a reusable template",
+						ArgsUsage:    "<session-name> <template-name>",
+						BashComplete: completer{args: sessionNames}.complete,
+						Action: func(c *cli.Context) error {
+							if c.NArg() != 2 {
+								return fmt.Errorf("both session and template names required")
+							}
+
+							sessionName := c.Args().Get(0)
+							templateName := c.Args().Get(1)
+							sessionManager := session.NewManager(".servo")
+							if _, err := sessionManager.SaveTemplate(sessionName, templateName); err != nil {
+								return fmt.Errorf("failed to save template: %w", err)
+							}
+
+							fmt.Printf("✅ Saved session '%s' as template '%s'\n", sessionName, templateName)
+							fmt.Println("💡 Secrets and volumes are not included in templates")
+							return nil
+						},
+					},
+					{
+						Name:      "from-template",
+						Usage:     "Create a new session from a template",
+						ArgsUsage: "<template-name> <session-name>",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:    "description",
+								Usage:   "Session description (defaults to the template's)",
+								Aliases: []string{"d"},
+							},
+						},
+						Action: func(c *cli.Context) error {
+							if c.NArg() != 2 {
+								return fmt.Errorf("both template and session names required")
+							}
+
+							templateName := c.Args().Get(0)
+							sessionName := c.Args().Get(1)
+							sessionManager := session.NewManager(".servo")
+							if _, err := sessionManager.CreateFromTemplate(templateName, sessionName, c.String("description")); err != nil {
+								return fmt.Errorf("failed to create session from template: %w", err)
+							}
+
+							fmt.Printf("✅ Created session '%s' from template '%s'\n", sessionName, templateName)
+							return nil
+						},
+					},
+					{
+						Name:  "list-templates",
+						Usage: "List saved session templates",
+						Action: func(c *cli.Context) error {
+							sessionManager := session.NewManager(".servo")
+							templates, err := sessionManager.ListTemplates()
+							if err != nil {
+								return fmt.Errorf("failed to list templates: %w", err)
+							}
+
+							if len(templates) == 0 {
+								fmt.Println("No templates found")
+								return nil
+							}
+
+							fmt.Println("Templates:")
+							for _, tmpl := range templates {
+								source := ""
+								if tmpl.SourceSession != "" {
+									source = fmt.Sprintf(" (from %s)", tmpl.SourceSession)
+								}
+								fmt.Printf("  • %s%s - %s\n", tmpl.Name, source, tmpl.Description)
+							}
+							return nil
+						},
+					},
+				},
+			},
+
+			{
+				Name:        "config",
+				Usage:       "Manage project configuration",
+				Description: "Edit the project configuration in .servo/project.yaml",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "edit",
+						Usage: "Open project.yaml in $EDITOR and validate it on save",
+						Action: func(c *cli.Context) error {
+							editCmd := commands.NewConfigEditCommand()
+							return editCmd.Execute([]string{})
+						},
+					},
+					{
+						Name:        "default-clients",
+						Usage:       "Show or set the clients install uses without --clients",
+						Description: "Without a subcommand, print the default install clients. They must be enabled clients; when none are set, install targets every enabled client.",
+						Action: func(c *cli.Context) error {
+							return commands.NewConfigDefaultClientsCommand().Execute([]string{})
+						},
+						Subcommands: []*cli.Command{
+							{
+								Name:         "set",
+								Usage:        "Set the default install clients",
+								ArgsUsage:    "<client> [<client> ...]",
+								BashComplete: completer{args: clients, multiple: true}.complete,
+								Action: func(c *cli.Context) error {
+									return commands.NewConfigDefaultClientsCommand().Execute(append([]string{"set"}, c.Args().Slice()...))
+								},
+							},
+							{
+								Name:  "clear",
+								Usage: "Install to every enabled client again",
+								Action: func(c *cli.Context) error {
+									return commands.NewConfigDefaultClientsCommand().Execute([]string{"clear"})
+								},
+							},
+						},
+					},
+				},
+			},
+
+			{
+				Name:        "project",
+				Usage:       "Maintain the .servo project directory",
+				Description: "Repair project files servo generated once and that may have gone stale",
+				Subcommands: []*cli.Command{
+					{
+						Name:        "fix-gitignore",
+						Usage:       "Rewrite .servo/.gitignore to the recommended set",
+						Description: "Replace the servo managed block of .servo/.gitignore with the current recommended entries (secrets, active session pointers, service volumes and logs). Lines you added are kept below the block; for a file written before the block existed, every line servo init did not write is kept.",
+						Action: func(c *cli.Context) error {
+							return commands.NewProjectFixGitignoreCommand().Execute([]string{})
+						},
+					},
+				},
+			},
+
+			{
+				Name:        "catalog",
+				Usage:       "Discover servers from catalog indexes",
+				Description: "Add catalog indexes, lists of {name, description, source} entries kept under ~/.servo, search them, and install a listed server with 'servo install <name>'",
+				Subcommands: []*cli.Command{
+					{
+						Name:        "add",
+						Usage:       "Fetch and save a catalog index",
+						ArgsUsage:   "<index-url>",
+						Description: "Fetch the index at the URL, validate it and save it to ~/.servo/catalogs.yaml. Adding the same URL again refreshes it.",
+						Action: func(c *cli.Context) error {
+							if c.NArg() == 0 {
+								return fmt.Errorf("catalog index URL required")
+							}
+							catalogCmd := commands.NewCatalogCommand(parser)
+							return catalogCmd.Add(c.Args().First())
+						},
+					},
+					{
+						Name:        "search",
+						Usage:       "Search the added catalogs",
+						ArgsUsage:   "[term]",
+						Description: "List catalog servers whose name or description contains the term, ignoring case. Without a term every server is listed.",
+						Action: func(c *cli.Context) error {
+							catalogCmd := commands.NewCatalogCommand(parser)
+							return catalogCmd.Search(c.Args().First())
+						},
+					},
 				},
 			},
 
 			{
 				Name:        "validate",
 				Usage:       "Validate .servo file or source",
-				Description: "Validate the structure and content of a .servo file",
-				ArgsUsage:   "<source>",
+				Description: "Validate the structure and content of a .servo file, or with --installed every manifest already installed in the project",
+				ArgsUsage:   "<source>...",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "output",
+						Usage:   "Output format (text, json)",
+						Aliases: []string{"o"},
+						Value:   "text",
+					},
+					&cli.BoolFlag{
+						Name:  "local-only",
+						Usage: "Validate local files only and refuse sources that require network access",
+					},
+					&cli.BoolFlag{
+						Name:  "strict",
+						Usage: "Reject unknown top-level manifest keys instead of ignoring them",
+					},
+					&cli.BoolFlag{
+						Name:  "keep-going",
+						Usage: "With several sources, validate them all and summarize the failures instead of stopping at the first",
+					},
+					&cli.StringFlag{
+						Name:  "servo-version",
+						Usage: "Validate under this servo_version schema instead of the version each file declares",
+					},
+					&cli.IntFlag{
+						Name:  "search-depth",
+						Usage: "Directory levels below a local or cloned source to search for a manifest (0 searches only the source directory)",
+						Value: mcp.DefaultManifestSearchDepth,
+					},
+					&cli.BoolFlag{
+						Name:  "installed",
+						Usage: "Validate the manifests installed in the active session and check their required secrets",
+					},
+					&cli.BoolFlag{
+						Name:  "all",
+						Usage: "With --installed, validate every session instead of only the active one",
+					},
+				},
 				Action: func(c *cli.Context) error {
+					if c.Bool("installed") {
+						if c.NArg() > 0 {
+							return fmt.Errorf("--installed does not take a source")
+						}
+						// validate runs outside projects, so locate the project for --installed here
+						if err := enterProjectRoot(); err != nil {
+							return err
+						}
+						installedCmd := commands.NewValidateInstalledCommand(parser, validator)
+						return installedCmd.ExecuteWithOptions(commands.ValidateInstalledOptions{
+							All:    c.Bool("all"),
+							Output: c.String("output"),
+						})
+					}
+					if c.Bool("all") {
+						return fmt.Errorf("--all requires --installed")
+					}
 					if c.NArg() == 0 {
 						return fmt.Errorf("source required")
 					}
+					if c.IsSet("servo-version") && c.String("servo-version") == "" {
+						return fmt.Errorf("--servo-version requires a version (supported: %s)", strings.Join(pkg.SupportedServoVersions, ", "))
+					}
+					if err := setSearchDepth(c, parser); err != nil {
+						return err
+					}
 
+					sources, err := sourceArgs(c)
+					if err != nil {
+						return err
+					}
 					validateCmd := commands.NewValidateCommand(parser, validator)
-					return validateCmd.Execute([]string{c.Args().First()})
+					return validateCmd.ExecuteWithOptions(sources, commands.ValidateOptions{
+						Output:       c.String("output"),
+						LocalOnly:    c.Bool("local-only"),
+						Strict:       c.Bool("strict"),
+						KeepGoing:    c.Bool("keep-going"),
+						ServoVersion: c.String("servo-version"),
+					})
 				},
 			},
 
@@ -385,9 +1196,10 @@ func NewApp(version string) (*cli.App, error) {
 						},
 					},
 					{
-						Name:      "enable",
-						Usage:     "Enable support for one or more clients in the current project",
-						ArgsUsage: "<client> [<client> ...]",
+						Name:         "enable",
+						Usage:        "Enable support for one or more clients in the current project",
+						ArgsUsage:    "<client> [<client> ...]",
+						BashComplete: completer{args: clients, multiple: true}.complete,
 						Action: func(c *cli.Context) error {
 							if !projectManager.IsProject() {
 								return fmt.Errorf("not in a servo project directory")
@@ -424,9 +1236,10 @@ func NewApp(version string) (*cli.App, error) {
 						},
 					},
 					{
-						Name:      "disable",
-						Usage:     "Disable support for one or more clients in the current project",
-						ArgsUsage: "<client> [<client> ...]",
+						Name:         "disable",
+						Usage:        "Disable support for one or more clients in the current project",
+						ArgsUsage:    "<client> [<client> ...]",
+						BashComplete: completer{args: clients, multiple: true}.complete,
 						Action: func(c *cli.Context) error {
 							if !projectManager.IsProject() {
 								return fmt.Errorf("not in a servo project directory")
@@ -462,6 +1275,24 @@ func NewApp(version string) (*cli.App, error) {
 							return nil
 						},
 					},
+					{
+						Name:  "rename-legacy",
+						Usage: "Replace deprecated client names in project.yaml with their current names",
+						Action: func(c *cli.Context) error {
+							renames, err := projectManager.RenameLegacyClients()
+							if err != nil {
+								return fmt.Errorf("failed to rename legacy clients: %w", err)
+							}
+							if len(renames) == 0 {
+								fmt.Println("✅ No legacy client names found")
+								return nil
+							}
+							for _, rename := range renames {
+								fmt.Printf("✅ Renamed client '%s' to '%s'\n", rename.From, rename.To)
+							}
+							return nil
+						},
+					},
 				},
 			},
 
@@ -543,80 +1374,282 @@ func NewApp(version string) (*cli.App, error) {
 			{
 				Name:        "secrets",
 				Usage:       "Manage project secrets",
-				Description: "Manage encrypted secrets for the current project",
+				Description: "Manage the current project's secrets, stored base64-encoded or encrypted with a key",
 				Subcommands: []*cli.Command{
 					{
-						Name:  "list",
-						Usage: "List all secrets in current project",
+						Name:         "list",
+						Usage:        "List all secrets in current project",
+						Flags:        []cli.Flag{secretsSessionFlag()},
+						BashComplete: completer{flags: sessionFlagValues}.complete,
 						Action: func(c *cli.Context) error {
 							secretsCmd := commands.NewSecretsCommand(projectManager)
-							return secretsCmd.Execute([]string{"list"})
+							return secretsCmd.Execute(withSecretsSession(c, "list"))
 						},
 					},
 					{
-						Name:      "set",
-						Usage:     "Set a secret value",
-						ArgsUsage: "<key> <value>",
+						Name:         "set",
+						Usage:        "Set a secret value",
+						ArgsUsage:    "<key> <value>",
+						Flags:        []cli.Flag{secretsSessionFlag()},
+						BashComplete: completer{flags: sessionFlagValues}.complete,
 						Action: func(c *cli.Context) error {
 							if c.NArg() < 2 {
 								return fmt.Errorf("secret key and value required")
 							}
 							secretsCmd := commands.NewSecretsCommand(projectManager)
-							return secretsCmd.Execute([]string{"set", c.Args().Get(0), c.Args().Get(1)})
+							return secretsCmd.Execute(withSecretsSession(c, "set", c.Args().Get(0), c.Args().Get(1)))
 						},
 					},
 					{
-						Name:      "get",
-						Usage:     "Get a secret value",
-						ArgsUsage: "<key>",
+						Name:         "get",
+						Usage:        "Get a secret value",
+						ArgsUsage:    "<key>",
+						Flags:        []cli.Flag{secretsSessionFlag()},
+						BashComplete: completer{flags: sessionFlagValues}.complete,
 						Action: func(c *cli.Context) error {
 							if c.NArg() == 0 {
 								return fmt.Errorf("secret key required")
 							}
 							secretsCmd := commands.NewSecretsCommand(projectManager)
-							return secretsCmd.Execute([]string{"get", c.Args().First()})
+							return secretsCmd.Execute(withSecretsSession(c, "get", c.Args().First()))
 						},
 					},
 					{
-						Name:      "delete",
-						Usage:     "Delete a secret",
-						ArgsUsage: "<key>",
+						Name:         "delete",
+						Usage:        "Delete a secret",
+						ArgsUsage:    "<key>",
+						Flags:        []cli.Flag{secretsSessionFlag()},
+						BashComplete: completer{flags: sessionFlagValues}.complete,
 						Action: func(c *cli.Context) error {
 							if c.NArg() == 0 {
 								return fmt.Errorf("secret key required")
 							}
 							secretsCmd := commands.NewSecretsCommand(projectManager)
-							return secretsCmd.Execute([]string{"delete", c.Args().First()})
+							return secretsCmd.Execute(withSecretsSession(c, "delete", c.Args().First()))
 						},
 					},
 					{
 						Name:      "export",
-						Usage:     "Export encrypted secrets to file",
+						Usage:     "Export secrets to a plaintext file",
 						ArgsUsage: "<output-file>",
 						Action: func(c *cli.Context) error {
 							if c.NArg() == 0 {
 								return fmt.Errorf("output file required")
 							}
 							secretsCmd := commands.NewSecretsCommand(projectManager)
-							return secretsCmd.Execute([]string{"export", c.Args().First()})
+							return secretsCmd.Execute([]string{"export", userPath(c.Args().First())})
 						},
 					},
 					{
 						Name:      "import",
-						Usage:     "Import encrypted secrets from file",
+						Usage:     "Import secrets from an exported file",
 						ArgsUsage: "<input-file>",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "dry-run",
+								Usage: "Report which secrets would be added, changed, left unchanged or removed, without writing",
+							},
+						},
 						Action: func(c *cli.Context) error {
 							if c.NArg() == 0 {
 								return fmt.Errorf("input file required")
 							}
+							// Flags after the file are not parsed by the CLI; importSecrets
+							// reads them from the forwarded arguments
+							args := append([]string{"import", userPath(c.Args().First())}, c.Args().Tail()...)
+							if c.Bool("dry-run") {
+								args = append(args, "--dry-run")
+							}
+							secretsCmd := commands.NewSecretsCommand(projectManager)
+							return secretsCmd.Execute(args)
+						},
+					},
+					{
+						Name:      "import-env",
+						Usage:     "Set secrets from a KEY=VALUE dotenv file",
+						ArgsUsage: "<file>",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "overwrite",
+								Usage: "Replace secrets that are already set",
+							},
+							secretsSessionFlag(),
+						},
+						BashComplete: completer{flags: sessionFlagValues}.complete,
+						Action: func(c *cli.Context) error {
+							if c.NArg() == 0 {
+								return fmt.Errorf("env file required")
+							}
+							args := withSecretsSession(c, append([]string{"import-env", userPath(c.Args().First())}, c.Args().Tail()...)...)
+							if c.Bool("overwrite") {
+								args = append(args, "--overwrite")
+							}
+							secretsCmd := commands.NewSecretsCommand(projectManager)
+							return secretsCmd.Execute(args)
+						},
+					},
+					{
+						Name:        "rekey",
+						Usage:       "Re-encrypt every secret with a new key",
+						Description: "Decrypt the store with the current key (SERVO_SECRETS_KEY or a prompt) and encrypt it with a new one (SERVO_SECRETS_NEW_KEY or a prompt). A base64-encoded store becomes encrypted",
+						Action: func(c *cli.Context) error {
 							secretsCmd := commands.NewSecretsCommand(projectManager)
-							return secretsCmd.Execute([]string{"import", c.Args().First()})
+							return secretsCmd.Execute([]string{"rekey"})
 						},
 					},
 				},
 			},
+
+			{
+				Name:        "completion",
+				Usage:       "Generate a shell completion script",
+				Description: "Print a completion script for bash, zsh or fish that completes commands, flags, session, client and server names. Load it with e.g. 'source <(servo completion bash)'",
+				ArgsUsage:   "<bash|zsh|fish>",
+				BashComplete: func(c *cli.Context) {
+					printCandidates(c, []string{"bash", "fish", "zsh"}, nil)
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() == 0 {
+						return fmt.Errorf("shell required (bash, zsh or fish)")
+					}
+
+					script, err := completionScript(c.Args().First(), c.App.Name)
+					if err != nil {
+						return err
+					}
+					fmt.Fprint(c.App.Writer, script)
+					return nil
+				},
+			},
 		},
 	}
 
 	return app, nil
 }
+
+// ExitCode returns the process exit code for an error returned by the app: 1 for
+// user and usage errors, 2 for validation failures and 3 for unmet environment
+// requirements
+func ExitCode(err error) int {
+	return commands.ExitCode(err)
+}
+
+// secretsSessionFlag selects a session's secrets namespace instead of the project-global secrets
+func secretsSessionFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:    "session",
+		Aliases: []string{"s"},
+		Usage:   "Use this session's secrets namespace, which overrides project-global secrets for the session",
+	}
+}
+
+// withSecretsSession builds SecretsCommand arguments, passing --session through when set
+func withSecretsSession(c *cli.Context, args ...string) []string {
+	if sessionName := c.String("session"); sessionName != "" {
+		args = append(args, "--session", sessionName)
+	}
+	return args
+}
+
+// sourceArgs returns the sources given to a command. urfave/cli stops parsing flags
+// at the first positional argument, so a flag after a source would be taken as
+// another source; it is rejected instead.
+func sourceArgs(c *cli.Context) ([]string, error) {
+	sources := c.Args().Slice()
+	for _, arg := range sources {
+		if strings.HasPrefix(arg, "-") {
+			return nil, fmt.Errorf("flag %s must come before the sources: servo %s [options] <source>...", arg, c.Command.Name)
+		}
+	}
+	return sources, nil
+}
+
+// setSearchDepth applies --search-depth to the parser when given on the command line
+// or in .servorc. A .servorc value becomes the flag's default, so IsSet misses it and
+// a depth other than the built-in default is applied as well.
+func setSearchDepth(c *cli.Context, parser *mcp.Parser) error {
+	depth := c.Int("search-depth")
+	if !c.IsSet("search-depth") && depth == mcp.DefaultManifestSearchDepth {
+		return nil
+	}
+	if depth < 0 {
+		return fmt.Errorf("--search-depth must be 0 or greater")
+	}
+	parser.SearchDepth = &depth
+	return nil
+}
+
+// projectIndependentCommands run in the current directory without locating a parent project
+var projectIndependentCommands = map[string]bool{
+	"":           true,
+	"init":       true,
+	"import":     true,
+	"catalog":    true,
+	"validate":   true,
+	"completion": true,
+	"help":       true,
+	"h":          true,
+}
+
+// launchDir is the directory servo was run from when enterProjectRoot moved to a
+// parent project's root; it is empty when the working directory did not change
+var launchDir string
+
+// enterProjectRoot switches to the nearest parent project when servo runs from a
+// subdirectory, recording the subdirectory so sessions can resolve scoped overrides.
+// Paths given on the command line still refer to the launch directory; resolve them
+// with userPath or userSource.
+func enterProjectRoot() error {
+	launchDir = ""
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	root, relPath, err := project.FindProjectRoot(cwd)
+	if err != nil || relPath == "" {
+		// Outside any project, or already at the root; commands report this themselves
+		return nil
+	}
+
+	if err := os.Chdir(root); err != nil {
+		return fmt.Errorf("failed to enter project root %s: %w", root, err)
+	}
+	launchDir = cwd
+	return os.Setenv(session.ScopeEnvVar, relPath)
+}
+
+// userPath resolves a relative path given on the command line against the launch
+// directory. A path inside the project comes back relative to the project root,
+// which is the working directory by then, so it can still be stored in project files.
+func userPath(p string) string {
+	if p == "" || launchDir == "" || filepath.IsAbs(p) {
+		return p
+	}
+	abs := filepath.Join(launchDir, p)
+	cwd, err := os.Getwd()
+	if err != nil {
+		return abs
+	}
+	rel, err := filepath.Rel(cwd, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return abs
+	}
+	if rel == "." {
+		return rel
+	}
+	// Keep the ./ so a path such as servers/db is not read as GitHub shorthand
+	return "." + string(filepath.Separator) + rel
+}
+
+// userSource resolves an install source like userPath when it names a local path in
+// the launch directory; URLs, git and shorthand sources are returned unchanged
+func userSource(source string) string {
+	if launchDir == "" || filepath.IsAbs(source) {
+		return source
+	}
+	if _, err := os.Stat(filepath.Join(launchDir, source)); err != nil {
+		return source
+	}
+	return userPath(source)
+}
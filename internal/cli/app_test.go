@@ -1,6 +1,9 @@
 package cli
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/urfave/cli/v2"
@@ -321,3 +324,91 @@ func TestApp_ClientListCommand(t *testing.T) {
 		t.Error("List command missing action")
 	}
 }
+
+func TestApp_SecretsImportTrailingDryRun(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+	os.MkdirAll(".servo", 0755)
+	os.WriteFile(".servo/project.yaml", []byte("version: 1\n"), 0644)
+	os.WriteFile(".servo/secrets.yaml", []byte("version: \"1.0\"\nsecrets:\n  kept: dmFsdWU=\n"), 0600)
+	os.WriteFile("backup.yaml", []byte("version: \"1.0\"\nsecrets: {}\n"), 0600)
+	before, _ := os.ReadFile(".servo/secrets.yaml")
+
+	app, err := NewApp("test-version")
+	if err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+	app.Writer = &strings.Builder{}
+	if err := app.Run([]string{"servo", "secrets", "import", "backup.yaml", "--dry-run"}); err != nil {
+		t.Fatalf("secrets import --dry-run failed: %v", err)
+	}
+	if after, _ := os.ReadFile(".servo/secrets.yaml"); string(after) != string(before) {
+		t.Error("A --dry-run after the file must leave the secrets store untouched")
+	}
+
+	err = app.Run([]string{"servo", "secrets", "import", "backup.yaml", "--dryrun"})
+	if err == nil || !strings.Contains(err.Error(), "unknown flag --dryrun") {
+		t.Errorf("Expected an unknown flag error, got %v", err)
+	}
+	if after, _ := os.ReadFile(".servo/secrets.yaml"); string(after) != string(before) {
+		t.Error("A rejected import must leave the secrets store untouched")
+	}
+}
+
+func TestApp_FlagAfterSource(t *testing.T) {
+	for _, command := range []string{"install", "validate"} {
+		app, err := NewApp("test-version")
+		if err != nil {
+			t.Fatalf("Failed to create app: %v", err)
+		}
+		app.Writer = &strings.Builder{}
+		err = app.Run([]string{"servo", command, "./server.servo", "--clients", "vscode"})
+		if err == nil || !strings.Contains(err.Error(), "flag --clients must come before the sources") {
+			t.Errorf("%s: expected a flag placement error, got %v", command, err)
+		}
+	}
+}
+
+func TestApp_PathsFromSubdirectory(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	root, _ := filepath.EvalSymlinks(t.TempDir())
+	os.Chdir(root)
+	os.MkdirAll(".servo", 0755)
+	os.WriteFile(".servo/project.yaml", []byte("version: 1\n"), 0644)
+	os.MkdirAll(filepath.Join("src", "api"), 0755)
+	os.Chdir(filepath.Join(root, "src"))
+
+	app, err := NewApp("test-version")
+	if err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+	app.Writer = &strings.Builder{}
+	if err := app.Run([]string{"servo", "secrets", "export", "backup.yaml"}); err != nil {
+		t.Fatalf("secrets export failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "src", "backup.yaml")); err != nil {
+		t.Errorf("Expected the export in the directory servo ran from: %v", err)
+	}
+
+	// Paths inside the project come back relative to the root, others absolute
+	tests := map[string]string{
+		"api":             "./src/api",
+		"../README.md":    "./README.md",
+		"..":              ".",
+		"../../elsewhere": filepath.Join(filepath.Dir(root), "elsewhere"),
+		"/abs/path":       "/abs/path",
+	}
+	for input, want := range tests {
+		if got := userPath(input); got != want {
+			t.Errorf("userPath(%q) = %q, want %q", input, got, want)
+		}
+	}
+	if got := userSource("api"); got != "./src/api" {
+		t.Errorf("userSource(%q) = %q, want ./src/api", "api", got)
+	}
+	if got := userSource("acme/servers"); got != "acme/servers" {
+		t.Errorf("userSource() changed a shorthand source: %q", got)
+	}
+}
@@ -1,7 +1,10 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/servo/servo/internal/client"
 	"github.com/servo/servo/internal/config"
@@ -56,4 +59,83 @@ func NewBaseDependenciesWithParsers(parser *mcp.Parser, validator *mcp.Validator
 		Validator:      validator,
 		ServoDir:       servoDir,
 	}
-}
\ No newline at end of file
+}
+
+// resolveSession returns the named session, or the active session falling back to the default
+func resolveSession(sessionManager *session.Manager, proj *project.Project, sessionName string) (string, error) {
+	if sessionName != "" {
+		exists, err := sessionManager.Exists(sessionName)
+		if err != nil {
+			return "", fmt.Errorf("failed to check if session exists: %w", err)
+		}
+		if !exists {
+			return "", fmt.Errorf("session '%s' does not exist", sessionName)
+		}
+		return sessionName, nil
+	}
+
+	activeName, err := sessionManager.GetActiveName()
+	if err != nil {
+		return "", fmt.Errorf("failed to get active session: %w", err)
+	}
+	if activeName != "" {
+		return activeName, nil
+	}
+	return proj.DefaultSession, nil
+}
+
+// allClientsKeyword is the --clients value that stands for every enabled project client
+const allClientsKeyword = "all"
+
+// expandAllClients replaces an "all" entry in clients with the project's enabled
+// clients, keeping any clients listed alongside it and dropping duplicates. A list
+// without "all" is returned unchanged.
+func expandAllClients(clients []string, proj *project.Project) ([]string, error) {
+	if !containsAllClients(clients) {
+		return clients, nil
+	}
+	if len(proj.Clients) == 0 {
+		return nil, fmt.Errorf("--clients all found no enabled clients in this project; enable one with 'servo client enable <name>' or list clients explicitly")
+	}
+
+	var expanded []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			expanded = append(expanded, name)
+		}
+	}
+	for _, name := range clients {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name != allClientsKeyword {
+			add(project.CanonicalClientName(name))
+			continue
+		}
+		for _, enabled := range proj.Clients {
+			add(enabled)
+		}
+	}
+	return expanded, nil
+}
+
+// containsAllClients reports whether clients includes the "all" keyword
+func containsAllClients(clients []string) bool {
+	for _, name := range clients {
+		if strings.EqualFold(strings.TrimSpace(name), allClientsKeyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// requireActiveSession returns the active session. When none is active the
+// session.NoActiveSessionError is marked as an unmet environment requirement, so
+// every command that needs a session fails with the same message and exit code.
+func requireActiveSession(sessionManager *session.Manager) (*session.Session, error) {
+	active, err := sessionManager.RequireActive()
+	if errors.Is(err, session.ErrNoActiveSession) {
+		return nil, environmentFailure(err)
+	}
+	return active, err
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+)
+
+// runBatch runs fn once per item for commands that accept several sources or
+// targets. Without keepGoing it stops at the first failure and returns that error
+// unchanged. With keepGoing it runs every item, prints a summary of the failures to
+// stderr (stdout may carry JSON), and returns an error counting them that keeps
+// the failures' exit code when they all share one. A single item is run directly,
+// so single-item commands behave exactly as before.
+func runBatch(items []string, keepGoing bool, fn func(item string) error) error {
+	if len(items) == 1 {
+		return fn(items[0])
+	}
+
+	type failure struct {
+		item string
+		err  error
+	}
+	var failures []failure
+
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			if !keepGoing {
+				return err
+			}
+			failures = append(failures, failure{item: item, err: err})
+		}
+	}
+
+	if !keepGoing {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n📋 Summary: %d succeeded, %d failed\n", len(items)-len(failures), len(failures))
+	for _, f := range failures {
+		fmt.Fprintf(os.Stderr, "  ❌ %s: %v\n", f.item, f.err)
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+	code := ExitCode(failures[0].err)
+	for _, f := range failures[1:] {
+		if ExitCode(f.err) != code {
+			code = ExitUsage
+			break
+		}
+	}
+	return withExitCode(fmt.Errorf("%d of %d item(s) failed", len(failures), len(items)), code)
+}
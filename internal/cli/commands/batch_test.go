@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/mcp"
+)
+
+func TestRunBatch(t *testing.T) {
+	failing := map[string]bool{"b": true, "d": true}
+	run := func(keepGoing bool) ([]string, error) {
+		var ran []string
+		err := runBatch([]string{"a", "b", "c", "d"}, keepGoing, func(item string) error {
+			ran = append(ran, item)
+			if failing[item] {
+				return errors.New(item + " broke")
+			}
+			return nil
+		})
+		return ran, err
+	}
+
+	ran, err := run(false)
+	if err == nil || err.Error() != "b broke" || !reflect.DeepEqual(ran, []string{"a", "b"}) {
+		t.Errorf("without keep-going: ran %v, err %v; want stop at b with its error", ran, err)
+	}
+
+	ran, err = run(true)
+	if err == nil || err.Error() != "2 of 4 item(s) failed" || len(ran) != 4 {
+		t.Errorf("with keep-going: ran %v, err %v; want all items and a failure count", ran, err)
+	}
+
+	// A single item returns its own error, with no summary wrapping
+	err = runBatch([]string{"b"}, true, func(item string) error { return errors.New("single") })
+	if err == nil || err.Error() != "single" {
+		t.Errorf("single item error = %v, want unwrapped error", err)
+	}
+}
+
+func TestValidateCommand_KeepGoing(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.servo")
+	os.WriteFile(valid, []byte(`servo_version: "1.0"
+name: valid
+install:
+  type: local
+  method: local
+  setup_commands:
+    - "true"
+server:
+  transport: stdio
+  command: valid
+  args: ["--stdio"]
+`), 0644)
+	missing := filepath.Join(dir, "missing.servo")
+
+	cmd := NewValidateCommand(mcp.NewParser(), mcp.NewValidator())
+	err := cmd.ExecuteWithOptions([]string{missing, valid, missing}, ValidateOptions{KeepGoing: true})
+	if err == nil || !strings.Contains(err.Error(), "2 of 3 item(s) failed") {
+		t.Errorf("Expected both missing sources to be counted, got %v", err)
+	}
+
+	if err := cmd.ExecuteWithOptions([]string{valid, valid}, ValidateOptions{KeepGoing: true}); err != nil {
+		t.Errorf("Expected valid sources to pass, got %v", err)
+	}
+
+	// Several sources in JSON mode print one array of reports
+	reader, writer, _ := os.Pipe()
+	stdout := os.Stdout
+	os.Stdout = writer
+	err = cmd.ExecuteWithOptions([]string{valid, missing}, ValidateOptions{Output: "json", KeepGoing: true})
+	os.Stdout = stdout
+	writer.Close()
+	output, _ := io.ReadAll(reader)
+
+	var reports []SourceValidationReport
+	if jsonErr := json.Unmarshal(output, &reports); jsonErr != nil {
+		t.Fatalf("Expected one JSON array, got %v:\n%s", jsonErr, output)
+	}
+	if len(reports) != 2 || reports[0].Source != valid || !reports[0].Valid || reports[1].Source != missing || reports[1].Valid {
+		t.Errorf("Unexpected reports %s", output)
+	}
+	if ExitCode(err) != ExitEnvironment {
+		t.Errorf("Expected an environment failure for the missing source, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/servo/servo/internal/catalog"
+	"github.com/servo/servo/internal/logging"
+	"github.com/servo/servo/internal/mcp"
+)
+
+// CatalogCommand manages the catalog indexes that let servers be installed by name
+type CatalogCommand struct {
+	parser *mcp.Parser
+
+	// storePath overrides the catalog store in the home directory
+	storePath string
+}
+
+// NewCatalogCommand creates a new catalog command
+func NewCatalogCommand(parser *mcp.Parser) *CatalogCommand {
+	return &CatalogCommand{parser: parser}
+}
+
+// Name returns the command name
+func (c *CatalogCommand) Name() string {
+	return "catalog"
+}
+
+// Description returns the command description
+func (c *CatalogCommand) Description() string {
+	return "Add catalog indexes and search the servers they list"
+}
+
+// Add fetches the index at indexURL, validates it and saves it to the catalog
+// store. Adding a URL again refreshes its entries.
+func (c *CatalogCommand) Add(indexURL string) error {
+	if !strings.HasPrefix(indexURL, "http://") && !strings.HasPrefix(indexURL, "https://") {
+		return fmt.Errorf("catalog index must be an http:// or https:// URL: %s", indexURL)
+	}
+
+	data, err := c.parser.FetchURL(indexURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch catalog index: %w", err)
+	}
+	entries, err := catalog.ParseIndex(data)
+	if err != nil {
+		return fmt.Errorf("invalid catalog index %s: %w", indexURL, err)
+	}
+
+	path, err := c.path()
+	if err != nil {
+		return err
+	}
+	store, err := catalog.Load(path)
+	if err != nil {
+		return err
+	}
+	refreshed := store.Add(indexURL, entries)
+	if err := store.Save(path); err != nil {
+		return fmt.Errorf("failed to save catalog: %w", err)
+	}
+
+	if refreshed {
+		fmt.Printf("✅ Refreshed catalog %s (%d servers)\n", indexURL, len(entries))
+	} else {
+		fmt.Printf("✅ Added catalog %s (%d servers)\n", indexURL, len(entries))
+	}
+	fmt.Printf("💡 Install a listed server with: servo install <name>\n")
+	return nil
+}
+
+// Search prints the catalog servers whose name or description contains term; an
+// empty term lists every server
+func (c *CatalogCommand) Search(term string) error {
+	path, err := c.path()
+	if err != nil {
+		return err
+	}
+	store, err := catalog.Load(path)
+	if err != nil {
+		return err
+	}
+
+	if len(store.Catalogs) == 0 {
+		fmt.Printf("No catalogs added. Add one with: servo catalog add <index-url>\n")
+		return nil
+	}
+
+	matches := store.Search(term)
+	if len(matches) == 0 {
+		fmt.Printf("No catalog servers match '%s'\n", term)
+		return nil
+	}
+
+	fmt.Printf("%-25s %-40s %s\n", "NAME", "DESCRIPTION", "SOURCE")
+	fmt.Printf("%-25s %-40s %s\n", "----", "-----------", "------")
+	for _, match := range matches {
+		description := match.Description
+		if description == "" {
+			description = "-"
+		}
+		fmt.Printf("%-25s %-40s %s\n", match.Name, description, match.Source)
+	}
+	return nil
+}
+
+// path returns the catalog store this command reads and writes
+func (c *CatalogCommand) path() (string, error) {
+	if c.storePath != "" {
+		return c.storePath, nil
+	}
+	return catalog.StorePath()
+}
+
+// resolveCatalogSource returns the source a catalog lists for a bare server name.
+// Paths, URLs, repositories and names no catalog lists are returned unchanged.
+func resolveCatalogSource(source string) string {
+	if strings.ContainsAny(source, `/\:@`) || strings.HasSuffix(source, ".servo") {
+		return source
+	}
+	if _, err := os.Stat(source); err == nil {
+		return source
+	}
+
+	path, err := catalog.StorePath()
+	if err != nil {
+		return source
+	}
+	store, err := catalog.Load(path)
+	if err != nil {
+		logging.Warn("ignoring catalog store", "error", err)
+		return source
+	}
+
+	match, ok := store.Find(source)
+	if !ok {
+		return source
+	}
+	fmt.Printf("🔗 Resolved '%s' from catalog %s: %s\n", source, match.CatalogURL, match.Source)
+	return match.Source
+}
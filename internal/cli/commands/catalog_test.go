@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/catalog"
+	"github.com/servo/servo/internal/manifest"
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/session"
+)
+
+func TestCatalogCommand_AddAndInstall(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	indexes := map[string]string{
+		"/index.yaml": "- name: notes\n  description: Searchable notes\n  source: notes-server.servo\n",
+		"/bad.yaml":   "- name: notes\n  description: no source\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		index, ok := indexes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(index))
+	}))
+	defer server.Close()
+
+	storePath, err := catalog.StorePath()
+	if err != nil {
+		t.Fatalf("StorePath() error = %v", err)
+	}
+
+	cmd := NewCatalogCommand(mcp.NewParser())
+	if err := cmd.Add(server.URL + "/bad.yaml"); err == nil || !strings.Contains(err.Error(), "source is required") {
+		t.Fatalf("Add() of an invalid index error = %v, want a shape error", err)
+	}
+	if _, err := os.Stat(storePath); !os.IsNotExist(err) {
+		t.Fatal("an invalid index must not be saved")
+	}
+	if err := cmd.Add("./index.yaml"); err == nil {
+		t.Error("Add() should require an http(s) URL")
+	}
+
+	if err := cmd.Add(server.URL + "/index.yaml"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := cmd.Search("NOTES"); err != nil {
+		t.Errorf("Search() error = %v", err)
+	}
+
+	// Bare names resolve through the catalog; anything path-like is left alone
+	if got := resolveCatalogSource("notes"); got != "notes-server.servo" {
+		t.Errorf("resolveCatalogSource(notes) = %q", got)
+	}
+	for _, source := range []string{"unknown", "./notes", "github:acme/notes", filepath.Join("dir", "notes")} {
+		if got := resolveCatalogSource(source); got != source {
+			t.Errorf("resolveCatalogSource(%q) = %q, want it unchanged", source, got)
+		}
+	}
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+	os.WriteFile("notes-server.servo", []byte("servo_version: \"1.0\"\nname: notes\nserver:\n  transport: stdio\n  command: notes\n"), 0644)
+
+	install := NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	if err := install.ExecuteWithOptions([]string{"notes"}, []string{"vscode"}, "", false); err != nil {
+		t.Fatalf("install of a catalog name failed: %v", err)
+	}
+	store := manifest.NewStore(session.NewManager(".servo").ManifestsDir("default"), mcp.NewParser())
+	if names, _ := store.Names(); len(names) != 1 || names[0] != "notes" {
+		t.Errorf("Expected the catalog server to be installed, got %v", names)
+	}
+}
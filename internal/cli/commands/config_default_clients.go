@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/servo/servo/internal/project"
+)
+
+// ConfigDefaultClientsCommand shows and sets the clients install targets when
+// --clients is omitted
+type ConfigDefaultClientsCommand struct {
+	projectManager *project.Manager
+}
+
+// NewConfigDefaultClientsCommand creates a new config default-clients command
+func NewConfigDefaultClientsCommand() *ConfigDefaultClientsCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &ConfigDefaultClientsCommand{
+		projectManager: deps.ProjectManager,
+	}
+}
+
+// Name returns the command name
+func (c *ConfigDefaultClientsCommand) Name() string {
+	return "default-clients"
+}
+
+// Description returns the command description
+func (c *ConfigDefaultClientsCommand) Description() string {
+	return "Show or set the clients install uses without --clients"
+}
+
+// Execute shows the default install clients, or runs "set <client>..." or "clear"
+func (c *ConfigDefaultClientsCommand) Execute(args []string) error {
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	if len(args) == 0 {
+		return c.show()
+	}
+	switch args[0] {
+	case "set":
+		if len(args) < 2 {
+			return fmt.Errorf("at least one client name required (e.g. vscode, claude-code, cursor)")
+		}
+		return c.set(args[1:])
+	case "clear":
+		return c.set(nil)
+	default:
+		return fmt.Errorf("unknown default-clients action '%s' (supported: set, clear)", args[0])
+	}
+}
+
+// show prints the default install clients, or that every enabled client is used
+func (c *ConfigDefaultClientsCommand) show() error {
+	proj, err := c.projectManager.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	if len(proj.DefaultInstallClients) == 0 {
+		if len(proj.Clients) == 0 {
+			fmt.Println("📋 Default install clients: every detected client (no clients enabled)")
+		} else {
+			fmt.Printf("📋 Default install clients: all enabled clients (%s)\n", strings.Join(proj.Clients, ", "))
+		}
+		return nil
+	}
+	fmt.Printf("📋 Default install clients: %s\n", strings.Join(proj.DefaultInstallClients, ", "))
+	return nil
+}
+
+// set records the default install clients; an empty list clears them
+func (c *ConfigDefaultClientsCommand) set(clients []string) error {
+	if err := c.projectManager.SetDefaultInstallClients(clients); err != nil {
+		return err
+	}
+	if len(clients) == 0 {
+		fmt.Println("✅ Cleared default install clients; install targets every enabled client")
+		return nil
+	}
+
+	proj, err := c.projectManager.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	fmt.Printf("✅ Default install clients set to %s\n", strings.Join(proj.DefaultInstallClients, ", "))
+	return nil
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/project"
+)
+
+func TestConfigDefaultClientsCommand_Execute(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	cmd := NewConfigDefaultClientsCommand()
+	if err := cmd.Execute(nil); err == nil {
+		t.Fatal("Expected error outside of a project")
+	}
+
+	projectManager := project.NewManager()
+	if _, err := projectManager.Init("default", []string{"vscode", "cursor"}); err != nil {
+		t.Fatalf("Failed to init project: %v", err)
+	}
+
+	if err := cmd.Execute(nil); err != nil {
+		t.Errorf("Execute() show error = %v", err)
+	}
+	if err := cmd.Execute([]string{"set"}); err == nil {
+		t.Error("Expected set without clients to fail")
+	}
+	if err := cmd.Execute([]string{"remove", "vscode"}); err == nil || !strings.Contains(err.Error(), "supported: set, clear") {
+		t.Errorf("Expected an unknown action error, got %v", err)
+	}
+
+	if err := cmd.Execute([]string{"set", "cursor"}); err != nil {
+		t.Fatalf("Execute(set cursor) error = %v", err)
+	}
+	proj, _ := projectManager.Get()
+	if strings.Join(proj.DefaultInstallClients, ",") != "cursor" {
+		t.Errorf("default install clients = %v, want [cursor]", proj.DefaultInstallClients)
+	}
+
+	if err := cmd.Execute([]string{"clear"}); err != nil {
+		t.Fatalf("Execute(clear) error = %v", err)
+	}
+	proj, _ = projectManager.Get()
+	if len(proj.DefaultInstallClients) != 0 {
+		t.Errorf("default install clients after clear = %v, want none", proj.DefaultInstallClients)
+	}
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/utils"
+)
+
+// editFunc opens a file in an editor and returns once the editor exits
+type editFunc func(path string) error
+
+// ConfigEditCommand opens project.yaml in the user's editor and validates the result
+type ConfigEditCommand struct {
+	projectManager *project.Manager
+	edit           editFunc
+}
+
+// NewConfigEditCommand creates a new config edit command
+func NewConfigEditCommand() *ConfigEditCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &ConfigEditCommand{
+		projectManager: deps.ProjectManager,
+		edit:           openInEditor,
+	}
+}
+
+// Name returns the command name
+func (c *ConfigEditCommand) Name() string {
+	return "edit"
+}
+
+// Description returns the command description
+func (c *ConfigEditCommand) Description() string {
+	return "Edit project.yaml in $EDITOR"
+}
+
+// Execute runs the config edit command
+func (c *ConfigEditCommand) Execute(args []string) error {
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	projectFile := c.projectManager.GetProjectFile()
+	original, err := os.ReadFile(projectFile)
+	if err != nil {
+		return fmt.Errorf("failed to read project file: %w", err)
+	}
+
+	// A rejected edit is kept next to project.yaml and resumed on the next run
+	editFile := projectFile + ".edit"
+	if _, err := os.Stat(editFile); err == nil {
+		fmt.Printf("📝 Resuming previous edit from %s\n", editFile)
+	} else if err := os.WriteFile(editFile, original, 0644); err != nil {
+		return fmt.Errorf("failed to create edit file: %w", err)
+	}
+
+	if err := c.edit(editFile); err != nil {
+		return fmt.Errorf("editor failed (edit kept at %s): %w", editFile, err)
+	}
+
+	edited, err := os.ReadFile(editFile)
+	if err != nil {
+		return fmt.Errorf("failed to read edited file: %w", err)
+	}
+
+	if bytes.Equal(edited, original) {
+		os.Remove(editFile)
+		fmt.Println("No changes made to project.yaml")
+		return nil
+	}
+
+	if err := c.projectManager.SaveRaw(edited); err != nil {
+		fmt.Printf("❌ Edit rejected: %v\n", err)
+		fmt.Printf("   Your changes are saved in %s; run 'servo config edit' again to fix them\n", editFile)
+		return fmt.Errorf("invalid project configuration: %w", err)
+	}
+
+	os.Remove(editFile)
+	fmt.Println("✅ Updated project.yaml")
+	return nil
+}
+
+// openInEditor opens a file using $VISUAL or $EDITOR, falling back to a platform default
+func openInEditor(path string) error {
+	parts := editorCommand()
+	cmd := exec.Command(parts[0], append(parts[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// editorCommand splits the configured editor into a command and its arguments.
+// Editors are often configured with arguments, e.g. "code --wait"; a variable
+// holding only whitespace counts as unset
+func editorCommand() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if parts := strings.Fields(os.Getenv(name)); len(parts) > 0 {
+			return parts
+		}
+	}
+	if utils.CurrentPlatform() == utils.PlatformWindows {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
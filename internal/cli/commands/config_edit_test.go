@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/project"
+)
+
+func TestConfigEditCommand_Execute(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	cmd := NewConfigEditCommand()
+	if err := cmd.Execute(nil); err == nil {
+		t.Fatal("Expected error outside of a project")
+	}
+
+	projectManager := project.NewManager()
+	if _, err := projectManager.Init("default", []string{"vscode"}); err != nil {
+		t.Fatalf("Failed to init project: %v", err)
+	}
+	projectFile := projectManager.GetProjectFile()
+	editFile := projectFile + ".edit"
+
+	// No changes leaves the file alone and cleans up
+	cmd.edit = func(path string) error { return nil }
+	if err := cmd.Execute(nil); err != nil {
+		t.Fatalf("Execute() without changes error = %v", err)
+	}
+	if _, err := os.Stat(editFile); !os.IsNotExist(err) {
+		t.Error("Edit file should be removed when nothing changed")
+	}
+
+	// Invalid YAML is rejected, project.yaml is untouched and the edit is preserved
+	before, _ := os.ReadFile(projectFile)
+	cmd.edit = func(path string) error {
+		return os.WriteFile(path, []byte("default_session: [unterminated\n"), 0644)
+	}
+	if err := cmd.Execute(nil); err == nil {
+		t.Fatal("Expected invalid YAML to be rejected")
+	}
+	after, _ := os.ReadFile(projectFile)
+	if string(before) != string(after) {
+		t.Error("project.yaml should not change when the edit is rejected")
+	}
+	if _, err := os.Stat(editFile); err != nil {
+		t.Fatalf("Rejected edit should be preserved: %v", err)
+	}
+
+	// The next run resumes the preserved edit; unknown keys and comments survive
+	edited := "# team settings\ndefault_session: default\nclients:\n  - vscode\ncustom_team_key:\n  owner: platform\n"
+	cmd.edit = func(path string) error {
+		data, _ := os.ReadFile(path)
+		if !strings.Contains(string(data), "unterminated") {
+			t.Error("Expected the preserved edit to be resumed")
+		}
+		return os.WriteFile(path, []byte(edited), 0644)
+	}
+	if err := cmd.Execute(nil); err != nil {
+		t.Fatalf("Execute() with valid edit error = %v", err)
+	}
+	after, _ = os.ReadFile(projectFile)
+	if string(after) != edited {
+		t.Errorf("Expected project.yaml to be written verbatim, got:\n%s", after)
+	}
+	if _, err := os.Stat(editFile); !os.IsNotExist(err) {
+		t.Error("Edit file should be removed after a successful save")
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		visual string
+		editor string
+		want   string
+	}{
+		{"visual wins", "code --wait", "nano", "code --wait"},
+		{"editor used when visual is unset", "", "nano", "nano"},
+		{"whitespace visual falls through", "   ", "nano", "nano"},
+		{"whitespace only uses default", " \t", "  ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", tt.visual)
+			t.Setenv("EDITOR", tt.editor)
+
+			got := editorCommand()
+			if len(got) == 0 {
+				t.Fatal("Expected an editor command")
+			}
+			if tt.want != "" && strings.Join(got, " ") != tt.want {
+				t.Errorf("editorCommand() = %v, want %q", got, tt.want)
+			}
+			if tt.want == "" && got[0] != "vi" && got[0] != "notepad" {
+				t.Errorf("Expected the platform default editor, got %v", got)
+			}
+		})
+	}
+}
@@ -3,20 +3,51 @@ package commands
 import (
 	"fmt"
 	"os"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/servo/servo/internal/config"
+	"github.com/servo/servo/internal/mcp"
 	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+	"github.com/servo/servo/pkg"
 )
 
 // ConfigureCommand handles generating MCP client configurations
 type ConfigureCommand struct {
 	projectManager *project.Manager
+	sessionManager *session.Manager
+	clientRegistry pkg.ClientRegistry
+	parser         *mcp.Parser
+
+	// NoDevcontainer generates client configs only, skipping devcontainer and docker-compose output
+	NoDevcontainer bool
+
+	// KeepGoing generates every client config even after one fails, then summarizes the failures
+	KeepGoing bool
+
+	// SkipSecretValidation generates even when required secrets are missing, leaving
+	// their placeholders unresolved and marking the output incomplete
+	SkipSecretValidation bool
+
+	// Clients regenerates only these clients' configs, skipping devcontainer and
+	// docker-compose output unless IncludeDevcontainer is set. Empty generates everything.
+	Clients []string
+
+	// IncludeDevcontainer also regenerates devcontainer output when Clients is set
+	IncludeDevcontainer bool
 }
 
 // NewConfigureCommand creates a new configure command
 func NewConfigureCommand() *ConfigureCommand {
+	deps := NewBaseCommandDependencies()
+
 	return &ConfigureCommand{
-		projectManager: project.NewManager(),
+		projectManager: deps.ProjectManager,
+		sessionManager: deps.SessionManager,
+		clientRegistry: deps.ClientRegistry,
+		parser:         deps.Parser,
 	}
 }
 
@@ -37,11 +68,20 @@ func (c *ConfigureCommand) Execute(args []string) error {
 		return fmt.Errorf("not in a servo project directory")
 	}
 
+	if c.IncludeDevcontainer && len(c.Clients) == 0 {
+		return fmt.Errorf("--include-devcontainer requires --client")
+	}
+
 	project, err := c.projectManager.Get()
 	if err != nil {
 		return fmt.Errorf("failed to get project: %w", err)
 	}
 
+	c.Clients, err = expandAllClients(c.Clients, project)
+	if err != nil {
+		return err
+	}
+
 	fmt.Printf("🔧 Generating MCP client configurations...\n")
 
 	if len(project.MCPServers) == 0 {
@@ -50,18 +90,33 @@ func (c *ConfigureCommand) Execute(args []string) error {
 	}
 
 	// Generate configurations
-	if err := c.generateConfigurations(); err != nil {
+	missingSecrets, err := c.generateConfigurations()
+	if err != nil {
 		return fmt.Errorf("failed to generate configurations: %w", err)
 	}
 
-	fmt.Printf("✅ Configuration files generated successfully!\n")
-	fmt.Printf("   → Devcontainer: .devcontainer/devcontainer.json\n")
-	fmt.Printf("   → Services: .devcontainer/docker-compose.yml\n")
+	if len(missingSecrets) > 0 {
+		fmt.Printf("⚠️  Configuration files generated INCOMPLETE: required secrets not configured: %s\n", strings.Join(missingSecrets, ", "))
+		fmt.Printf("   Secret placeholders were left unresolved and the output is marked with %s.\n", config.IncompleteMarkerKey)
+		fmt.Printf("   Set them with 'servo secrets set <name> <value>' and run 'servo configure' again before use.\n")
+	} else {
+		fmt.Printf("✅ Configuration files generated successfully!\n")
+	}
+	if c.generatesDevcontainer() && devcontainerEnabled(project, c.NoDevcontainer) {
+		fmt.Printf("   → Devcontainer: .devcontainer/devcontainer.json\n")
+		if composeFileGenerated() {
+			fmt.Printf("   → Services: .devcontainer/docker-compose.yml\n")
+		}
+	}
 
 	// Show which clients were configured
-	if len(project.Clients) > 0 {
+	configured := project.Clients
+	if len(c.Clients) > 0 {
+		configured, _ = c.scopedClients()
+	}
+	if len(configured) > 0 {
 		fmt.Printf("   → Client configs:\n")
-		for _, clientName := range project.Clients {
+		for _, clientName := range configured {
 			switch clientName {
 			case "vscode":
 				fmt.Printf("     • VSCode: .vscode/mcp.json\n")
@@ -77,20 +132,87 @@ func (c *ConfigureCommand) Execute(args []string) error {
 	return nil
 }
 
-// generateConfigurations generates all necessary configuration files
-func (c *ConfigureCommand) generateConfigurations() error {
+// generatesDevcontainer reports whether this run regenerates devcontainer output,
+// which a run scoped to named clients only does with IncludeDevcontainer
+func (c *ConfigureCommand) generatesDevcontainer() bool {
+	return len(c.Clients) == 0 || c.IncludeDevcontainer
+}
+
+// scopedClients resolves the clients named in Clients, accepting legacy names, and
+// fails on any client the registry does not know
+func (c *ConfigureCommand) scopedClients() ([]string, error) {
+	var names []string
+	for _, name := range c.Clients {
+		client, err := c.clientRegistry.Get(project.CanonicalClientName(name))
+		if err != nil {
+			var available []string
+			for _, registered := range c.clientRegistry.List() {
+				available = append(available, registered.Name())
+			}
+			sort.Strings(available)
+			return nil, fmt.Errorf("unknown client '%s' (available: %s)", name, strings.Join(available, ", "))
+		}
+		if !slices.Contains(names, client.Name()) {
+			names = append(names, client.Name())
+		}
+	}
+	return names, nil
+}
+
+// generateConfigurations generates all necessary configuration files: the
+// devcontainer output followed by the config of each installed client, or only the
+// clients named in Clients. It returns the required secrets that were missing when
+// secret validation is skipped.
+func (c *ConfigureCommand) generateConfigurations() ([]string, error) {
 	servoDir := c.projectManager.GetServoDir()
 	configManager := config.NewConfigGeneratorManager(servoDir)
+	configManager.SetSkipSecretValidation(c.SkipSecretValidation)
 
-	// Generate devcontainer configuration
-	if err := configManager.GenerateDevcontainer(); err != nil {
-		return fmt.Errorf("failed to generate devcontainer: %w", err)
+	activeSession, err := requireActiveSession(c.sessionManager)
+	if err != nil {
+		return nil, err
 	}
 
-	// Generate docker-compose configuration
-	if err := configManager.GenerateDockerCompose(); err != nil {
-		return fmt.Errorf("failed to generate docker-compose: %w", err)
+	scoped, err := c.scopedClients()
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	var targets []string
+	if c.generatesDevcontainer() {
+		targets = append(targets, "devcontainer")
+	}
+	manifests, secretsProvider, err := clientConfigInputs(c.projectManager, c.sessionManager, c.parser, activeSession.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(scoped) > 0 {
+		// Named clients are generated even when their app is not detected
+		targets = append(targets, scoped...)
+	} else {
+		for _, client := range c.clientRegistry.List() {
+			if client.IsInstalled() {
+				targets = append(targets, client.Name())
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	err = runBatch(targets, c.KeepGoing, func(target string) error {
+		if target == "devcontainer" {
+			_, err := generateDevcontainerConfigs(c.projectManager, configManager, c.NoDevcontainer)
+			return err
+		}
+		client, err := c.clientRegistry.Get(target)
+		if err != nil {
+			return err
+		}
+		return writeClientConfig(client, manifests, secretsProvider, clientEnvFilesEnabled(c.projectManager))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return configManager.MissingSecrets(), nil
 }
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/servo/servo/internal/manifest"
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+)
+
+// CopyManifestCommand copies one installed server from one session to another
+type CopyManifestCommand struct {
+	projectManager *project.Manager
+	sessionManager *session.Manager
+	parser         *mcp.Parser
+
+	// Overwrite replaces the server when the target session already has it
+	Overwrite bool
+	// Reconfigure rebuilds generated configuration when the target session is active
+	Reconfigure bool
+}
+
+// NewCopyManifestCommand creates a new session copy-manifest command
+func NewCopyManifestCommand() *CopyManifestCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &CopyManifestCommand{
+		projectManager: deps.ProjectManager,
+		sessionManager: deps.SessionManager,
+		parser:         deps.Parser,
+	}
+}
+
+// Name returns the command name
+func (c *CopyManifestCommand) Name() string {
+	return "copy-manifest"
+}
+
+// Description returns the command description
+func (c *CopyManifestCommand) Description() string {
+	return "Copy one server's manifest from a session to another"
+}
+
+// Execute copies args[0] from session args[1] to session args[2]
+func (c *CopyManifestCommand) Execute(args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("server, source session and target session required")
+	}
+	serverName, sourceName, targetName := args[0], args[1], args[2]
+
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	if err := session.ValidateServerName(serverName); err != nil {
+		return err
+	}
+
+	// The server may be named by its key or by the name its manifest declares
+	store := manifest.NewStore(c.sessionManager.ManifestsDir(sourceName), c.parser)
+	serverName, err := store.ResolveKey(serverName)
+	if err != nil {
+		return err
+	}
+
+	if err := c.sessionManager.CopyManifest(serverName, sourceName, targetName, c.Overwrite); err != nil {
+		var exists *session.ManifestExistsError
+		if errors.As(err, &exists) {
+			return fmt.Errorf("%w; use --overwrite to replace it", err)
+		}
+		return err
+	}
+
+	// Track the target session on the project's server entry, as install does
+	proj, err := c.projectManager.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	for _, server := range proj.MCPServers {
+		if server.Name != serverName {
+			continue
+		}
+		err := c.projectManager.AddMCPServerToSession(server.Name, server.Source, server.Clients, targetName, false)
+		var alreadyTracked *project.ServerAlreadyExistsError
+		if err != nil && !errors.As(err, &alreadyTracked) {
+			return fmt.Errorf("failed to update project configuration: %w", err)
+		}
+		break
+	}
+
+	fmt.Printf("✅ Copied '%s' from session '%s' to '%s'\n", serverName, sourceName, targetName)
+
+	activeName, err := c.sessionManager.GetActiveName()
+	if err != nil {
+		return fmt.Errorf("failed to get active session: %w", err)
+	}
+	if activeName != targetName {
+		return nil
+	}
+	if !c.Reconfigure {
+		fmt.Printf("💡 '%s' is the active session; run 'servo reconfigure' to update client configurations\n", targetName)
+		return nil
+	}
+
+	reconfigure := NewReconfigureCommand()
+	return reconfigure.Execute(nil)
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+)
+
+func TestCopyManifestCommand_Execute(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+	if _, err := session.NewManager(".servo").Create("staging", "Staging", ""); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
+	manifest := `servo_version: "1.0"
+name: search
+server:
+  transport: stdio
+  command: search-mcp
+`
+	stagingManifests := filepath.Join(".servo", "sessions", "staging", "manifests")
+	os.MkdirAll(stagingManifests, 0755)
+	if err := os.WriteFile(filepath.Join(stagingManifests, "search.servo"), []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	pm := project.NewManager()
+	if err := pm.AddMCPServerToSession("search", "./search.servo", []string{"vscode"}, "staging", false); err != nil {
+		t.Fatalf("Failed to track server: %v", err)
+	}
+
+	// default is the active session, so the copy is followed by a reconfigure
+	cmd := NewCopyManifestCommand()
+	cmd.Reconfigure = true
+	if err := cmd.Execute([]string{"search", "staging", "default"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(".servo", "sessions", "default", "manifests", "search.servo")); err != nil {
+		t.Errorf("Expected manifest in target session: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(".devcontainer", "devcontainer.json")); err != nil {
+		t.Errorf("Expected reconfigure to regenerate devcontainer.json: %v", err)
+	}
+
+	proj, err := pm.Get()
+	if err != nil {
+		t.Fatalf("Failed to get project: %v", err)
+	}
+	if len(proj.MCPServers) != 1 || strings.Join(proj.MCPServers[0].Sessions, ",") != "staging,default" {
+		t.Errorf("Expected server to be tracked in both sessions, got %+v", proj.MCPServers)
+	}
+
+	err = NewCopyManifestCommand().Execute([]string{"search", "staging", "default"})
+	if err == nil || !strings.Contains(err.Error(), "--overwrite") {
+		t.Errorf("Expected overwrite hint, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/servo/servo/internal/manifest"
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+)
+
+// DoctorCommand checks that the system meets the requirements of installed servers
+type DoctorCommand struct {
+	projectManager *project.Manager
+	sessionManager *session.Manager
+	parser         *mcp.Parser
+	validator      *mcp.Validator
+	runCommand     commandRunner
+}
+
+// NewDoctorCommand creates a new doctor command
+func NewDoctorCommand() *DoctorCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &DoctorCommand{
+		projectManager: deps.ProjectManager,
+		sessionManager: deps.SessionManager,
+		parser:         deps.Parser,
+		validator:      deps.Validator,
+		runCommand:     runShellCommand,
+	}
+}
+
+// Name returns the command name
+func (c *DoctorCommand) Name() string {
+	return "doctor"
+}
+
+// Description returns the command description
+func (c *DoctorCommand) Description() string {
+	return "Check system requirements of installed servers"
+}
+
+// Execute runs the doctor command
+func (c *DoctorCommand) Execute(args []string) error {
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	activeSession, err := requireActiveSession(c.sessionManager)
+	if err != nil {
+		return err
+	}
+
+	// A stale .gitignore may let secrets or volumes be committed, but is only a warning
+	if current, err := c.projectManager.GitignoreIsCurrent(); err != nil {
+		fmt.Printf("⚠️  Could not check .servo/.gitignore: %v\n", err)
+	} else if !current {
+		fmt.Printf("⚠️  .servo/.gitignore is missing recommended entries; run 'servo project fix-gitignore'\n")
+	}
+
+	// A structural problem is reported but does not stop the requirement checks
+	sessionErr := c.sessionManager.Verify(activeSession.Name)
+	if sessionErr != nil {
+		fmt.Printf("❌ Session '%s' failed verification: %v\n\n", activeSession.Name, sessionErr)
+	} else {
+		fmt.Printf("✅ Session '%s' verified\n\n", activeSession.Name)
+	}
+
+	store := manifest.NewStore(c.sessionManager.ManifestsDir(activeSession.Name), c.parser)
+	manifests, err := store.ListManifests()
+	if err != nil {
+		return fmt.Errorf("failed to list manifests: %w", err)
+	}
+
+	names := make([]string, 0, len(manifests))
+	for name := range manifests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("🩺 Checking system requirements (session: %s)\n", activeSession.Name)
+
+	checked := 0
+	failures := 0
+	for _, name := range names {
+		servoDef := manifests[name]
+		if servoDef == nil || servoDef.Requirements == nil || len(servoDef.Requirements.System) == 0 {
+			continue
+		}
+
+		fmt.Printf("\n%s:\n", name)
+		for _, result := range RunSystemChecks(servoDef.Requirements, c.validator, c.runCommand) {
+			checked++
+			if result.Passed() {
+				fmt.Printf("  ✅ %s\n", result.Requirement.Name)
+				continue
+			}
+			failures++
+			printSystemCheckFailure(result, "  ")
+		}
+	}
+
+	fmt.Println()
+	switch {
+	case failures > 0:
+		return environmentFailure(fmt.Errorf("%d of %d system requirement(s) not met", failures, checked))
+	case checked == 0:
+		fmt.Println("✅ No system requirements declared by installed servers")
+	default:
+		fmt.Printf("✅ All %d system requirement(s) met\n", checked)
+	}
+
+	if sessionErr != nil {
+		return environmentFailure(fmt.Errorf("session '%s' failed verification: %w", activeSession.Name, sessionErr))
+	}
+	return nil
+}
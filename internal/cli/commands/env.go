@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
+	"github.com/servo/servo/internal/manifest"
+	"github.com/servo/servo/internal/mcp"
 	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
 	"gopkg.in/yaml.v3"
 )
 
@@ -91,6 +95,10 @@ func (c *EnvCommand) setEnvVar(args []string) error {
 	key := args[0]
 	value := args[1]
 
+	if err := c.validateConfigValue(key, value); err != nil {
+		return err
+	}
+
 	envData, err := c.loadEnvData()
 	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to load environment variables: %w", err)
@@ -200,6 +208,12 @@ func (c *EnvCommand) importEnvVars(args []string) error {
 		return fmt.Errorf("failed to parse environment variables file: %w", err)
 	}
 
+	for key, value := range importData.Env {
+		if err := c.validateConfigValue(key, value); err != nil {
+			return err
+		}
+	}
+
 	envData, err := c.loadEnvData()
 	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to load existing environment variables: %w", err)
@@ -225,6 +239,47 @@ func (c *EnvCommand) importEnvVars(args []string) error {
 	return nil
 }
 
+// validateConfigValue rejects a value that is not among the options of a select or
+// multiselect config field bound to the same env_var in any installed manifest
+func (c *EnvCommand) validateConfigValue(key, value string) error {
+	sessionManager := session.NewManager(c.projectManager.GetServoDir())
+	sessions, err := sessionManager.ListNames()
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	parser := mcp.NewParser()
+	for _, sessionName := range sessions {
+		manifests, err := manifest.NewStore(sessionManager.ManifestsDir(sessionName), parser).ListManifests()
+		if err != nil {
+			return fmt.Errorf("failed to load manifests for session %s: %w", sessionName, err)
+		}
+
+		servers := make([]string, 0, len(manifests))
+		for server := range manifests {
+			servers = append(servers, server)
+		}
+		sort.Strings(servers)
+
+		for _, server := range servers {
+			schema := manifests[server].ConfigurationSchema
+			if schema == nil {
+				continue
+			}
+			for configName, config := range schema.Config {
+				if config.EnvVar != key {
+					continue
+				}
+				if err := config.ValidateValue(value); err != nil {
+					return fmt.Errorf("%s: config %s (%s): %w", key, configName, server, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 func (c *EnvCommand) loadEnvData() (*EnvData, error) {
 	servoDir := c.projectManager.GetServoDir()
 	envPath := filepath.Join(servoDir, "env.yaml")
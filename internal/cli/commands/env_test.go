@@ -3,9 +3,11 @@ package commands
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
 )
 
 func TestEnvCommand_SetAndGet(t *testing.T) {
@@ -236,3 +238,80 @@ active_session: default
 		t.Fatalf("Failed to export environment variables: %v", err)
 	}
 }
+
+func TestEnvCommand_SetValidatesSelectOptions(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(oldWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	servoDir := filepath.Join(tempDir, ".servo")
+	if err := os.MkdirAll(servoDir, 0755); err != nil {
+		t.Fatalf("Failed to create servo directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(servoDir, "project.yaml"), []byte("default_session: default\n"), 0644); err != nil {
+		t.Fatalf("Failed to create project file: %v", err)
+	}
+
+	sessionManager := session.NewManager(servoDir)
+	if _, err := sessionManager.Create("default", "Default session", ""); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	manifestDir := filepath.Join(sessionManager.GetSessionDir("default"), "manifests")
+	if err := os.MkdirAll(manifestDir, 0755); err != nil {
+		t.Fatalf("Failed to create manifests directory: %v", err)
+	}
+	manifestContent := `servo_version: "1.0"
+name: logger
+configuration_schema:
+  config:
+    level:
+      description: Log level
+      type: select
+      options: [debug, info, warn]
+      env_var: LOG_LEVEL
+    outputs:
+      description: Log outputs
+      type: multiselect
+      options: [stdout, file]
+      env_var: LOG_OUTPUTS
+`
+	if err := os.WriteFile(filepath.Join(manifestDir, "logger.servo"), []byte(manifestContent), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	envCmd := NewEnvCommand(project.NewManager())
+
+	for _, args := range [][]string{
+		{"set", "LOG_LEVEL", "info"},
+		{"set", "LOG_OUTPUTS", "stdout,file"},
+		{"set", "UNRELATED", "anything"},
+	} {
+		if err := envCmd.Execute(args); err != nil {
+			t.Errorf("Execute(%v) error = %v", args, err)
+		}
+	}
+
+	err = envCmd.Execute([]string{"set", "LOG_LEVEL", "verbose"})
+	if err == nil || !strings.Contains(err.Error(), "debug, info, warn") {
+		t.Errorf("Expected invalid select value to list options, got %v", err)
+	}
+	if err := envCmd.Execute([]string{"set", "LOG_OUTPUTS", "stdout,syslog"}); err == nil {
+		t.Error("Expected invalid multiselect element to be rejected")
+	}
+
+	// The rejected values must not replace what was stored
+	envData, err := envCmd.loadEnvData()
+	if err != nil {
+		t.Fatalf("Failed to load env data: %v", err)
+	}
+	if envData.Env["LOG_LEVEL"] != "info" || envData.Env["LOG_OUTPUTS"] != "stdout,file" {
+		t.Errorf("Unexpected stored values: %v", envData.Env)
+	}
+}
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/servo/servo/internal/mcp"
+)
+
+// Exit codes servo returns so scripts can branch on why a command failed.
+// Success stays 0.
+const (
+	// ExitUsage covers user and usage errors, and any failure not classified below
+	ExitUsage = 1
+	// ExitValidation means a manifest or installed server failed validation
+	ExitValidation = 2
+	// ExitEnvironment means the machine or session does not meet requirements,
+	// as reported by doctor and install's system checks
+	ExitEnvironment = 3
+)
+
+// exitError attaches an exit code to an error. It satisfies urfave/cli's ExitCoder
+// and keeps the wrapped error reachable through errors.Is and errors.As.
+type exitError struct {
+	err  error
+	code int
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// ExitCode returns the code the process exits with
+func (e *exitError) ExitCode() int { return e.code }
+
+// withExitCode wraps err so the process exits with code. A nil err stays nil.
+func withExitCode(err error, code int) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{err: err, code: code}
+}
+
+// validationFailure marks err as a validation failure
+func validationFailure(err error) error {
+	return withExitCode(err, ExitValidation)
+}
+
+// environmentFailure marks err as an unmet environment requirement
+func environmentFailure(err error) error {
+	return withExitCode(err, ExitEnvironment)
+}
+
+// manifestFailure classifies an error from reading a manifest source, for validate
+// and install alike. Content that is not a valid manifest is a validation failure;
+// a source that could not be read, such as a missing file or a failed fetch or
+// clone, is an environment failure. An error that already carries a code keeps it.
+func manifestFailure(err error) error {
+	var coded *exitError
+	var invalid *mcp.ManifestError
+	switch {
+	case err == nil || errors.As(err, &coded):
+		return err
+	case errors.As(err, &invalid):
+		return validationFailure(err)
+	default:
+		return environmentFailure(err)
+	}
+}
+
+// ExitCode returns the exit code for an error returned by a command: 0 for nil,
+// the code of the outermost error in the chain that carries one, or ExitUsage
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var coder interface{ ExitCode() int }
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	return ExitUsage
+}
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+	"github.com/urfave/cli/v2"
+)
+
+func TestExitCode(t *testing.T) {
+	base := errors.New("broken")
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: 0},
+		{name: "plain error", err: base, want: ExitUsage},
+		{name: "validation", err: validationFailure(base), want: ExitValidation},
+		{name: "environment", err: environmentFailure(base), want: ExitEnvironment},
+		{name: "wrapped", err: fmt.Errorf("install failed: %w", environmentFailure(base)), want: ExitEnvironment},
+		{name: "cli exit", err: cli.Exit("custom", 4), want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+
+	err := validationFailure(base)
+	if !errors.Is(err, base) || err.Error() != "broken" {
+		t.Errorf("Expected the exit code wrapper to keep the original error, got %v", err)
+	}
+	if _, ok := err.(cli.ExitCoder); !ok {
+		t.Error("Expected the exit code wrapper to satisfy cli.ExitCoder")
+	}
+	if validationFailure(nil) != nil {
+		t.Error("Expected a nil error to stay nil")
+	}
+}
+
+func TestRunBatch_ExitCode(t *testing.T) {
+	codes := map[string]error{
+		"a": validationFailure(errors.New("invalid")),
+		"b": validationFailure(errors.New("invalid")),
+		"c": errors.New("missing"),
+	}
+	fail := func(item string) error { return codes[item] }
+
+	if got := ExitCode(runBatch([]string{"a", "b"}, true, fail)); got != ExitValidation {
+		t.Errorf("Expected shared validation failures to keep their code, got %d", got)
+	}
+	if got := ExitCode(runBatch([]string{"a", "c"}, true, fail)); got != ExitUsage {
+		t.Errorf("Expected mixed failures to fall back to %d, got %d", ExitUsage, got)
+	}
+}
+
+func TestValidateCommand_ExitCode(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.servo")
+	os.WriteFile(invalid, []byte("servo_version: \"1.0\"\nname: invalid\n"), 0644)
+
+	cmd := NewValidateCommand(mcp.NewParser(), mcp.NewValidator())
+	if got := ExitCode(cmd.Execute([]string{invalid})); got != ExitValidation {
+		t.Errorf("Expected an invalid manifest to exit %d, got %d", ExitValidation, got)
+	}
+	if got := ExitCode(cmd.ExecuteWithOptions([]string{invalid}, ValidateOptions{Output: "json"})); got != ExitValidation {
+		t.Errorf("Expected an invalid JSON report to exit %d, got %d", ExitValidation, got)
+	}
+	if got := ExitCode(cmd.ExecuteWithOptions([]string{invalid}, ValidateOptions{Output: "xml"})); got != ExitUsage {
+		t.Errorf("Expected an unsupported format to exit %d, got %d", ExitUsage, got)
+	}
+
+	malformed := filepath.Join(dir, "malformed.servo")
+	os.WriteFile(malformed, []byte("name: [unclosed\n"), 0644)
+	missing := filepath.Join(dir, "missing.servo")
+	tests := []struct {
+		name   string
+		source string
+		opts   ValidateOptions
+		want   int
+	}{
+		{name: "malformed YAML", source: malformed, want: ExitValidation},
+		{name: "malformed YAML as JSON", source: malformed, opts: ValidateOptions{Output: "json"}, want: ExitValidation},
+		{name: "unreadable file", source: missing, want: ExitEnvironment},
+		{name: "unreadable file as JSON", source: missing, opts: ValidateOptions{Output: "json"}, want: ExitEnvironment},
+		{name: "--local-only refusal", source: "https://example.com/api.servo", opts: ValidateOptions{LocalOnly: true}, want: ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(cmd.ExecuteWithOptions([]string{tt.source}, tt.opts)); got != tt.want {
+				t.Errorf("Expected exit code %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestManifestFailure(t *testing.T) {
+	invalid := &mcp.ManifestError{Err: errors.New("failed to parse YAML")}
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: 0},
+		{name: "invalid manifest", err: fmt.Errorf("failed to parse servo file: %w", invalid), want: ExitValidation},
+		{name: "unreadable source", err: errors.New("failed to read file api.servo"), want: ExitEnvironment},
+		{name: "already classified", err: withExitCode(errors.New("refused"), ExitUsage), want: ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(manifestFailure(tt.err)); got != tt.want {
+				t.Errorf("ExitCode(manifestFailure(%v)) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoActiveSession_ExitCode(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	projectManager := project.NewManager()
+	if _, err := projectManager.Init("main", []string{"vscode"}); err != nil {
+		t.Fatalf("Failed to init project: %v", err)
+	}
+	if err := projectManager.AddMCPServerToSession("api", "api.servo", []string{"vscode"}, "main", false); err != nil {
+		t.Fatalf("Failed to add server: %v", err)
+	}
+
+	commands := map[string]func() error{
+		"configure": func() error { return NewConfigureCommand().Execute(nil) },
+		"work":      func() error { return NewWorkCommand().Execute(nil) },
+		"doctor":    func() error { return NewDoctorCommand().Execute(nil) },
+	}
+	for name, run := range commands {
+		t.Run(name, func(t *testing.T) {
+			err := run()
+			if !errors.Is(err, session.ErrNoActiveSession) {
+				t.Fatalf("Expected ErrNoActiveSession, got %v", err)
+			}
+			if got := ExitCode(err); got != ExitEnvironment {
+				t.Errorf("Expected exit code %d, got %d", ExitEnvironment, got)
+			}
+		})
+	}
+}
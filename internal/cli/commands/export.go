@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/servo/servo/internal/bundle"
+	"github.com/servo/servo/internal/project"
+)
+
+// ExportCommand writes the project's shareable configuration to a bundle file
+type ExportCommand struct {
+	projectManager *project.Manager
+	servoDir       string
+}
+
+// NewExportCommand creates a new export command
+func NewExportCommand() *ExportCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &ExportCommand{
+		projectManager: deps.ProjectManager,
+		servoDir:       deps.ServoDir,
+	}
+}
+
+// Name returns the command name
+func (c *ExportCommand) Name() string {
+	return "export"
+}
+
+// Description returns the command description
+func (c *ExportCommand) Description() string {
+	return "Export project configuration to a shareable bundle"
+}
+
+// Execute runs the export command
+func (c *ExportCommand) Execute(args []string) error {
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("output file is required\nUsage: servo export <file>")
+	}
+	outputFile := args[0]
+
+	// Write to a temporary file so a failed export never leaves a truncated bundle
+	tmpFile := outputFile + ".tmp"
+	f, err := os.Create(tmpFile)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle file: %w", err)
+	}
+
+	meta, err := bundle.Export(c.servoDir, f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write bundle file: %w", closeErr)
+	}
+	if err != nil {
+		os.Remove(tmpFile)
+		return fmt.Errorf("failed to export project: %w", err)
+	}
+	if err := os.Rename(tmpFile, outputFile); err != nil {
+		os.Remove(tmpFile)
+		return fmt.Errorf("failed to write bundle file: %w", err)
+	}
+
+	fmt.Printf("📦 Exported project to %s\n", outputFile)
+	fmt.Printf("   Sessions: %d\n", len(meta.Sessions))
+	if len(meta.RequiredSecrets) > 0 {
+		fmt.Printf("   Required secrets: %d (definitions only, no values)\n", len(meta.RequiredSecrets))
+	}
+	fmt.Println("   Secret values and volumes are not included.")
+	return nil
+}
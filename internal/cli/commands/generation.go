@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/servo/servo/internal/config"
+	"github.com/servo/servo/internal/global"
+	"github.com/servo/servo/internal/logging"
+	"github.com/servo/servo/internal/manifest"
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+	"github.com/servo/servo/pkg"
+)
+
+// devcontainerDisabledMessage explains why no devcontainer output exists
+const devcontainerDisabledMessage = "Devcontainer output is disabled for this project (config.no_devcontainer in .servo/project.yaml)"
+
+// devcontainerEnabled reports whether devcontainer and docker-compose files should be
+// generated, given the project setting and a per-command override
+func devcontainerEnabled(proj *project.Project, noDevcontainer bool) bool {
+	if noDevcontainer {
+		return false
+	}
+	return proj == nil || !proj.Config.NoDevcontainer
+}
+
+// generateDevcontainerConfigs writes devcontainer.json and docker-compose.yml unless
+// devcontainer output is disabled, reporting whether they were generated. The opt-in
+// servers.json bundle is written either way.
+func generateDevcontainerConfigs(projectManager *project.Manager, configManager *config.ConfigGeneratorManager, noDevcontainer bool) (bool, error) {
+	proj, err := projectManager.Get()
+	if err != nil {
+		return false, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	if err := configManager.GenerateBundle(); err != nil {
+		return false, fmt.Errorf("failed to generate %s: %w", config.BundleFileName, err)
+	}
+
+	generated := devcontainerEnabled(proj, noDevcontainer)
+	if generated {
+		if err := configManager.GenerateDevcontainer(); err != nil {
+			return false, fmt.Errorf("failed to generate devcontainer: %w", err)
+		}
+		if err := configManager.GenerateDockerCompose(); err != nil {
+			return false, fmt.Errorf("failed to generate docker-compose: %w", err)
+		}
+	}
+
+	// Checksums let reconfigure spot generated files that were edited by hand
+	var written []string
+	for _, gen := range configManager.Generators() {
+		if out, ok := gen.(config.OutputGenerator); ok {
+			written = append(written, out.WrittenFiles()...)
+		}
+	}
+	if err := config.RecordChecksums(projectManager.GetServoDir(), written); err != nil {
+		return false, fmt.Errorf("failed to record generated file checksums: %w", err)
+	}
+	return generated, nil
+}
+
+// generateClientConfigs writes the MCP configuration of every installed client from a
+// session's manifests
+func generateClientConfigs(projectManager *project.Manager, sessionManager *session.Manager, clientRegistry pkg.ClientRegistry, parser *mcp.Parser, sessionName string) error {
+	manifests, secretsProvider, err := clientConfigInputs(projectManager, sessionManager, parser, sessionName)
+	if err != nil {
+		return err
+	}
+	envFiles := clientEnvFilesEnabled(projectManager)
+
+	// Generate configurations for all clients
+	for _, client := range clientRegistry.List() {
+		if client.IsInstalled() { // Only generate for installed clients
+			if err := writeClientConfig(client, manifests, secretsProvider, envFiles); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeClientConfig writes one client's MCP configuration, naming secret env vars as
+// the manifests' secret targets map them for the client and moving secret-referencing
+// environment variables to an env file when envFiles is set and the client supports it
+func writeClientConfig(client pkg.Client, manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error), envFiles bool) error {
+	manifests = clientManifests(client.Name(), manifests)
+
+	var err error
+	if envFileClient, ok := client.(pkg.EnvFileClient); ok && envFiles {
+		err = envFileClient.GenerateConfigWithEnvFile(manifests, secretsProvider)
+	} else {
+		err = client.GenerateConfig(manifests, secretsProvider)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate config for %s: %w", client.Name(), err)
+	}
+	return nil
+}
+
+// clientManifests returns copies of the manifests as clientName sees them, with
+// secret env vars renamed by the secrets' targets
+func clientManifests(clientName string, manifests []pkg.ServoDefinition) []pkg.ServoDefinition {
+	targeted := make([]pkg.ServoDefinition, len(manifests))
+	for i := range manifests {
+		targeted[i] = manifests[i].ForClient(clientName)
+	}
+	return targeted
+}
+
+// clientEnvFilesEnabled reports whether the project sets config.client_env_files
+func clientEnvFilesEnabled(projectManager *project.Manager) bool {
+	proj, err := projectManager.Get()
+	return err == nil && proj.Config.ClientEnvFiles
+}
+
+// clientConfigInputs loads a session's manifests and the secrets provider that client
+// config generation takes. The provider leaves every secret as a placeholder.
+func clientConfigInputs(projectManager *project.Manager, sessionManager *session.Manager, parser *mcp.Parser, sessionName string) ([]pkg.ServoDefinition, func(string) (string, error), error) {
+	// Get manifests from specified session
+	store := manifest.NewStore(sessionManager.ManifestsDir(sessionName), parser)
+	manifestsMap, err := store.ListManifests()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list manifests: %w", err)
+	}
+
+	// Clients key servers by name, so only one of each duplicate reaches the config
+	duplicates := manifest.DuplicateNames(manifestsMap)
+	duplicateNames := make([]string, 0, len(duplicates))
+	for name := range duplicates {
+		duplicateNames = append(duplicateNames, name)
+	}
+	sort.Strings(duplicateNames)
+	for _, name := range duplicateNames {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: server name '%s' is declared by multiple manifests in session '%s' (%s.servo); only one will appear in client configs\n",
+			name, sessionName, strings.Join(duplicates[name], ".servo, "))
+	}
+
+	// Convert to slice format for client interface
+	manifests := make([]pkg.ServoDefinition, 0, len(manifestsMap))
+	for _, manifest := range manifestsMap {
+		manifests = append(manifests, *manifest)
+	}
+
+	proj, err := projectManager.Get()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get project configuration: %w", err)
+	}
+	if proj.Config.IncludeGlobalServers {
+		if manifests, err = layerGlobalServers(manifests, parser); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Create secrets provider
+	configuredSecrets, err := projectManager.GetConfiguredSecrets(sessionName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get configured secrets: %w", err)
+	}
+
+	secretsProvider := func(secretName string) (string, error) {
+		if !configuredSecrets[secretName] {
+			return "", fmt.Errorf("secret '%s' is not configured", secretName)
+		}
+		// Return placeholder - in real usage, this would decrypt the actual secret
+		return "", nil
+	}
+
+	return manifests, secretsProvider, nil
+}
+
+// layerGlobalServers appends the servers installed with --global to a session's
+// manifests. A project server keeps its name, hiding the global server it shadows.
+func layerGlobalServers(manifests []pkg.ServoDefinition, parser *mcp.Parser) ([]pkg.ServoDefinition, error) {
+	dir, err := global.Dir()
+	if err != nil {
+		return nil, err
+	}
+	globalManifests, err := global.NewStore(dir, parser).ListManifests()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list global manifests: %w", err)
+	}
+
+	projectNames := make(map[string]bool, len(manifests))
+	for _, m := range manifests {
+		projectNames[m.Name] = true
+	}
+	keys := make([]string, 0, len(globalManifests))
+	for key := range globalManifests {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		globalManifest := globalManifests[key]
+		if projectNames[globalManifest.Name] {
+			logging.Info("project server shadows global server", "server", globalManifest.Name)
+			continue
+		}
+		manifests = append(manifests, *globalManifest)
+	}
+	return manifests, nil
+}
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/servo/servo/internal/bundle"
+	"github.com/servo/servo/internal/project"
+)
+
+// ImportCommand recreates a project from a bundle written by servo export
+type ImportCommand struct {
+	projectManager *project.Manager
+	servoDir       string
+}
+
+// NewImportCommand creates a new import command
+func NewImportCommand() *ImportCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &ImportCommand{
+		projectManager: deps.ProjectManager,
+		servoDir:       deps.ServoDir,
+	}
+}
+
+// Name returns the command name
+func (c *ImportCommand) Name() string {
+	return "import"
+}
+
+// Description returns the command description
+func (c *ImportCommand) Description() string {
+	return "Import project configuration from a bundle"
+}
+
+// Execute runs the import command
+func (c *ImportCommand) Execute(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("bundle file is required\nUsage: servo import <file>")
+	}
+
+	if c.projectManager.IsProject() {
+		return fmt.Errorf("a servo project already exists in this directory; import into a fresh directory")
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer f.Close()
+
+	meta, err := bundle.Import(f, c.servoDir)
+	if err != nil {
+		return fmt.Errorf("failed to import bundle: %w", err)
+	}
+
+	fmt.Printf("✅ Imported project from %s\n", args[0])
+	for _, s := range meta.Sessions {
+		fmt.Printf("  • session %s\n", s.Name)
+	}
+
+	// Bundles never carry secrets, so every required one is missing project-wide
+	missing, err := c.projectManager.GetMissingSecrets("")
+	if err != nil {
+		return fmt.Errorf("failed to check required secrets: %w", err)
+	}
+	if len(missing) > 0 {
+		fmt.Println()
+		fmt.Println("🔐 Set the required secrets before starting work:")
+		for _, secret := range missing {
+			if secret.Description != "" {
+				fmt.Printf("  servo secrets set %s    # %s\n", secret.Name, secret.Description)
+			} else {
+				fmt.Printf("  servo secrets set %s\n", secret.Name)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/servo/servo/internal/manifest"
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+	"github.com/servo/servo/pkg"
+)
+
+// clientConfigSource describes an existing client MCP config file that can be imported
+type clientConfigSource struct {
+	Client     string
+	Path       string
+	ServersKey string
+}
+
+// defaultClientConfigSources lists the client config files checked by import-clients
+var defaultClientConfigSources = []clientConfigSource{
+	{Client: "claude-code", Path: ".mcp.json", ServersKey: "mcpServers"},
+	{Client: "vscode", Path: ".vscode/mcp.json", ServersKey: "servers"},
+	{Client: "cursor", Path: ".cursor/mcp.json", ServersKey: "mcpServers"},
+}
+
+// secretEnvPattern matches env/header names that usually carry credentials
+var secretEnvPattern = regexp.MustCompile(`(?i)(key|token|secret|password|passwd|credential|auth)`)
+
+// invalidNameChars matches runs of characters not allowed in a manifest name
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ImportClientsCommand converts existing client MCP configs into session manifests
+type ImportClientsCommand struct {
+	projectManager *project.Manager
+	sessionManager *session.Manager
+	parser         *mcp.Parser
+	sources        []clientConfigSource
+}
+
+// ImportedSecret records a literal credential that was replaced with a placeholder
+type ImportedSecret struct {
+	Server string
+	Key    string
+	Secret string
+}
+
+// NewImportClientsCommand creates a new import-clients command
+func NewImportClientsCommand() *ImportClientsCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &ImportClientsCommand{
+		projectManager: deps.ProjectManager,
+		sessionManager: deps.SessionManager,
+		parser:         deps.Parser,
+		sources:        defaultClientConfigSources,
+	}
+}
+
+// Name returns the command name
+func (c *ImportClientsCommand) Name() string {
+	return "import-clients"
+}
+
+// Description returns the command description
+func (c *ImportClientsCommand) Description() string {
+	return "Import servers from existing MCP client configurations"
+}
+
+// Execute runs the import-clients command
+func (c *ImportClientsCommand) Execute(args []string) error {
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	activeSession, err := requireActiveSession(c.sessionManager)
+	if err != nil {
+		return err
+	}
+
+	store := manifest.NewStore(c.sessionManager.ManifestsDir(activeSession.Name), c.parser)
+	existing, err := store.ListManifests()
+	if err != nil {
+		return fmt.Errorf("failed to list manifests: %w", err)
+	}
+
+	imported := 0
+	var flagged []ImportedSecret
+
+	for _, source := range c.sources {
+		servers, err := readClientServers(source)
+		if err != nil {
+			return err
+		}
+		if len(servers) == 0 {
+			continue
+		}
+
+		fmt.Printf("📥 Importing %d server(s) from %s\n", len(servers), source.Path)
+
+		names := make([]string, 0, len(servers))
+		for name := range servers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, rawName := range names {
+			servoDef, secrets := BuildManifestFromClientConfig(rawName, servers[rawName], source.Path)
+
+			if _, ok := existing[servoDef.Name]; ok {
+				fmt.Printf("  • %s: already installed in session %s, skipping\n", servoDef.Name, activeSession.Name)
+				continue
+			}
+
+			if err := servoDef.Validate(); err != nil {
+				fmt.Fprintf(os.Stderr, "  ⚠️  %s: skipping, the imported manifest is invalid: %v\n", servoDef.Name, err)
+				continue
+			}
+
+			if err := store.SaveManifest(servoDef.Name, servoDef, source.Path); err != nil {
+				return fmt.Errorf("failed to store manifest for %s: %w", servoDef.Name, err)
+			}
+
+			if err := c.projectManager.AddMCPServerToSession(servoDef.Name, source.Path, []string{source.Client}, activeSession.Name, false); err != nil {
+				return fmt.Errorf("failed to register server %s: %w", servoDef.Name, err)
+			}
+
+			for _, secret := range secrets {
+				if err := c.projectManager.AddRequiredSecret(secret.Secret, fmt.Sprintf("Imported from %s (%s)", source.Path, secret.Key)); err != nil && !strings.Contains(err.Error(), "already exists") {
+					return fmt.Errorf("failed to add required secret %s: %w", secret.Secret, err)
+				}
+			}
+
+			existing[servoDef.Name] = servoDef
+			flagged = append(flagged, secrets...)
+			imported++
+			fmt.Printf("  • %s (%s)\n", servoDef.Name, servoDef.Server.Transport)
+		}
+	}
+
+	if imported == 0 {
+		fmt.Println("No new servers found in client configurations")
+		return nil
+	}
+
+	fmt.Printf("✅ Imported %d server(s) into session '%s'\n", imported, activeSession.Name)
+
+	if len(flagged) > 0 {
+		fmt.Println()
+		fmt.Println("⚠️  Literal credentials were found and NOT imported. Store them as secrets:")
+		for _, secret := range flagged {
+			fmt.Printf("   servo secrets set %s <value>    # %s.%s\n", secret.Secret, secret.Server, secret.Key)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("💡 Run 'servo configure' to regenerate client configurations from the imported manifests")
+	return nil
+}
+
+// readClientServers reads the server map from a client config file, returning nil when it doesn't exist
+func readClientServers(source clientConfigSource) (map[string]map[string]interface{}, error) {
+	data, err := os.ReadFile(source.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", source.Path, err)
+	}
+
+	// Other top-level keys (e.g. VSCode "inputs") are ignored
+	var config map[string]json.RawMessage
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", source.Path, err)
+	}
+
+	rawServers, ok := config[source.ServersKey]
+	if !ok {
+		return nil, nil
+	}
+
+	var servers map[string]map[string]interface{}
+	if err := json.Unmarshal(rawServers, &servers); err != nil {
+		return nil, fmt.Errorf("failed to parse %s in %s: %w", source.ServersKey, source.Path, err)
+	}
+
+	return servers, nil
+}
+
+// BuildManifestFromClientConfig reverse-engineers a minimal manifest from a client server entry.
+// Literal credentials in env vars or headers are replaced by secret placeholders and returned.
+func BuildManifestFromClientConfig(name string, entry map[string]interface{}, sourcePath string) (*pkg.ServoDefinition, []ImportedSecret) {
+	servoDef := &pkg.ServoDefinition{
+		ServoVersion: "1.0",
+		Name:         normalizeImportedServerName(name),
+		Version:      "0.0.0",
+		Description:  fmt.Sprintf("Imported from %s", sourcePath),
+		// The client already ran the server as configured, so there is nothing to install
+		Install: pkg.Install{
+			Type:   "local",
+			Method: "local",
+		},
+		Server: pkg.Server{
+			Transport: "stdio",
+		},
+	}
+
+	var secrets []ImportedSecret
+	addSecrets := func(values map[string]string) map[string]string {
+		if len(values) == 0 {
+			return nil
+		}
+		result := make(map[string]string, len(values))
+		for key, value := range values {
+			if value == "" || strings.Contains(value, "${") || !secretEnvPattern.MatchString(key) {
+				result[key] = value
+				continue
+			}
+
+			secretName := strings.ToLower(strings.ReplaceAll(key, "-", "_"))
+			result[key] = authScheme(key, value) + fmt.Sprintf("${%s}", secretName)
+			secrets = append(secrets, ImportedSecret{Server: servoDef.Name, Key: key, Secret: secretName})
+
+			if servoDef.ConfigurationSchema == nil {
+				servoDef.ConfigurationSchema = &pkg.ConfigurationSchema{Secrets: make(map[string]pkg.SecretSchema)}
+			}
+			secretType := "api_key"
+			if strings.Contains(strings.ToLower(key), "pass") {
+				secretType = "password"
+			}
+			servoDef.ConfigurationSchema.Secrets[secretName] = pkg.SecretSchema{
+				Description: fmt.Sprintf("%s for %s", key, servoDef.Name),
+				Type:        secretType,
+				Required:    true,
+				EnvVar:      key,
+			}
+		}
+		return result
+	}
+
+	if url, ok := entry["url"].(string); ok && url != "" {
+		servoDef.Server.Transport = "http"
+		if transport, ok := entry["type"].(string); ok && transport == "sse" {
+			servoDef.Server.Transport = "sse"
+		}
+		servoDef.Install.Type = "remote"
+		servoDef.Install.Method = "remote"
+		servoDef.Server.URL = url
+		servoDef.Server.Headers = addSecrets(stringMap(entry["headers"]))
+	} else {
+		servoDef.Server.Command, _ = entry["command"].(string)
+		if args, ok := entry["args"].([]interface{}); ok {
+			for _, arg := range args {
+				servoDef.Server.Args = append(servoDef.Server.Args, fmt.Sprint(arg))
+			}
+		}
+		if cwd, ok := entry["cwd"].(string); ok {
+			servoDef.Server.WorkingDirectory = cwd
+		}
+	}
+	servoDef.Server.Environment = addSecrets(stringMap(entry["env"]))
+
+	sort.Slice(secrets, func(i, j int) bool { return secrets[i].Key < secrets[j].Key })
+
+	return servoDef, secrets
+}
+
+// normalizeImportedServerName converts a client server key into a valid manifest name
+func normalizeImportedServerName(name string) string {
+	normalized := strings.ToLower(name)
+	normalized = invalidNameChars.ReplaceAllString(normalized, "-")
+	normalized = strings.Trim(normalized, "-")
+	if normalized == "" || normalized[0] < 'a' || normalized[0] > 'z' {
+		normalized = "server-" + normalized
+	}
+	return strings.TrimSuffix(normalized, "-")
+}
+
+// authScheme returns the scheme prefix of an Authorization header value, such as
+// "Bearer ", which stays in the header while the credential becomes a secret
+func authScheme(key, value string) string {
+	if !strings.EqualFold(key, "Authorization") {
+		return ""
+	}
+	scheme, credential, found := strings.Cut(value, " ")
+	if !found || scheme == "" || strings.TrimSpace(credential) == "" {
+		return ""
+	}
+	return scheme + " "
+}
+
+// stringMap converts a decoded JSON object into a string map
+func stringMap(value interface{}) map[string]string {
+	raw, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	result := make(map[string]string, len(raw))
+	for key, v := range raw {
+		result[key] = fmt.Sprint(v)
+	}
+	return result
+}
@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+)
+
+func TestBuildManifestFromClientConfig_Stdio(t *testing.T) {
+	entry := map[string]interface{}{
+		"command": "npx",
+		"args":    []interface{}{"-y", "@example/server"},
+		"env": map[string]interface{}{
+			"GITHUB_TOKEN": "ghp_literal_value",
+			"LOG_LEVEL":    "debug",
+			"API_KEY":      "${api_key}",
+		},
+	}
+
+	servoDef, secrets := BuildManifestFromClientConfig("GitHub_Server", entry, ".mcp.json")
+
+	if servoDef.Name != "github-server" {
+		t.Errorf("Expected normalized name 'github-server', got '%s'", servoDef.Name)
+	}
+	if servoDef.Server.Command != "npx" || len(servoDef.Server.Args) != 2 {
+		t.Errorf("Expected command and args to be imported, got %s %v", servoDef.Server.Command, servoDef.Server.Args)
+	}
+	if servoDef.Server.Environment["LOG_LEVEL"] != "debug" {
+		t.Errorf("Expected non-secret env to be imported verbatim")
+	}
+	if servoDef.Server.Environment["API_KEY"] != "${api_key}" {
+		t.Errorf("Expected existing placeholder to be preserved, got '%s'", servoDef.Server.Environment["API_KEY"])
+	}
+	if servoDef.Server.Environment["GITHUB_TOKEN"] != "${github_token}" {
+		t.Errorf("Expected literal secret to become the ${github_token} placeholder, got '%s'", servoDef.Server.Environment["GITHUB_TOKEN"])
+	}
+
+	if len(secrets) != 1 || secrets[0].Secret != "github_token" {
+		t.Fatalf("Expected github_token to be flagged, got %v", secrets)
+	}
+	if _, ok := servoDef.ConfigurationSchema.Secrets["github_token"]; !ok {
+		t.Errorf("Expected flagged secret to be declared in configuration_schema")
+	}
+
+	if err := mcp.NewValidator().Validate(servoDef); err != nil {
+		t.Errorf("Imported manifest should validate: %v", err)
+	}
+}
+
+func TestBuildManifestFromClientConfig_Remote(t *testing.T) {
+	entry := map[string]interface{}{
+		"type": "sse",
+		"url":  "https://mcp.example.com/sse",
+		"headers": map[string]interface{}{
+			"Authorization": "Bearer literal",
+		},
+	}
+
+	servoDef, secrets := BuildManifestFromClientConfig("remote", entry, ".vscode/mcp.json")
+
+	if servoDef.Server.Transport != "sse" || servoDef.Server.URL != "https://mcp.example.com/sse" {
+		t.Errorf("Expected sse transport with url, got %s %s", servoDef.Server.Transport, servoDef.Server.URL)
+	}
+	if len(secrets) != 1 || servoDef.Server.Headers["Authorization"] != "Bearer ${authorization}" {
+		t.Errorf("Expected Authorization credential to be flagged with its scheme kept, got %v", servoDef.Server.Headers)
+	}
+	if servoDef.Install.Type != "remote" || len(servoDef.Install.SetupCommands) != 0 {
+		t.Errorf("Expected a remote install with no setup commands, got %+v", servoDef.Install)
+	}
+	if err := mcp.NewValidator().Validate(servoDef); err != nil {
+		t.Errorf("Imported manifest should validate: %v", err)
+	}
+}
+
+func TestImportClientsCommand_Execute(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(tmpDir)
+
+	if _, err := project.NewManager().Init("default", []string{"vscode"}); err != nil {
+		t.Fatalf("Failed to init project: %v", err)
+	}
+	sessionManager := session.NewManager(".servo")
+	if _, err := sessionManager.Create("default", "Default", ""); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := sessionManager.Activate("default"); err != nil {
+		t.Fatalf("Failed to activate session: %v", err)
+	}
+
+	os.MkdirAll(".vscode", 0755)
+	vscodeConfig := `{
+  "inputs": [],
+  "servers": {
+    "notes": {"command": "node", "args": ["notes.js"], "env": {"NOTES_SECRET": "s3cr3t"}},
+    "broken": {"args": ["--stdio"]}
+  }
+}`
+	os.WriteFile(filepath.Join(".vscode", "mcp.json"), []byte(vscodeConfig), 0644)
+
+	cmd := NewImportClientsCommand()
+	if err := cmd.Execute([]string{}); err != nil {
+		t.Fatalf("import-clients failed: %v", err)
+	}
+
+	manifestPath := filepath.Join(".servo", "sessions", "default", "manifests", "notes.servo")
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatalf("Expected manifest to be written: %v", err)
+	}
+	if strings.Contains(string(data), "s3cr3t") {
+		t.Errorf("Manifest must not contain the literal secret value")
+	}
+
+	// An entry that does not make a valid manifest is skipped
+	if _, err := os.Stat(filepath.Join(".servo", "sessions", "default", "manifests", "broken.servo")); !os.IsNotExist(err) {
+		t.Errorf("Expected no manifest for an invalid entry")
+	}
+
+	proj, err := project.NewManager().Get()
+	if err != nil {
+		t.Fatalf("Failed to read project: %v", err)
+	}
+	if len(proj.MCPServers) != 1 || proj.MCPServers[0].Name != "notes" {
+		t.Errorf("Expected imported server to be registered, got %v", proj.MCPServers)
+	}
+	if len(proj.RequiredSecrets) != 1 || proj.RequiredSecrets[0].Name != "notes_secret" {
+		t.Errorf("Expected flagged secret to be required, got %v", proj.RequiredSecrets)
+	}
+
+	// Importing again skips servers already in the session
+	if err := cmd.Execute([]string{}); err != nil {
+		t.Fatalf("second import-clients failed: %v", err)
+	}
+}
+
+func TestImportClientsCommand_RendersThroughConfigure(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(tmpDir)
+	t.Setenv("SERVO_NON_INTERACTIVE", "1")
+
+	projectManager := project.NewManager()
+	if _, err := projectManager.Init("default", []string{"claude-code"}); err != nil {
+		t.Fatalf("Failed to init project: %v", err)
+	}
+	sessionManager := session.NewManager(".servo")
+	if _, err := sessionManager.Create("default", "Default", ""); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := sessionManager.Activate("default"); err != nil {
+		t.Fatalf("Failed to activate session: %v", err)
+	}
+
+	os.WriteFile(".mcp.json", []byte(`{"mcpServers": {"github": {"command": "npx", "args": ["-y", "github-mcp"], "env": {"GITHUB_TOKEN": "ghp_literal_value"}}}}`), 0644)
+	if err := NewImportClientsCommand().Execute(nil); err != nil {
+		t.Fatalf("import-clients failed: %v", err)
+	}
+
+	if err := NewSecretsCommand(projectManager).Execute([]string{"set", "github_token", "ghp_from_secrets"}); err != nil {
+		t.Fatalf("Failed to set secret: %v", err)
+	}
+	if err := NewConfigureCommand().Execute(nil); err != nil {
+		t.Fatalf("configure failed: %v", err)
+	}
+
+	data, err := os.ReadFile(".mcp.json")
+	if err != nil {
+		t.Fatalf("Failed to read .mcp.json: %v", err)
+	}
+	// Client configs carry secrets as ${secret} placeholders; the imported one must
+	// name the secret that was set, not a form nothing resolves
+	if !strings.Contains(string(data), `"GITHUB_TOKEN": "${github_token}"`) {
+		t.Errorf("Expected the ${github_token} placeholder in .mcp.json, got:\n%s", data)
+	}
+	configured, err := projectManager.GetConfiguredSecrets("default")
+	if err != nil || !configured["github_token"] {
+		t.Errorf("Expected github_token to be a configured secret, got %v (%v)", configured, err)
+	}
+}
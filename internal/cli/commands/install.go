@@ -3,9 +3,11 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/servo/servo/internal/config"
+	"github.com/servo/servo/internal/logging"
 	"github.com/servo/servo/internal/manifest"
 	"github.com/servo/servo/internal/mcp"
 	"github.com/servo/servo/internal/project"
@@ -21,6 +23,42 @@ type InstallCommand struct {
 	clientRegistry pkg.ClientRegistry
 	parser         *mcp.Parser
 	validator      *mcp.Validator
+	runCommand     commandRunner
+
+	// SkipSystemChecks installs even when a requirements.system check_command fails
+	SkipSystemChecks bool
+
+	// NoDevcontainer skips devcontainer and docker-compose output for this install
+	NoDevcontainer bool
+
+	// ManifestPath reads this exact file, relative to the repository or directory
+	// root, instead of searching the source for a manifest
+	ManifestPath string
+
+	// NoUpdate keeps an existing server untouched even when the project sets
+	// config.install_update_default
+	NoUpdate bool
+
+	// CreateSession creates an explicitly named target session when it does not exist yet
+	CreateSession bool
+
+	// SessionDescription describes a session created by CreateSession
+	SessionDescription string
+
+	// KeepGoing installs every source even after one fails, then summarizes the failures
+	KeepGoing bool
+
+	// Dev installs from a local checkout directory, which the generated workspace
+	// bind-mounts instead of the manifest's install source
+	Dev bool
+
+	// Force installs even when servers listed in the manifest's depends_on are not
+	// installed in the target session, warning instead of failing
+	Force bool
+
+	// Global installs into the user profile under ~/.servo/global and the user-level
+	// client configs instead of the current project
+	Global bool
 }
 
 // NewInstallCommand creates a new project install command
@@ -34,6 +72,7 @@ func NewInstallCommand(parser *mcp.Parser, validator *mcp.Validator) *InstallCom
 		clientRegistry: deps.ClientRegistry,
 		parser:         deps.Parser,
 		validator:      deps.Validator,
+		runCommand:     runShellCommand,
 	}
 }
 
@@ -52,8 +91,32 @@ func (c *InstallCommand) Execute(args []string) error {
 	return c.ExecuteWithOptions(args, nil, "", false)
 }
 
+// ExecuteSources installs each source in turn with the same options, stopping at the
+// first failure unless KeepGoing is set
+func (c *InstallCommand) ExecuteSources(sources []string, clients []string, sessionName string, forceUpdate bool) error {
+	if len(sources) == 0 {
+		return fmt.Errorf("server source is required\nUsage: servo install <source>")
+	}
+	return runBatch(sources, c.KeepGoing, func(source string) error {
+		return c.ExecuteWithOptions([]string{source}, clients, sessionName, forceUpdate)
+	})
+}
+
 // ExecuteWithOptions runs the install command with specific options
 func (c *InstallCommand) ExecuteWithOptions(args []string, clients []string, sessionName string, forceUpdate bool) error {
+	if c.Global {
+		if sessionName != "" || c.CreateSession || c.Dev {
+			return fmt.Errorf("--global cannot be combined with --session, --create-session or --dev")
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("server source is required\nUsage: servo install --global <source>")
+		}
+		if containsAllClients(clients) {
+			return fmt.Errorf("--clients all expands to a project's enabled clients and cannot be combined with --global")
+		}
+		return c.installGlobal(resolveSourceShorthand(resolveCatalogSource(args[0])), clients, forceUpdate)
+	}
+
 	if !c.projectManager.IsProject() {
 		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
 		return fmt.Errorf("not in a servo project directory")
@@ -63,10 +126,14 @@ func (c *InstallCommand) ExecuteWithOptions(args []string, clients []string, ses
 		return fmt.Errorf("server source is required\nUsage: servo install <source>")
 	}
 
-	source := args[0]
-
-	// Validate and cleanup clients list - only support devcontainer-compatible clients
-	clients = c.validateClients(clients)
+	source := resolveSourceShorthand(resolveCatalogSource(args[0]))
+	if c.Dev {
+		devSource, err := resolveDevSource(args[0])
+		if err != nil {
+			return err
+		}
+		source = devSource
+	}
 
 	// Get project configuration to determine session
 	project, err := c.projectManager.Get()
@@ -74,6 +141,18 @@ func (c *InstallCommand) ExecuteWithOptions(args []string, clients []string, ses
 		return fmt.Errorf("failed to get project configuration: %w", err)
 	}
 
+	// Validate and cleanup clients list - only support devcontainer-compatible clients
+	explicitClients := len(clients) > 0
+	clients, err = expandAllClients(clients, project)
+	if err != nil {
+		return err
+	}
+	clients = c.validateClients(clients)
+
+	if !forceUpdate && !c.NoUpdate && project.Config.InstallUpdateDefault {
+		forceUpdate = true
+	}
+
 	// Use specified session or fall back to active/default session
 	targetSession := sessionName
 	explicitSession := targetSession != "" // Track if user explicitly specified a session
@@ -86,26 +165,73 @@ func (c *InstallCommand) ExecuteWithOptions(args []string, clients []string, ses
 
 		if activeSession != nil {
 			targetSession = activeSession.Name
+			if err := c.sessionManager.Touch(targetSession); err != nil {
+				fmt.Printf("Warning: failed to record session use: %v\n", err)
+			}
 		} else {
 			targetSession = project.DefaultSession
 		}
 	}
 
-	// Parse the source to get server name (could be file, URL, or repo)
-	serverName, err := c.extractServerName(source)
+	// Reinstalling a server recorded with --path reads the same manifest again
+	// instead of searching the source for one
+	if c.ManifestPath == "" && !c.Dev {
+		if recorded := recordedManifestPath(project, source); recorded != "" {
+			fmt.Printf("📄 Using manifest path %s recorded for %s\n", recorded, source)
+			c.ManifestPath = recorded
+		}
+	}
+
+	// Parse the source once (it may be a file, URL or repository); every later step
+	// works from this definition
+	servoDef, err := c.parseSource(source)
+	if err != nil {
+		return manifestFailure(fmt.Errorf("failed to parse servo file: %w", err))
+	}
+	serverName, err := c.serverKey(servoDef)
 	if err != nil {
-		return fmt.Errorf("failed to determine server name: %w", err)
+		return validationFailure(fmt.Errorf("failed to determine server name: %w", err))
+	}
+
+	if !explicitClients {
+		clients, err = c.defaultClients(servoDef, project)
+		if err != nil {
+			return err
+		}
 	}
 
 	fmt.Printf("📦 Adding MCP server '%s' to project (session: %s)...\n", serverName, targetSession)
+	declaredName := servoDef.Name
+	if warning := c.validator.CheckFilename(declaredName, c.manifestFile(source)); warning != "" {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+
+	if err := c.checkSystemRequirements(servoDef); err != nil {
+		return err
+	}
 
 	// Ensure session directories exist (only check if explicitly specified)
 	if explicitSession {
-		if err := c.validateSessionExists(targetSession); err != nil {
+		if err := c.ensureSession(targetSession); err != nil {
 			return err
 		}
 	}
 
+	replaced, err := c.checkNameCollisions(serverName, declaredName, targetSession, forceUpdate)
+	if err != nil {
+		return err
+	}
+
+	if err := c.checkServerDependencies(serverName, servoDef, targetSession); err != nil {
+		return err
+	}
+
+	// Conflicting manifests are only removed once every check has passed, so a
+	// failed install leaves the session as it was
+	if err := c.replaceConflicts(replaced, declaredName, targetSession); err != nil {
+		return err
+	}
+
 	// Add server to project configuration for specific session
 	if err := c.projectManager.AddMCPServerToSession(serverName, source, clients, targetSession, forceUpdate); err != nil {
 		// Handle the special case where server already exists and no update was requested
@@ -117,14 +243,20 @@ func (c *InstallCommand) ExecuteWithOptions(args []string, clients []string, ses
 		}
 		return fmt.Errorf("failed to add server to project: %w", err)
 	}
+	if err := c.projectManager.SetMCPServerPath(serverName, c.ManifestPath); err != nil {
+		return fmt.Errorf("failed to record manifest path: %w", err)
+	}
+	if err := c.projectManager.SetMCPServerDev(serverName, c.Dev); err != nil {
+		return fmt.Errorf("failed to record dev checkout: %w", err)
+	}
 
 	// Extract and add required secrets from the servo file
-	if err := c.addRequiredSecretsFromSource(source); err != nil {
+	if err := c.addRequiredSecrets(servoDef); err != nil {
 		return fmt.Errorf("failed to extract required secrets: %w", err)
 	}
 
 	// Store manifest in session and generate configurations dynamically
-	if err := c.storeManifestAndGenerateConfigs(serverName, source, targetSession); err != nil {
+	if err := c.storeManifestAndGenerateConfigs(serverName, source, servoDef, targetSession); err != nil {
 		return fmt.Errorf("failed to store manifest and generate configurations: %w", err)
 	}
 
@@ -132,22 +264,88 @@ func (c *InstallCommand) ExecuteWithOptions(args []string, clients []string, ses
 	fmt.Println()
 	fmt.Println("Updated files:")
 	fmt.Println("  • .servo/project.yaml (server declaration)")
-	fmt.Println("  • .devcontainer/devcontainer.json (installation commands)")
+	if proj, err := c.projectManager.Get(); err == nil && devcontainerEnabled(proj, c.NoDevcontainer) {
+		fmt.Println("  • .devcontainer/devcontainer.json (installation commands)")
+	}
 	fmt.Println("  • .vscode/mcp.json (MCP configuration)")
 	if c.hasClaudeCodeClient(clients) {
 		fmt.Println("  • .mcp.json (Claude Code configuration)")
 	}
 
+	c.printPostInstallMessage(serverName, targetSession)
+
 	return nil
 }
 
+// printPostInstallMessage shows the manifest author's post-install notes, if any.
+// The message is printed verbatim so secret and config placeholders are never expanded.
+func (c *InstallCommand) printPostInstallMessage(serverName, sessionName string) {
+	store := manifest.NewStore(c.sessionManager.ManifestsDir(sessionName), c.parser)
+	servoDef, err := store.GetManifest(serverName)
+	if err != nil || servoDef.PostInstallMessage == "" {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("📝 Notes from %s:\n", serverName)
+	for _, line := range strings.Split(strings.TrimRight(servoDef.PostInstallMessage, "\n"), "\n") {
+		fmt.Printf("   %s\n", line)
+	}
+}
+
+// defaultClients picks the clients for an install without --clients: the manifest's
+// recommended clients among the project's default install clients (every enabled
+// client when default_install_clients is unset), or all of those clients when the
+// manifest recommends none of them
+func (c *InstallCommand) defaultClients(servoDef *pkg.ServoDefinition, proj *project.Project) ([]string, error) {
+	if err := proj.ValidateDefaultInstallClients(); err != nil {
+		return nil, err
+	}
+	candidates := proj.Clients
+	if len(proj.DefaultInstallClients) > 0 {
+		candidates = proj.DefaultInstallClients
+	}
+
+	var enabled []string
+	for _, client := range candidates {
+		if supportedClients[client] {
+			enabled = append(enabled, client)
+		}
+	}
+	if len(enabled) == 0 {
+		enabled = c.validateClients(nil)
+	}
+
+	if servoDef.Clients == nil {
+		return enabled, nil
+	}
+
+	recommended := make(map[string]bool)
+	for _, client := range servoDef.Clients.Recommended {
+		recommended[strings.TrimSpace(strings.ToLower(client))] = true
+	}
+
+	var clients []string
+	for _, client := range enabled {
+		if recommended[client] {
+			clients = append(clients, client)
+		}
+	}
+	if len(clients) == 0 {
+		return enabled, nil
+	}
+	return clients, nil
+}
+
+// supportedClients lists the devcontainer-compatible clients install can target
+var supportedClients = map[string]bool{
+	"vscode":      true,
+	"claude-code": true,
+	"cursor":      true,
+}
+
 // validateClients ensures only supported devcontainer-compatible clients are included
 func (c *InstallCommand) validateClients(clients []string) []string {
-	supportedClients := map[string]bool{
-		"vscode":      true,
-		"claude-code": true,
-		"cursor":      true,
-	}
 
 	// If no clients specified, default to all supported clients
 	if len(clients) == 0 {
@@ -167,84 +365,110 @@ func (c *InstallCommand) validateClients(clients []string) []string {
 	return validClients
 }
 
-// extractServerName extracts a server name from various source formats
-func (c *InstallCommand) extractServerName(source string) (string, error) {
-	// If it's a file path, parse it
-	if strings.HasSuffix(source, ".servo") {
-		// Use parser to extract name from .servo file
-		servoDef, err := c.parser.ParseFromFile(source)
-		if err != nil {
-			return "", fmt.Errorf("failed to parse servo file: %w", err)
-		}
-		if servoDef.Name == "" {
-			return "", fmt.Errorf("servo file missing name field")
+// serverKey returns the key a parsed manifest is installed under
+func (c *InstallCommand) serverKey(servoDef *pkg.ServoDefinition) (string, error) {
+	if servoDef.Name == "" {
+		if c.ManifestPath != "" {
+			return "", fmt.Errorf("servo file %s missing name field", c.ManifestPath)
 		}
-		return servoDef.Name, nil
+		return "", fmt.Errorf("servo file missing name field")
 	}
+	return servoDef.Key(), nil
+}
 
-	// Handle URLs (git repos, direct URLs)
-	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
-		// Try parsing as URL first
-		servoDef, err := c.parser.ParseFromURL(source)
-		if err == nil {
-			return servoDef.Name, nil
-		}
+// checkServerDependencies refuses to install a server whose depends_on names servers
+// that are not installed in the session, unless Force is set, or that would close a
+// depends_on cycle with the servers installed there, which Force does not override
+func (c *InstallCommand) checkServerDependencies(serverName string, servoDef *pkg.ServoDefinition, sessionName string) error {
+	if len(servoDef.DependsOn) == 0 {
+		return nil
+	}
 
-		// If URL parsing fails, try as git repo
-		if strings.Contains(source, "github.com") || strings.Contains(source, ".git") {
-			servoDef, err := c.parser.ParseFromGitRepo(source, "")
-			if err == nil {
-				return servoDef.Name, nil
-			}
-		}
+	store := manifest.NewStore(c.sessionManager.ManifestsDir(sessionName), c.parser)
+	installed, err := store.ListManifests()
+	if err != nil {
+		return fmt.Errorf("failed to check installed manifests: %w", err)
+	}
 
-		// Fallback: extract from URL path
-		if strings.Contains(source, "/") {
-			parts := strings.Split(source, "/")
-			name := parts[len(parts)-1]
-			if strings.Contains(name, ".") {
-				name = strings.Split(name, ".")[0]
-			}
-			return name, nil
+	withServer := map[string]*pkg.ServoDefinition{serverName: servoDef}
+	for key, installedDef := range installed {
+		if key != serverName {
+			withServer[key] = installedDef
 		}
 	}
+	if cycle := manifest.DependencyCycle(withServer); cycle != nil {
+		return fmt.Errorf("installing %s would create a server depends_on cycle: %s", serverName, strings.Join(cycle, " -> "))
+	}
 
-	// Handle git SSH URLs
-	if strings.HasPrefix(source, "git@") || strings.Contains(source, "ssh://") {
-		servoDef, err := c.parser.ParseFromGitRepo(source, "")
-		if err == nil {
-			return servoDef.Name, nil
-		}
+	missing := manifest.MissingDependencies(servoDef, installed)
+	if len(missing) == 0 {
+		return nil
+	}
 
-		// Fallback: extract repo name from SSH URL
-		// e.g., git@github.com:user/repo.git -> repo
-		parts := strings.Split(source, "/")
-		if len(parts) > 0 {
-			name := parts[len(parts)-1]
-			name = strings.TrimSuffix(name, ".git")
-			return name, nil
-		}
+	list := strings.Join(missing, ", ")
+	if c.Force {
+		fmt.Printf("⚠️  %s depends on %s, not installed in session '%s'; installing anyway (--force)\n", servoDef.Name, list, sessionName)
+		return nil
 	}
+	fmt.Printf("❌ %s depends on servers not installed in session '%s': %s\n", servoDef.Name, sessionName, list)
+	fmt.Printf("   Install them first, or use --force to install anyway.\n")
+	return fmt.Errorf("missing server dependencies in session '%s': %s", sessionName, list)
+}
 
-	// Handle directory paths
-	if strings.Contains(source, "/") {
-		// Try parsing from directory first
-		servoDef, err := c.parser.ParseFromDirectory(source)
-		if err == nil {
-			return servoDef.Name, nil
-		}
+// checkNameCollisions refuses to install a server whose declared name is already
+// declared by a manifest stored under a different key in the session. With forceUpdate
+// it returns the keys of the conflicting manifests, which replaceConflicts removes.
+func (c *InstallCommand) checkNameCollisions(serverName, declaredName, sessionName string, forceUpdate bool) ([]string, error) {
+	store := manifest.NewStore(c.sessionManager.ManifestsDir(sessionName), c.parser)
+	holders, err := store.ManifestsNamed(declaredName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check installed manifests: %w", err)
+	}
 
-		// Fallback: extract from path
-		parts := strings.Split(source, "/")
-		name := parts[len(parts)-1]
-		if strings.Contains(name, ".") {
-			name = strings.Split(name, ".")[0]
+	var conflicts []string
+	for _, key := range holders {
+		// A manifest stored under the same key is handled by the already-exists flow
+		if key != serverName {
+			conflicts = append(conflicts, key)
 		}
-		return name, nil
+	}
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+
+	if !forceUpdate {
+		files := strings.Join(conflicts, ".servo, ") + ".servo"
+		fmt.Printf("⚠️  Server name '%s' is already declared by %s in session '%s'\n", declaredName, files, sessionName)
+		fmt.Printf("   Use --update flag to replace the existing server.\n")
+		return nil, fmt.Errorf("server name '%s' conflicts with installed manifest %s", declaredName, files)
+	}
+	return conflicts, nil
+}
+
+// replaceConflicts removes the manifests checkNameCollisions found declaring the same
+// name, and their project entries, so the new server replaces them
+func (c *InstallCommand) replaceConflicts(conflicts []string, declaredName, sessionName string) error {
+	if len(conflicts) == 0 {
+		return nil
 	}
 
-	// Default: assume it's already a server name
-	return source, nil
+	store := manifest.NewStore(c.sessionManager.ManifestsDir(sessionName), c.parser)
+	project, err := c.projectManager.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get project configuration: %w", err)
+	}
+	for _, key := range conflicts {
+		fmt.Printf("🔄 Replacing %s.servo, which also declares '%s'\n", key, declaredName)
+		if err := store.RemoveManifest(key); err != nil {
+			return err
+		}
+		if projectServerInSession(project, key, sessionName) {
+			if err := c.projectManager.RemoveMCPServerFromSession(key, sessionName); err != nil {
+				return fmt.Errorf("failed to update project configuration: %w", err)
+			}
+		}
+	}
+	return nil
 }
 
 // hasClaudeCodeClient checks if Claude Code is in the clients list
@@ -257,26 +481,27 @@ func (c *InstallCommand) hasClaudeCodeClient(clients []string) bool {
 	return false
 }
 
-// storeManifestAndGenerateConfigs stores the server manifest and generates all configurations dynamically
-func (c *InstallCommand) storeManifestAndGenerateConfigs(serverName, source, sessionName string) error {
-	// Get session directory
-	sessionDir := c.sessionManager.GetSessionDir(sessionName)
-
+// storeManifestAndGenerateConfigs stores the server manifest parsed from source and
+// generates all configurations dynamically
+func (c *InstallCommand) storeManifestAndGenerateConfigs(serverName, source string, servoDef *pkg.ServoDefinition, sessionName string) error {
 	// Create manifest store for this session
-	store := manifest.NewStore(sessionDir, c.parser)
+	store := manifest.NewStore(c.sessionManager.ManifestsDir(sessionName), c.parser)
 
-	// Store the manifest
-	if err := store.StoreManifest(serverName, source); err != nil {
+	label := source
+	if c.ManifestPath != "" {
+		label += " (path: " + c.ManifestPath + ")"
+	}
+	if c.Dev {
+		servoDef.Install = devInstall(servoDef.Install)
+		label += " (dev)"
+	}
+	if err := store.SaveManifest(serverName, servoDef, label); err != nil {
 		return fmt.Errorf("failed to store manifest: %w", err)
 	}
 
 	// Generate all configurations dynamically from manifests
-	if err := c.configManager.GenerateDevcontainer(); err != nil {
-		return fmt.Errorf("failed to generate devcontainer: %w", err)
-	}
-
-	if err := c.configManager.GenerateDockerCompose(); err != nil {
-		return fmt.Errorf("failed to generate docker-compose: %w", err)
+	if _, err := generateDevcontainerConfigs(c.projectManager, c.configManager, c.NoDevcontainer); err != nil {
+		return err
 	}
 
 	// Generate MCP configurations for all installed clients based on target session
@@ -289,14 +514,9 @@ func (c *InstallCommand) storeManifestAndGenerateConfigs(serverName, source, ses
 
 // generateMCPConfigurations generates MCP configurations for all registered clients using active session
 func (c *InstallCommand) generateMCPConfigurations() error {
-	// Get active session and manifests
-	activeSession, err := c.sessionManager.GetActive()
+	activeSession, err := requireActiveSession(c.sessionManager)
 	if err != nil {
-		return fmt.Errorf("failed to get active session: %w", err)
-	}
-
-	if activeSession == nil {
-		return fmt.Errorf("no active session found")
+		return err
 	}
 
 	return c.generateMCPConfigurationsForSession(activeSession.Name)
@@ -304,100 +524,196 @@ func (c *InstallCommand) generateMCPConfigurations() error {
 
 // generateMCPConfigurationsForSession generates MCP configurations for a specific session
 func (c *InstallCommand) generateMCPConfigurationsForSession(sessionName string) error {
-	// Get project configuration
-	_, err := c.projectManager.Get()
-	if err != nil {
-		return fmt.Errorf("failed to get project: %w", err)
+	return generateClientConfigs(c.projectManager, c.sessionManager, c.clientRegistry, c.parser, sessionName)
+}
+
+// addRequiredSecrets adds the required secrets a manifest declares to the project
+func (c *InstallCommand) addRequiredSecrets(servoDef *pkg.ServoDefinition) error {
+	// Extract required secrets from configuration schema
+	if servoDef.ConfigurationSchema != nil && servoDef.ConfigurationSchema.Secrets != nil {
+		for secretName, secretSchema := range servoDef.ConfigurationSchema.Secrets {
+			if secretSchema.Required {
+				err := c.projectManager.AddRequiredSecret(secretName, secretSchema.Description)
+				if err != nil {
+					// If secret already exists, that's fine
+					if !strings.Contains(err.Error(), "already exists") {
+						return fmt.Errorf("failed to add required secret %s: %w", secretName, err)
+					}
+				}
+			}
+		}
 	}
 
-	// Get manifests from specified session
-	store := manifest.NewStore(c.sessionManager.GetSessionDir(sessionName), c.parser)
-	manifestsMap, err := store.ListManifests()
-	if err != nil {
-		return fmt.Errorf("failed to list manifests: %w", err)
+	return nil
+}
+
+// checkSystemRequirements runs the manifest's system check commands, blocking the
+// install on failure unless SkipSystemChecks is set
+func (c *InstallCommand) checkSystemRequirements(servoDef *pkg.ServoDefinition) error {
+	if servoDef.Requirements == nil || len(servoDef.Requirements.System) == 0 {
+		return nil
+	}
+
+	if c.SkipSystemChecks {
+		fmt.Println("⚠️  Skipping system requirement checks")
+		return nil
+	}
+
+	failed := failedSystemChecks(RunSystemChecks(servoDef.Requirements, c.validator, c.runCommand))
+	if len(failed) == 0 {
+		return nil
 	}
 
-	// Convert to slice format for client interface
-	manifests := make([]pkg.ServoDefinition, 0, len(manifestsMap))
-	for _, manifest := range manifestsMap {
-		manifests = append(manifests, *manifest)
+	fmt.Println("System requirements not met:")
+	for _, result := range failed {
+		printSystemCheckFailure(result, "  ")
+	}
+	fmt.Println("   Use --skip-system-checks to install anyway.")
+	return environmentFailure(fmt.Errorf("%d system requirement(s) not met", len(failed)))
+}
+
+// ensureSession makes sure an explicitly named session exists, creating it when
+// CreateSession is set
+func (c *InstallCommand) ensureSession(sessionName string) error {
+	if !c.CreateSession {
+		return c.validateSessionExists(sessionName)
 	}
 
-	// Create secrets provider
-	configuredSecrets, err := c.projectManager.GetConfiguredSecrets()
+	description := c.SessionDescription
+	if description == "" {
+		description = fmt.Sprintf("Session: %s", sessionName)
+	}
+	created, err := c.sessionManager.CreateIfMissing(sessionName, description)
 	if err != nil {
-		return fmt.Errorf("failed to get configured secrets: %w", err)
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	if created {
+		fmt.Printf("✅ Created session '%s'\n", sessionName)
 	}
+	return nil
+}
 
-	secretsProvider := func(secretName string) (string, error) {
-		if !configuredSecrets[secretName] {
-			return "", fmt.Errorf("secret '%s' is not configured", secretName)
-		}
-		// Return placeholder - in real usage, this would decrypt the actual secret
-		return "", nil
+// validateSessionExists ensures the session exists (fails if it doesn't)
+func (c *InstallCommand) validateSessionExists(sessionName string) error {
+	// Check if session exists
+	exists, err := c.sessionManager.Exists(sessionName)
+	if err != nil {
+		return fmt.Errorf("failed to check if session exists: %w", err)
 	}
 
-	// Generate configurations for all clients
-	clients := c.clientRegistry.List()
-	for _, client := range clients {
-		if client.IsInstalled() { // Only generate for installed clients
-			if err := client.GenerateConfig(manifests, secretsProvider); err != nil {
-				return fmt.Errorf("failed to generate config for %s: %w", client.Name(), err)
-			}
+	if !exists {
+		existing := "none"
+		if names, err := c.sessionManager.ListNames(); err == nil && len(names) > 0 {
+			existing = strings.Join(names, ", ")
 		}
+		return fmt.Errorf("session '%s' does not exist (existing sessions: %s). Use --create-session to create it", sessionName, existing)
 	}
 
 	return nil
 }
 
-// addRequiredSecretsFromSource extracts and adds required secrets from a servo source
-func (c *InstallCommand) addRequiredSecretsFromSource(source string) error {
-	// Parse the source to extract required secrets
-	var servoDef *pkg.ServoDefinition
-	var err error
+// parseSource parses a servo definition from a file, URL or git repository source
+func (c *InstallCommand) parseSource(source string) (*pkg.ServoDefinition, error) {
+	if c.ManifestPath != "" {
+		return c.parseSourcePath(source)
+	}
+	if c.Dev {
+		logging.Info("resolving source", "source", source, "via", "dev")
+		return c.parser.ParseFromDirectory(source)
+	}
 
 	switch {
+	case strings.HasPrefix(source, mcp.GitHubShorthandPrefix):
+		logging.Info("resolving source", "source", source, "via", "github")
+		return c.parser.ParseFromGitRepo(source, "")
 	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
-		servoDef, err = c.parser.ParseFromURL(source)
+		logging.Info("resolving source", "source", source, "via", "url")
+		return c.parser.ParseFromURL(source)
 	case strings.Contains(source, "@") || strings.Contains(source, "git"):
-		servoDef, err = c.parser.ParseFromGitRepo(source, "")
+		logging.Info("resolving source", "source", source, "via", "git")
+		return c.parser.ParseFromGitRepo(source, "")
 	default:
-		servoDef, err = c.parser.ParseFromFile(source)
+		logging.Info("resolving source", "source", source, "via", "file")
+		return c.parser.ParseFromFile(source)
 	}
+}
 
+// resolveDevSource checks that a --dev source is a local directory and returns its
+// path relative to the project root, so project.yaml holds no developer-specific
+// absolute path. A checkout that has no relative path (another volume) stays absolute.
+func resolveDevSource(source string) (string, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return "", fmt.Errorf("--dev requires a local checkout directory: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("--dev requires a local checkout directory, but %s is a file", source)
+	}
+	absSource, err := filepath.Abs(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", source, err)
+	}
+	projectRoot, err := os.Getwd()
 	if err != nil {
-		return fmt.Errorf("failed to parse servo file: %w", err)
+		return "", fmt.Errorf("failed to get working directory: %w", err)
 	}
+	if relSource, err := filepath.Rel(projectRoot, absSource); err == nil {
+		return relSource, nil
+	}
+	return absSource, nil
+}
 
-	// Extract required secrets from configuration schema
-	if servoDef.ConfigurationSchema != nil && servoDef.ConfigurationSchema.Secrets != nil {
-		for secretName, secretSchema := range servoDef.ConfigurationSchema.Secrets {
-			if secretSchema.Required {
-				err := c.projectManager.AddRequiredSecret(secretName, secretSchema.Description)
-				if err != nil {
-					// If secret already exists, that's fine
-					if !strings.Contains(err.Error(), "already exists") {
-						return fmt.Errorf("failed to add required secret %s: %w", secretName, err)
-					}
-				}
-			}
+// recordedManifestPath returns the --path the project recorded for servers installed
+// from source, or "" when none was recorded or the servers disagree on it
+func recordedManifestPath(proj *project.Project, source string) string {
+	recorded := ""
+	for _, server := range proj.MCPServers {
+		if server.Source != source || server.Path == "" {
+			continue
+		}
+		if recorded != "" && recorded != server.Path {
+			return ""
 		}
+		recorded = server.Path
 	}
+	return recorded
+}
 
-	return nil
+// devInstall rewrites a manifest's install section for a mounted checkout: the type
+// becomes local and the repository is dropped, keeping the setup and build commands
+func devInstall(install pkg.Install) pkg.Install {
+	install.Type = "local"
+	install.Method = "local"
+	install.Repository = ""
+	install.Subdirectory = ""
+	return install
 }
 
-// validateSessionExists ensures the session exists (fails if it doesn't)
-func (c *InstallCommand) validateSessionExists(sessionName string) error {
-	// Check if session exists
-	exists, err := c.sessionManager.Exists(sessionName)
-	if err != nil {
-		return fmt.Errorf("failed to check if session exists: %w", err)
+// manifestFile returns the manifest filename a source was read from, or "" when the
+// source is a URL or repository without an explicit --path
+func (c *InstallCommand) manifestFile(source string) string {
+	if c.ManifestPath != "" {
+		return c.ManifestPath
 	}
+	return localManifestFile(c.parser, source)
+}
 
-	if !exists {
-		return fmt.Errorf("session '%s' does not exist. Create it first with: servo session create %s", sessionName, sessionName)
+// parseSourcePath parses the file at ManifestPath inside a local directory or a
+// cloned repository
+func (c *InstallCommand) parseSourcePath(source string) (*pkg.ServoDefinition, error) {
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		logging.Info("resolving source", "source", source, "via", "directory", "path", c.ManifestPath)
+		relPath := filepath.Clean(filepath.FromSlash(c.ManifestPath))
+		if !filepath.IsLocal(relPath) {
+			return nil, fmt.Errorf("invalid manifest path %q: must be relative to %s", c.ManifestPath, source)
+		}
+		fullPath := filepath.Join(source, relPath)
+		if _, err := os.Stat(fullPath); err != nil {
+			return nil, fmt.Errorf("file %s not found in %s", c.ManifestPath, source)
+		}
+		return c.parser.ParseFromFile(fullPath)
 	}
 
-	return nil
+	logging.Info("resolving source", "source", source, "via", "git", "path", c.ManifestPath)
+	return c.parser.ParseFromGitRepoFile(source, c.ManifestPath)
 }
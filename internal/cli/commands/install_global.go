@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/servo/servo/internal/global"
+	"github.com/servo/servo/pkg"
+)
+
+// installGlobal records a server under ~/.servo/global and merges it into the
+// user-level config of each client, leaving every project untouched
+func (c *InstallCommand) installGlobal(source string, clients []string, forceUpdate bool) error {
+	servoDef, err := c.parseSource(source)
+	if err != nil {
+		return manifestFailure(fmt.Errorf("failed to parse source %s: %w", source, err))
+	}
+	serverName, err := c.serverKey(servoDef)
+	if err != nil {
+		return validationFailure(fmt.Errorf("failed to determine server name: %w", err))
+	}
+
+	dir, err := global.Dir()
+	if err != nil {
+		return err
+	}
+	state, err := global.Load(dir)
+	if err != nil {
+		return err
+	}
+	if _, exists := state.Servers[serverName]; exists && !forceUpdate {
+		fmt.Printf("⚠️  Server '%s' is already installed globally\n", serverName)
+		fmt.Printf("   Use --update flag to update the existing server.\n")
+		fmt.Printf("   Nothing to do.\n")
+		return nil
+	}
+
+	if len(clients) > 0 {
+		clients = c.validateClients(clients)
+	} else {
+		clients = c.globalClients()
+	}
+	if len(clients) == 0 {
+		return fmt.Errorf("no installed client supports a global config; choose clients with --clients")
+	}
+
+	fmt.Printf("📦 Adding MCP server '%s' to the user profile...\n", serverName)
+	if err := c.checkSystemRequirements(servoDef); err != nil {
+		return err
+	}
+
+	label := source
+	if c.ManifestPath != "" {
+		label += " (path: " + c.ManifestPath + ")"
+	}
+	if err := global.NewStore(dir, c.parser).SaveManifest(serverName, servoDef, label); err != nil {
+		return fmt.Errorf("failed to store manifest: %w", err)
+	}
+
+	state.Servers[serverName] = global.Server{Source: source, Clients: clients}
+	if err := state.Save(dir); err != nil {
+		return fmt.Errorf("failed to save global state: %w", err)
+	}
+
+	var updated []string
+	for _, clientName := range clients {
+		path, err := c.mergeGlobalClient(clientName, dir, state)
+		if err != nil {
+			return fmt.Errorf("failed to update %s global config: %w", clientName, err)
+		}
+		updated = append(updated, path)
+	}
+
+	fmt.Printf("✅ Added server '%s' globally\n", serverName)
+	fmt.Println()
+	fmt.Println("Updated files:")
+	fmt.Printf("  • %s (server manifest)\n", global.ManifestsDir(dir))
+	for _, path := range updated {
+		fmt.Printf("  • %s\n", path)
+	}
+	if servoDef.ConfigurationSchema != nil && len(servoDef.ConfigurationSchema.Secrets) > 0 {
+		fmt.Printf("⚠️  Project secrets are not available to global servers; ${...} placeholders are written as-is\n")
+	}
+	fmt.Printf("💡 Restart your clients to pick up '%s'\n", serverName)
+
+	return nil
+}
+
+// mergeGlobalClient rewrites one client's user-level config with every global server
+// recorded for it, returning the file it wrote
+func (c *InstallCommand) mergeGlobalClient(clientName, dir string, state *global.State) (string, error) {
+	registered, err := c.clientRegistry.Get(clientName)
+	if err != nil {
+		return "", err
+	}
+	globalClient, ok := registered.(pkg.GlobalConfigClient)
+	if !ok {
+		return "", fmt.Errorf("client '%s' has no user-level config", clientName)
+	}
+
+	store := global.NewStore(dir, c.parser)
+	var manifests []pkg.ServoDefinition
+	for _, name := range state.ServersFor(clientName) {
+		servoDef, err := store.GetManifest(name)
+		if err != nil {
+			return "", err
+		}
+		manifests = append(manifests, servoDef.ForClient(clientName))
+	}
+
+	// Secrets live in the project, so global servers keep their placeholders
+	noSecrets := func(name string) (string, error) {
+		return "", fmt.Errorf("secret '%s' is not available globally", name)
+	}
+	if err := globalClient.MergeGlobalConfig(manifests, noSecrets); err != nil {
+		return "", err
+	}
+	return globalClient.GlobalConfigPath()
+}
+
+// globalClients returns, sorted, the installed clients that have a user-level config
+func (c *InstallCommand) globalClients() []string {
+	var clients []string
+	for _, registered := range c.clientRegistry.List() {
+		if _, ok := registered.(pkg.GlobalConfigClient); ok && supportedClients[registered.Name()] && registered.IsInstalled() {
+			clients = append(clients, registered.Name())
+		}
+	}
+	sort.Strings(clients)
+	return clients
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/servo/servo/internal/global"
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+)
+
+func TestInstallCommand_Global(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	// A server configured by hand in the user-level config must survive the merge
+	cursorPath := filepath.Join(home, ".cursor", "mcp.json")
+	os.MkdirAll(filepath.Dir(cursorPath), 0755)
+	os.WriteFile(cursorPath, []byte(`{"mcpServers": {"manual": {"command": "manual"}}, "other": true}`), 0644)
+
+	os.WriteFile("notes.servo", []byte("servo_version: \"1.0\"\nname: notes\nserver:\n  transport: stdio\n  command: notes\n  args: [\"--token\", \"${notes_token}\"]\n"), 0644)
+
+	install := NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	install.Global = true
+	if err := install.ExecuteWithOptions([]string{"notes.servo"}, []string{"cursor", "vscode"}, "default", false); err == nil {
+		t.Error("--global with --session should fail")
+	}
+	if err := install.ExecuteWithOptions([]string{"notes.servo"}, []string{"cursor", "vscode"}, "", false); err != nil {
+		t.Fatalf("global install failed outside a project: %v", err)
+	}
+
+	var cursorConfig struct {
+		Servers map[string]struct {
+			Command string   `json:"command"`
+			Args    []string `json:"args"`
+		} `json:"mcpServers"`
+		Other bool `json:"other"`
+	}
+	data, _ := os.ReadFile(cursorPath)
+	if err := json.Unmarshal(data, &cursorConfig); err != nil {
+		t.Fatalf("Failed to parse %s: %v", cursorPath, err)
+	}
+	if cursorConfig.Servers["manual"].Command != "manual" || !cursorConfig.Other {
+		t.Errorf("merge dropped existing entries: %s", data)
+	}
+	if notes := cursorConfig.Servers["notes"]; notes.Command != "notes" || len(notes.Args) != 2 || notes.Args[1] != "${notes_token}" {
+		t.Errorf("notes entry = %+v, want the command with its secret placeholder kept", notes)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".config", "Code", "User", "mcp.json")); err != nil {
+		t.Errorf("Expected the VS Code user config to be written: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".claude.json")); !os.IsNotExist(err) {
+		t.Error("a client that was not chosen must not be touched")
+	}
+
+	dir, _ := global.Dir()
+	state, err := global.Load(dir)
+	if err != nil || state.Servers["notes"].Source != "notes.servo" {
+		t.Fatalf("global state = %+v, %v", state, err)
+	}
+
+	// Projects only see global servers when they opt in, and their own servers win
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+	os.WriteFile(".servo/sessions/default/manifests/notes.servo", []byte("servo_version: \"1.0\"\nname: notes\nserver:\n  transport: stdio\n  command: project-notes\n"), 0644)
+	globalOnly := "servo_version: \"1.0\"\nname: journal\nserver:\n  transport: stdio\n  command: journal\n"
+	os.WriteFile(filepath.Join(global.ManifestsDir(dir), "journal.servo"), []byte(globalOnly), 0644)
+
+	projectManager := project.NewManager()
+	sessionManager := session.NewManager(".servo")
+	commands := func() map[string]string {
+		manifests, _, err := clientConfigInputs(projectManager, sessionManager, mcp.NewParser(), "default")
+		if err != nil {
+			t.Fatalf("clientConfigInputs() error = %v", err)
+		}
+		commands := make(map[string]string)
+		for _, m := range manifests {
+			commands[m.Name] = m.Server.Command
+		}
+		return commands
+	}
+
+	if got := commands(); len(got) != 1 {
+		t.Errorf("without include_global_servers, got servers %v", got)
+	}
+	proj, _ := projectManager.Get()
+	proj.Config.IncludeGlobalServers = true
+	if err := projectManager.Save(proj); err != nil {
+		t.Fatalf("Failed to save project: %v", err)
+	}
+	got := commands()
+	if len(got) != 2 || got["notes"] != "project-notes" || got["journal"] != "journal" {
+		t.Errorf("with include_global_servers, got servers %v", got)
+	}
+}
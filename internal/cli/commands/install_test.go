@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/servo/servo/internal/manifest"
 	"github.com/servo/servo/internal/mcp"
 	"github.com/servo/servo/internal/project"
 	"github.com/servo/servo/internal/session"
@@ -653,12 +655,16 @@ server:
 		{"test-server.servo", "extraction-test-server", false},
 		{"./another-test.servo", "another-server", false},
 		{"nonexistent.servo", "", true},
-		{"invalid-source-without-extension", "invalid-source-without-extension", false}, // Command uses source as name
+		{"invalid-source-without-extension", "", true}, // Not a manifest, so there is no name to install under
 	}
 
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf("extract_%s", strings.ReplaceAll(tc.source, "/", "_")), func(t *testing.T) {
-			name, err := cmd.extractServerName(tc.source)
+			var name string
+			servoDef, err := cmd.parseSource(tc.source)
+			if err == nil {
+				name, err = cmd.serverKey(servoDef)
+			}
 
 			if tc.shouldFail {
 				if err == nil {
@@ -727,6 +733,125 @@ server:
 	}
 }
 
+func TestInstallCommand_CreateSession(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+
+	mockServoContent := `servo_version: "1.0"
+name: "create-session-server"
+version: "1.0.0"
+description: "Test session creation"
+
+server:
+  transport: "stdio"
+  command: "python"
+  args: ["-m", "test_server"]`
+
+	if err := os.WriteFile("create-session.servo", []byte(mockServoContent), 0644); err != nil {
+		t.Fatalf("Failed to create servo file: %v", err)
+	}
+	args := []string{"create-session.servo"}
+
+	// Without CreateSession the error names the sessions that do exist
+	cmd := NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	err := cmd.ExecuteWithOptions(args, []string{"vscode"}, "feature", false)
+	if err == nil {
+		t.Fatal("Expected error when installing to a missing session")
+	}
+	if !strings.Contains(err.Error(), "existing sessions: default") || !strings.Contains(err.Error(), "--create-session") {
+		t.Errorf("Expected error to list existing sessions and suggest --create-session, got: %v", err)
+	}
+
+	sessionManager := session.NewManager(".servo")
+	if exists, _ := sessionManager.Exists("feature"); exists {
+		t.Fatal("Session should not be created without CreateSession")
+	}
+
+	// With CreateSession the session is created before installing into it
+	cmd = NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	cmd.CreateSession = true
+	cmd.SessionDescription = "Feature work"
+	_ = cmd.ExecuteWithOptions(args, []string{"vscode"}, "feature", false)
+
+	created, err := sessionManager.Get("feature")
+	if err != nil {
+		t.Fatalf("Expected session 'feature' to be created: %v", err)
+	}
+	if created.Description != "Feature work" {
+		t.Errorf("Description = %q, want %q", created.Description, "Feature work")
+	}
+}
+
+func TestInstallCommand_UpdateDefault(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+
+	mockServoContent := `servo_version: "1.0"
+name: "update-default-server"
+version: "1.0.0"
+description: "Test install update default"
+
+server:
+  transport: "stdio"
+  command: "python"
+  args: ["-m", "test_server"]`
+
+	if err := os.WriteFile("update-default.servo", []byte(mockServoContent), 0644); err != nil {
+		t.Fatalf("Failed to create servo file: %v", err)
+	}
+
+	projectManager := project.NewManager()
+	proj, err := projectManager.Get()
+	if err != nil {
+		t.Fatalf("Failed to get project: %v", err)
+	}
+	proj.MCPServers = []project.MCPServer{{
+		Name:     "update-default-server",
+		Source:   "old.servo",
+		Clients:  []string{"vscode"},
+		Sessions: []string{"default"},
+	}}
+	proj.Config.InstallUpdateDefault = true
+	if err := projectManager.Save(proj); err != nil {
+		t.Fatalf("Failed to save project: %v", err)
+	}
+
+	source := func() string {
+		proj, err := projectManager.Get()
+		if err != nil {
+			t.Fatalf("Failed to get project: %v", err)
+		}
+		return proj.MCPServers[0].Source
+	}
+
+	// NoUpdate restores the already-exists behaviour
+	cmd := NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	cmd.NoUpdate = true
+	if err := cmd.ExecuteWithOptions([]string{"update-default.servo"}, []string{"vscode"}, "", false); err != nil {
+		t.Fatalf("Expected already-exists install to be a no-op, got: %v", err)
+	}
+	if got := source(); got != "old.servo" {
+		t.Errorf("Source = %q after NoUpdate install, want old.servo", got)
+	}
+
+	// Without a flag the project default updates the existing server
+	cmd = NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	_ = cmd.ExecuteWithOptions([]string{"update-default.servo"}, []string{"vscode"}, "", false)
+	if got := source(); got != "update-default.servo" {
+		t.Errorf("Source = %q after default install, want update-default.servo", got)
+	}
+}
+
 func TestInstallCommand_EmptyClients(t *testing.T) {
 	// Setup temporary directory
 	tempDir, err := ioutil.TempDir("", "servo_empty_clients_test_")
@@ -846,3 +971,371 @@ func TestInstallCommand_ErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestInstallCommand_ManifestPath(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+
+	// Two manifests in one source: directory discovery would be ambiguous
+	os.MkdirAll("repo/servers/db", 0755)
+	os.WriteFile("repo/other.servo", []byte("servo_version: \"1.0\"\nname: other\nserver:\n  transport: stdio\n  command: other\n"), 0644)
+	os.WriteFile("repo/servers/db/db.servo", []byte("servo_version: \"1.0\"\nname: db\nserver:\n  transport: stdio\n  command: db\n"), 0644)
+
+	cmd := NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	cmd.ManifestPath = "servers/missing.servo"
+	err := cmd.ExecuteWithOptions([]string{"repo"}, []string{"vscode"}, "", false)
+	if err == nil || !strings.Contains(err.Error(), "servers/missing.servo") {
+		t.Fatalf("Expected missing path error naming the path, got %v", err)
+	}
+
+	cmd.ManifestPath = "servers/db/db.servo"
+	if err := cmd.ExecuteWithOptions([]string{"repo"}, []string{"vscode"}, "", false); err != nil {
+		t.Fatalf("Install with manifest path failed: %v", err)
+	}
+	if _, err := os.Stat(".servo/sessions/default/manifests/db.servo"); err != nil {
+		t.Errorf("Expected db manifest to be stored: %v", err)
+	}
+
+	proj, err := project.NewManager().Get()
+	if err != nil {
+		t.Fatalf("Failed to get project: %v", err)
+	}
+	if len(proj.MCPServers) != 1 || proj.MCPServers[0].Name != "db" || proj.MCPServers[0].Path != "servers/db/db.servo" {
+		t.Errorf("Expected db server with recorded path, got %+v", proj.MCPServers)
+	}
+	// Reinstalling from the same source reads the recorded path instead of searching
+	cmd = NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	if err := cmd.ExecuteWithOptions([]string{"repo"}, []string{"vscode"}, "", true); err != nil {
+		t.Fatalf("Reinstall without --path failed: %v", err)
+	}
+	if cmd.ManifestPath != "servers/db/db.servo" {
+		t.Errorf("Expected the recorded manifest path to be reused, got %q", cmd.ManifestPath)
+	}
+}
+
+func TestInstallCommand_NameCollision(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+
+	// A manifest stored under a source-derived key that declares the same name
+	manifestDir := ".servo/sessions/default/manifests"
+	os.MkdirAll(manifestDir, 0755)
+	os.WriteFile(filepath.Join(manifestDir, "legacy-api.servo"), []byte("servo_version: \"1.0\"\nname: api-server\nserver:\n  transport: stdio\n  command: old\n"), 0644)
+	os.WriteFile("api.servo", []byte("servo_version: \"1.0\"\nname: api-server\nserver:\n  transport: stdio\n  command: new\n"), 0644)
+
+	cmd := NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	err := cmd.ExecuteWithOptions([]string{"api.servo"}, []string{"vscode"}, "", false)
+	if err == nil || !strings.Contains(err.Error(), "legacy-api.servo") {
+		t.Fatalf("Expected collision error naming legacy-api.servo, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(manifestDir, "api-server.servo")); !os.IsNotExist(err) {
+		t.Error("Colliding manifest should not be stored without --update")
+	}
+
+	// A replacement that fails a later check leaves the conflicting manifest in place
+	os.WriteFile("api-deps.servo", []byte("servo_version: \"1.0\"\nname: api-server\ndepends_on: [database-server]\nserver:\n  transport: stdio\n  command: new\n"), 0644)
+	if err := cmd.ExecuteWithOptions([]string{"api-deps.servo"}, []string{"vscode"}, "", true); err == nil {
+		t.Fatal("Expected the missing dependency to fail the install")
+	}
+	if _, err := os.Stat(filepath.Join(manifestDir, "legacy-api.servo")); err != nil {
+		t.Errorf("Expected a failed install to keep the conflicting manifest: %v", err)
+	}
+
+	if err := cmd.ExecuteWithOptions([]string{"api.servo"}, []string{"vscode"}, "", true); err != nil {
+		t.Fatalf("Install with --update failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(manifestDir, "legacy-api.servo")); !os.IsNotExist(err) {
+		t.Error("Expected conflicting manifest to be replaced")
+	}
+	if _, err := os.Stat(filepath.Join(manifestDir, "api-server.servo")); err != nil {
+		t.Errorf("Expected new manifest to be stored: %v", err)
+	}
+}
+
+func TestInstallCommand_DefaultClientsFromManifest(t *testing.T) {
+	tests := []struct {
+		name        string
+		recommended string
+		clients     []string
+		defaults    []string
+		want        []string
+	}{
+		{name: "recommended and enabled", recommended: "[cursor, claude-code]", want: []string{"cursor"}},
+		{name: "no overlap falls back to project", recommended: "[claude-code]", want: []string{"vscode", "cursor"}},
+		{name: "no recommendation", want: []string{"vscode", "cursor"}},
+		{name: "explicit clients win", recommended: "[cursor]", clients: []string{"claude-code"}, want: []string{"claude-code"}},
+		{name: "all expands to enabled", recommended: "[cursor]", clients: []string{"all"}, want: []string{"vscode", "cursor"}},
+		{name: "all with explicit client", clients: []string{"ALL", "claude-code", "vscode"}, want: []string{"vscode", "cursor", "claude-code"}},
+		{name: "default install clients", defaults: []string{"cursor"}, want: []string{"cursor"}},
+		{name: "recommendation outside defaults", recommended: "[vscode]", defaults: []string{"cursor"}, want: []string{"cursor"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalWd, _ := os.Getwd()
+			defer os.Chdir(originalWd)
+			os.Chdir(t.TempDir())
+
+			if err := setupInstallTestProject(t); err != nil {
+				t.Fatalf("Failed to setup project: %v", err)
+			}
+			projectManager := project.NewManager()
+			proj, _ := projectManager.Get()
+			proj.Clients = []string{"vscode", "cursor"}
+			proj.DefaultInstallClients = tt.defaults
+			projectManager.Save(proj)
+
+			content := "servo_version: \"1.0\"\nname: api\nserver:\n  transport: stdio\n  command: api\n"
+			if tt.recommended != "" {
+				content += "clients:\n  recommended: " + tt.recommended + "\n"
+			}
+			os.WriteFile("api.servo", []byte(content), 0644)
+
+			cmd := NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+			if err := cmd.ExecuteWithOptions([]string{"api.servo"}, tt.clients, "", false); err != nil {
+				t.Fatalf("Install failed: %v", err)
+			}
+
+			proj, err := projectManager.Get()
+			if err != nil {
+				t.Fatalf("Failed to get project: %v", err)
+			}
+			if len(proj.MCPServers) != 1 || strings.Join(proj.MCPServers[0].Clients, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected server clients %v, got %+v", tt.want, proj.MCPServers)
+			}
+		})
+	}
+}
+
+func TestInstallCommand_Dev(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+	projectManager := project.NewManager()
+	proj, _ := projectManager.Get()
+	proj.Config.DevcontainerMode = project.DevcontainerModeCompose
+	projectManager.Save(proj)
+
+	definition := `servo_version: "1.0"
+name: api-server
+install:
+  type: git
+  method: git
+  repository: https://github.com/example/api-server.git
+  setup_commands:
+    - make setup
+server:
+  transport: stdio
+  command: api-server
+`
+	os.MkdirAll("checkout", 0755)
+	os.WriteFile("checkout/api-server.servo", []byte(definition), 0644)
+	cmd := NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	cmd.Dev = true
+	if err := cmd.ExecuteWithOptions([]string{"checkout/api-server.servo"}, []string{"vscode"}, "", false); err == nil {
+		t.Fatal("Expected --dev to reject a file source")
+	}
+	if err := cmd.ExecuteWithOptions([]string{"checkout"}, []string{"vscode"}, "", false); err != nil {
+		t.Fatalf("Install with --dev failed: %v", err)
+	}
+
+	proj, err := project.NewManager().Get()
+	if err != nil {
+		t.Fatalf("Failed to get project: %v", err)
+	}
+	// The checkout is recorded relative to the project root, not as a host path
+	if len(proj.MCPServers) != 1 || !proj.MCPServers[0].Dev || proj.MCPServers[0].Source != "checkout" {
+		t.Fatalf("Expected dev server recording checkout, got %+v", proj.MCPServers)
+	}
+
+	stored, err := manifest.NewStore(session.NewManager(".servo").ManifestsDir("default"), mcp.NewParser()).GetManifest("api-server")
+	if err != nil {
+		t.Fatalf("Failed to read stored manifest: %v", err)
+	}
+	if stored.Install.Type != "local" || stored.Install.Repository != "" || len(stored.Install.SetupCommands) != 1 {
+		t.Errorf("Expected local install keeping setup commands, got %+v", stored.Install)
+	}
+
+	compose, err := os.ReadFile(".devcontainer/docker-compose.yml")
+	if err != nil {
+		t.Fatalf("Failed to read docker-compose.yml: %v", err)
+	}
+	cwd, _ := os.Getwd()
+	mount := "../checkout:/workspaces/" + filepath.Base(cwd) + "/.servo/dev/api-server:cached"
+	if !strings.Contains(string(compose), mount) {
+		t.Errorf("Expected workspace mount %s, got:\n%s", mount, compose)
+	}
+
+	// Reinstalling from a regular source replaces the manifest and drops the mount
+	os.WriteFile("api.servo", []byte(definition), 0644)
+	cmd = NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	if err := cmd.ExecuteWithOptions([]string{"api.servo"}, []string{"vscode"}, "", true); err != nil {
+		t.Fatalf("Install with --update failed: %v", err)
+	}
+	proj, _ = project.NewManager().Get()
+	if proj.MCPServers[0].Dev || proj.MCPServers[0].Source != "api.servo" {
+		t.Errorf("Expected regular server after reinstall, got %+v", proj.MCPServers[0])
+	}
+	stored, _ = manifest.NewStore(session.NewManager(".servo").ManifestsDir("default"), mcp.NewParser()).GetManifest("api-server")
+	if stored == nil || stored.Install.Type != "git" {
+		t.Errorf("Expected git install after reinstall, got %+v", stored)
+	}
+	compose, _ = os.ReadFile(".devcontainer/docker-compose.yml")
+	if strings.Contains(string(compose), "/.servo/dev/") {
+		t.Errorf("Expected dev mount to be removed, got:\n%s", compose)
+	}
+}
+
+func TestInstallCommand_StableID(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+
+	os.WriteFile("search.servo", []byte("servo_version: \"1.0\"\nid: acme-search\nname: search\nserver:\n  transport: stdio\n  command: search\n"), 0644)
+	os.WriteFile("web-search.servo", []byte("servo_version: \"1.0\"\nid: acme-search\nname: web-search\nserver:\n  transport: stdio\n  command: web-search\n"), 0644)
+
+	cmd := NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	if err := cmd.ExecuteWithOptions([]string{"search.servo"}, []string{"vscode"}, "", false); err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	store := manifest.NewStore(session.NewManager(".servo").ManifestsDir("default"), mcp.NewParser())
+	stored, err := store.GetManifest("acme-search")
+	if err != nil {
+		t.Fatalf("Expected manifest stored under its id: %v", err)
+	}
+
+	// The renamed manifest keeps its id, so it is the same server and needs --update
+	if err := cmd.ExecuteWithOptions([]string{"web-search.servo"}, []string{"vscode"}, "", false); err != nil {
+		t.Fatalf("Install of renamed manifest failed: %v", err)
+	}
+	stored, _ = store.GetManifest("acme-search")
+	if stored.Name != "search" {
+		t.Errorf("Expected the installed manifest to be kept without --update, got %s", stored.Name)
+	}
+
+	if err := cmd.ExecuteWithOptions([]string{"web-search.servo"}, []string{"vscode"}, "", true); err != nil {
+		t.Fatalf("Install with --update failed: %v", err)
+	}
+	stored, _ = store.GetManifest("acme-search")
+	if stored.Name != "web-search" {
+		t.Errorf("Expected the rename to update the manifest, got %s", stored.Name)
+	}
+	names, _ := store.Names()
+	if len(names) != 1 {
+		t.Errorf("Expected a single stored manifest, got %v", names)
+	}
+	proj, _ := project.NewManager().Get()
+	if len(proj.MCPServers) != 1 || proj.MCPServers[0].Name != "acme-search" || proj.MCPServers[0].Source != "web-search.servo" {
+		t.Errorf("Expected one server tracked by id, got %+v", proj.MCPServers)
+	}
+}
+
+func TestInstallCommand_ServerDependencies(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+
+	os.WriteFile("database-server.servo", []byte("servo_version: \"1.1\"\nname: database-server\nserver:\n  transport: stdio\n  command: db\n"), 0644)
+	os.WriteFile("api-server.servo", []byte("servo_version: \"1.1\"\nname: api-server\ndepends_on: [database-server]\nserver:\n  transport: stdio\n  command: api\n"), 0644)
+
+	cmd := NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	err := cmd.ExecuteWithOptions([]string{"api-server.servo"}, []string{"vscode"}, "", false)
+	if err == nil || !strings.Contains(err.Error(), "missing server dependencies in session 'default': database-server") {
+		t.Fatalf("Expected the missing dependency to fail the install, got %v", err)
+	}
+	store := manifest.NewStore(session.NewManager(".servo").ManifestsDir("default"), mcp.NewParser())
+	if names, _ := store.Names(); len(names) != 0 {
+		t.Errorf("Expected nothing installed after a refused install, got %v", names)
+	}
+
+	cmd.Force = true
+	if err := cmd.ExecuteWithOptions([]string{"api-server.servo"}, []string{"vscode"}, "", false); err != nil {
+		t.Fatalf("Expected --force to install anyway, got %v", err)
+	}
+
+	cmd.Force = false
+	if err := cmd.ExecuteWithOptions([]string{"database-server.servo"}, []string{"vscode"}, "", false); err != nil {
+		t.Fatalf("Install of the dependency failed: %v", err)
+	}
+	if err := cmd.ExecuteWithOptions([]string{"api-server.servo"}, []string{"vscode"}, "", true); err != nil {
+		t.Errorf("Expected the install to pass once the dependency is installed, got %v", err)
+	}
+
+	// An update that closes a cycle is refused, even with --force
+	os.WriteFile("database-server.servo", []byte("servo_version: \"1.1\"\nname: database-server\ndepends_on: [api-server]\nserver:\n  transport: stdio\n  command: db\n"), 0644)
+	cmd.Force = true
+	err = cmd.ExecuteWithOptions([]string{"database-server.servo"}, []string{"vscode"}, "", true)
+	if err == nil || !strings.Contains(err.Error(), "cycle: api-server -> database-server -> api-server") {
+		t.Errorf("Expected the depends_on cycle to fail the install, got %v", err)
+	}
+}
+
+func TestInstallCommand_AllClientsWithoutEnabled(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+	projectManager := project.NewManager()
+	proj, _ := projectManager.Get()
+	proj.Clients = nil
+	projectManager.Save(proj)
+	os.WriteFile("api.servo", []byte("servo_version: \"1.0\"\nname: api\nserver:\n  transport: stdio\n  command: api\n"), 0644)
+
+	cmd := NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	err := cmd.ExecuteWithOptions([]string{"api.servo"}, []string{"all"}, "", false)
+	if err == nil || !strings.Contains(err.Error(), "servo client enable") {
+		t.Errorf("Expected --clients all without enabled clients to point at 'servo client enable', got %v", err)
+	}
+
+	cmd = NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	cmd.Global = true
+	err = cmd.ExecuteWithOptions([]string{"api.servo"}, []string{"all"}, "", false)
+	if err == nil || !strings.Contains(err.Error(), "--global") {
+		t.Errorf("Expected --clients all to be rejected with --global, got %v", err)
+	}
+}
+
+func TestInstallCommand_SourceExitCode(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+	os.WriteFile("malformed.servo", []byte("name: [unclosed\n"), 0644)
+
+	cmd := NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	if got := ExitCode(cmd.ExecuteWithOptions([]string{"malformed.servo"}, []string{"vscode"}, "", false)); got != ExitValidation {
+		t.Errorf("Expected a malformed manifest to exit %d, got %d", ExitValidation, got)
+	}
+	if got := ExitCode(cmd.ExecuteWithOptions([]string{"missing.servo"}, []string{"vscode"}, "", false)); got != ExitEnvironment {
+		t.Errorf("Expected an unreadable source to exit %d, got %d", ExitEnvironment, got)
+	}
+}
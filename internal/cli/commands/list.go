@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/servo/servo/internal/manifest"
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+)
+
+// ListCommand lists the MCP servers installed in a session
+type ListCommand struct {
+	projectManager *project.Manager
+	sessionManager *session.Manager
+	parser         *mcp.Parser
+}
+
+// NewListCommand creates a new list command
+func NewListCommand() *ListCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &ListCommand{
+		projectManager: deps.ProjectManager,
+		sessionManager: deps.SessionManager,
+		parser:         deps.Parser,
+	}
+}
+
+// Name returns the command name
+func (c *ListCommand) Name() string {
+	return "list"
+}
+
+// Description returns the command description
+func (c *ListCommand) Description() string {
+	return "List installed MCP servers"
+}
+
+// Execute lists the servers in the active session
+func (c *ListCommand) Execute(args []string) error {
+	return c.ExecuteWithOptions("", "")
+}
+
+// ExecuteWithOptions lists the servers installed in sessionName (the active
+// session when empty), keeping only those tagged with tag when it is set
+func (c *ListCommand) ExecuteWithOptions(sessionName, tag string) error {
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	proj, err := c.projectManager.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	targetSession, err := resolveSession(c.sessionManager, proj, sessionName)
+	if err != nil {
+		return err
+	}
+
+	store := manifest.NewStore(c.sessionManager.ManifestsDir(targetSession), c.parser)
+	manifests, err := store.ListManifests()
+	if err != nil {
+		return fmt.Errorf("failed to list manifests: %w", err)
+	}
+
+	var keys []string
+	if tag != "" {
+		keys = manifest.NewTagIndex(manifests).Servers(tag)
+	} else {
+		for key := range manifests {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+	}
+
+	if len(keys) == 0 {
+		if tag != "" {
+			fmt.Printf("No MCP servers tagged '%s' in session '%s'\n", tag, targetSession)
+		} else {
+			fmt.Printf("No MCP servers installed in session '%s'\n", targetSession)
+		}
+		return nil
+	}
+
+	if tag != "" {
+		fmt.Printf("MCP Servers tagged '%s' (session: %s):\n", tag, targetSession)
+	} else {
+		fmt.Printf("MCP Servers (session: %s):\n", targetSession)
+	}
+	fmt.Printf("%-25s %-10s %-25s %-30s %s\n", "NAME", "VERSION", "TAGS", "CAPABILITIES", "LINK")
+	fmt.Printf("%-25s %-10s %-25s %-30s %s\n", "----", "-------", "----", "------------", "----")
+	for _, key := range keys {
+		servo := manifests[key]
+		tags := "-"
+		if serverTags := manifest.Tags(servo); len(serverTags) > 0 {
+			tags = strings.Join(serverTags, ", ")
+		}
+		version := servo.Version
+		if version == "" {
+			version = "-"
+		}
+		capabilities := manifest.CapabilityCounts(servo)
+		if capabilities == "" {
+			capabilities = "-"
+		}
+		link := manifest.ProjectURL(servo)
+		if link == "" {
+			link = "-"
+		}
+		fmt.Printf("%-25s %-10s %-25s %-30s %s\n", servo.Name, version, tags, capabilities, link)
+	}
+
+	return nil
+}
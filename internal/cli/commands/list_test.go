@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestListCommand_ExecuteWithOptions(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+
+	manifest := `servo_version: "1.0"
+name: postgres
+version: "1.0.0"
+metadata:
+  tags: [database, storage]
+server:
+  transport: stdio
+  command: postgres-mcp
+`
+	if err := os.WriteFile(filepath.Join(".servo", "sessions", "default", "manifests", "postgres.servo"), []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	cmd := NewListCommand()
+	for _, tag := range []string{"", "database", "missing"} {
+		if err := cmd.ExecuteWithOptions("", tag); err != nil {
+			t.Errorf("ExecuteWithOptions(tag=%q) error = %v", tag, err)
+		}
+	}
+
+	err := cmd.ExecuteWithOptions("nonexistent", "")
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("Expected missing session error, got %v", err)
+	}
+}
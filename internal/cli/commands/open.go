@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/servo/servo/internal/manifest"
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+	"github.com/servo/servo/internal/utils"
+	"github.com/servo/servo/pkg"
+)
+
+// browseFunc opens a URL in the user's browser
+type browseFunc func(url string) error
+
+// OpenCommand opens an installed server's homepage or repository in the browser
+type OpenCommand struct {
+	projectManager *project.Manager
+	sessionManager *session.Manager
+	parser         *mcp.Parser
+	browse         browseFunc
+}
+
+// NewOpenCommand creates a new open command
+func NewOpenCommand() *OpenCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &OpenCommand{
+		projectManager: deps.ProjectManager,
+		sessionManager: deps.SessionManager,
+		parser:         deps.Parser,
+		browse:         openInBrowser,
+	}
+}
+
+// Name returns the command name
+func (c *OpenCommand) Name() string {
+	return "open"
+}
+
+// Description returns the command description
+func (c *OpenCommand) Description() string {
+	return "Open an MCP server's homepage or repository in the browser"
+}
+
+// Execute opens the page of a server in the active session
+func (c *OpenCommand) Execute(args []string) error {
+	return c.ExecuteWithOptions(args, "")
+}
+
+// ExecuteWithOptions opens the page of a server installed in sessionName (the
+// active session when empty). A server without a web link is reported, not an error.
+func (c *OpenCommand) ExecuteWithOptions(args []string, sessionName string) error {
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("server name is required\nUsage: servo open <server>")
+	}
+	serverName := args[0]
+
+	proj, err := c.projectManager.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	targetSession, err := resolveSession(c.sessionManager, proj, sessionName)
+	if err != nil {
+		return err
+	}
+
+	servo, err := c.findManifest(serverName, targetSession)
+	if err != nil {
+		return err
+	}
+
+	link := manifest.ProjectURL(servo)
+	if link == "" {
+		fmt.Printf("⚠️  Server '%s' has no homepage or repository URL; nothing to open\n", serverName)
+		return nil
+	}
+
+	fmt.Printf("🔗 Opening %s\n", link)
+	if err := c.browse(link); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	return nil
+}
+
+// findManifest looks a server up by its stored key or the name its manifest declares
+func (c *OpenCommand) findManifest(serverName, sessionName string) (*pkg.ServoDefinition, error) {
+	store := manifest.NewStore(c.sessionManager.ManifestsDir(sessionName), c.parser)
+	key, err := store.ResolveKey(serverName)
+	if err != nil {
+		return nil, err
+	}
+	servo, err := store.GetManifest(key)
+	if err != nil {
+		return nil, fmt.Errorf("server '%s' is not installed in session '%s'", serverName, sessionName)
+	}
+	return servo, nil
+}
+
+// openInBrowser hands a URL to the platform's default handler without waiting for it
+func openInBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch utils.CurrentPlatform() {
+	case utils.PlatformDarwin:
+		cmd = exec.Command("open", url)
+	case utils.PlatformWindows:
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpenCommand_ExecuteWithOptions(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+
+	manifestDir := filepath.Join(".servo", "sessions", "default", "manifests")
+	os.WriteFile(filepath.Join(manifestDir, "postgres.servo"), []byte(`servo_version: "1.0"
+name: postgres
+metadata:
+  repository: https://github.com/example/postgres-mcp
+server:
+  transport: stdio
+  command: postgres-mcp
+`), 0644)
+	os.WriteFile(filepath.Join(manifestDir, "acme-search.servo"), []byte(`servo_version: "1.0"
+id: acme-search
+name: search
+metadata:
+  homepage: https://search.example.com
+  repository: https://github.com/example/search
+server:
+  transport: stdio
+  command: search
+`), 0644)
+	os.WriteFile(filepath.Join(manifestDir, "bare.servo"), []byte(`servo_version: "1.0"
+name: bare
+server:
+  transport: stdio
+  command: bare
+`), 0644)
+
+	var opened []string
+	cmd := NewOpenCommand()
+	cmd.browse = func(url string) error {
+		opened = append(opened, url)
+		return nil
+	}
+
+	for _, server := range []string{"postgres", "acme-search", "search", "bare"} {
+		if err := cmd.ExecuteWithOptions([]string{server}, ""); err != nil {
+			t.Errorf("open %s: %v", server, err)
+		}
+	}
+	want := []string{"https://github.com/example/postgres-mcp", "https://search.example.com", "https://search.example.com"}
+	if strings.Join(opened, " ") != strings.Join(want, " ") {
+		t.Errorf("Opened %v, want %v", opened, want)
+	}
+
+	if err := cmd.ExecuteWithOptions([]string{"missing"}, ""); err == nil || !strings.Contains(err.Error(), "not installed") {
+		t.Errorf("Expected not installed error, got %v", err)
+	}
+	if err := cmd.ExecuteWithOptions(nil, ""); err == nil {
+		t.Error("Expected an error without a server name")
+	}
+}
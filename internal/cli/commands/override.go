@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/servo/servo/internal/config"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+)
+
+// OverrideShowCommand prints the docker-compose and devcontainer configuration a
+// session generates once every override layer is merged, without writing it
+type OverrideShowCommand struct {
+	projectManager *project.Manager
+	sessionManager *session.Manager
+}
+
+// NewOverrideShowCommand creates a new override show command
+func NewOverrideShowCommand() *OverrideShowCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &OverrideShowCommand{
+		projectManager: deps.ProjectManager,
+		sessionManager: deps.SessionManager,
+	}
+}
+
+// Name returns the command name
+func (c *OverrideShowCommand) Name() string {
+	return "show"
+}
+
+// Description returns the command description
+func (c *OverrideShowCommand) Description() string {
+	return "Show the merged override result for a session and which layer set each value"
+}
+
+// Execute prints the merged configuration of the active session
+func (c *OverrideShowCommand) Execute(args []string) error {
+	return c.ExecuteWithOptions("")
+}
+
+// ExecuteWithOptions prints the merged configuration of sessionName (the active
+// session when empty)
+func (c *OverrideShowCommand) ExecuteWithOptions(sessionName string) error {
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	data, err := c.Render(sessionName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(string(data))
+	return nil
+}
+
+// Render returns the effective docker-compose.yml and devcontainer.json of sessionName
+// (the active session when empty) as YAML, each value commented with the layer that
+// won: manifest, project, env or session. The generators' own merge is used, so this
+// is exactly what configure would write, except that secrets are not validated.
+func (c *OverrideShowCommand) Render(sessionName string) ([]byte, error) {
+	proj, err := c.projectManager.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	targetSession, err := resolveSession(c.sessionManager, proj, sessionName)
+	if err != nil {
+		return nil, err
+	}
+
+	servoDir := c.projectManager.GetServoDir()
+	compose := config.NewDockerComposeGenerator(servoDir)
+	compose.SetSession(targetSession)
+	devcontainer := config.NewDevcontainerGenerator(servoDir)
+	devcontainer.SetSession(targetSession)
+
+	var out bytes.Buffer
+	for _, section := range []struct {
+		title    string
+		generate func() (*config.EffectiveConfig, error)
+	}{
+		{title: ".devcontainer/docker-compose.yml", generate: compose.Effective},
+		{title: ".devcontainer/devcontainer.json (shown as YAML)", generate: devcontainer.Effective},
+	} {
+		effective, err := section.generate()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s: %w", section.title, err)
+		}
+		if effective == nil {
+			continue
+		}
+		data, err := effective.AnnotatedYAML()
+		if err != nil {
+			return nil, err
+		}
+
+		if out.Len() > 0 {
+			out.WriteString("---\n")
+		}
+		fmt.Fprintf(&out, "# %s for session %s\n", section.title, effective.Session)
+		out.Write(data)
+	}
+	return out.Bytes(), nil
+}
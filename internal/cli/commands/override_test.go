@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/project"
+)
+
+func TestOverrideShowCommand_Render(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+	projectManager := project.NewManager()
+	proj, _ := projectManager.Get()
+	proj.Config.DevcontainerMode = project.DevcontainerModeCompose
+	projectManager.Save(proj)
+
+	overridePath := filepath.Join(".servo", "sessions", "default", "config", "docker-compose.yml")
+	os.MkdirAll(filepath.Dir(overridePath), 0755)
+	if err := os.WriteFile(overridePath, []byte("services:\n  workspace:\n    working_dir: /src\n"), 0644); err != nil {
+		t.Fatalf("Failed to write override: %v", err)
+	}
+
+	cmd := NewOverrideShowCommand()
+	data, err := cmd.Render("default")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, want := range []string{
+		"# .devcontainer/docker-compose.yml for session default",
+		"working_dir: /src # session",
+		"# .devcontainer/devcontainer.json (shown as YAML) for session default",
+		"service: workspace # manifest",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Render() output missing %q:\n%s", want, data)
+		}
+	}
+	if _, err := os.Stat(".devcontainer"); !os.IsNotExist(err) {
+		t.Error("Render() should not write generated files")
+	}
+
+	if _, err := cmd.Render("missing"); err == nil || !strings.Contains(err.Error(), "session 'missing' does not exist") {
+		t.Errorf("Expected unknown session error, got %v", err)
+	}
+
+	// A single-container devcontainer has no compose section; a compose override
+	// would keep compose mode, so it goes first
+	os.Remove(overridePath)
+	proj.Config.DevcontainerMode = project.DevcontainerModeSingle
+	projectManager.Save(proj)
+	data, err = cmd.Render("default")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if strings.Contains(string(data), "docker-compose.yml") || !strings.Contains(string(data), "devcontainer.json") {
+		t.Errorf("Expected only the devcontainer section in single-container mode:\n%s", data)
+	}
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/servo/servo/internal/project"
+)
+
+// ProjectFixGitignoreCommand rewrites .servo/.gitignore to the recommended set
+type ProjectFixGitignoreCommand struct {
+	projectManager *project.Manager
+}
+
+// NewProjectFixGitignoreCommand creates a new project fix-gitignore command
+func NewProjectFixGitignoreCommand() *ProjectFixGitignoreCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &ProjectFixGitignoreCommand{
+		projectManager: deps.ProjectManager,
+	}
+}
+
+// Name returns the command name
+func (c *ProjectFixGitignoreCommand) Name() string {
+	return "fix-gitignore"
+}
+
+// Description returns the command description
+func (c *ProjectFixGitignoreCommand) Description() string {
+	return "Rewrite .servo/.gitignore to the recommended set, keeping your own lines"
+}
+
+// Execute rewrites the managed block of .servo/.gitignore
+func (c *ProjectFixGitignoreCommand) Execute(args []string) error {
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	changed, err := c.projectManager.FixGitignore()
+	if err != nil {
+		return err
+	}
+	if !changed {
+		fmt.Println("✅ .servo/.gitignore is already up to date")
+		return nil
+	}
+	fmt.Println("✅ Updated .servo/.gitignore; lines you added are kept below the servo managed block")
+	fmt.Println("💡 Review it with 'git diff .servo/.gitignore' before committing")
+	return nil
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/project"
+)
+
+func TestProjectFixGitignoreCommand_Execute(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	cmd := NewProjectFixGitignoreCommand()
+	if err := cmd.Execute(nil); err == nil {
+		t.Fatal("Expected error outside of a project")
+	}
+
+	if _, err := project.NewManager().Init("default", nil); err != nil {
+		t.Fatalf("Failed to init project: %v", err)
+	}
+	path := filepath.Join(".servo", ".gitignore")
+	os.WriteFile(path, []byte("secrets.yaml\n*.local\n"), 0644)
+
+	if err := cmd.Execute(nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "active_session\n") || !strings.HasSuffix(string(data), "\n*.local\n") {
+		t.Errorf("Expected the managed block with the user line kept below it, got:\n%s", data)
+	}
+
+	if err := cmd.Execute(nil); err != nil {
+		t.Errorf("Execute() on a current file error = %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/servo/servo/internal/config"
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+	"github.com/servo/servo/pkg"
+)
+
+// ReconfigureCommand deletes servo-generated outputs and regenerates them, so the
+// result reflects only the current manifests, overrides and project config
+type ReconfigureCommand struct {
+	projectManager *project.Manager
+	sessionManager *session.Manager
+	clientRegistry pkg.ClientRegistry
+	parser         *mcp.Parser
+
+	// Force discards hand edits to generated files instead of refusing to run
+	Force bool
+}
+
+// NewReconfigureCommand creates a new reconfigure command
+func NewReconfigureCommand() *ReconfigureCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &ReconfigureCommand{
+		projectManager: deps.ProjectManager,
+		sessionManager: deps.SessionManager,
+		clientRegistry: deps.ClientRegistry,
+		parser:         deps.Parser,
+	}
+}
+
+// Name returns the command name
+func (c *ReconfigureCommand) Name() string {
+	return "reconfigure"
+}
+
+// Description returns the command description
+func (c *ReconfigureCommand) Description() string {
+	return "Delete generated configuration and regenerate it from scratch"
+}
+
+// Execute runs the reconfigure command
+func (c *ReconfigureCommand) Execute(args []string) error {
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	servoDir := c.projectManager.GetServoDir()
+	modified, err := config.ModifiedFiles(servoDir)
+	if err != nil {
+		return fmt.Errorf("failed to check generated files: %w", err)
+	}
+	if len(modified) > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: these generated files were edited since servo last wrote them:\n")
+		for _, file := range modified {
+			fmt.Fprintf(os.Stderr, "   • %s\n", file)
+		}
+		if !c.Force {
+			fmt.Fprintf(os.Stderr, "💡 Move the edits into .servo overrides, or rerun with --force to discard them\n")
+			return fmt.Errorf("refusing to overwrite %d hand-edited generated file(s)", len(modified))
+		}
+	}
+
+	fmt.Printf("🧹 Removing generated configuration...\n")
+	removed, err := config.CleanGenerated(servoDir)
+	if err != nil {
+		return fmt.Errorf("failed to clean generated files: %w", err)
+	}
+	for _, file := range removed {
+		fmt.Printf("   → Removed %s\n", file)
+	}
+
+	fmt.Printf("🔧 Regenerating configuration...\n")
+	configure := &ConfigureCommand{
+		projectManager: c.projectManager,
+		sessionManager: c.sessionManager,
+		clientRegistry: c.clientRegistry,
+		parser:         c.parser,
+	}
+	if _, err := configure.generateConfigurations(); err != nil {
+		return fmt.Errorf("failed to generate configurations: %w", err)
+	}
+
+	fmt.Printf("✅ Configuration rebuilt from the current session manifests and overrides\n")
+	return nil
+}
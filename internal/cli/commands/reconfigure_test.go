@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/config"
+	"github.com/servo/servo/internal/project"
+)
+
+func TestReconfigureCommand_Execute(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+
+	manifest := `servo_version: "1.0"
+name: search
+server:
+  transport: stdio
+  command: search-mcp
+`
+	if err := os.WriteFile(filepath.Join(".servo", "sessions", "default", "manifests", "search.servo"), []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	pm := project.NewManager()
+	setBundle := func(enabled bool) {
+		proj, err := pm.Get()
+		if err != nil {
+			t.Fatalf("Failed to get project: %v", err)
+		}
+		proj.Config.Bundle = enabled
+		proj.Config.DevcontainerMode = project.DevcontainerModeCompose
+		if err := pm.Save(proj); err != nil {
+			t.Fatalf("Failed to save project: %v", err)
+		}
+	}
+
+	setBundle(true)
+	if _, err := NewConfigureCommand().generateConfigurations(); err != nil {
+		t.Fatalf("Failed to generate configurations: %v", err)
+	}
+	if _, err := os.Stat(config.BundleFileName); err != nil {
+		t.Fatalf("Expected %s after configure: %v", config.BundleFileName, err)
+	}
+
+	// Turning the bundle off leaves a stale servers.json that only reconfigure removes
+	setBundle(false)
+	if err := NewReconfigureCommand().Execute(nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := os.Stat(config.BundleFileName); !os.IsNotExist(err) {
+		t.Errorf("Expected stale %s to be removed", config.BundleFileName)
+	}
+	composePath := filepath.Join(".devcontainer", "docker-compose.yml")
+	if _, err := os.Stat(composePath); err != nil {
+		t.Fatalf("Expected %s to be regenerated: %v", composePath, err)
+	}
+
+	// Hand edits block reconfigure unless forced
+	if err := os.WriteFile(composePath, []byte("# edited\n"), 0644); err != nil {
+		t.Fatalf("Failed to edit compose file: %v", err)
+	}
+	err := NewReconfigureCommand().Execute(nil)
+	if err == nil || !strings.Contains(err.Error(), "hand-edited") {
+		t.Fatalf("Expected hand-edit error, got %v", err)
+	}
+
+	cmd := NewReconfigureCommand()
+	cmd.Force = true
+	if err := cmd.Execute(nil); err != nil {
+		t.Fatalf("Execute() with Force error = %v", err)
+	}
+	data, _ := os.ReadFile(composePath)
+	if strings.Contains(string(data), "# edited") {
+		t.Errorf("Expected forced reconfigure to regenerate %s", composePath)
+	}
+}
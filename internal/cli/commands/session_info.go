@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/servo/servo/internal/manifest"
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+)
+
+// SessionInfoCommand shows a session's details and the servers installed in it
+type SessionInfoCommand struct {
+	projectManager *project.Manager
+	sessionManager *session.Manager
+	parser         *mcp.Parser
+}
+
+// NewSessionInfoCommand creates a new session info command
+func NewSessionInfoCommand() *SessionInfoCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &SessionInfoCommand{
+		projectManager: deps.ProjectManager,
+		sessionManager: deps.SessionManager,
+		parser:         deps.Parser,
+	}
+}
+
+// Name returns the command name
+func (c *SessionInfoCommand) Name() string {
+	return "info"
+}
+
+// Description returns the command description
+func (c *SessionInfoCommand) Description() string {
+	return "Show a session's details and installed servers"
+}
+
+// Execute shows the session named in args, or the active session when none is given
+func (c *SessionInfoCommand) Execute(args []string) error {
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	proj, err := c.projectManager.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	sessionName := ""
+	if len(args) > 0 {
+		sessionName = args[0]
+	}
+	sessionName, err = resolveSession(c.sessionManager, proj, sessionName)
+	if err != nil {
+		return err
+	}
+
+	sess, err := c.sessionManager.Get(sessionName)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	active := ""
+	if sess.Active {
+		active = " (active)"
+	}
+	fmt.Printf("Session: %s%s\n", sess.Name, active)
+	if sess.Description != "" {
+		fmt.Printf("Description: %s\n", sess.Description)
+	}
+	if !sess.CreatedAt.IsZero() {
+		fmt.Printf("Created:     %s\n", sess.CreatedAt.Format(time.RFC3339))
+	}
+	if !sess.LastUsedAt.IsZero() {
+		fmt.Printf("Last used:   %s\n", sess.LastUsedAt.Format(time.RFC3339))
+	}
+	if len(sess.Clients) > 0 {
+		fmt.Printf("Clients:     %s\n", strings.Join(sess.Clients, ", "))
+	}
+	if len(sess.Profiles) > 0 {
+		fmt.Printf("Profiles:    %s\n", strings.Join(sess.Profiles, ", "))
+	}
+	if len(sess.Tags) > 0 {
+		fmt.Printf("Tags:        %s\n", strings.Join(sess.Tags, ", "))
+	}
+
+	store := manifest.NewStore(c.sessionManager.ManifestsDir(sessionName), c.parser)
+	manifests, err := store.ListManifests()
+	if err != nil {
+		return fmt.Errorf("failed to list manifests: %w", err)
+	}
+
+	fmt.Println()
+	if len(manifests) == 0 {
+		fmt.Printf("MCP Servers: (none installed)\n")
+		return nil
+	}
+
+	keys := make([]string, 0, len(manifests))
+	for key := range manifests {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Printf("MCP Servers: %d installed\n", len(keys))
+	for _, key := range keys {
+		servo := manifests[key]
+		if servo.Version != "" {
+			fmt.Printf("  • %s (%s)\n", servo.Name, servo.Version)
+		} else {
+			fmt.Printf("  • %s\n", servo.Name)
+		}
+		if servo.Description != "" {
+			fmt.Printf("    %s\n", servo.Description)
+		}
+		if link := manifest.ProjectURL(servo); link != "" {
+			fmt.Printf("    🔗 %s\n", link)
+		}
+		for _, line := range manifest.CapabilityNames(servo) {
+			fmt.Printf("    🧰 %s\n", line)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/manifest"
+)
+
+func TestSessionInfoCommand_Execute(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	cmd := NewSessionInfoCommand()
+	if err := cmd.Execute(nil); err == nil {
+		t.Fatal("Expected error outside of a project")
+	}
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+	if err := cmd.Execute(nil); err != nil {
+		t.Errorf("Execute() on an empty session error = %v", err)
+	}
+
+	servo := `servo_version: "1.0"
+name: search
+version: "1.2.0"
+server:
+  transport: stdio
+  command: search-server
+capabilities:
+  tools: [search, fetch]
+  prompts: [summarize]
+metadata:
+  homepage: https://search.example.com
+`
+	os.WriteFile(".servo/sessions/default/manifests/search.servo", []byte(servo), 0644)
+
+	reader, writer, _ := os.Pipe()
+	stdout := os.Stdout
+	os.Stdout = writer
+	err := cmd.Execute([]string{"default"})
+	os.Stdout = stdout
+	writer.Close()
+	output, _ := io.ReadAll(reader)
+	if err != nil {
+		t.Errorf("Execute(default) error = %v", err)
+	}
+	if !strings.Contains(string(output), "🔗 https://search.example.com") {
+		t.Errorf("Expected the manifest's project link, got:\n%s", output)
+	}
+	if err := cmd.Execute([]string{"missing"}); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("Expected an unknown session error, got %v", err)
+	}
+
+	manifests, err := manifest.NewStore(cmd.sessionManager.ManifestsDir("default"), cmd.parser).ListManifests()
+	if err != nil {
+		t.Fatalf("Failed to list manifests: %v", err)
+	}
+	if got := manifest.CapabilityCounts(manifests["search"]); got != "2 tools, 1 prompt" {
+		t.Errorf("Expected capabilities parsed from the manifest, got %q", got)
+	}
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/servo/servo/internal/session"
+)
+
+// SessionListFormats are the values accepted by session list --format
+var SessionListFormats = []string{"table", "plain", "json"}
+
+// FormatSessions renders sessions for session list. An empty format keeps the
+// bulleted output; "table" aligns columns, "plain" emits one tab-separated line per
+// session for cut and awk, and "json" emits the full session objects.
+func FormatSessions(sessions []*session.Session, format string) (string, error) {
+	var b strings.Builder
+
+	switch format {
+	case "":
+		b.WriteString("Sessions:\n")
+		for _, sess := range sessions {
+			status := ""
+			if sess.Active {
+				status = " (active)"
+			}
+			fmt.Fprintf(&b, "  • %s%s - %s\n", sess.Name, status, sess.Description)
+		}
+
+	case "table":
+		w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tACTIVE\tDESCRIPTION\tCREATED")
+		for _, sess := range sessions {
+			active := ""
+			if sess.Active {
+				active = "*"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", sess.Name, active, sess.Description, formatCreated(sess))
+		}
+		if err := w.Flush(); err != nil {
+			return "", fmt.Errorf("failed to format sessions: %w", err)
+		}
+
+	case "plain":
+		for _, sess := range sessions {
+			fmt.Fprintf(&b, "%s\t%t\t%s\t%s\n", sess.Name, sess.Active, sess.Description, formatCreated(sess))
+		}
+
+	case "json":
+		if sessions == nil {
+			sessions = []*session.Session{}
+		}
+		data, err := json.MarshalIndent(sessions, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal sessions: %w", err)
+		}
+		b.Write(data)
+		b.WriteString("\n")
+
+	default:
+		return "", fmt.Errorf("unsupported format '%s' (supported: %s)", format, strings.Join(SessionListFormats, ", "))
+	}
+
+	return b.String(), nil
+}
+
+// formatCreated returns a session's creation time in RFC 3339, or "" when unknown
+func formatCreated(sess *session.Session) string {
+	if sess.CreatedAt.IsZero() {
+		return ""
+	}
+	return sess.CreatedAt.Format(time.RFC3339)
+}
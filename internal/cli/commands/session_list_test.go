@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/servo/servo/internal/session"
+)
+
+func TestFormatSessions(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	sessions := []*session.Session{
+		{Name: "default", Description: "Default session", Active: true, CreatedAt: created},
+		{Name: "staging-long-name", Description: "Staging", CreatedAt: created},
+	}
+
+	bulleted, err := FormatSessions(sessions, "")
+	if err != nil {
+		t.Fatalf("FormatSessions() error = %v", err)
+	}
+	if want := "Sessions:\n  • default (active) - Default session\n  • staging-long-name - Staging\n"; bulleted != want {
+		t.Errorf("default format = %q, want %q", bulleted, want)
+	}
+
+	table, err := FormatSessions(sessions, "table")
+	if err != nil {
+		t.Fatalf("FormatSessions() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(table, "\n"), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "NAME") {
+		t.Fatalf("unexpected table:\n%s", table)
+	}
+	// Every column starts at the same offset on each line
+	column := strings.Index(lines[0], "ACTIVE")
+	if lines[1][column] != '*' || strings.Index(lines[2], "Staging") != strings.Index(lines[0], "DESCRIPTION") {
+		t.Errorf("table columns are not aligned:\n%s", table)
+	}
+
+	plain, err := FormatSessions(sessions, "plain")
+	if err != nil {
+		t.Fatalf("FormatSessions() error = %v", err)
+	}
+	if want := "default\ttrue\tDefault session\t2024-03-01T12:00:00Z\nstaging-long-name\tfalse\tStaging\t2024-03-01T12:00:00Z\n"; plain != want {
+		t.Errorf("plain format = %q, want %q", plain, want)
+	}
+
+	jsonOutput, err := FormatSessions(sessions, "json")
+	if err != nil {
+		t.Fatalf("FormatSessions() error = %v", err)
+	}
+	var decoded []session.Session
+	if err := json.Unmarshal([]byte(jsonOutput), &decoded); err != nil {
+		t.Fatalf("json format is not valid JSON: %v", err)
+	}
+	if len(decoded) != 2 || decoded[0].Name != "default" || !decoded[0].Active || !decoded[1].CreatedAt.Equal(created) {
+		t.Errorf("unexpected json sessions: %+v", decoded)
+	}
+
+	if empty, _ := FormatSessions(nil, "json"); strings.TrimSpace(empty) != "[]" {
+		t.Errorf("expected empty JSON array, got %q", empty)
+	}
+
+	if _, err := FormatSessions(sessions, "yaml"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestFormatSessions_JSONLastUsedAt(t *testing.T) {
+	used := time.Date(2024, 3, 2, 9, 30, 0, 0, time.UTC)
+	sessions := []*session.Session{
+		{Name: "fresh"},
+		{Name: "used", LastUsedAt: used},
+	}
+
+	output, err := FormatSessions(sessions, "json")
+	if err != nil {
+		t.Fatalf("FormatSessions() error = %v", err)
+	}
+
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("json format is not valid JSON: %v", err)
+	}
+	if len(decoded) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(decoded))
+	}
+
+	// A session that was never used has no last_used_at rather than the zero time
+	if value, ok := decoded[0]["last_used_at"]; ok {
+		t.Errorf("expected last_used_at to be omitted for an unused session, got %v", value)
+	}
+	if decoded[1]["last_used_at"] != "2024-03-02T09:30:00Z" {
+		t.Errorf("expected last_used_at for a used session, got %v", decoded[1]["last_used_at"])
+	}
+	if decoded[0]["name"] != "fresh" {
+		t.Errorf("expected the remaining session fields, got %v", decoded[0])
+	}
+}
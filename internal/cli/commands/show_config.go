@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+	"github.com/servo/servo/pkg"
+)
+
+// ShowConfigCommand prints the MCP configuration a client would be given, without
+// writing it
+type ShowConfigCommand struct {
+	projectManager *project.Manager
+	sessionManager *session.Manager
+	clientRegistry pkg.ClientRegistry
+	parser         *mcp.Parser
+}
+
+// NewShowConfigCommand creates a new show-config command
+func NewShowConfigCommand() *ShowConfigCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &ShowConfigCommand{
+		projectManager: deps.ProjectManager,
+		sessionManager: deps.SessionManager,
+		clientRegistry: deps.ClientRegistry,
+		parser:         deps.Parser,
+	}
+}
+
+// Name returns the command name
+func (c *ShowConfigCommand) Name() string {
+	return "show-config"
+}
+
+// Description returns the command description
+func (c *ShowConfigCommand) Description() string {
+	return "Print the MCP configuration a client would receive"
+}
+
+// Execute prints the configuration of the client named by args[0] for the active session
+func (c *ShowConfigCommand) Execute(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("client name is required")
+	}
+	return c.ExecuteWithOptions(args[0], "")
+}
+
+// ExecuteWithOptions prints the configuration clientName would get from sessionName
+// (the active session when empty)
+func (c *ShowConfigCommand) ExecuteWithOptions(clientName, sessionName string) error {
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	data, err := c.Render(clientName, sessionName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(data))
+	return nil
+}
+
+// Render returns the configuration clientName would get from sessionName (the active
+// session when empty), exactly as it would be written to disk
+func (c *ShowConfigCommand) Render(clientName, sessionName string) ([]byte, error) {
+	proj, err := c.projectManager.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	targetSession, err := resolveSession(c.sessionManager, proj, sessionName)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := c.clientRegistry.Get(project.CanonicalClientName(clientName))
+	if err != nil {
+		var names []string
+		for _, registered := range c.clientRegistry.List() {
+			names = append(names, registered.Name())
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown client '%s' (available: %s)", clientName, strings.Join(names, ", "))
+	}
+
+	manifests, secretsProvider, err := clientConfigInputs(c.projectManager, c.sessionManager, c.parser, targetSession)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := client.RenderConfig(clientManifests(client.Name(), manifests), secretsProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render config for %s: %w", client.Name(), err)
+	}
+	return data, nil
+}
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestShowConfigCommand_Render(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+
+	manifest := `servo_version: "1.0"
+name: search
+server:
+  transport: stdio
+  command: search-mcp
+  environment:
+    API_KEY: "${SEARCH_API_KEY}"
+`
+	if err := os.WriteFile(filepath.Join(".servo", "sessions", "default", "manifests", "search.servo"), []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	cmd := NewShowConfigCommand()
+	data, err := cmd.Render("vscode", "")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, want := range []string{`"servers"`, `"search"`, "${SEARCH_API_KEY}"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Render() output missing %s: %s", want, data)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(".vscode", "mcp.json")); !os.IsNotExist(err) {
+		t.Errorf("Render() should not write .vscode/mcp.json")
+	}
+
+	// Legacy client names resolve like they do in project.yaml
+	if _, err := cmd.Render("claude", ""); err != nil {
+		t.Errorf("Render(claude) error = %v", err)
+	}
+
+	_, err = cmd.Render("notepad", "")
+	if err == nil || !strings.Contains(err.Error(), "unknown client 'notepad'") {
+		t.Errorf("Expected unknown client error, got %v", err)
+	}
+}
+
+func TestShowConfigCommand_RenderSecretTargets(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+
+	manifest := `servo_version: "1.0"
+name: search
+server:
+  transport: stdio
+  command: search-mcp
+  environment:
+    API_KEY: "${search_api_key}"
+configuration_schema:
+  secrets:
+    search_api_key:
+      description: Search API key
+      type: api_key
+      env_var: API_KEY
+      targets:
+        vscode: VSCODE_SEARCH_KEY
+`
+	if err := os.WriteFile(filepath.Join(".servo", "sessions", "default", "manifests", "search.servo"), []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	cmd := NewShowConfigCommand()
+	vscode, err := cmd.Render("vscode", "")
+	if err != nil {
+		t.Fatalf("Render(vscode) error = %v", err)
+	}
+	if !strings.Contains(string(vscode), `"VSCODE_SEARCH_KEY": "${search_api_key}"`) || strings.Contains(string(vscode), `"API_KEY"`) {
+		t.Errorf("Expected vscode to read the secret from its mapped env var: %s", vscode)
+	}
+
+	cursor, err := cmd.Render("cursor", "")
+	if err != nil {
+		t.Fatalf("Render(cursor) error = %v", err)
+	}
+	if !strings.Contains(string(cursor), `"API_KEY": "${search_api_key}"`) {
+		t.Errorf("Expected cursor to keep the default env_var: %s", cursor)
+	}
+}
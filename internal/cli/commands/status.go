@@ -6,14 +6,19 @@ import (
 	"strings"
 
 	"github.com/servo/servo/internal/client"
+	"github.com/servo/servo/internal/manifest"
+	"github.com/servo/servo/internal/mcp"
 	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
 	"github.com/servo/servo/pkg"
 )
 
 // StatusCommand handles project status display
 type StatusCommand struct {
 	projectManager *project.Manager
+	sessionManager *session.Manager
 	clientRegistry *client.Registry
+	parser         *mcp.Parser
 }
 
 // NewStatusCommand creates a new status command
@@ -22,7 +27,9 @@ func NewStatusCommand() *StatusCommand {
 	
 	return &StatusCommand{
 		projectManager: deps.ProjectManager,
+		sessionManager: deps.SessionManager,
 		clientRegistry: deps.ClientRegistry,
+		parser:         deps.Parser,
 	}
 }
 
@@ -60,8 +67,10 @@ func (c *StatusCommand) Execute(args []string) error {
 		fmt.Printf("Clients:     (none configured)\n")
 	}
 
-	if project.ActiveSession != "" {
-		fmt.Printf("Active Session: %s\n", project.ActiveSession)
+	if activeSession, err := requireActiveSession(c.sessionManager); err == nil {
+		fmt.Printf("Active Session: %s\n", activeSession.Name)
+	} else {
+		fmt.Printf("Active Session: ⚠️  %v\n", err)
 	}
 	fmt.Printf("Default Session: %s\n", project.DefaultSession)
 
@@ -69,12 +78,24 @@ func (c *StatusCommand) Execute(args []string) error {
 	fmt.Println()
 	if len(project.MCPServers) > 0 {
 		fmt.Printf("MCP Servers: %d configured\n", len(project.MCPServers))
+		serverManifests := c.serverManifests(project)
 		for _, server := range project.MCPServers {
 			clientList := "all clients"
 			if len(server.Clients) > 0 {
 				clientList = strings.Join(server.Clients, ", ")
 			}
-			fmt.Printf("  • %s (%s)\n", server.Name, clientList)
+			servo := serverManifests[server.Name]
+			if tags := manifest.Tags(servo); len(tags) > 0 {
+				fmt.Printf("  • %s (%s) [%s]\n", server.Name, clientList, strings.Join(tags, ", "))
+			} else {
+				fmt.Printf("  • %s (%s)\n", server.Name, clientList)
+			}
+			if link := manifest.ProjectURL(servo); link != "" {
+				fmt.Printf("    🔗 %s\n", link)
+			}
+			for _, line := range manifest.CapabilityNames(servo) {
+				fmt.Printf("    🧰 %s\n", line)
+			}
 		}
 	} else {
 		fmt.Printf("MCP Servers: (none configured)\n")
@@ -107,6 +128,30 @@ func (c *StatusCommand) Execute(args []string) error {
 	return nil
 }
 
+// serverManifests returns each project server's manifest, read from the first
+// session that has the server installed
+func (c *StatusCommand) serverManifests(project *project.Project) map[string]*pkg.ServoDefinition {
+	found := make(map[string]*pkg.ServoDefinition)
+	loaded := make(map[string]map[string]*pkg.ServoDefinition)
+
+	for _, server := range project.MCPServers {
+		for _, sessionName := range server.Sessions {
+			manifests, ok := loaded[sessionName]
+			if !ok {
+				store := manifest.NewStore(c.sessionManager.ManifestsDir(sessionName), c.parser)
+				manifests, _ = store.ListManifests()
+				loaded[sessionName] = manifests
+			}
+			if servo, ok := manifests[server.Name]; ok {
+				found[server.Name] = servo
+				break
+			}
+		}
+	}
+
+	return found
+}
+
 func (c *StatusCommand) checkDevcontainerExists() bool {
 	_, err := os.Stat(".devcontainer/devcontainer.json")
 	return err == nil
@@ -179,8 +224,15 @@ func (c *StatusCommand) showSecretsStatus(project *project.Project) {
 		return
 	}
 
+	// Session-namespaced secrets count for the active session only
+	activeName, err := c.sessionManager.GetActiveName()
+	if err != nil {
+		fmt.Printf("  ⚠️  Warning: Failed to get active session: %v\n", err)
+		return
+	}
+
 	// Get missing secrets from project manager (uses proper decryption)
-	missingSecrets, err := c.projectManager.GetMissingSecrets()
+	missingSecrets, err := c.projectManager.GetMissingSecrets(activeName)
 	if err != nil {
 		fmt.Printf("  ⚠️  Warning: Failed to check secrets status: %v\n", err)
 		return
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/utils"
+	"github.com/servo/servo/pkg"
+)
+
+// SystemCheckResult is the outcome of one system requirement's check_command
+type SystemCheckResult struct {
+	Requirement pkg.SystemRequirement
+	Err         error // nil when the check passed
+}
+
+// Passed reports whether the requirement's check command succeeded
+func (r SystemCheckResult) Passed() bool {
+	return r.Err == nil
+}
+
+// commandRunner executes a shell command, returning an error on non-zero exit
+type commandRunner func(command string) error
+
+// RunSystemChecks executes each system requirement's check_command after it passes
+// the same safety validation as setup commands. Unsafe commands are never run.
+func RunSystemChecks(requirements *pkg.Requirements, validator *mcp.Validator, run commandRunner) []SystemCheckResult {
+	if requirements == nil {
+		return nil
+	}
+
+	results := make([]SystemCheckResult, 0, len(requirements.System))
+	for _, req := range requirements.System {
+		result := SystemCheckResult{Requirement: req}
+		if err := validator.ValidateCommandSafety(req.CheckCommand); err != nil {
+			result.Err = fmt.Errorf("check command rejected: %w", err)
+		} else if err := run(req.CheckCommand); err != nil {
+			result.Err = err
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// failedSystemChecks returns the results whose check command did not pass
+func failedSystemChecks(results []SystemCheckResult) []SystemCheckResult {
+	var failed []SystemCheckResult
+	for _, result := range results {
+		if !result.Passed() {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// printSystemCheckFailure reports a failed requirement with its install hint for this platform
+func printSystemCheckFailure(result SystemCheckResult, indent string) {
+	req := result.Requirement
+	fmt.Printf("%s❌ %s: %s\n", indent, req.Name, req.Description)
+	fmt.Printf("%s   check '%s' failed: %v\n", indent, req.CheckCommand, result.Err)
+	if hint, ok := req.Platforms[utils.CurrentPlatform()]; ok && hint != "" {
+		fmt.Printf("%s   Install: %s\n", indent, hint)
+	} else if req.InstallHint != "" {
+		fmt.Printf("%s   Install: %s\n", indent, req.InstallHint)
+	}
+}
+
+// runShellCommand runs a check command through the platform shell
+func runShellCommand(command string) error {
+	var cmd *exec.Cmd
+	if utils.CurrentPlatform() == utils.PlatformWindows {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if len(output) > 0 {
+			return fmt.Errorf("%w: %s", err, firstLine(string(output)))
+		}
+		return err
+	}
+	return nil
+}
+
+// firstLine returns the first line of command output for concise error messages
+func firstLine(output string) string {
+	return strings.TrimSpace(strings.SplitN(output, "\n", 2)[0])
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/pkg"
+)
+
+func TestRunSystemChecks(t *testing.T) {
+	requirements := &pkg.Requirements{
+		System: []pkg.SystemRequirement{
+			{Name: "git", Description: "Git for cloning", CheckCommand: "git --version"},
+			{Name: "docker", Description: "Docker for services", CheckCommand: "docker --version"},
+			{Name: "danger", Description: "Unsafe check", CheckCommand: "sudo whoami"},
+		},
+	}
+
+	var ran []string
+	runner := func(command string) error {
+		ran = append(ran, command)
+		if strings.HasPrefix(command, "docker") {
+			return fmt.Errorf("exit status 127")
+		}
+		return nil
+	}
+
+	results := RunSystemChecks(requirements, mcp.NewValidator(), runner)
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	if !results[0].Passed() {
+		t.Errorf("Expected git check to pass, got %v", results[0].Err)
+	}
+	if results[1].Passed() {
+		t.Error("Expected docker check to fail")
+	}
+	if results[2].Passed() || !strings.Contains(results[2].Err.Error(), "rejected") {
+		t.Errorf("Expected unsafe check to be rejected, got %v", results[2].Err)
+	}
+	for _, command := range ran {
+		if strings.Contains(command, "sudo") {
+			t.Error("Unsafe check command should never be executed")
+		}
+	}
+
+	if failed := failedSystemChecks(results); len(failed) != 2 {
+		t.Errorf("Expected 2 failed checks, got %d", len(failed))
+	}
+
+	if results := RunSystemChecks(nil, mcp.NewValidator(), runner); len(results) != 0 {
+		t.Errorf("Expected no results without requirements, got %d", len(results))
+	}
+}
+
+func TestInstallCommand_SystemChecks(t *testing.T) {
+	tempDir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(tempDir)
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+
+	servoContent := `servo_version: "1.0"
+name: "needs-tool"
+version: "1.0.0"
+description: "Server needing a system tool"
+requirements:
+  system:
+    - name: "missing-tool"
+      description: "A tool that is not installed"
+      check_command: "missing-tool --version"
+      install_hint: "Install missing-tool"
+server:
+  transport: "stdio"
+  command: "python"
+  args: ["-m", "server"]`
+
+	if err := os.WriteFile("needs-tool.servo", []byte(servoContent), 0644); err != nil {
+		t.Fatalf("Failed to create servo file: %v", err)
+	}
+
+	cmd := NewInstallCommand(mcp.NewParser(), mcp.NewValidator())
+	cmd.runCommand = func(command string) error {
+		return fmt.Errorf("exit status 127")
+	}
+
+	err := cmd.ExecuteWithOptions([]string{"needs-tool.servo"}, []string{"vscode"}, "", false)
+	if err == nil || !strings.Contains(err.Error(), "system requirement") {
+		t.Fatalf("Expected install to be blocked by system checks, got %v", err)
+	}
+	if _, err := os.Stat(".servo/sessions/default/manifests/needs-tool.servo"); !os.IsNotExist(err) {
+		t.Error("Manifest should not be stored when system checks fail")
+	}
+
+	cmd.SkipSystemChecks = true
+	if err := cmd.ExecuteWithOptions([]string{"needs-tool.servo"}, []string{"vscode"}, "", false); err != nil {
+		t.Fatalf("Expected install to succeed with SkipSystemChecks, got %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/servo/servo/internal/config"
+	"github.com/servo/servo/internal/manifest"
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+	"github.com/servo/servo/pkg"
+)
+
+// UninstallCommand removes an MCP server from one session or from every session
+type UninstallCommand struct {
+	projectManager *project.Manager
+	sessionManager *session.Manager
+	configManager  *config.ConfigGeneratorManager
+	clientRegistry pkg.ClientRegistry
+	parser         *mcp.Parser
+}
+
+// NewUninstallCommand creates a new uninstall command
+func NewUninstallCommand() *UninstallCommand {
+	deps := NewBaseCommandDependencies()
+
+	return &UninstallCommand{
+		projectManager: deps.ProjectManager,
+		sessionManager: deps.SessionManager,
+		configManager:  deps.ConfigManager,
+		clientRegistry: deps.ClientRegistry,
+		parser:         deps.Parser,
+	}
+}
+
+// Name returns the command name
+func (c *UninstallCommand) Name() string {
+	return "uninstall"
+}
+
+// Description returns the command description
+func (c *UninstallCommand) Description() string {
+	return "Remove MCP server from a session or from all sessions"
+}
+
+// Execute runs the uninstall command against the active session
+func (c *UninstallCommand) Execute(args []string) error {
+	return c.ExecuteWithOptions(args, "", false)
+}
+
+// ExecuteWithOptions removes a server from sessionName (the active session when
+// empty), or from every session that has it when allSessions is set
+func (c *UninstallCommand) ExecuteWithOptions(args []string, sessionName string, allSessions bool) error {
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("server name is required\nUsage: servo uninstall <server>")
+	}
+	serverName := args[0]
+
+	if allSessions && sessionName != "" {
+		return fmt.Errorf("--session and --all-sessions cannot be used together")
+	}
+
+	proj, err := c.projectManager.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get project configuration: %w", err)
+	}
+
+	// keys maps each target session to the key the server is stored under there
+	var keys map[string]string
+	if allSessions {
+		keys, err = c.sessionsWithServer(proj, serverName)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			fmt.Printf("⚠️  Server '%s' is not installed in any session\n", serverName)
+			return fmt.Errorf("server '%s' is not installed in any session", serverName)
+		}
+	} else {
+		target, err := resolveSession(c.sessionManager, proj, sessionName)
+		if err != nil {
+			return err
+		}
+		key, installed, err := c.installedKey(proj, serverName, target)
+		if err != nil {
+			return err
+		}
+		if !installed {
+			return fmt.Errorf("server '%s' is not installed in session '%s'", serverName, target)
+		}
+		keys = map[string]string{target: key}
+	}
+
+	targets := make([]string, 0, len(keys))
+	for target := range keys {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	for _, target := range targets {
+		if err := c.removeFromSession(proj, keys[target], target); err != nil {
+			return err
+		}
+	}
+
+	if err := c.regenerateConfigs(); err != nil {
+		return fmt.Errorf("failed to regenerate configurations: %w", err)
+	}
+
+	fmt.Printf("✅ Removed server '%s' from %d session(s):\n", serverName, len(targets))
+	for _, target := range targets {
+		fmt.Printf("  • %s\n", target)
+	}
+
+	return nil
+}
+
+// sessionsWithServer maps every session that has the server installed to the key
+// it is stored under there
+func (c *UninstallCommand) sessionsWithServer(proj *project.Project, serverName string) (map[string]string, error) {
+	sessions, err := c.sessionManager.ListNames()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	found := make(map[string]string)
+	for _, name := range sessions {
+		key, installed, err := c.installedKey(proj, serverName, name)
+		if err != nil {
+			return nil, fmt.Errorf("session %s: %w", name, err)
+		}
+		if installed {
+			found[name] = key
+		}
+	}
+	// A project entry may still reference a session whose directory is gone
+	for _, server := range proj.MCPServers {
+		if server.Name != serverName {
+			continue
+		}
+		for _, s := range server.Sessions {
+			if _, ok := found[s]; !ok {
+				found[s] = serverName
+			}
+		}
+	}
+	return found, nil
+}
+
+// installedKey returns the key a server is stored under in a session, given its key
+// or the name its manifest declares, and whether the session holds the server's
+// manifest or the project declares the server for that session
+func (c *UninstallCommand) installedKey(proj *project.Project, serverName, sessionName string) (string, bool, error) {
+	store := manifest.NewStore(c.sessionManager.ManifestsDir(sessionName), c.parser)
+	key, err := store.ResolveKey(serverName)
+	if err != nil {
+		return "", false, err
+	}
+	if _, err := store.GetManifest(key); err == nil {
+		return key, true, nil
+	}
+
+	return key, projectServerInSession(proj, key, sessionName), nil
+}
+
+// removeFromSession deletes the session's stored manifest and its project declaration
+func (c *UninstallCommand) removeFromSession(proj *project.Project, serverName, sessionName string) error {
+	store := manifest.NewStore(c.sessionManager.ManifestsDir(sessionName), c.parser)
+	if err := store.RemoveManifest(serverName); err != nil {
+		return fmt.Errorf("failed to remove manifest from session %s: %w", sessionName, err)
+	}
+
+	if projectServerInSession(proj, serverName, sessionName) {
+		if err := c.projectManager.RemoveMCPServerFromSession(serverName, sessionName); err != nil {
+			return fmt.Errorf("failed to update project configuration: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// regenerateConfigs rebuilds devcontainer, compose and client configs for the active session
+func (c *UninstallCommand) regenerateConfigs() error {
+	activeName, err := c.sessionManager.GetActiveName()
+	if err != nil {
+		return fmt.Errorf("failed to get active session: %w", err)
+	}
+	if activeName == "" {
+		return nil
+	}
+
+	if _, err := generateDevcontainerConfigs(c.projectManager, c.configManager, false); err != nil {
+		return err
+	}
+	return generateClientConfigs(c.projectManager, c.sessionManager, c.clientRegistry, c.parser, activeName)
+}
+
+// projectServerInSession reports whether project.yaml declares the server for a session
+func projectServerInSession(proj *project.Project, serverName, sessionName string) bool {
+	for _, server := range proj.MCPServers {
+		if server.Name != serverName {
+			continue
+		}
+		for _, s := range server.Sessions {
+			if s == sessionName {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+)
+
+// setupUninstallTestProject creates sessions dev and prod with "api" installed in both
+func setupUninstallTestProject(t *testing.T) {
+	t.Helper()
+
+	originalWd, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(originalWd) })
+	os.Chdir(t.TempDir())
+
+	projectManager := project.NewManager()
+	if _, err := projectManager.Init("dev", []string{"vscode"}); err != nil {
+		t.Fatalf("Failed to init project: %v", err)
+	}
+
+	sessionManager := session.NewManager(".servo")
+	manifest := "servo_version: \"1.0\"\nname: api\nserver:\n  transport: stdio\n  command: api\n"
+	for _, name := range []string{"dev", "prod"} {
+		if _, err := sessionManager.Create(name, "", ""); err != nil {
+			t.Fatalf("Failed to create session %s: %v", name, err)
+		}
+		manifestDir := filepath.Join(sessionManager.GetSessionDir(name), "manifests")
+		os.MkdirAll(manifestDir, 0755)
+		if err := os.WriteFile(filepath.Join(manifestDir, "api.servo"), []byte(manifest), 0644); err != nil {
+			t.Fatalf("Failed to write manifest: %v", err)
+		}
+		if err := projectManager.AddMCPServerToSession("api", "api.servo", []string{"vscode"}, name, false); err != nil {
+			t.Fatalf("Failed to add server: %v", err)
+		}
+	}
+	if err := sessionManager.Activate("dev"); err != nil {
+		t.Fatalf("Failed to activate session: %v", err)
+	}
+}
+
+func apiManifestExists(sessionName string) bool {
+	_, err := os.Stat(filepath.Join(".servo", "sessions", sessionName, "manifests", "api.servo"))
+	return err == nil
+}
+
+func TestUninstallCommand_ActiveSession(t *testing.T) {
+	setupUninstallTestProject(t)
+
+	cmd := NewUninstallCommand()
+	if err := cmd.Execute([]string{"api"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if apiManifestExists("dev") {
+		t.Error("Manifest should be removed from the active session")
+	}
+	if !apiManifestExists("prod") {
+		t.Error("Manifest in other sessions should be kept")
+	}
+
+	proj, _ := project.NewManager().Get()
+	if len(proj.MCPServers) != 1 || len(proj.MCPServers[0].Sessions) != 1 || proj.MCPServers[0].Sessions[0] != "prod" {
+		t.Errorf("Expected api to remain only in prod, got %+v", proj.MCPServers)
+	}
+
+	if err := cmd.Execute([]string{"api"}); err == nil {
+		t.Error("Expected error when the server is not installed in the session")
+	}
+}
+
+func TestUninstallCommand_DeclaredName(t *testing.T) {
+	setupUninstallTestProject(t)
+
+	// A manifest with an id is stored under the id, not its declared name
+	manifestDir := filepath.Join(".servo", "sessions", "dev", "manifests")
+	content := "servo_version: \"1.0\"\nid: acme-search\nname: search\nserver:\n  transport: stdio\n  command: search\n"
+	os.WriteFile(filepath.Join(manifestDir, "acme-search.servo"), []byte(content), 0644)
+	if err := project.NewManager().AddMCPServerToSession("acme-search", "search.servo", []string{"vscode"}, "dev", false); err != nil {
+		t.Fatalf("Failed to add server: %v", err)
+	}
+
+	if err := NewUninstallCommand().Execute([]string{"search"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(manifestDir, "acme-search.servo")); !os.IsNotExist(err) {
+		t.Error("Expected the manifest stored under the id to be removed")
+	}
+	proj, _ := project.NewManager().Get()
+	for _, server := range proj.MCPServers {
+		if server.Name == "acme-search" {
+			t.Errorf("Expected the project entry to be removed, got %+v", server)
+		}
+	}
+}
+
+func TestUninstallCommand_NamedSession(t *testing.T) {
+	setupUninstallTestProject(t)
+
+	cmd := NewUninstallCommand()
+	if err := cmd.ExecuteWithOptions([]string{"api"}, "prod", false); err != nil {
+		t.Fatalf("ExecuteWithOptions() error = %v", err)
+	}
+	if apiManifestExists("prod") || !apiManifestExists("dev") {
+		t.Error("Only the named session should be affected")
+	}
+
+	if err := cmd.ExecuteWithOptions([]string{"api"}, "missing", false); err == nil {
+		t.Error("Expected error for unknown session")
+	}
+	if err := cmd.ExecuteWithOptions([]string{"api"}, "dev", true); err == nil {
+		t.Error("Expected error when combining --session and --all-sessions")
+	}
+}
+
+func TestUninstallCommand_AllSessions(t *testing.T) {
+	setupUninstallTestProject(t)
+
+	cmd := NewUninstallCommand()
+	if err := cmd.ExecuteWithOptions([]string{"api"}, "", true); err != nil {
+		t.Fatalf("ExecuteWithOptions() error = %v", err)
+	}
+	if apiManifestExists("dev") || apiManifestExists("prod") {
+		t.Error("Manifests should be removed from every session")
+	}
+
+	proj, _ := project.NewManager().Get()
+	if len(proj.MCPServers) != 0 {
+		t.Errorf("Expected server to be removed from project, got %+v", proj.MCPServers)
+	}
+
+	err := cmd.ExecuteWithOptions([]string{"api"}, "", true)
+	if err == nil || !strings.Contains(err.Error(), "not installed in any session") {
+		t.Errorf("Expected not installed in any session error, got %v", err)
+	}
+}
@@ -1,7 +1,12 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/servo/servo/internal/mcp"
@@ -32,6 +37,57 @@ func (c *ValidateCommand) Description() string {
 	return "Validate .servo file or source"
 }
 
+// ValidateOptions controls how validation results are reported
+type ValidateOptions struct {
+	Output string // "text" (default) or "json"
+
+	// LocalOnly rejects URL and git sources so validation never touches the network.
+	// Manifest rules themselves are always offline: install.repository is checked
+	// for URL syntax only and is never fetched.
+	LocalOnly bool
+
+	// Strict fails on unknown top-level manifest keys, which are otherwise ignored, and
+	// on a license that is not a recognized SPDX identifier, which is otherwise a warning
+	Strict bool
+
+	// KeepGoing validates every source even after one fails, then summarizes the failures
+	KeepGoing bool
+
+	// ServoVersion validates under this servo_version schema instead of the one each
+	// file declares. Keys the schema does not define are rejected, as with Strict.
+	ServoVersion string
+}
+
+// ValidationIssue is a single validation error or warning
+type ValidationIssue struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationReport is the machine-readable validation result
+type ValidationReport struct {
+	Valid    bool              `json:"valid"`
+	Errors   []ValidationIssue `json:"errors"`
+	Warnings []ValidationIssue `json:"warnings"`
+
+	// sourceErr is the error that kept the source from being parsed, which decides
+	// the exit code
+	sourceErr error
+}
+
+// issueFieldPattern extracts the leading field path from validator messages like "server.url is required"
+var issueFieldPattern = regexp.MustCompile(`^([a-z_]+(?:\.[a-z_]+)*) (?:is|must|cannot|has|should)\b`)
+
+// newValidationIssue builds an issue from an error, deriving the field from the message when possible
+func newValidationIssue(err error) ValidationIssue {
+	message := err.Error()
+	issue := ValidationIssue{Message: message}
+	if match := issueFieldPattern.FindStringSubmatch(message); match != nil {
+		issue.Field = match[1]
+	}
+	return issue
+}
+
 // Execute runs the validate command
 func (c *ValidateCommand) Execute(args []string) error {
 	if len(args) == 0 {
@@ -43,26 +99,96 @@ func (c *ValidateCommand) Execute(args []string) error {
 		return c.showHelp()
 	}
 
-	source := args[0]
+	var opts ValidateOptions
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--output", "-o":
+			if i+1 < len(args) {
+				opts.Output = args[i+1]
+				i++
+			}
+		case "--local-only":
+			opts.LocalOnly = true
+		case "--strict":
+			opts.Strict = true
+		case "--keep-going":
+			opts.KeepGoing = true
+		case "--servo-version":
+			if i+1 >= len(args) || strings.HasPrefix(args[i+1], "-") {
+				return fmt.Errorf("--servo-version requires a version (supported: %s)", strings.Join(pkg.SupportedServoVersions, ", "))
+			}
+			opts.ServoVersion = args[i+1]
+			i++
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+
+	return c.ExecuteWithOptions(positional, opts)
+}
+
+// ExecuteWithOptions runs the validate command with specific options
+func (c *ValidateCommand) ExecuteWithOptions(args []string, opts ValidateOptions) error {
+	if len(args) == 0 {
+		return fmt.Errorf("source is required\nUsage: servo validate <source>")
+	}
+
+	switch opts.Output {
+	case "", "text", "json":
+	default:
+		return fmt.Errorf("unsupported output format '%s' (supported: text, json)", opts.Output)
+	}
+	if opts.ServoVersion != "" && !slices.Contains(pkg.SupportedServoVersions, opts.ServoVersion) {
+		return fmt.Errorf("unsupported --servo-version '%s' (supported: %s)", opts.ServoVersion, strings.Join(pkg.SupportedServoVersions, ", "))
+	}
+
+	if opts.Output == "json" && len(args) > 1 {
+		return c.validateJSONBatch(args, opts)
+	}
+	return runBatch(args, opts.KeepGoing, func(source string) error {
+		if opts.Output == "json" {
+			return c.validateJSON(source, opts)
+		}
+		return c.validateText(source, opts)
+	})
+}
 
+// validateText validates one source and prints a human-readable summary
+func (c *ValidateCommand) validateText(source string, opts ValidateOptions) error {
 	fmt.Printf("Validating: %s\n", source)
 
 	// Parse the source
-	servoFile, err := c.parseSource(source)
+	servoFile, err := c.parseSourceWithOptions(source, opts)
 	if err != nil {
 		fmt.Printf("❌ Failed to parse source: %v\n", err)
-		return err
+		return manifestFailure(err)
 	}
 
 	fmt.Printf("✓ Successfully parsed .servo file\n")
+	if opts.ServoVersion != "" && servoFile.ServoVersion != opts.ServoVersion {
+		fmt.Printf("💡 Validating under servo_version %s (file declares %q)\n", opts.ServoVersion, servoFile.ServoVersion)
+	}
 
 	// Validate the servo file
-	if err := c.validator.Validate(servoFile); err != nil {
+	if err := c.validate(servoFile, opts); err != nil {
 		fmt.Printf("❌ Validation failed: %v\n", err)
-		return err
+		return validationFailure(err)
+	}
+	licenseWarning := c.validator.CheckLicense(servoFile.License)
+	if licenseWarning != "" && opts.Strict {
+		fmt.Printf("❌ Validation failed: %s\n", licenseWarning)
+		return validationFailure(fmt.Errorf("%s", licenseWarning))
 	}
 
-	fmt.Printf("✅ Validation passed!\n\n")
+	fmt.Printf("✅ Validation passed!\n")
+	if warning := c.validator.CheckFilename(servoFile.Name, localManifestFile(c.parser, source)); warning != "" {
+		fmt.Printf("⚠️  Warning: %s\n", warning)
+	}
+	if licenseWarning != "" {
+		fmt.Printf("⚠️  Warning: %s\n", licenseWarning)
+	}
+	fmt.Println()
 
 	// Display summary
 	fmt.Printf("Package Information:\n")
@@ -103,10 +229,15 @@ func (c *ValidateCommand) Execute(args []string) error {
 	}
 
 	// Display dependencies
-	if servoFile.Dependencies != nil && len(servoFile.Dependencies.Services) > 0 {
+	if services := servoFile.AllServices(); len(services) > 0 {
 		fmt.Printf("\nServices:\n")
-		for name, service := range servoFile.Dependencies.Services {
-			fmt.Printf("  - %s: %s\n", name, service.Image)
+		names := make([]string, 0, len(services))
+		for name := range services {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("  - %s: %s\n", name, services[name].Image)
 		}
 	}
 
@@ -163,10 +294,157 @@ func (c *ValidateCommand) Execute(args []string) error {
 	return nil
 }
 
+// SourceValidationReport is the validation result of one of several sources
+type SourceValidationReport struct {
+	Source string `json:"source"`
+	*ValidationReport
+}
+
+// validateJSONBatch validates several sources and prints their reports as one JSON
+// array, stopping after the first invalid source unless KeepGoing is set
+func (c *ValidateCommand) validateJSONBatch(sources []string, opts ValidateOptions) error {
+	reports := []SourceValidationReport{}
+	batchErr := runBatch(sources, opts.KeepGoing, func(source string) error {
+		report := c.ReportWithOptions(source, opts)
+		reports = append(reports, SourceValidationReport{Source: source, ValidationReport: report})
+		return reportFailure(report)
+	})
+
+	output, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal validation reports: %w", err)
+	}
+	fmt.Println(string(output))
+	return batchErr
+}
+
+// Report validates a source and collects the result without printing anything
+func (c *ValidateCommand) Report(source string) *ValidationReport {
+	return c.ReportWithOptions(source, ValidateOptions{})
+}
+
+// ReportWithOptions validates a source with specific options and collects the result
+func (c *ValidateCommand) ReportWithOptions(source string, opts ValidateOptions) *ValidationReport {
+	report := &ValidationReport{
+		Errors:   []ValidationIssue{},
+		Warnings: []ValidationIssue{},
+	}
+
+	servoFile, err := c.parseSourceWithOptions(source, opts)
+	if err != nil {
+		report.Errors = append(report.Errors, ValidationIssue{Field: "source", Message: err.Error()})
+		report.sourceErr = err
+		return report
+	}
+
+	if err := c.validate(servoFile, opts); err != nil {
+		report.Errors = append(report.Errors, newValidationIssue(err))
+	}
+	if warning := c.validator.CheckFilename(servoFile.Name, localManifestFile(c.parser, source)); warning != "" {
+		report.Warnings = append(report.Warnings, ValidationIssue{Field: "name", Message: warning})
+	}
+	if warning := c.validator.CheckLicense(servoFile.License); warning != "" {
+		issue := ValidationIssue{Field: "license", Message: warning}
+		if opts.Strict {
+			report.Errors = append(report.Errors, issue)
+		} else {
+			report.Warnings = append(report.Warnings, issue)
+		}
+	}
+
+	report.Valid = len(report.Errors) == 0
+	return report
+}
+
+// validateJSON prints the validation report as JSON, failing only when errors exist
+func (c *ValidateCommand) validateJSON(source string, opts ValidateOptions) error {
+	report := c.ReportWithOptions(source, opts)
+
+	output, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal validation report: %w", err)
+	}
+	fmt.Println(string(output))
+	return reportFailure(report)
+}
+
+// reportFailure returns the failure a report with errors stands for: the parse
+// error's when the source could not be parsed, else a validation failure
+func reportFailure(report *ValidationReport) error {
+	if report.Valid {
+		return nil
+	}
+	if report.sourceErr != nil {
+		return manifestFailure(fmt.Errorf("validation failed with %d error(s): %w", len(report.Errors), report.sourceErr))
+	}
+	return validationFailure(fmt.Errorf("validation failed with %d error(s)", len(report.Errors)))
+}
+
+// validate checks a parsed definition under the declared or the forced servo_version
+func (c *ValidateCommand) validate(servoFile *pkg.ServoDefinition, opts ValidateOptions) error {
+	if opts.ServoVersion != "" {
+		return c.validator.ValidateAs(servoFile, opts.ServoVersion)
+	}
+	return c.validator.Validate(servoFile)
+}
+
+// parseSourceWithOptions parses a source, refusing remote sources in local-only mode
+func (c *ValidateCommand) parseSourceWithOptions(source string, opts ValidateOptions) (*pkg.ServoDefinition, error) {
+	source = resolveSourceShorthand(source)
+	if opts.LocalOnly && isRemoteSource(source) {
+		return nil, withExitCode(fmt.Errorf("remote source %s cannot be validated with --local-only; use a local file or directory", source), ExitUsage)
+	}
+	if (opts.Strict || opts.ServoVersion != "") && !c.parser.Strict {
+		c.parser.Strict = true
+		defer func() { c.parser.Strict = false }()
+	}
+	return c.parseSource(source)
+}
+
+// isRemoteSource reports whether parsing a source requires network access
+func isRemoteSource(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") ||
+		strings.HasPrefix(source, mcp.GitHubShorthandPrefix)
+}
+
+// resolveSourceShorthand turns a bare org/repo[/subdir][@ref] source into its
+// github: form. A local path with the same name wins, with a note on how to ask for
+// the repository instead.
+func resolveSourceShorthand(source string) string {
+	if !mcp.IsBareGitHubShorthand(source) {
+		return source
+	}
+	if _, err := os.Stat(source); err == nil {
+		fmt.Fprintf(os.Stderr, "💡 Using local path '%s'; use '%s%s' for the GitHub repository\n", source, mcp.GitHubShorthandPrefix, source)
+		return source
+	}
+	return mcp.GitHubShorthandPrefix + source
+}
+
+// localManifestFile returns the .servo file a local source resolves to, or "" for
+// remote sources whose filename carries no meaning
+func localManifestFile(parser *mcp.Parser, source string) string {
+	info, err := os.Stat(source)
+	if err != nil || isRemoteSource(source) {
+		return ""
+	}
+	if !info.IsDir() {
+		return source
+	}
+
+	manifestFile, err := parser.FindManifestFile(source)
+	if err != nil {
+		return ""
+	}
+	return manifestFile
+}
+
 // parseSource parses a source based on its format
 func (c *ValidateCommand) parseSource(source string) (*pkg.ServoDefinition, error) {
 	switch {
-	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+	case strings.HasPrefix(source, mcp.GitHubShorthandPrefix):
+		return c.parser.ParseFromGitRepo(source, "")
+	case isRemoteSource(source):
 		if strings.Contains(source, "github.com") && !strings.HasSuffix(source, ".servo") {
 			return c.parser.ParseFromGitRepo(source, "")
 		} else {
@@ -183,15 +461,41 @@ func (c *ValidateCommand) showHelp() error {
 	fmt.Printf(`validate - Validate .servo file or source
 
 USAGE:
-    servo validate <source>
+    servo validate [OPTIONS] <source>...
+    servo validate --installed [--all] [-o <format>]
 
 ARGUMENTS:
-    <source>    .servo file, git repository URL, or local directory containing .servo files
+    <source>    .servo file, git repository URL, github:org/repo[/subdir][@ref]
+                shorthand, or local directory containing .servo files
+
+OPTIONS:
+    -o, --output <format>    Output format: text (default) or json
+    --local-only             Refuse URL and git sources so no network access happens
+    --strict                 Fail on unknown top-level keys such as a misspelled 'serve:'
+                             and on a license that is not an SPDX identifier
+    --keep-going             With several sources, validate them all and summarize the
+                             failures instead of stopping at the first
+    --servo-version <ver>    Validate under this servo_version instead of the file's
+                             own, failing on keys that version does not define
+    --installed              Validate the manifests installed in the active session and
+                             check their required secrets instead of a source
+    --all                    With --installed, check every session
+
+NOTES:
+    Manifest validation is always offline. install.repository is checked for
+    URL syntax only and is never fetched; only URL and git sources are
+    downloaded before validation.
 
 EXAMPLES:
     servo validate ./graphiti.servo
     servo validate https://github.com/user/repo.git
     servo validate ./local-directory
+    servo validate --output json ./graphiti.servo
+    servo validate --local-only ./graphiti.servo
+    servo validate --strict ./graphiti.servo
+    servo validate --keep-going servers/*.servo
+    servo validate --servo-version 1.0 ./graphiti.servo
+    servo validate --installed --all
 `)
 	return nil
 }
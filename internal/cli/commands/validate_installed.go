@@ -0,0 +1,329 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/servo/servo/internal/manifest"
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+	"github.com/servo/servo/pkg"
+)
+
+// ValidateInstalledCommand validates the manifests already installed in a project,
+// catching servers that became invalid after a schema change or lost their secrets
+type ValidateInstalledCommand struct {
+	projectManager *project.Manager
+	sessionManager *session.Manager
+	parser         *mcp.Parser
+	validator      *mcp.Validator
+}
+
+// NewValidateInstalledCommand creates a new validate --installed command
+func NewValidateInstalledCommand(parser *mcp.Parser, validator *mcp.Validator) *ValidateInstalledCommand {
+	deps := NewBaseDependenciesWithParsers(parser, validator)
+
+	return &ValidateInstalledCommand{
+		projectManager: deps.ProjectManager,
+		sessionManager: deps.SessionManager,
+		parser:         deps.Parser,
+		validator:      deps.Validator,
+	}
+}
+
+// ValidateInstalledOptions controls which sessions are checked and how results are reported
+type ValidateInstalledOptions struct {
+	All    bool   // check every session instead of only the active one
+	Output string // "text" (default) or "json"
+}
+
+// ServerValidationResult is the validation result of one installed manifest
+type ServerValidationResult struct {
+	Name     string            `json:"name"`
+	Valid    bool              `json:"valid"`
+	Errors   []ValidationIssue `json:"errors"`
+	Warnings []ValidationIssue `json:"warnings"`
+}
+
+// SessionValidationReport is the validation result of one session's installed servers
+type SessionValidationReport struct {
+	Session        string                   `json:"session"`
+	Servers        []ServerValidationResult `json:"servers"`
+	MissingSecrets []string                 `json:"missing_secrets"`
+}
+
+// InstalledValidationReport is the project-wide validation result
+type InstalledValidationReport struct {
+	Valid    bool                      `json:"valid"`
+	Sessions []SessionValidationReport `json:"sessions"`
+}
+
+// failures counts invalid servers and missing secrets across all sessions
+func (r *InstalledValidationReport) failures() (invalidServers, missingSecrets int) {
+	for _, sessionReport := range r.Sessions {
+		for _, server := range sessionReport.Servers {
+			if !server.Valid {
+				invalidServers++
+			}
+		}
+		missingSecrets += len(sessionReport.MissingSecrets)
+	}
+	return invalidServers, missingSecrets
+}
+
+// Execute validates the installed servers of the active session, or all sessions with --all
+func (c *ValidateInstalledCommand) Execute(args []string) error {
+	var opts ValidateInstalledOptions
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--all":
+			opts.All = true
+		case "--output", "-o":
+			if i+1 < len(args) {
+				opts.Output = args[i+1]
+				i++
+			}
+		}
+	}
+	return c.ExecuteWithOptions(opts)
+}
+
+// ExecuteWithOptions validates installed servers and prints a project-wide health summary,
+// failing when any manifest is invalid or any required secret is missing
+func (c *ValidateInstalledCommand) ExecuteWithOptions(opts ValidateInstalledOptions) error {
+	switch opts.Output {
+	case "", "text", "json":
+	default:
+		return fmt.Errorf("unsupported output format '%s' (supported: text, json)", opts.Output)
+	}
+
+	if !c.projectManager.IsProject() {
+		fmt.Fprintf(os.Stderr, "Not in a servo project directory. Run 'servo init' to initialize.\n")
+		return fmt.Errorf("not in a servo project directory")
+	}
+
+	report, err := c.Report(opts.All)
+	if err != nil {
+		return err
+	}
+
+	if opts.Output == "json" {
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal validation report: %w", err)
+		}
+		fmt.Println(string(output))
+	} else {
+		printInstalledReport(report)
+	}
+
+	if !report.Valid {
+		invalidServers, missingSecrets := report.failures()
+		return validationFailure(fmt.Errorf("installed validation failed: %d invalid server(s), %d missing secret(s)", invalidServers, missingSecrets))
+	}
+	return nil
+}
+
+// Report validates the installed servers of the active session, or of every session
+// when all is set, without printing anything
+func (c *ValidateInstalledCommand) Report(all bool) (*InstalledValidationReport, error) {
+	proj, err := c.projectManager.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	var sessionNames []string
+	if all {
+		sessionNames, err = c.sessionManager.ListNames()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sessions: %w", err)
+		}
+		sort.Strings(sessionNames)
+	} else {
+		sessionName, err := resolveSession(c.sessionManager, proj, "")
+		if err != nil {
+			return nil, err
+		}
+		sessionNames = []string{sessionName}
+	}
+
+	report := &InstalledValidationReport{Valid: true, Sessions: []SessionValidationReport{}}
+	for _, sessionName := range sessionNames {
+		sessionReport, err := c.validateSession(proj, sessionName)
+		if err != nil {
+			return nil, err
+		}
+		invalid := len(sessionReport.MissingSecrets) > 0
+		for _, server := range sessionReport.Servers {
+			invalid = invalid || !server.Valid
+		}
+		if invalid {
+			report.Valid = false
+		}
+		report.Sessions = append(report.Sessions, *sessionReport)
+	}
+	return report, nil
+}
+
+// validateSession validates every manifest stored in a session, reports servers the
+// project tracks in the session without a manifest, and lists missing required secrets
+func (c *ValidateInstalledCommand) validateSession(proj *project.Project, sessionName string) (*SessionValidationReport, error) {
+	sessionReport := &SessionValidationReport{
+		Session:        sessionName,
+		Servers:        []ServerValidationResult{},
+		MissingSecrets: []string{},
+	}
+
+	store := manifest.NewStore(c.sessionManager.ManifestsDir(sessionName), c.parser)
+	names, err := store.Names()
+	if err != nil {
+		return nil, err
+	}
+
+	installed := make(map[string]bool)
+	parsed := make(map[string]*pkg.ServoDefinition)
+	requiredSecrets := make(map[string]bool)
+	for _, name := range names {
+		installed[name] = true
+		result := ServerValidationResult{
+			Name:     name,
+			Errors:   []ValidationIssue{},
+			Warnings: []ValidationIssue{},
+		}
+
+		servoFile, err := store.GetManifest(name)
+		if err != nil {
+			result.Errors = append(result.Errors, ValidationIssue{Field: "source", Message: err.Error()})
+		} else {
+			parsed[name] = servoFile
+			if err := c.validator.Validate(servoFile); err != nil {
+				result.Errors = append(result.Errors, newValidationIssue(err))
+			}
+			if servoFile.ConfigurationSchema != nil {
+				for secretName, secret := range servoFile.ConfigurationSchema.Secrets {
+					if secret.Required {
+						requiredSecrets[secretName] = true
+					}
+				}
+			}
+		}
+
+		result.Valid = len(result.Errors) == 0
+		sessionReport.Servers = append(sessionReport.Servers, result)
+	}
+
+	// Clients key servers by declared name, so only one of each duplicate is usable
+	duplicates := manifest.DuplicateNames(parsed)
+	for i, server := range sessionReport.Servers {
+		if servoFile := parsed[server.Name]; servoFile != nil && len(duplicates[servoFile.Name]) > 0 {
+			sessionReport.Servers[i].Warnings = append(sessionReport.Servers[i].Warnings, ValidationIssue{
+				Field:   "name",
+				Message: fmt.Sprintf("server name '%s' is declared by multiple manifests (%s.servo)", servoFile.Name, strings.Join(duplicates[servoFile.Name], ".servo, ")),
+			})
+		}
+	}
+
+	// Servers named in depends_on must be installed in the same session
+	for i, server := range sessionReport.Servers {
+		servoFile := parsed[server.Name]
+		if servoFile == nil {
+			continue
+		}
+		for _, missing := range manifest.MissingDependencies(servoFile, parsed) {
+			sessionReport.Servers[i].Errors = append(sessionReport.Servers[i].Errors, ValidationIssue{
+				Field:   "depends_on",
+				Message: fmt.Sprintf("depends on server '%s', which is not installed in session '%s'", missing, sessionName),
+			})
+			sessionReport.Servers[i].Valid = false
+		}
+	}
+
+	// A server tracked in project.yaml for this session must have its manifest on disk
+	for _, server := range proj.MCPServers {
+		if installed[server.Name] || !slices.Contains(server.Sessions, sessionName) {
+			continue
+		}
+		sessionReport.Servers = append(sessionReport.Servers, ServerValidationResult{
+			Name:     server.Name,
+			Errors:   []ValidationIssue{{Message: fmt.Sprintf("manifest missing from session '%s'; reinstall with '%s'", sessionName, reinstallCommand(server))}},
+			Warnings: []ValidationIssue{},
+		})
+	}
+	sort.SliceStable(sessionReport.Servers, func(i, j int) bool {
+		return sessionReport.Servers[i].Name < sessionReport.Servers[j].Name
+	})
+
+	for _, required := range proj.RequiredSecrets {
+		requiredSecrets[required.Name] = true
+	}
+	if len(requiredSecrets) > 0 {
+		configured, err := c.projectManager.GetConfiguredSecrets(sessionName)
+		if err != nil {
+			// An unreadable secrets file leaves every required secret unconfigured
+			configured = map[string]bool{}
+		}
+		for secretName := range requiredSecrets {
+			if !configured[secretName] {
+				sessionReport.MissingSecrets = append(sessionReport.MissingSecrets, secretName)
+			}
+		}
+		sort.Strings(sessionReport.MissingSecrets)
+	}
+
+	return sessionReport, nil
+}
+
+// printInstalledReport prints per-session results followed by a project-wide summary
+func printInstalledReport(report *InstalledValidationReport) {
+	servers := 0
+	for _, sessionReport := range report.Sessions {
+		fmt.Printf("Session: %s\n", sessionReport.Session)
+		if len(sessionReport.Servers) == 0 {
+			fmt.Printf("  (no servers installed)\n")
+		}
+		for _, server := range sessionReport.Servers {
+			servers++
+			if server.Valid {
+				fmt.Printf("  ✅ %s\n", server.Name)
+			} else {
+				fmt.Printf("  ❌ %s\n", server.Name)
+			}
+			for _, issue := range server.Errors {
+				fmt.Printf("     - %s\n", issue.Message)
+			}
+			for _, issue := range server.Warnings {
+				fmt.Printf("     ⚠️  %s\n", issue.Message)
+			}
+		}
+		for _, secretName := range sessionReport.MissingSecrets {
+			fmt.Printf("  🔑 Missing secret: %s (run 'servo secrets set %s <value>')\n", secretName, secretName)
+		}
+		fmt.Println()
+	}
+
+	invalidServers, missingSecrets := report.failures()
+	if report.Valid {
+		fmt.Printf("✅ %d server(s) in %d session(s) are valid\n", servers, len(report.Sessions))
+		return
+	}
+	fmt.Printf("❌ %d of %d server(s) invalid, %d missing secret(s) across %d session(s)\n",
+		invalidServers, servers, missingSecrets, len(report.Sessions))
+}
+
+// reinstallCommand returns the install command that reproduces a tracked server,
+// with the --path and --dev it was installed with
+func reinstallCommand(server project.MCPServer) string {
+	args := []string{"servo", "install"}
+	if server.Path != "" {
+		args = append(args, "--path", server.Path)
+	}
+	if server.Dev {
+		args = append(args, "--dev")
+	}
+	return strings.Join(append(args, server.Source), " ")
+}
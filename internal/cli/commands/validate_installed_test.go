@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+)
+
+func TestValidateInstalledCommand_Report(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+	if _, err := session.NewManager(".servo").Create("staging", "Staging", ""); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
+	valid := `servo_version: "1.0"
+name: search
+install:
+  type: local
+  method: local
+  setup_commands:
+    - "true"
+server:
+  transport: stdio
+  command: search-mcp
+  args: ["--stdio"]
+configuration_schema:
+  secrets:
+    api_key:
+      description: Search API key
+      type: api_key
+      env_var: SEARCH_API_KEY
+      required: true
+`
+	writeManifest := func(sessionName, key, content string) {
+		dir := filepath.Join(".servo", "sessions", sessionName, "manifests")
+		os.MkdirAll(dir, 0755)
+		if err := os.WriteFile(filepath.Join(dir, key+".servo"), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write manifest: %v", err)
+		}
+	}
+	writeManifest("default", "search", valid)
+	writeManifest("staging", "broken", "servo_version: \"1.0\"\nname: broken\n")
+
+	pm := project.NewManager()
+	if err := pm.AddMCPServerToSession("ghost", "./ghost.servo", []string{"vscode"}, "default", false); err != nil {
+		t.Fatalf("Failed to track server: %v", err)
+	}
+
+	cmd := NewValidateInstalledCommand(mcp.NewParser(), mcp.NewValidator())
+
+	report, err := cmd.Report(false)
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if report.Valid || len(report.Sessions) != 1 {
+		t.Fatalf("Expected one invalid session, got %+v", report)
+	}
+	defaultReport := report.Sessions[0]
+	if len(defaultReport.Servers) != 2 || defaultReport.Servers[0].Name != "ghost" || defaultReport.Servers[0].Valid || !defaultReport.Servers[1].Valid {
+		t.Errorf("Expected missing ghost manifest and valid search, got %+v", defaultReport.Servers)
+	}
+	if !reflect.DeepEqual(defaultReport.MissingSecrets, []string{"api_key"}) {
+		t.Errorf("MissingSecrets = %v, want [api_key]", defaultReport.MissingSecrets)
+	}
+
+	// Configuring the secret and dropping the ghost leaves the active session healthy
+	if err := NewSecretsCommand(pm).Execute([]string{"set", "api_key", "secret"}); err != nil {
+		t.Fatalf("Failed to set secret: %v", err)
+	}
+	if err := pm.RemoveMCPServer("ghost"); err != nil {
+		t.Fatalf("Failed to remove server: %v", err)
+	}
+	if err := cmd.ExecuteWithOptions(ValidateInstalledOptions{}); err != nil {
+		t.Errorf("Expected active session to validate, got %v", err)
+	}
+
+	err = cmd.ExecuteWithOptions(ValidateInstalledOptions{All: true, Output: "json"})
+	if err == nil || !strings.Contains(err.Error(), "1 invalid server(s)") {
+		t.Errorf("Expected broken staging manifest to fail --all, got %v", err)
+	}
+}
+
+func TestValidateInstalledCommand_ServerDependencies(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup project: %v", err)
+	}
+
+	manifest := func(name, dependsOn string) string {
+		return "servo_version: \"1.1\"\nname: " + name + "\n" + dependsOn + "install:\n  type: local\n  method: local\n  setup_commands: [\"true\"]\nserver:\n  transport: stdio\n  command: " + name + "\n  args: [\"--stdio\"]\n"
+	}
+	dir := filepath.Join(".servo", "sessions", "default", "manifests")
+	os.WriteFile(filepath.Join(dir, "api-server.servo"), []byte(manifest("api-server", "depends_on: [database-server]\n")), 0644)
+
+	cmd := NewValidateInstalledCommand(mcp.NewParser(), mcp.NewValidator())
+	report, err := cmd.Report(false)
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	servers := report.Sessions[0].Servers
+	if report.Valid || len(servers) != 1 || servers[0].Valid || servers[0].Errors[0].Field != "depends_on" {
+		t.Fatalf("Expected the missing database-server to fail api-server, got %+v", servers)
+	}
+
+	os.WriteFile(filepath.Join(dir, "database-server.servo"), []byte(manifest("database-server", "")), 0644)
+	if report, _ = cmd.Report(false); !report.Valid {
+		t.Errorf("Expected the session to validate once the dependency is installed, got %+v", report.Sessions[0].Servers)
+	}
+}
+
+func TestReinstallCommand(t *testing.T) {
+	tests := []struct {
+		server project.MCPServer
+		want   string
+	}{
+		{server: project.MCPServer{Source: "https://github.com/acme/search.git"}, want: "servo install https://github.com/acme/search.git"},
+		{server: project.MCPServer{Source: "https://github.com/acme/servers.git", Path: "servers/db/db.servo"}, want: "servo install --path servers/db/db.servo https://github.com/acme/servers.git"},
+		{server: project.MCPServer{Source: "../api", Dev: true}, want: "servo install --dev ../api"},
+	}
+	for _, tt := range tests {
+		if got := reinstallCommand(tt.server); got != tt.want {
+			t.Errorf("reinstallCommand(%+v) = %q, want %q", tt.server, got, tt.want)
+		}
+	}
+}
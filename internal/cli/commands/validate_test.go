@@ -3,6 +3,7 @@ package commands
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/servo/servo/internal/mcp"
@@ -387,4 +388,275 @@ func TestValidateCommand_EmptyFile(t *testing.T) {
 	if err == nil {
 		t.Errorf("Empty .servo file should return error")
 	}
-}
\ No newline at end of file
+}
+
+func TestValidateCommand_Report(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	validContent := `servo_version: "1.0"
+name: "report-server"
+version: "1.0.0"
+install:
+  type: "local"
+  method: "local"
+  setup_commands:
+    - "npm install"
+server:
+  transport: "stdio"
+  command: "node"
+  args: ["index.js"]
+`
+	validFile := filepath.Join(tmpDir, "valid.servo")
+	if err := os.WriteFile(validFile, []byte(validContent), 0644); err != nil {
+		t.Fatalf("Failed to create test .servo file: %v", err)
+	}
+
+	invalidFile := filepath.Join(tmpDir, "invalid.servo")
+	if err := os.WriteFile(invalidFile, []byte("servo_version: \"1.0\"\nname: \"Bad Name\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test .servo file: %v", err)
+	}
+
+	cmd := NewValidateCommand(mcp.NewParser(), mcp.NewValidator())
+
+	report := cmd.Report(validFile)
+	if !report.Valid || len(report.Errors) != 0 {
+		t.Errorf("Expected valid report, got %+v", report)
+	}
+
+	report = cmd.Report(invalidFile)
+	if report.Valid || len(report.Errors) != 1 {
+		t.Fatalf("Expected one error, got %+v", report)
+	}
+	if report.Errors[0].Field != "name" {
+		t.Errorf("Expected error field 'name', got '%s'", report.Errors[0].Field)
+	}
+
+	report = cmd.Report(filepath.Join(tmpDir, "missing.servo"))
+	if report.Valid || report.Errors[0].Field != "source" {
+		t.Errorf("Expected source error for missing file, got %+v", report)
+	}
+
+	// Exit status follows validity regardless of output format
+	if err := cmd.Execute([]string{"--output", "json", validFile}); err != nil {
+		t.Errorf("Valid file with JSON output should not return error, got: %v", err)
+	}
+	if err := cmd.Execute([]string{"--output", "json", invalidFile}); err == nil {
+		t.Error("Invalid file with JSON output should return error")
+	}
+	if err := cmd.Execute([]string{"--output", "yaml", validFile}); err == nil {
+		t.Error("Unsupported output format should return error")
+	}
+}
+
+func TestValidateCommand_LocalOnly(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A git manifest validates offline: the repository is checked for syntax, never fetched
+	content := `servo_version: "1.0"
+name: "offline-server"
+version: "1.0.0"
+install:
+  type: "git"
+  method: "git"
+  repository: "https://unreachable.invalid/org/repo.git"
+  setup_commands:
+    - "npm install"
+server:
+  transport: "stdio"
+  command: "node"
+  args: ["index.js"]
+`
+	servoFile := filepath.Join(tmpDir, "offline.servo")
+	if err := os.WriteFile(servoFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test .servo file: %v", err)
+	}
+
+	cmd := NewValidateCommand(mcp.NewParser(), mcp.NewValidator())
+	opts := ValidateOptions{LocalOnly: true}
+
+	if err := cmd.Execute([]string{"--local-only", servoFile}); err != nil {
+		t.Errorf("Local file should validate with --local-only, got: %v", err)
+	}
+
+	report := cmd.ReportWithOptions("https://example.com/file.servo", opts)
+	if report.Valid || len(report.Errors) != 1 || report.Errors[0].Field != "source" {
+		t.Fatalf("Expected source error for remote source, got %+v", report)
+	}
+	if !strings.Contains(report.Errors[0].Message, "--local-only") {
+		t.Errorf("Expected error to mention --local-only, got %q", report.Errors[0].Message)
+	}
+
+	if err := cmd.ExecuteWithOptions([]string{"https://github.com/example/repo"}, opts); err == nil {
+		t.Error("Remote git source should be rejected with --local-only")
+	}
+}
+
+func TestValidateCommand_Strict(t *testing.T) {
+	content := `servo_version: "1.0"
+name: "strict-server"
+version: "1.0.0"
+install:
+  type: "local"
+  method: "local"
+  setup_commands:
+    - "true"
+server:
+  transport: "stdio"
+  command: "python"
+  args: ["-m", "server"]
+serve:
+  command: "typo"
+`
+	servoFile := filepath.Join(t.TempDir(), "strict-server.servo")
+	if err := os.WriteFile(servoFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test .servo file: %v", err)
+	}
+
+	parser := mcp.NewParser()
+	cmd := NewValidateCommand(parser, mcp.NewValidator())
+
+	if report := cmd.Report(servoFile); !report.Valid {
+		t.Fatalf("Expected lenient validation to pass, got %+v", report)
+	}
+
+	report := cmd.ReportWithOptions(servoFile, ValidateOptions{Strict: true})
+	if report.Valid || len(report.Errors) != 1 || !strings.Contains(report.Errors[0].Message, "did you mean 'server'?") {
+		t.Fatalf("Expected strict validation to reject 'serve', got %+v", report)
+	}
+	if err := cmd.Execute([]string{"--strict", servoFile}); err == nil {
+		t.Error("Expected --strict to fail on unknown keys")
+	}
+
+	if parser.Strict {
+		t.Error("Strict mode should not outlive the validation that requested it")
+	}
+}
+
+func TestValidateCommand_ServoVersion(t *testing.T) {
+	content := `servo_version: "2.0"
+name: "versioned-server"
+install:
+  type: "local"
+  method: "local"
+  setup_commands:
+    - "true"
+server:
+  transport: "stdio"
+  command: "python"
+  args: ["-m", "server"]
+`
+	dir := t.TempDir()
+	servoFile := filepath.Join(dir, "versioned-server.servo")
+	if err := os.WriteFile(servoFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test .servo file: %v", err)
+	}
+
+	parser := mcp.NewParser()
+	cmd := NewValidateCommand(parser, mcp.NewValidator())
+
+	if report := cmd.Report(servoFile); report.Valid {
+		t.Fatalf("Expected the declared servo_version 2.0 to be rejected, got %+v", report)
+	}
+	if report := cmd.ReportWithOptions(servoFile, ValidateOptions{ServoVersion: "1.0"}); !report.Valid {
+		t.Fatalf("Expected validation under 1.0 to ignore the declared version, got %+v", report)
+	}
+	if err := cmd.Execute([]string{"--servo-version", "1.0", servoFile}); err != nil {
+		t.Errorf("Expected --servo-version 1.0 to pass, got %v", err)
+	}
+
+	// Keys the forced schema does not define are errors
+	extraFile := filepath.Join(dir, "extra.servo")
+	os.WriteFile(extraFile, []byte(content+"sandbox:\n  network: none\n"), 0644)
+	report := cmd.ReportWithOptions(extraFile, ValidateOptions{ServoVersion: "1.0"})
+	if report.Valid || !strings.Contains(report.Errors[0].Message, "unknown top-level key 'sandbox'") {
+		t.Errorf("Expected keys outside the 1.0 schema to be rejected, got %+v", report)
+	}
+	if parser.Strict {
+		t.Error("Strict parsing should not outlive the validation that forced a version")
+	}
+
+	err := cmd.ExecuteWithOptions([]string{servoFile}, ValidateOptions{ServoVersion: "0.9"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported --servo-version '0.9'") {
+		t.Errorf("Expected an unsupported version error, got %v", err)
+	}
+	if err := cmd.Execute([]string{servoFile, "--servo-version"}); err == nil || !strings.Contains(err.Error(), "--servo-version requires a version") {
+		t.Errorf("Expected a bare --servo-version to be rejected, got %v", err)
+	}
+
+	// Fields a later version introduced are errors under an older one
+	newerFile := filepath.Join(dir, "newer.servo")
+	os.WriteFile(newerFile, []byte(strings.Replace(content, `"2.0"`, `"1.1"`, 1)+"id: versioned-id\n"), 0644)
+	if report := cmd.Report(newerFile); !report.Valid {
+		t.Fatalf("Expected id to be valid under the declared 1.1, got %+v", report)
+	}
+	report = cmd.ReportWithOptions(newerFile, ValidateOptions{ServoVersion: "1.0"})
+	if report.Valid || !strings.Contains(report.Errors[0].Message, "id requires servo_version 1.1") {
+		t.Errorf("Expected id to be rejected under 1.0, got %+v", report)
+	}
+}
+
+func TestValidateCommand_Report_FilenameMismatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := "servo_version: \"1.0\"\nname: bar\ninstall:\n  type: local\n  method: local\n  setup_commands: [\"true\"]\nserver:\n  transport: stdio\n  command: node\n  args: [index.js]\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "foo.servo"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test .servo file: %v", err)
+	}
+
+	cmd := NewValidateCommand(mcp.NewParser(), mcp.NewValidator())
+
+	// Directory sources resolve to the file they contain
+	for _, source := range []string{filepath.Join(tmpDir, "foo.servo"), tmpDir} {
+		report := cmd.Report(source)
+		if !report.Valid {
+			t.Errorf("A filename mismatch must not invalidate %s: %+v", source, report)
+		}
+		if len(report.Warnings) != 1 || report.Warnings[0].Field != "name" || !strings.Contains(report.Warnings[0].Message, "foo.servo") {
+			t.Errorf("Expected a name warning for %s, got %+v", source, report.Warnings)
+		}
+	}
+}
+
+func TestValidateCommand_License(t *testing.T) {
+	servoFile := filepath.Join(t.TempDir(), "licensed.servo")
+	content := "servo_version: \"1.0\"\nname: licensed\nlicense: Apache 2.0\ninstall:\n  type: local\n  method: local\n  setup_commands: [\"true\"]\nserver:\n  transport: stdio\n  command: node\n  args: [index.js]\n"
+	if err := os.WriteFile(servoFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test .servo file: %v", err)
+	}
+
+	cmd := NewValidateCommand(mcp.NewParser(), mcp.NewValidator())
+
+	report := cmd.Report(servoFile)
+	if !report.Valid || len(report.Warnings) != 1 || report.Warnings[0].Field != "license" || !strings.Contains(report.Warnings[0].Message, "'Apache-2.0'") {
+		t.Errorf("Expected an unknown license to warn with a suggestion, got %+v", report)
+	}
+
+	report = cmd.ReportWithOptions(servoFile, ValidateOptions{Strict: true})
+	if report.Valid || len(report.Errors) != 1 || report.Errors[0].Field != "license" {
+		t.Errorf("Expected --strict to reject an unknown license, got %+v", report)
+	}
+	if err := cmd.Execute([]string{"--strict", servoFile}); err == nil {
+		t.Error("Expected --strict to fail on an unknown license")
+	}
+}
+
+func TestResolveSourceShorthand(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	if got := resolveSourceShorthand("acme/search@v1"); got != "github:acme/search@v1" {
+		t.Errorf("Expected bare shorthand to expand, got %s", got)
+	}
+
+	// An existing local directory of the same name is preferred
+	os.MkdirAll(filepath.Join("acme", "search"), 0755)
+	if got := resolveSourceShorthand("acme/search"); got != "acme/search" {
+		t.Errorf("Expected local path to win, got %s", got)
+	}
+
+	for _, source := range []string{"./acme/search", "github:acme/search", "server.servo"} {
+		if got := resolveSourceShorthand(source); got != source {
+			t.Errorf("Expected %s to be left alone, got %s", source, got)
+		}
+	}
+}
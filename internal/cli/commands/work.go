@@ -3,20 +3,40 @@ package commands
 import (
 	"fmt"
 	"os"
+	"os/exec"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/servo/servo/internal/config"
+	"github.com/servo/servo/internal/mcp"
 	"github.com/servo/servo/internal/project"
 	"github.com/servo/servo/internal/registry"
 	"github.com/servo/servo/internal/session"
 	"github.com/servo/servo/pkg"
 )
 
+// defaultWaitTimeout bounds how long --wait polls service healthchecks
+const defaultWaitTimeout = 2 * time.Minute
+
+// composeFilePath is the generated compose file used to start services
+const composeFilePath = ".devcontainer/docker-compose.yml"
+
+// healthPollInterval is the delay between healthcheck polls while waiting
+const healthPollInterval = 2 * time.Second
+
+// serviceHealthFunc reports a compose service's container state and health status
+type serviceHealthFunc func(service string) (state string, health string, err error)
+
 // WorkCommand handles starting the development environment
 type WorkCommand struct {
 	projectManager *project.Manager
 	sessionManager *session.Manager
 	clientRegistry pkg.ClientRegistry
+	parser         *mcp.Parser
+	serviceHealth  serviceHealthFunc
+	composeUp      composeUpFunc
+	pollInterval   time.Duration
 }
 
 // NewWorkCommand creates a new work command
@@ -30,6 +50,10 @@ func NewWorkCommand() *WorkCommand {
 		projectManager: project.NewManager(),
 		sessionManager: session.NewManager(servoDir),
 		clientRegistry: registry.GetDefaultRegistry(),
+		parser:         mcp.NewParser(),
+		serviceHealth:  dockerComposeServiceHealth,
+		composeUp:      dockerComposeUp,
+		pollInterval:   healthPollInterval,
 	}
 }
 
@@ -58,6 +82,9 @@ func (c *WorkCommand) Execute(args []string) error {
 	// Parse command line options
 	var client string
 	var shouldLaunchClient bool
+	var wait bool
+	var services []string
+	waitTimeout := defaultWaitTimeout
 
 	for i, arg := range args {
 		switch arg {
@@ -66,6 +93,20 @@ func (c *WorkCommand) Execute(args []string) error {
 				client = args[i+1]
 				shouldLaunchClient = true
 			}
+		case "--wait":
+			wait = true
+		case "--service":
+			if i+1 < len(args) {
+				services = append(services, args[i+1])
+			}
+		case "--wait-timeout":
+			if i+1 < len(args) {
+				timeout, err := time.ParseDuration(args[i+1])
+				if err != nil {
+					return fmt.Errorf("invalid wait timeout '%s': %w", args[i+1], err)
+				}
+				waitTimeout = timeout
+			}
 		case "--vscode":
 			client = "vscode"
 			shouldLaunchClient = true
@@ -75,12 +116,30 @@ func (c *WorkCommand) Execute(args []string) error {
 		}
 	}
 
+	withDevcontainer := devcontainerEnabled(project, false)
+	if wait && !withDevcontainer {
+		return fmt.Errorf("--wait requires devcontainer output; remove config.no_devcontainer from .servo/project.yaml")
+	}
+	if len(services) > 0 && !withDevcontainer {
+		return fmt.Errorf("--service requires devcontainer output; remove config.no_devcontainer from .servo/project.yaml")
+	}
+
+	activeSession, err := requireActiveSession(c.sessionManager)
+	if err != nil {
+		return err
+	}
+
+	// Record use of the active session without re-sweeping every session's Active flag
+	if err := c.sessionManager.Touch(activeSession.Name); err != nil {
+		fmt.Printf("Warning: failed to record session use: %v\n", err)
+	}
+
 	projectName, err := c.projectManager.GetProjectName()
 	if err != nil {
 		projectName = "unknown"
 	}
 	fmt.Printf("🚀 Starting development environment for project: %s\n", projectName)
-	fmt.Printf("📍 Active session: %s\n", project.ActiveSession)
+	fmt.Printf("📍 Active session: %s\n", activeSession.Name)
 	fmt.Println()
 
 	// Step 1: Generate configurations
@@ -93,10 +152,23 @@ func (c *WorkCommand) Execute(args []string) error {
 
 	// Step 2: Configuration ready
 	fmt.Println("✅ Development environment configured")
-	fmt.Printf("   → Devcontainer: .devcontainer/devcontainer.json\n")
-	fmt.Printf("   → Services: .devcontainer/docker-compose.yml\n")
+	if withDevcontainer {
+		fmt.Printf("   → Devcontainer: .devcontainer/devcontainer.json\n")
+		if composeFileGenerated() {
+			fmt.Printf("   → Services: .devcontainer/docker-compose.yml\n")
+		}
+	} else {
+		fmt.Printf("ℹ️  %s; only MCP client configurations were generated\n", devcontainerDisabledMessage)
+	}
 	fmt.Println()
 
+	if wait || len(services) > 0 {
+		if err := c.startServices(services, wait, waitTimeout); err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+
 	// Step 3: Show MCP server status
 	if len(project.MCPServers) > 0 {
 		fmt.Println("📦 MCP Servers configured:")
@@ -144,17 +216,20 @@ func (c *WorkCommand) generateConfigurations() error {
 	servoDir := c.projectManager.GetServoDir()
 	configManager := config.NewConfigGeneratorManager(servoDir)
 
-	// Generate devcontainer configuration
-	if err := configManager.GenerateDevcontainer(); err != nil {
-		return fmt.Errorf("failed to generate devcontainer: %w", err)
+	generated, err := generateDevcontainerConfigs(c.projectManager, configManager, false)
+	if err != nil || generated {
+		return err
 	}
 
-	// Generate docker-compose configuration
-	if err := configManager.GenerateDockerCompose(); err != nil {
-		return fmt.Errorf("failed to generate docker-compose: %w", err)
+	// Without a devcontainer, client configs are the only way servers reach the editor
+	activeName, err := c.sessionManager.GetActiveName()
+	if err != nil {
+		return fmt.Errorf("failed to get active session: %w", err)
 	}
-
-	return nil
+	if activeName == "" {
+		return nil
+	}
+	return generateClientConfigs(c.projectManager, c.sessionManager, c.clientRegistry, c.parser, activeName)
 }
 
 // getLaunchCommand returns the command to launch the specified client
@@ -177,3 +252,188 @@ func (c *WorkCommand) getLaunchCommand(clientName string) string {
 	return client.GetLaunchCommand(pwd)
 }
 
+// startServices brings up compose services and, with wait, waits until every started
+// manifest service with a healthcheck reports healthy. Without selected services the
+// active session's manifest services are started; otherwise only the selected
+// services and their depends_on closure are.
+func (c *WorkCommand) startServices(selected []string, wait bool, timeout time.Duration) error {
+	baseGenerator := config.NewBaseGenerator(c.projectManager.GetServoDir())
+	project, activeSession, manifests, err := baseGenerator.GetActiveSessionData()
+	if err != nil {
+		return fmt.Errorf("failed to load session services: %w", err)
+	}
+
+	servicePrefix, err := baseGenerator.ResolveServicePrefix(project)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	services := manifestServiceHealthChecks(manifests, baseGenerator.ResolveActiveProfiles(project, activeSession), servicePrefix)
+	if len(selected) > 0 {
+		if !composeFileGenerated() {
+			return fmt.Errorf("--service needs %s, which a single-container devcontainer does not have", composeFilePath)
+		}
+		dependencies, err := composeServiceDependencies(composeFilePath)
+		if err != nil {
+			return err
+		}
+		names, err = serviceClosure(dependencies, selected)
+		if err != nil {
+			return err
+		}
+
+		// Named services start even when their profile is inactive
+		allServices := manifestServiceHealthChecks(manifests, manifestServiceProfiles(manifests), servicePrefix)
+		services = make(map[string]*pkg.HealthCheck)
+		for _, name := range names {
+			if healthCheck, ok := allServices[name]; ok {
+				services[name] = healthCheck
+			}
+		}
+		fmt.Printf("🐳 Starting services: %s\n", strings.Join(names, ", "))
+	} else {
+		if len(services) == 0 {
+			fmt.Println("✅ No dependency services to wait for")
+			return nil
+		}
+		for name := range services {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Println("🐳 Starting services...")
+	}
+
+	if err := c.composeUp(names); err != nil {
+		return fmt.Errorf("failed to start services: %w", err)
+	}
+	if !wait {
+		return nil
+	}
+
+	fmt.Printf("⏳ Waiting up to %s for services to become healthy...\n", timeout)
+	return c.waitForServices(services, timeout)
+}
+
+// manifestServiceHealthChecks maps compose service names to their manifest healthchecks
+// (nil when the service has none), skipping services gated by inactive profiles.
+// Service names follow the servicePrefix template.
+func manifestServiceHealthChecks(manifests map[string]*pkg.ServoDefinition, activeProfiles []string, servicePrefix string) map[string]*pkg.HealthCheck {
+	active := make(map[string]bool, len(activeProfiles))
+	for _, profile := range activeProfiles {
+		active[profile] = true
+	}
+
+	services := make(map[string]*pkg.HealthCheck)
+	for manifestName, manifest := range manifests {
+		if manifest == nil {
+			continue
+		}
+
+		for name, service := range manifest.AllServices() {
+			if service == nil || !serviceProfileActive(service.Profiles, active) {
+				continue
+			}
+			services[config.ComposeServiceName(servicePrefix, manifestName, name)] = service.HealthCheck
+		}
+	}
+	return services
+}
+
+// serviceProfileActive reports whether a service gated by profiles is started
+func serviceProfileActive(profiles []string, active map[string]bool) bool {
+	if len(profiles) == 0 {
+		return true
+	}
+	for _, profile := range profiles {
+		if active[profile] {
+			return true
+		}
+	}
+	return false
+}
+
+// waitForServices polls service health until all healthchecked services are healthy,
+// one turns unhealthy, or the timeout expires. Services without a healthcheck are ready immediately.
+func (c *WorkCommand) waitForServices(services map[string]*pkg.HealthCheck, timeout time.Duration) error {
+	pending := make(map[string]string)
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if services[name] == nil {
+			fmt.Printf("   ✅ %s ready (no healthcheck)\n", name)
+			continue
+		}
+		pending[name] = "starting"
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		for _, name := range names {
+			if _, ok := pending[name]; !ok {
+				continue
+			}
+
+			state, health, err := c.serviceHealth(name)
+			if err != nil {
+				pending[name] = err.Error()
+				continue
+			}
+
+			switch {
+			case health == "healthy":
+				delete(pending, name)
+				fmt.Printf("   ✅ %s healthy\n", name)
+			case health == "unhealthy":
+				fmt.Printf("   ❌ %s unhealthy\n", name)
+				return fmt.Errorf("service %s is unhealthy", name)
+			case state == "exited" || state == "dead":
+				fmt.Printf("   ❌ %s %s\n", name, state)
+				return fmt.Errorf("service %s is not running (%s)", name, state)
+			default:
+				pending[name] = health
+				if pending[name] == "" {
+					pending[name] = state
+				}
+			}
+		}
+
+		if len(pending) == 0 {
+			fmt.Println("✅ All services ready")
+			return nil
+		}
+
+		if !time.Now().Before(deadline) {
+			for _, name := range names {
+				if status, ok := pending[name]; ok {
+					fmt.Printf("   ⏱️  %s not healthy (%s)\n", name, status)
+				}
+			}
+			return fmt.Errorf("timed out after %s waiting for %d service(s) to become healthy", timeout, len(pending))
+		}
+
+		time.Sleep(c.pollInterval)
+	}
+}
+
+// dockerComposeServiceHealth queries docker compose for a service's container state and health
+func dockerComposeServiceHealth(service string) (string, string, error) {
+	output, err := exec.Command("docker", "compose", "-f", composeFilePath, "ps", "--all", "--format", "{{.State}} {{.Health}}", service).Output()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to query service %s: %w", service, err)
+	}
+
+	fields := strings.Fields(strings.TrimSpace(string(output)))
+	switch len(fields) {
+	case 0:
+		return "missing", "", nil
+	case 1:
+		return fields[0], "", nil
+	default:
+		return fields[0], fields[1], nil
+	}
+}
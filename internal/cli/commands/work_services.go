@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/servo/servo/pkg"
+)
+
+// composeUpFunc starts the named compose services in the background
+type composeUpFunc func(services []string) error
+
+// dockerComposeUp starts compose services with docker compose up -d
+func dockerComposeUp(services []string) error {
+	upArgs := append([]string{"compose", "-f", composeFilePath, "up", "-d"}, services...)
+	cmd := exec.Command("docker", upArgs...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// composeFileGenerated reports whether a compose file was generated; a
+// single-container devcontainer has none
+func composeFileGenerated() bool {
+	_, err := os.Stat(composeFilePath)
+	return err == nil
+}
+
+// composeServiceDependencies reads the services of a compose file and the services
+// each depends_on, in either the list or the map form
+func composeServiceDependencies(path string) (map[string][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var compose struct {
+		Services map[string]struct {
+			DependsOn interface{} `yaml:"depends_on"`
+		} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(data, &compose); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	dependencies := make(map[string][]string, len(compose.Services))
+	for name, service := range compose.Services {
+		var dependsOn []string
+		switch deps := service.DependsOn.(type) {
+		case []interface{}:
+			for _, dep := range deps {
+				if depName, ok := dep.(string); ok {
+					dependsOn = append(dependsOn, depName)
+				}
+			}
+		case map[string]interface{}:
+			for depName := range deps {
+				dependsOn = append(dependsOn, depName)
+			}
+		}
+		dependencies[name] = dependsOn
+	}
+	return dependencies, nil
+}
+
+// serviceClosure returns, sorted, the selected services and every service they
+// depend on directly or transitively. An unknown name is an error listing the
+// available services.
+func serviceClosure(dependencies map[string][]string, selected []string) ([]string, error) {
+	for _, name := range selected {
+		if _, ok := dependencies[name]; !ok {
+			available := make([]string, 0, len(dependencies))
+			for service := range dependencies {
+				available = append(available, service)
+			}
+			sort.Strings(available)
+			return nil, fmt.Errorf("unknown service '%s' (available: %s)", name, strings.Join(available, ", "))
+		}
+	}
+
+	included := make(map[string]bool)
+	queue := append([]string(nil), selected...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if included[name] {
+			continue
+		}
+		included[name] = true
+		queue = append(queue, dependencies[name]...)
+	}
+
+	closure := make([]string, 0, len(included))
+	for name := range included {
+		closure = append(closure, name)
+	}
+	sort.Strings(closure)
+	return closure, nil
+}
+
+// manifestServiceProfiles returns every compose profile a manifest service is gated by
+func manifestServiceProfiles(manifests map[string]*pkg.ServoDefinition) []string {
+	var profiles []string
+	for _, manifest := range manifests {
+		for _, service := range manifest.AllServices() {
+			if service != nil {
+				profiles = append(profiles, service.Profiles...)
+			}
+		}
+	}
+	return profiles
+}
@@ -2,7 +2,15 @@ package commands
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/servo/servo/internal/config"
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+	"github.com/servo/servo/pkg"
 )
 
 func TestWorkCommand_Execute_NotInProject(t *testing.T) {
@@ -159,6 +167,47 @@ sessions:
 	}
 }
 
+func TestWorkCommand_Execute_NoDevcontainer(t *testing.T) {
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(t.TempDir())
+
+	projectManager := project.NewManager()
+	proj, err := projectManager.Init("main", []string{"vscode"})
+	if err != nil {
+		t.Fatalf("Failed to init project: %v", err)
+	}
+	proj.Config.NoDevcontainer = true
+	if err := projectManager.Save(proj); err != nil {
+		t.Fatalf("Failed to save project: %v", err)
+	}
+	sessionManager := session.NewManager(".servo")
+	if _, err := sessionManager.Create("main", "", ""); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := sessionManager.Activate("main"); err != nil {
+		t.Fatalf("Failed to activate session: %v", err)
+	}
+
+	cmd := NewWorkCommand()
+	if err := cmd.Execute([]string{}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := os.Stat(".devcontainer"); !os.IsNotExist(err) {
+		t.Error("Expected no .devcontainer output when config.no_devcontainer is set")
+	}
+
+	err = cmd.Execute([]string{"--wait"})
+	if err == nil || !strings.Contains(err.Error(), "no_devcontainer") {
+		t.Errorf("Expected --wait to explain the devcontainer opt-out, got %v", err)
+	}
+
+	err = cmd.Execute([]string{"--service", "app-db"})
+	if err == nil || !strings.Contains(err.Error(), "no_devcontainer") {
+		t.Errorf("Expected --service to explain the devcontainer opt-out, got %v", err)
+	}
+}
+
 func TestWorkCommand_Name(t *testing.T) {
 	cmd := NewWorkCommand()
 	if cmd.Name() != "work" {
@@ -321,3 +370,208 @@ func containsSubstring(s, substr string) bool {
 		}())
 }
 
+
+func TestWorkCommand_WaitForServices(t *testing.T) {
+	services := map[string]*pkg.HealthCheck{
+		"app-db":    {Test: []string{"CMD", "pg_isready"}},
+		"app-cache": nil,
+	}
+
+	polls := 0
+	cmd := &WorkCommand{
+		serviceHealth: func(service string) (string, string, error) {
+			if service != "app-db" {
+				t.Errorf("Service without healthcheck should not be polled: %s", service)
+			}
+			polls++
+			if polls < 3 {
+				return "running", "starting", nil
+			}
+			return "running", "healthy", nil
+		},
+	}
+
+	if err := cmd.waitForServices(services, time.Second); err != nil {
+		t.Fatalf("waitForServices() error = %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("Expected 3 polls, got %d", polls)
+	}
+
+	cmd.serviceHealth = func(service string) (string, string, error) {
+		return "running", "unhealthy", nil
+	}
+	if err := cmd.waitForServices(services, time.Second); err == nil {
+		t.Error("Expected error for unhealthy service")
+	}
+
+	cmd.serviceHealth = func(service string) (string, string, error) {
+		return "running", "starting", nil
+	}
+	cmd.pollInterval = 10 * time.Millisecond
+	if err := cmd.waitForServices(services, 50*time.Millisecond); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Expected timeout error, got %v", err)
+	}
+
+	// Only services without healthchecks are ready immediately
+	cmd.serviceHealth = nil
+	if err := cmd.waitForServices(map[string]*pkg.HealthCheck{"app-web": nil}, 0); err != nil {
+		t.Errorf("Services without healthchecks should be ready, got %v", err)
+	}
+}
+
+func TestManifestServiceHealthChecks(t *testing.T) {
+	hc := &pkg.HealthCheck{Test: []string{"CMD", "true"}}
+	manifests := map[string]*pkg.ServoDefinition{
+		"app": {
+			Services: map[string]*pkg.ServiceDependency{
+				"db":    {Image: "postgres:15", HealthCheck: hc},
+				"debug": {Image: "busybox", Profiles: []string{"debug"}},
+			},
+			Dependencies: &pkg.Dependencies{
+				Services: map[string]pkg.ServiceDependency{
+					"cache": {Image: "redis:7"},
+				},
+			},
+		},
+	}
+
+	services := manifestServiceHealthChecks(manifests, nil, config.DefaultServicePrefix)
+	if len(services) != 2 || services["app-db"] != hc {
+		t.Errorf("Unexpected services without profiles: %v", services)
+	}
+	if check, ok := services["app-cache"]; !ok || check != nil {
+		t.Errorf("Expected app-cache without healthcheck, got %v", services)
+	}
+
+	services = manifestServiceHealthChecks(manifests, []string{"debug"}, config.DefaultServicePrefix)
+	if _, ok := services["app-debug"]; !ok {
+		t.Error("Expected profile-gated service when its profile is active")
+	}
+}
+
+func TestComposeServiceDependencies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	compose := `services:
+  workspace:
+    image: dev
+    depends_on:
+      app-db:
+        condition: service_healthy
+  app-api:
+    image: api
+    depends_on: [app-db, app-cache]
+  app-db:
+    image: postgres:15
+  app-cache:
+    image: redis:7
+`
+	if err := os.WriteFile(path, []byte(compose), 0644); err != nil {
+		t.Fatalf("Failed to write compose file: %v", err)
+	}
+
+	dependencies, err := composeServiceDependencies(path)
+	if err != nil {
+		t.Fatalf("composeServiceDependencies() error = %v", err)
+	}
+	if len(dependencies) != 4 {
+		t.Errorf("Expected 4 services, got %v", dependencies)
+	}
+	if deps := dependencies["workspace"]; len(deps) != 1 || deps[0] != "app-db" {
+		t.Errorf("Expected map-form depends_on for workspace, got %v", deps)
+	}
+	if deps := dependencies["app-api"]; len(deps) != 2 {
+		t.Errorf("Expected list-form depends_on for app-api, got %v", deps)
+	}
+
+	if _, err := composeServiceDependencies(filepath.Join(t.TempDir(), "missing.yml")); err == nil {
+		t.Error("Expected error for a missing compose file")
+	}
+}
+
+func TestServiceClosure(t *testing.T) {
+	dependencies := map[string][]string{
+		"workspace": {"app-api"},
+		"app-api":   {"app-db", "app-cache"},
+		"app-db":    nil,
+		"app-cache": {"app-db"},
+		"app-ml":    {"app-db"},
+	}
+
+	tests := []struct {
+		name     string
+		selected []string
+		want     string
+		wantErr  string
+	}{
+		{name: "leaf", selected: []string{"app-db"}, want: "app-db"},
+		{name: "transitive", selected: []string{"workspace"}, want: "app-api,app-cache,app-db,workspace"},
+		{name: "repeated", selected: []string{"app-ml", "app-cache", "app-ml"}, want: "app-cache,app-db,app-ml"},
+		{name: "unknown", selected: []string{"app-db", "app-web"}, wantErr: "unknown service 'app-web' (available: app-api, app-cache, app-db, app-ml, workspace)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := serviceClosure(dependencies, tt.selected)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("serviceClosure() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("serviceClosure() error = %v", err)
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("serviceClosure() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkCommand_Execute_Service(t *testing.T) {
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(t.TempDir())
+
+	if err := setupInstallTestProject(t); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+	manifest := `servo_version: "1.0"
+name: app
+server:
+  transport: stdio
+  command: app-server
+services:
+  db:
+    image: postgres:15
+  api:
+    image: app-api
+    depends_on: [db]
+  ml:
+    image: app-ml
+`
+	os.WriteFile(".servo/sessions/default/manifests/app.servo", []byte(manifest), 0644)
+
+	var started []string
+	cmd := NewWorkCommand()
+	cmd.composeUp = func(services []string) error {
+		started = services
+		return nil
+	}
+
+	err := cmd.Execute([]string{"--service", "app-missing"})
+	if err == nil || !strings.Contains(err.Error(), "unknown service 'app-missing' (available: ") {
+		t.Errorf("Expected an unknown service error listing the available services, got %v", err)
+	}
+	if started != nil {
+		t.Errorf("Expected no services started for an unknown name, got %v", started)
+	}
+
+	if err := cmd.Execute([]string{"--service", "app-api"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if strings.Join(started, ",") != "app-api,app-db" {
+		t.Errorf("Expected app-api and its dependency started, got %v", started)
+	}
+}
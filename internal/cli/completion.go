@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
+	"github.com/servo/servo/pkg"
+)
+
+// completionFlag is the hidden flag urfave/cli uses to request completions
+const completionFlag = "--generate-bash-completion"
+
+// completionScripts hold the per-shell scripts printed by `servo completion`.
+// Each one re-invokes the binary with completionFlag so candidates stay dynamic.
+var completionScripts = map[string]string{
+	"bash": `# bash completion for {{prog}}
+_{{prog}}_bash_complete() {
+  local cur words
+  COMPREPLY=()
+  cur="${COMP_WORDS[COMP_CWORD]}"
+  words=("${COMP_WORDS[@]:0:$COMP_CWORD}")
+  local opts
+  if [[ "$cur" == -* ]]; then
+    opts=$("${words[@]}" "$cur" ` + completionFlag + ` 2>/dev/null)
+  else
+    opts=$("${words[@]}" ` + completionFlag + ` 2>/dev/null)
+  fi
+  COMPREPLY=($(compgen -W "${opts}" -- "${cur}"))
+  return 0
+}
+
+complete -o bashdefault -o default -F _{{prog}}_bash_complete {{prog}}
+`,
+	"zsh": `#compdef {{prog}}
+
+_{{prog}}_zsh_complete() {
+  local -a opts
+  local cur
+  cur=${words[-1]}
+  if [[ "$cur" == -* ]]; then
+    opts=("${(@f)$(${words[@]:0:#words[@]-1} ${cur} ` + completionFlag + ` 2>/dev/null)}")
+  else
+    opts=("${(@f)$(${words[@]:0:#words[@]-1} ` + completionFlag + ` 2>/dev/null)}")
+  fi
+
+  if [[ "${opts[1]}" != "" ]]; then
+    _describe 'values' opts
+  else
+    _files
+  fi
+}
+
+compdef _{{prog}}_zsh_complete {{prog}}
+`,
+	"fish": `# fish completion for {{prog}}
+function __{{prog}}_complete
+  set -l args (commandline -opc)
+  set -l cur (commandline -ct)
+  if string match -q -- '-*' $cur
+    $args $cur ` + completionFlag + ` 2>/dev/null
+  else
+    $args ` + completionFlag + ` 2>/dev/null
+  end
+end
+
+complete -c {{prog}} -f -a '(__{{prog}}_complete)'
+`,
+}
+
+// completionScript returns the completion script for shell, bound to the program name
+func completionScript(shell, prog string) (string, error) {
+	script, ok := completionScripts[shell]
+	if !ok {
+		return "", fmt.Errorf("unsupported shell '%s' (supported: bash, zsh, fish)", shell)
+	}
+	return strings.ReplaceAll(script, "{{prog}}", prog), nil
+}
+
+// completionSource lists candidate values for an argument or flag
+type completionSource func() []string
+
+// completer describes the dynamic completions offered by a command
+type completer struct {
+	// args supplies candidates for positional arguments
+	args completionSource
+	// multiple offers args at every position, skipping values already given,
+	// instead of only for the first argument
+	multiple bool
+	// flags supplies candidates for flag values, keyed by every spelling of the flag
+	flags map[string]completionSource
+}
+
+// complete prints the candidates for the word being completed, one per line
+func (cp completer) complete(c *cli.Context) {
+	// Completion skips the app's Before hook, so locate the project here
+	enterProjectRoot()
+
+	previous := ""
+	if len(os.Args) > 2 {
+		previous = os.Args[len(os.Args)-2]
+	}
+
+	if source, ok := cp.flags[previous]; ok {
+		printCandidates(c, source(), nil)
+		return
+	}
+	if strings.HasPrefix(previous, "-") || cp.args == nil {
+		cli.DefaultCompleteWithFlags(c.Command)(c)
+		return
+	}
+
+	if !cp.multiple && c.NArg() > 0 {
+		return
+	}
+	printCandidates(c, cp.args(), c.Args().Slice())
+}
+
+// printCandidates writes each candidate not listed in skip
+func printCandidates(c *cli.Context, candidates, skip []string) {
+	given := make(map[string]bool, len(skip))
+	for _, s := range skip {
+		given[s] = true
+	}
+	for _, candidate := range candidates {
+		if !given[candidate] {
+			fmt.Fprintln(c.App.Writer, candidate)
+		}
+	}
+}
+
+// sessionNames lists the project's sessions
+func sessionNames() []string {
+	names, err := session.NewManager(".servo").ListNames()
+	if err != nil {
+		return nil
+	}
+	return names
+}
+
+// clientNames lists every client known to the registry
+func clientNames(registry pkg.ClientRegistry) completionSource {
+	return func() []string {
+		var names []string
+		for _, client := range registry.List() {
+			names = append(names, client.Name())
+		}
+		sort.Strings(names)
+		return names
+	}
+}
+
+// withAllClients adds the "all" keyword that install and configure accept to a
+// list of client names
+func withAllClients(source completionSource) completionSource {
+	return func() []string {
+		return append([]string{"all"}, source()...)
+	}
+}
+
+// serverNames lists the MCP servers declared in project.yaml
+func serverNames(projectManager *project.Manager) completionSource {
+	return func() []string {
+		proj, err := projectManager.Get()
+		if err != nil {
+			return nil
+		}
+
+		seen := make(map[string]bool)
+		var names []string
+		for _, server := range proj.MCPServers {
+			if !seen[server.Name] {
+				seen[server.Name] = true
+				names = append(names, server.Name)
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+}
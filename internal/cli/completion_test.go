@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/session"
+)
+
+func TestCompletionScript(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		script, err := completionScript(shell, "servo")
+		if err != nil {
+			t.Fatalf("completionScript(%s) error = %v", shell, err)
+		}
+		if !strings.Contains(script, completionFlag) || strings.Contains(script, "{{prog}}") {
+			t.Errorf("%s script not bound to servo:\n%s", shell, script)
+		}
+	}
+
+	if _, err := completionScript("powershell", "servo"); err == nil {
+		t.Error("Expected unsupported shell to fail")
+	}
+}
+
+func TestCompletion_DynamicSources(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	if err := os.MkdirAll(".servo", 0755); err != nil {
+		t.Fatalf("Failed to create .servo: %v", err)
+	}
+	projectYAML := "default_session: dev\nclients: [vscode]\nmcp_servers:\n  - name: web\n    source: ./web.servo\n    sessions: [dev]\n  - name: api\n    source: ./api.servo\n    sessions: [dev, prod]\n"
+	if err := os.WriteFile(".servo/project.yaml", []byte(projectYAML), 0644); err != nil {
+		t.Fatalf("Failed to write project.yaml: %v", err)
+	}
+	sessionManager := session.NewManager(".servo")
+	for _, name := range []string{"prod", "dev"} {
+		if _, err := sessionManager.Create(name, name, ""); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+	}
+
+	originalArgs := os.Args
+	defer func() { os.Args = originalArgs }()
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "session names", args: []string{"servo", "session", "activate"}, want: []string{"dev", "prod"}},
+		{name: "first argument only", args: []string{"servo", "session", "rename", "dev"}, want: nil},
+		{name: "client names skip given", args: []string{"servo", "client", "enable", "vscode"}, want: []string{"claude-code", "cursor"}},
+		{name: "installed servers", args: []string{"servo", "uninstall"}, want: []string{"api", "web"}},
+		{name: "session flag value", args: []string{"servo", "uninstall", "--session"}, want: []string{"dev", "prod"}},
+		{name: "client flag value", args: []string{"servo", "work", "-c"}, want: []string{"claude-code", "cursor", "vscode"}},
+		{name: "client targets include all", args: []string{"servo", "install", "--clients"}, want: []string{"all", "claude-code", "cursor", "vscode"}},
+		{name: "shells", args: []string{"servo", "completion"}, want: []string{"bash", "fish", "zsh"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, err := NewApp("test-version")
+			if err != nil {
+				t.Fatalf("Failed to create app: %v", err)
+			}
+			var out bytes.Buffer
+			app.Writer = &out
+
+			os.Args = append(append([]string{}, tt.args...), completionFlag)
+			if err := app.Run(os.Args); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			got := strings.Fields(out.String())
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("completions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
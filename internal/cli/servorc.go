@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// servorcFile holds per-directory default flag values. Each top-level key names a
+// command flag (its long name or a long alias, without dashes) and becomes that
+// flag's default on every command that takes it, so explicit flags still win.
+const servorcFile = ".servorc"
+
+// servorcFlags are the flags a .servorc may set: common selections that change what
+// a command targets or how it reports, never flags that skip checks or discard work
+// such as --force
+var servorcFlags = []string{"clients", "client", "session", "output", "search-depth", "wait", "no-devcontainer"}
+
+// applyServorc reads path and makes its values the defaults of the matching command
+// flags. A missing file is not an error; an unreadable or invalid one is.
+func applyServorc(path string, commands []*cli.Command) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var defaults map[string]interface{}
+	if err := yaml.Unmarshal(data, &defaults); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !slices.Contains(servorcFlags, name) {
+			return fmt.Errorf("invalid %s: --%s cannot be set here (allowed: %s)", path, name, strings.Join(servorcFlags, ", "))
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, flag := range collectCommandFlags(commands, nil) {
+		name, ok := servorcKey(flag, defaults)
+		if !ok {
+			continue
+		}
+		used[name] = true
+		if err := setFlagDefault(flag, defaults[name]); err != nil {
+			return fmt.Errorf("invalid %s: %s: %w", path, name, err)
+		}
+	}
+	for _, name := range names {
+		if !used[name] {
+			return fmt.Errorf("invalid %s: no command takes a --%s flag", path, name)
+		}
+	}
+	return nil
+}
+
+// servorcKey returns the .servorc key that sets a flag: its long name, or else the
+// first of its aliases present. Only allowed flags are matched.
+func servorcKey(flag cli.Flag, defaults map[string]interface{}) (string, bool) {
+	for _, name := range flag.Names() {
+		if _, ok := defaults[name]; ok && slices.Contains(servorcFlags, name) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// collectCommandFlags appends the flags of commands and their subcommands to flags
+func collectCommandFlags(commands []*cli.Command, flags []cli.Flag) []cli.Flag {
+	for _, command := range commands {
+		flags = append(flags, command.Flags...)
+		flags = collectCommandFlags(command.Subcommands, flags)
+	}
+	return flags
+}
+
+// setFlagDefault replaces a flag's default with a .servorc value of the matching type
+func setFlagDefault(flag cli.Flag, value interface{}) error {
+	switch f := flag.(type) {
+	case *cli.StringFlag:
+		s, err := servorcScalar(value)
+		if err != nil {
+			return err
+		}
+		f.Value = s
+	case *cli.StringSliceFlag:
+		values, err := servorcList(value)
+		if err != nil {
+			return err
+		}
+		f.Value = cli.NewStringSlice(values...)
+	case *cli.BoolFlag:
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("expected true or false, got %v", value)
+		}
+		f.Value = b
+	case *cli.IntFlag:
+		i, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("expected a whole number, got %v", value)
+		}
+		f.Value = i
+	case *cli.DurationFlag:
+		s, err := servorcScalar(value)
+		if err != nil {
+			return err
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("expected a duration such as 30s, got %v", value)
+		}
+		f.Value = d
+	default:
+		return fmt.Errorf("this flag cannot be set in %s", servorcFile)
+	}
+	return nil
+}
+
+// servorcScalar returns a single .servorc value as a string
+func servorcScalar(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case int, float64, bool:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("expected a single value, got %v", value)
+	}
+}
+
+// servorcList returns a .servorc value given as a list or a comma-separated string
+func servorcList(value interface{}) ([]string, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		s, err := servorcScalar(value)
+		if err != nil {
+			return nil, err
+		}
+		var values []string
+		for _, item := range strings.Split(s, ",") {
+			values = append(values, strings.TrimSpace(item))
+		}
+		return values, nil
+	}
+
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		s, err := servorcScalar(item)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, s)
+	}
+	return values, nil
+}
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/mcp"
+	"github.com/urfave/cli/v2"
+)
+
+func TestApplyServorc(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantClients string
+		wantSession string
+		wantWait    bool
+	}{
+		{name: "defaults from servorc", args: []string{"app", "install"}, wantClients: "vscode,cursor", wantSession: "dev", wantWait: true},
+		{name: "explicit flags win", args: []string{"app", "install", "--clients", "claude-code", "--session", "prod", "--wait=false"}, wantClients: "claude-code", wantSession: "prod"},
+		{name: "subcommand flags", args: []string{"app", "session", "show"}, wantSession: "dev"},
+		{name: "matched through an alias", args: []string{"app", "configure"}, wantClients: "vscode,cursor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), servorcFile)
+			os.WriteFile(path, []byte("clients: [vscode, cursor]\nsession: dev\nwait: true\n"), 0644)
+
+			var clients, sessionName string
+			var wait bool
+			action := func(c *cli.Context) error {
+				clients = strings.Join(c.StringSlice("clients"), ",")
+				if c.Command.Name == "configure" {
+					clients = strings.Join(c.StringSlice("client"), ",")
+				}
+				sessionName = c.String("session")
+				wait = c.Bool("wait")
+				return nil
+			}
+			app := &cli.App{
+				Name: "app",
+				Commands: []*cli.Command{
+					{
+						Name: "install",
+						Flags: []cli.Flag{
+							&cli.StringSliceFlag{Name: "clients", Aliases: []string{"c"}},
+							&cli.StringFlag{Name: "session"},
+							&cli.BoolFlag{Name: "wait"},
+						},
+						Action: action,
+					},
+					{
+						Name:   "configure",
+						Flags:  []cli.Flag{&cli.StringSliceFlag{Name: "client", Aliases: []string{"c", "clients"}}},
+						Action: action,
+					},
+					{
+						Name: "session",
+						Subcommands: []*cli.Command{
+							{Name: "show", Flags: []cli.Flag{&cli.StringFlag{Name: "session"}}, Action: action},
+						},
+					},
+				},
+			}
+			app.Before = func(c *cli.Context) error { return applyServorc(path, c.App.Commands) }
+
+			if err := app.Run(tt.args); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if clients != tt.wantClients || sessionName != tt.wantSession || wait != tt.wantWait {
+				t.Errorf("got clients=%q session=%q wait=%v, want clients=%q session=%q wait=%v",
+					clients, sessionName, wait, tt.wantClients, tt.wantSession, tt.wantWait)
+			}
+		})
+	}
+}
+
+func TestApplyServorc_SearchDepth(t *testing.T) {
+	tests := []struct {
+		name    string
+		servorc string
+		args    []string
+		want    int
+		wantErr bool
+	}{
+		{name: "from servorc", servorc: "search-depth: 0\n", args: []string{"app", "install"}, want: 0},
+		{name: "explicit flag wins", servorc: "search-depth: 0\n", args: []string{"app", "install", "--search-depth", "4"}, want: 4},
+		{name: "negative in servorc", servorc: "search-depth: -1\n", args: []string{"app", "install"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), servorcFile)
+			os.WriteFile(path, []byte(tt.servorc), 0644)
+
+			parser := mcp.NewParser()
+			app := &cli.App{
+				Name: "app",
+				Commands: []*cli.Command{
+					{
+						Name:   "install",
+						Flags:  []cli.Flag{&cli.IntFlag{Name: "search-depth", Value: mcp.DefaultManifestSearchDepth}},
+						Action: func(c *cli.Context) error { return setSearchDepth(c, parser) },
+					},
+				},
+			}
+			app.Before = func(c *cli.Context) error { return applyServorc(path, c.App.Commands) }
+
+			err := app.Run(tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected a negative search depth to be rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if parser.SearchDepth == nil || *parser.SearchDepth != tt.want {
+				t.Errorf("SearchDepth = %v, want %d", parser.SearchDepth, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyServorc_Invalid(t *testing.T) {
+	commands := []*cli.Command{{
+		Name: "install",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "session"},
+			&cli.BoolFlag{Name: "wait"},
+			&cli.BoolFlag{Name: "force"},
+		},
+	}}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "not yaml", content: "session: [dev\n", wantErr: "failed to parse"},
+		{name: "unknown flag", content: "sesion: dev\n", wantErr: "--sesion cannot be set here"},
+		{name: "destructive flag", content: "force: true\n", wantErr: "--force cannot be set here"},
+		{name: "flag no command takes", content: "output: json\n", wantErr: "no command takes a --output flag"},
+		{name: "list for single value", content: "session: [dev, prod]\n", wantErr: "session: expected a single value"},
+		{name: "wrong bool", content: "wait: sometimes\n", wantErr: "wait: expected true or false"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), servorcFile)
+			os.WriteFile(path, []byte(tt.content), 0644)
+
+			err := applyServorc(path, commands)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.Contains(err.Error(), servorcFile) {
+				t.Errorf("applyServorc() error = %v, want %q naming %s", err, tt.wantErr, servorcFile)
+			}
+		})
+	}
+
+	if err := applyServorc(filepath.Join(t.TempDir(), servorcFile), commands); err != nil {
+		t.Errorf("Expected a missing %s to be ignored, got %v", servorcFile, err)
+	}
+}
+
+func TestApp_InvalidServorc(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+	os.WriteFile(servorcFile, []byte("clients: {vscode\n"), 0644)
+
+	app, err := NewApp("test-version")
+	if err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+	app.Writer = &strings.Builder{}
+	err = app.Run([]string{"servo", "completion", "bash"})
+	if err == nil || !strings.Contains(err.Error(), "failed to parse .servorc") {
+		t.Errorf("Expected a .servorc parse error, got %v", err)
+	}
+}
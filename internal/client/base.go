@@ -6,8 +6,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/servo/servo/internal/logging"
 	"github.com/servo/servo/internal/utils"
 	"github.com/servo/servo/pkg"
 )
@@ -83,20 +85,33 @@ func ReadJSONFile(path string, v interface{}) error {
 	return json.Unmarshal(data, v)
 }
 
+// RenderJSON encodes data exactly as WriteJSONFile writes it
+func RenderJSON(v interface{}) ([]byte, error) {
+	return json.MarshalIndent(v, "", "  ")
+}
+
 // WriteJSONFile writes data to a JSON file
 func WriteJSONFile(path string, v interface{}) error {
-	data, err := json.MarshalIndent(v, "", "  ")
+	data, err := RenderJSON(v)
 	if err != nil {
 		return err
 	}
+	return writeRendered(path, data)
+}
 
+// writeRendered writes already-rendered JSON to path
+func writeRendered(path string, data []byte) error {
 	// Ensure directory exists
 	dir := filepath.Dir(path)
 	if err := EnsureDirectory(dir); err != nil {
 		return err
 	}
 
-	return os.WriteFile(path, data, 0644)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	logging.Info("wrote file", "path", path)
+	return nil
 }
 
 // MergeServerConfigs merges server configurations
@@ -146,6 +161,58 @@ func ValidateJSONConfig(path string) error {
 	return ReadJSONFile(path, &config)
 }
 
+// WriteGeneratedConfig renders a client configuration and checks it with
+// CheckServersJSON before writing it, so a generator regression fails generation
+// without replacing the file on disk
+func WriteGeneratedConfig(path string, v interface{}, serversKey string) error {
+	data, err := RenderJSON(v)
+	if err != nil {
+		return err
+	}
+	if err := CheckServersJSON(data, serversKey); err != nil {
+		return fmt.Errorf("generated %s is not a valid client config: %w", path, err)
+	}
+	return writeRendered(path, data)
+}
+
+// CheckServersJSON checks that data is a JSON object whose serversKey holds an object
+// of servers, each an object with a command (stdio) or a url (http/sse)
+func CheckServersJSON(data []byte, serversKey string) error {
+	var config map[string]json.RawMessage
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("not a JSON object: %w", err)
+	}
+
+	raw, ok := config[serversKey]
+	if !ok {
+		return fmt.Errorf("missing required key %q", serversKey)
+	}
+	var servers map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &servers); err != nil || servers == nil {
+		return fmt.Errorf("%q must be an object of servers", serversKey)
+	}
+
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var server struct {
+			Command string `json:"command"`
+			URL     string `json:"url"`
+		}
+		if err := json.Unmarshal(servers[name], &server); err != nil {
+			return fmt.Errorf("%s.%s must be an object with a command or url", serversKey, name)
+		}
+		if server.Command == "" && server.URL == "" {
+			return fmt.Errorf("%s.%s has neither a command nor a url", serversKey, name)
+		}
+	}
+	return nil
+}
+
 // BackupConfigFile creates a backup of a configuration file
 func BackupConfigFile(path string) error {
 	if !FileExists(path) {
@@ -219,3 +286,59 @@ func ExpandSecretsInString(value string, secretsProvider func(string) (string, e
 
 	return result
 }
+
+// BuildMCPServerConfig converts a manifest's server section into a client server entry.
+// Remote transports (http/sse) produce a url entry, stdio produces a command entry.
+// The boolean result is false when the manifest has nothing a client can connect to.
+func BuildMCPServerConfig(manifest pkg.ServoDefinition, secretsProvider func(string) (string, error)) (pkg.MCPServerConfig, bool) {
+	server := manifest.Server
+
+	if server.IsRemote() {
+		if server.URL == "" {
+			return pkg.MCPServerConfig{}, false
+		}
+
+		serverConfig := pkg.MCPServerConfig{
+			Type: server.Transport,
+			URL:  ExpandSecretsInString(server.URL, secretsProvider),
+		}
+		if len(server.Headers) > 0 {
+			serverConfig.Headers = make(map[string]string)
+			for key, value := range server.Headers {
+				serverConfig.Headers[key] = ExpandSecretsInString(value, secretsProvider)
+			}
+		}
+		return serverConfig, true
+	}
+
+	if server.Command == "" {
+		return pkg.MCPServerConfig{}, false
+	}
+
+	// Clients take argv, so a command string like "go run main.go" is split
+	// rather than passed through as a single executable name
+	argv, err := server.Argv()
+	if err != nil {
+		argv = append([]string{server.Command}, server.Args...)
+	}
+
+	serverConfig := pkg.MCPServerConfig{
+		Command: argv[0],
+		Args:    make([]string, len(argv)-1),
+	}
+
+	// Copy and expand args
+	for i, arg := range argv[1:] {
+		serverConfig.Args[i] = ExpandSecretsInString(arg, secretsProvider)
+	}
+
+	// Build environment with secret expansion
+	if len(server.Environment) > 0 {
+		serverConfig.Environment = make(map[string]string)
+		for key, value := range server.Environment {
+			serverConfig.Environment[key] = ExpandSecretsInString(value, secretsProvider)
+		}
+	}
+
+	return serverConfig, true
+}
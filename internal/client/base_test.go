@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/servo/servo/internal/utils"
@@ -280,3 +281,58 @@ func TestMergeServerConfigs(t *testing.T) {
 		t.Errorf("expected server2 command 'new-command', got '%s'", result["server2"].Command)
 	}
 }
+
+func TestCheckServersJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "stdio and remote servers", data: `{"servers": {"a": {"command": "npx"}, "b": {"type": "http", "url": "https://example.com/mcp"}}}`},
+		{name: "no servers", data: `{"servers": {}}`},
+		{name: "extra top-level keys", data: `{"inputs": [], "servers": {}}`},
+		{name: "not JSON", data: `{"servers": `, wantErr: "not a JSON object"},
+		{name: "array", data: `[]`, wantErr: "not a JSON object"},
+		{name: "wrong key", data: `{"mcpServers": {}}`, wantErr: `missing required key "servers"`},
+		{name: "servers not an object", data: `{"servers": ["a"]}`, wantErr: `"servers" must be an object of servers`},
+		{name: "null servers", data: `{"servers": null}`, wantErr: `"servers" must be an object of servers`},
+		{name: "server not an object", data: `{"servers": {"a": "npx"}}`, wantErr: "servers.a must be an object with a command or url"},
+		{name: "server without command or url", data: `{"servers": {"a": {"command": "npx"}, "b": {"args": ["x"]}}}`, wantErr: "servers.b has neither a command nor a url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckServersJSON([]byte(tt.data), "servers")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckServersJSON() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckServersJSON() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteGeneratedConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mcp.json")
+	os.WriteFile(path, []byte(`{"mcpServers": {}}`), 0644)
+
+	err := WriteGeneratedConfig(path, map[string]interface{}{"servers": map[string]interface{}{}}, "mcpServers")
+	if err == nil || !strings.Contains(err.Error(), "is not a valid client config: missing required key \"mcpServers\"") {
+		t.Errorf("Expected a clear error naming the file and missing key, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != `{"mcpServers": {}}` {
+		t.Errorf("Expected a config that fails the check to leave the file alone, got %s", data)
+	}
+
+	config := map[string]interface{}{"mcpServers": map[string]interface{}{"a": map[string]string{"command": "npx"}}}
+	if err := WriteGeneratedConfig(path, config, "mcpServers"); err != nil {
+		t.Fatalf("WriteGeneratedConfig() error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), `"command": "npx"`) {
+		t.Errorf("Expected the config to be written, got %s", data)
+	}
+}
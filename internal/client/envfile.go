@@ -0,0 +1,112 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/servo/servo/internal/utils"
+	"github.com/servo/servo/pkg"
+)
+
+// SecretEnvReferences returns the environment variables of a stdio server whose
+// manifest values reference secrets, such as API_KEY: ${api_key}. The raw manifest
+// values are returned, so no secret is ever expanded.
+func SecretEnvReferences(manifest pkg.ServoDefinition) map[string]string {
+	if manifest.Server.IsRemote() {
+		return nil
+	}
+
+	refs := make(map[string]string)
+	for key, value := range manifest.Server.Environment {
+		if strings.Contains(value, "${") {
+			refs[key] = value
+		}
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+	return refs
+}
+
+// MoveSecretEnvToFile strips the secret-referencing variables from each server entry
+// and points the entry at envFileRef instead. It returns the moved variables keyed
+// by server name, ready for WriteEnvFile.
+func MoveSecretEnvToFile(servers map[string]pkg.MCPServerConfig, manifests []pkg.ServoDefinition, envFileRef string) map[string]map[string]string {
+	moved := make(map[string]map[string]string)
+	for _, manifest := range manifests {
+		server, ok := servers[manifest.Name]
+		refs := SecretEnvReferences(manifest)
+		if !ok || len(refs) == 0 {
+			continue
+		}
+
+		for key := range refs {
+			delete(server.Environment, key)
+		}
+		if len(server.Environment) == 0 {
+			server.Environment = nil
+		}
+		server.EnvFile = envFileRef
+		servers[manifest.Name] = server
+		moved[manifest.Name] = refs
+	}
+	return moved
+}
+
+// RenderEnvFile formats secret references as a .env file, grouped by server
+func RenderEnvFile(refs map[string]map[string]string) []byte {
+	var b strings.Builder
+	b.WriteString("# Generated by servo. Secret references only; values are never written here.\n")
+
+	serverNames := make([]string, 0, len(refs))
+	for name := range refs {
+		serverNames = append(serverNames, name)
+	}
+	sort.Strings(serverNames)
+
+	for _, serverName := range serverNames {
+		keys := make([]string, 0, len(refs[serverName]))
+		for key := range refs[serverName] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		fmt.Fprintf(&b, "\n# %s\n", serverName)
+		for _, key := range keys {
+			fmt.Fprintf(&b, "%s=%s\n", key, refs[serverName][key])
+		}
+	}
+	return []byte(b.String())
+}
+
+// WriteEnvFile writes the secret references to path, removing a previously generated
+// file when no server needs one
+func WriteEnvFile(path string, refs map[string]map[string]string) error {
+	if len(refs) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		return nil
+	}
+	return utils.WriteFileWithDir(path, RenderEnvFile(refs), 0644)
+}
+
+// WorkspaceFileRef returns how a client config refers to a file: paths inside the
+// current directory are anchored at ${workspaceFolder}, others are used as they are
+func WorkspaceFileRef(path string) string {
+	if filepath.IsAbs(path) {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return filepath.ToSlash(path)
+		}
+		rel, err := filepath.Rel(cwd, path)
+		if err != nil || !filepath.IsLocal(rel) {
+			return filepath.ToSlash(path)
+		}
+		path = rel
+	}
+	return "${workspaceFolder}/" + filepath.ToSlash(filepath.Clean(path))
+}
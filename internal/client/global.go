@@ -0,0 +1,75 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/servo/servo/internal/logging"
+	"github.com/servo/servo/internal/utils"
+	"github.com/servo/servo/pkg"
+)
+
+// BuildServers converts manifests into client server entries keyed by manifest name,
+// skipping manifests with nothing a client can connect to
+func BuildServers(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) map[string]pkg.MCPServerConfig {
+	servers := make(map[string]pkg.MCPServerConfig)
+	for _, manifest := range manifests {
+		if serverConfig, ok := BuildMCPServerConfig(manifest, secretsProvider); ok {
+			servers[manifest.Name] = serverConfig
+		}
+	}
+	return servers
+}
+
+// MergeServersIntoFile sets servers under key in the JSON object at path. Entries of
+// other names and every other top-level key are kept as they are, so a user-level
+// config shared with other tools survives the merge. A missing file starts empty.
+// An existing file is backed up to <path>.backup and replaced atomically, keeping its
+// permissions, so a failed write never leaves it truncated.
+func MergeServersIntoFile(path, key string, servers map[string]pkg.MCPServerConfig) error {
+	config := make(map[string]interface{})
+	perm := os.FileMode(0644)
+	if FileExists(path) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		perm = info.Mode().Perm()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &config); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+		}
+	}
+
+	entries := make(map[string]interface{})
+	if existing, ok := config[key]; ok && existing != nil {
+		existingEntries, ok := existing.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: '%s' is not an object", path, key)
+		}
+		entries = existingEntries
+	}
+	for name, server := range servers {
+		entries[name] = server
+	}
+	config[key] = entries
+
+	data, err := RenderJSON(config)
+	if err != nil {
+		return err
+	}
+	if err := BackupConfigFile(path); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	if err := utils.WriteFileAtomic(path, data, perm); err != nil {
+		return err
+	}
+	logging.Info("wrote file", "path", path)
+	return nil
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/servo/servo/pkg"
+)
+
+func TestMergeServersIntoFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user", "mcp.json")
+	servers := map[string]pkg.MCPServerConfig{"notes": {Command: "notes"}}
+
+	// A missing file is created
+	if err := MergeServersIntoFile(path, "servers", servers); err != nil {
+		t.Fatalf("MergeServersIntoFile() error = %v", err)
+	}
+
+	original := `{"inputs": [{"id": "key"}], "servers": {"manual": {"command": "manual"}, "notes": {"command": "old"}}}`
+	os.WriteFile(path, []byte(original), 0600)
+	os.Chmod(path, 0600)
+	if err := MergeServersIntoFile(path, "servers", servers); err != nil {
+		t.Fatalf("MergeServersIntoFile() error = %v", err)
+	}
+
+	// The file it replaced is backed up, and its permissions are kept
+	if backup, _ := os.ReadFile(path + ".backup"); string(backup) != original {
+		t.Errorf("backup = %q, want %q", backup, original)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the merged file to keep mode 0600, got %v (%v)", info.Mode().Perm(), err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 2 {
+		t.Errorf("Expected only the file and its backup, got %v", entries)
+	}
+
+	var config struct {
+		Inputs  []map[string]string            `json:"inputs"`
+		Servers map[string]pkg.MCPServerConfig `json:"servers"`
+	}
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatalf("Failed to parse merged file: %v", err)
+	}
+	if len(config.Inputs) != 1 || config.Servers["manual"].Command != "manual" || config.Servers["notes"].Command != "notes" {
+		t.Errorf("merged file = %s", data)
+	}
+
+	os.WriteFile(path, []byte(`{"servers": ["not", "an", "object"]}`), 0644)
+	if err := MergeServersIntoFile(path, "servers", servers); err == nil {
+		t.Error("MergeServersIntoFile() should refuse a key that is not an object")
+	}
+}
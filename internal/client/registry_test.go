@@ -44,6 +44,9 @@ func (m *MockClient) ListServers(scope string) ([]string, error)         { retur
 func (m *MockClient) GenerateConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) error {
 	return nil
 }
+func (m *MockClient) RenderConfig(manifests []pkg.ServoDefinition, secretsProvider func(string) (string, error)) ([]byte, error) {
+	return []byte("{}"), nil
+}
 
 func TestRegistry_Register(t *testing.T) {
 	registry := NewRegistry()
@@ -3,7 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/servo/servo/internal/manifest"
@@ -29,6 +31,12 @@ type BaseGenerator struct {
 	sessionManager  *session.Manager
 	overrideManager *override.Manager
 	servoDir        string
+	outputRoot      string
+	sessionName     string
+	written         []string
+
+	skipSecretValidation bool
+	missingSecrets       []string
 }
 
 // NewBaseGenerator creates a new base generator
@@ -43,24 +51,29 @@ func NewBaseGenerator(servoDir string) *BaseGenerator {
 	}
 }
 
-// GetActiveSessionData returns project, active session, and manifests
+// GetActiveSessionData returns project, active session, and manifests. The session
+// chosen with SetSession is used in place of the active one when set.
 func (g *BaseGenerator) GetActiveSessionData() (*project.Project, *session.Session, map[string]*pkg.ServoDefinition, error) {
 	project, err := g.projectManager.Get()
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
-	activeSession, err := g.sessionManager.GetActive()
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to get active session: %w", err)
-	}
-
-	if activeSession == nil {
-		return nil, nil, nil, fmt.Errorf("no active session found")
+	var activeSession *session.Session
+	if g.sessionName != "" {
+		activeSession, err = g.sessionManager.Get(g.sessionName)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to get session %s: %w", g.sessionName, err)
+		}
+	} else {
+		activeSession, err = g.sessionManager.RequireActive()
+		if err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
 	// Get manifests from session
-	store := manifest.NewStore(g.sessionManager.GetSessionDir(activeSession.Name), nil)
+	store := manifest.NewStore(g.sessionManager.ManifestsDir(activeSession.Name), nil)
 	manifests, err := store.ListManifests()
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to list manifests: %w", err)
@@ -69,11 +82,155 @@ func (g *BaseGenerator) GetActiveSessionData() (*project.Project, *session.Sessi
 	return project, activeSession, manifests, nil
 }
 
-// SetupOverrideManager updates the override manager with session directory
+// SetSession makes the generator read the named session instead of the active one.
+// Empty restores the active session.
+func (g *BaseGenerator) SetSession(name string) {
+	g.sessionName = name
+}
+
+// SetupOverrideManager updates the override manager with session directory and
+// the environment selected through SERVO_ENV
 func (g *BaseGenerator) SetupOverrideManager(sessionName string) {
 	sessionDir := g.sessionManager.GetSessionDir(sessionName)
 	projectDir, _ := os.Getwd()
 	g.overrideManager = override.NewManager(sessionDir, projectDir)
+	g.overrideManager.SetEnvironment(os.Getenv("SERVO_ENV"))
+}
+
+// ResolveActiveProfiles returns the compose profiles to start, preferring the session's list over the project's
+func (g *BaseGenerator) ResolveActiveProfiles(project *project.Project, activeSession *session.Session) []string {
+	if activeSession != nil && len(activeSession.Profiles) > 0 {
+		return activeSession.Profiles
+	}
+	if project != nil {
+		return project.Config.Profiles
+	}
+	return nil
+}
+
+// SetOutputRoot makes the generator write its files under root instead of the
+// current directory
+func (g *BaseGenerator) SetOutputRoot(root string) {
+	g.outputRoot = root
+}
+
+// SetSkipSecretValidation lets Generate proceed while required secrets are missing,
+// leaving their placeholders unresolved and marking the output as incomplete
+func (g *BaseGenerator) SetSkipSecretValidation(skip bool) {
+	g.skipSecretValidation = skip
+}
+
+// MissingSecrets returns, sorted, the required secrets the most recent Generate call
+// went ahead without. It is only ever non-empty when secret validation is skipped.
+func (g *BaseGenerator) MissingSecrets() []string {
+	return append([]string(nil), g.missingSecrets...)
+}
+
+// markIncomplete records the missing secrets in the generated config under
+// IncompleteMarkerKey, so a skipped secret check is visible in the output itself
+func (g *BaseGenerator) markIncomplete(config map[string]interface{}) {
+	if len(g.missingSecrets) == 0 {
+		return
+	}
+	config[IncompleteMarkerKey] = fmt.Sprintf("incomplete: generated with --skip-secret-validation; required secrets not configured: %s", strings.Join(g.missingSecrets, ", "))
+}
+
+// WrittenFiles returns the paths written by the most recent Generate call
+func (g *BaseGenerator) WrittenFiles() []string {
+	return append([]string(nil), g.written...)
+}
+
+// outputPath resolves a project-relative output path against the output root
+func (g *BaseGenerator) outputPath(rel string) string {
+	return filepath.Join(g.outputRoot, rel)
+}
+
+// DefaultVolumeRoot is where named service volumes persist, relative to the project root
+const DefaultVolumeRoot = ".servo/services"
+
+// ResolveVolumeRoot returns the project's configured volume root, or DefaultVolumeRoot
+func (g *BaseGenerator) ResolveVolumeRoot(project *project.Project) string {
+	if project != nil && strings.TrimSpace(project.Config.VolumeRoot) != "" {
+		return strings.TrimSpace(project.Config.VolumeRoot)
+	}
+	return DefaultVolumeRoot
+}
+
+// hostUserIDs reports the user and group IDs of the user running servo
+var hostUserIDs = func() (int, int) {
+	return os.Getuid(), os.Getgid()
+}
+
+// ResolveHostUser returns the host "uid:gid" that services writing to bind-mounted
+// volumes should run as, or "" unless the project sets config.host_user_ids. Hosts
+// without numeric IDs, such as Windows, also return "".
+func (g *BaseGenerator) ResolveHostUser(project *project.Project) string {
+	if project == nil || !project.Config.HostUserIDs {
+		return ""
+	}
+	uid, gid := hostUserIDs()
+	if uid < 0 || gid < 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", uid, gid)
+}
+
+// composeVolumeRoot returns the volume root as seen from .devcontainer/docker-compose.yml
+func composeVolumeRoot(volumeRoot string) string {
+	if filepath.IsAbs(volumeRoot) {
+		return filepath.ToSlash(filepath.Clean(volumeRoot))
+	}
+	return path.Join("..", filepath.ToSlash(volumeRoot))
+}
+
+// workspaceVolumeRoot returns the volume root inside the workspace container, whose
+// project directory is projectDir (see WorkspaceProjectDir). Absolute roots, and any
+// root of a project outside the workspace mount, have no path inside the container,
+// so "" is returned for them.
+func workspaceVolumeRoot(projectDir, volumeRoot string) string {
+	if filepath.IsAbs(volumeRoot) || projectDir == "" {
+		return ""
+	}
+	return path.Join(projectDir, filepath.ToSlash(volumeRoot))
+}
+
+// LogsRoot is where service log volumes persist, relative to the project root
+const LogsRoot = ".servo/logs"
+
+// isLogVolume reports whether a service volume is a named volume mounted at a log
+// directory: /var/log, anything below it, or a path ending in /logs. Log volumes
+// persist under LogsRoot/<manifest>/<service>/<logVolumeDir> rather than the volume
+// root.
+func isLogVolume(volume string) bool {
+	parts := strings.Split(volume, ":")
+	if len(parts) < 2 {
+		return false
+	}
+	if strings.HasPrefix(parts[0], "/") || strings.HasPrefix(parts[0], ".") {
+		return false
+	}
+
+	target := path.Clean(parts[1])
+	return target == "/var/log" || strings.HasPrefix(target, "/var/log/") || path.Base(target) == "logs"
+}
+
+// logVolumeDir names the directory a log volume persists in below its service's logs
+// directory: the container path with slashes turned into dashes, so each log mount
+// of a service gets its own directory (/var/log/nginx becomes var-log-nginx)
+func logVolumeDir(volume string) string {
+	target := path.Clean(strings.Split(volume, ":")[1])
+	return strings.ReplaceAll(strings.Trim(target, "/"), "/", "-")
+}
+
+// logVolumeDirs returns the logVolumeDir of each of a service's log volumes
+func logVolumeDirs(service *pkg.ServiceDependency) []string {
+	var dirs []string
+	for _, volume := range service.Volumes {
+		if isLogVolume(volume) {
+			dirs = append(dirs, logVolumeDir(volume))
+		}
+	}
+	return dirs
 }
 
 // ValidateSecretsBeforeGeneration ensures all required secrets are configured before generation.
@@ -81,10 +238,14 @@ func (g *BaseGenerator) SetupOverrideManager(sessionName string) {
 // This validation prevents configuration generation with missing secrets, which would
 // result in broken MCP server configurations. The method scans all manifests for
 // required secret dependencies and cross-references them with the project's configured
-// secrets to identify any missing dependencies.
+// secrets to identify any missing dependencies. Secrets resolve through the session's
+// namespace first, then the project-global secrets.
 //
-// Returns an error if any required secrets are missing from the project configuration.
-func (g *BaseGenerator) ValidateSecretsBeforeGeneration(project *project.Project, manifests map[string]*pkg.ServoDefinition) error {
+// Returns an error if any required secrets are missing from the project configuration,
+// unless secret validation is skipped, in which case the missing secrets are recorded
+// for MissingSecrets instead.
+func (g *BaseGenerator) ValidateSecretsBeforeGeneration(project *project.Project, sessionName string, manifests map[string]*pkg.ServoDefinition) error {
+	g.missingSecrets = nil
 
 	// Scan all manifests to build a comprehensive list of required secrets
 	// This ensures we validate against the complete secret dependency graph
@@ -105,7 +266,7 @@ func (g *BaseGenerator) ValidateSecretsBeforeGeneration(project *project.Project
 	}
 
 	// Get configured secrets
-	configuredSecrets, err := g.projectManager.GetConfiguredSecrets()
+	configuredSecrets, err := g.projectManager.GetConfiguredSecrets(sessionName)
 	if err != nil {
 		return fmt.Errorf("failed to get configured secrets: %w", err)
 	}
@@ -118,17 +279,23 @@ func (g *BaseGenerator) ValidateSecretsBeforeGeneration(project *project.Project
 		}
 	}
 
+	sort.Strings(missingSecrets)
+
 	if len(missingSecrets) > 0 {
+		if g.skipSecretValidation {
+			g.missingSecrets = missingSecrets
+			return nil
+		}
 		return fmt.Errorf("required secrets not configured: %v. Set them using: servo secrets set <name> <value>", missingSecrets)
 	}
 
 	return nil
 }
 
-// CreateSecretProvider creates a function to retrieve secrets for template expansion
-func (g *BaseGenerator) CreateSecretProvider(project *project.Project) func(string) (string, error) {
+// CreateSecretProvider creates a function to retrieve a session's secrets for template expansion
+func (g *BaseGenerator) CreateSecretProvider(project *project.Project, sessionName string) func(string) (string, error) {
 	// Get the configured secrets map
-	configuredSecrets, err := g.projectManager.GetConfiguredSecrets()
+	configuredSecrets, err := g.projectManager.GetConfiguredSecrets(sessionName)
 	if err != nil {
 		// Return a provider that always fails if we can't get configured secrets
 		return func(secretName string) (string, error) {
@@ -186,26 +353,9 @@ func (g *BaseGenerator) extractRequiredSecretsFromManifests(manifests map[string
 			}
 		}
 
-		// Extract from service definitions - check both Dependencies.Services and direct Services
-		var servicesToCheck map[string]*pkg.ServiceDependency
-		if manifest.Dependencies != nil && manifest.Dependencies.Services != nil {
-			servicesToCheck = make(map[string]*pkg.ServiceDependency)
-			for name, service := range manifest.Dependencies.Services {
-				servicesToCheck[name] = &service
-			}
-		}
-		if manifest.Services != nil {
-			if servicesToCheck == nil {
-				servicesToCheck = manifest.Services
-			} else {
-				// Merge both if present
-				for name, service := range manifest.Services {
-					servicesToCheck[name] = service
-				}
-			}
-		}
-
-		if servicesToCheck != nil {
+		// Extract from service definitions in dependencies.services and services
+		servicesToCheck := manifest.AllServices()
+		if len(servicesToCheck) > 0 {
 			for _, service := range servicesToCheck {
 				if service != nil && service.Environment != nil {
 					for _, envValue := range service.Environment {
@@ -239,6 +389,22 @@ func (g *BaseGenerator) extractRequiredSecretsFromManifests(manifests map[string
 // serviceNeedsSecrets checks if a service configuration needs secrets injection
 func (g *BaseGenerator) serviceNeedsSecrets(serviceConfig map[string]interface{}, availableSecrets map[string]bool) []string {
 	var neededSecrets []string
+	secretsSet := g.envSecretReferences(serviceConfig)
+	for secretName := range g.labelSecrets(serviceConfig) {
+		secretsSet[secretName] = true
+	}
+
+	// Convert set to slice
+	for secretName := range secretsSet {
+		neededSecrets = append(neededSecrets, secretName)
+	}
+
+	return neededSecrets
+}
+
+// envSecretReferences returns the secrets a service's environment names as a whole
+// ${name} value or a /run/secrets/name path
+func (g *BaseGenerator) envSecretReferences(serviceConfig map[string]interface{}) map[string]bool {
 	secretsSet := make(map[string]bool)
 
 	// Check environment section - handle both map and array formats
@@ -275,6 +441,13 @@ func (g *BaseGenerator) serviceNeedsSecrets(serviceConfig map[string]interface{}
 		}
 	}
 
+	return secretsSet
+}
+
+// labelSecrets returns the secrets a service declares in its servo.secrets label
+func (g *BaseGenerator) labelSecrets(serviceConfig map[string]interface{}) map[string]bool {
+	secretsSet := make(map[string]bool)
+
 	// Check labels for explicit secret declarations
 	if labels, ok := serviceConfig["labels"].(map[string]interface{}); ok {
 		if secretsLabel, ok := labels["servo.secrets"].(string); ok {
@@ -298,12 +471,7 @@ func (g *BaseGenerator) serviceNeedsSecrets(serviceConfig map[string]interface{}
 		}
 	}
 
-	// Convert set to slice
-	for secretName := range secretsSet {
-		neededSecrets = append(neededSecrets, secretName)
-	}
-
-	return neededSecrets
+	return secretsSet
 }
 
 // injectSecretsForTesting is a helper method for testing secrets injection
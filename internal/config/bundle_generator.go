@@ -0,0 +1,106 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/servo/servo/internal/client"
+)
+
+// BundleFileName is the consolidated server list written by the bundle generator
+const BundleFileName = "servers.json"
+
+// BundleServer is one server in servers.json, described independently of any client
+type BundleServer struct {
+	Name        string            `json:"name"`
+	Transport   string            `json:"transport"`
+	Command     string            `json:"command,omitempty"`
+	Args        []string          `json:"args,omitempty"`
+	Environment map[string]string `json:"env,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+}
+
+// Bundle is the document written to servers.json
+type Bundle struct {
+	Servers []BundleServer `json:"servers"`
+}
+
+// BundleGenerator writes every active-session server to a client-neutral servers.json.
+// It only writes when the project opts in with config.bundle.
+type BundleGenerator struct {
+	*BaseGenerator
+}
+
+// NewBundleGenerator creates a new bundle generator
+func NewBundleGenerator(servoDir string) *BundleGenerator {
+	return &BundleGenerator{
+		BaseGenerator: NewBaseGenerator(servoDir),
+	}
+}
+
+// Name returns the generator's registration name
+func (g *BundleGenerator) Name() string {
+	return BundleGeneratorName
+}
+
+// Generate writes servers.json at the project root when config.bundle is enabled
+func (g *BundleGenerator) Generate() error {
+	g.written = nil
+
+	proj, err := g.projectManager.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	if !proj.Config.Bundle {
+		return nil
+	}
+
+	project, activeSession, manifests, err := g.GetActiveSessionData()
+	if err != nil {
+		return err
+	}
+
+	// Placeholders such as ${API_KEY} are kept exactly as client configs keep them
+	secretsProvider := g.CreateSecretProvider(project, activeSession.Name)
+
+	bundle := Bundle{Servers: []BundleServer{}}
+	for _, manifest := range manifests {
+		serverConfig, ok := client.BuildMCPServerConfig(*manifest, secretsProvider)
+		if !ok {
+			continue
+		}
+
+		transport := manifest.Server.Transport
+		if transport == "" {
+			transport = "stdio"
+		}
+		bundle.Servers = append(bundle.Servers, BundleServer{
+			Name:        manifest.Name,
+			Transport:   transport,
+			Command:     serverConfig.Command,
+			Args:        serverConfig.Args,
+			Environment: serverConfig.Environment,
+			URL:         serverConfig.URL,
+			Headers:     serverConfig.Headers,
+		})
+	}
+	sort.Slice(bundle.Servers, func(i, j int) bool {
+		return bundle.Servers[i].Name < bundle.Servers[j].Name
+	})
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal server bundle: %w", err)
+	}
+
+	bundlePath := g.outputPath(BundleFileName)
+	if err := os.WriteFile(bundlePath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", BundleFileName, err)
+	}
+	g.written = append(g.written, bundlePath)
+
+	return nil
+}
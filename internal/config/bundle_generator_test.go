@@ -0,0 +1,103 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/servo/servo/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBundleGenerator_Generate(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+
+	manifests := map[string]string{
+		"search.servo": `servo_version: "1.0"
+name: search
+server:
+  transport: stdio
+  command: npx -y search-server
+  environment:
+    SEARCH_API_KEY: "${SEARCH_API_KEY}"
+`,
+		"remote.servo": `servo_version: "1.0"
+name: remote
+server:
+  transport: http
+  url: https://mcp.example.com/mcp
+  headers:
+    Authorization: "Bearer ${REMOTE_TOKEN}"
+`,
+	}
+	for file, content := range manifests {
+		if err := os.WriteFile(testManifestPath(file), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write manifest: %v", err)
+		}
+	}
+
+	// Without config.bundle nothing is written
+	generator := NewBundleGenerator(".servo")
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, err := os.Stat(BundleFileName); !os.IsNotExist(err) {
+		t.Fatalf("Expected no %s without config.bundle", BundleFileName)
+	}
+
+	proj := &project.Project{
+		Clients:        []string{"vscode"},
+		DefaultSession: "test",
+		ActiveSession:  "test",
+		Config:         project.ProjectConfig{Bundle: true},
+	}
+	projectData, _ := yaml.Marshal(proj)
+	if err := os.WriteFile(".servo/project.yaml", projectData, 0644); err != nil {
+		t.Fatalf("Failed to write project.yaml: %v", err)
+	}
+
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if written := generator.WrittenFiles(); len(written) != 1 || written[0] != BundleFileName {
+		t.Errorf("WrittenFiles() = %v, want [%s]", written, BundleFileName)
+	}
+
+	data, err := os.ReadFile(BundleFileName)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", BundleFileName, err)
+	}
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		t.Fatalf("Failed to parse %s: %v", BundleFileName, err)
+	}
+
+	if len(bundle.Servers) != 2 {
+		t.Fatalf("Expected 2 servers, got %+v", bundle.Servers)
+	}
+	remote, search := bundle.Servers[0], bundle.Servers[1]
+	if remote.Name != "remote" || remote.Transport != "http" || remote.URL != "https://mcp.example.com/mcp" || remote.Command != "" {
+		t.Errorf("Unexpected remote entry: %+v", remote)
+	}
+	if remote.Headers["Authorization"] != "Bearer ${REMOTE_TOKEN}" {
+		t.Errorf("Expected header secret placeholder to be kept, got %q", remote.Headers["Authorization"])
+	}
+	if search.Name != "search" || search.Transport != "stdio" || search.Command != "npx" || len(search.Args) != 2 || search.Args[1] != "search-server" {
+		t.Errorf("Unexpected search entry: %+v", search)
+	}
+	if search.Environment["SEARCH_API_KEY"] != "${SEARCH_API_KEY}" {
+		t.Errorf("Expected env secret placeholder to be kept, got %q", search.Environment["SEARCH_API_KEY"])
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
 	"github.com/servo/servo/pkg"
 	"gopkg.in/yaml.v3"
 )
@@ -171,14 +172,132 @@ func TestDevcontainerGeneration_EmptyManifests(t *testing.T) {
 	verifyMinimalDevcontainer(t)
 }
 
+func TestDevcontainerGeneration_LifecycleCommands(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+
+	generate := func(config project.ProjectConfig) map[string]interface{} {
+		proj := &project.Project{
+			Clients:        []string{"vscode"},
+			DefaultSession: "test",
+			ActiveSession:  "test",
+			Config:         config,
+		}
+		projectData, _ := yaml.Marshal(proj)
+		os.WriteFile(".servo/project.yaml", projectData, 0644)
+
+		if err := NewConfigGeneratorManager(".servo").GenerateDevcontainer(); err != nil {
+			t.Fatalf("Failed to generate devcontainer: %v", err)
+		}
+		var devcontainer map[string]interface{}
+		data, _ := os.ReadFile(".devcontainer/devcontainer.json")
+		if err := json.Unmarshal(data, &devcontainer); err != nil {
+			t.Fatalf("Failed to parse devcontainer.json: %v", err)
+		}
+		return devcontainer
+	}
+
+	defaults := generate(project.ProjectConfig{})
+	if _, ok := defaults["postCreateCommand"]; ok {
+		t.Errorf("Expected no postCreateCommand without config, got %v", defaults["postCreateCommand"])
+	}
+	generatedStart, _ := defaults["postStartCommand"].(string)
+
+	custom := generate(project.ProjectConfig{
+		PostCreateCommand: "pip install -r requirements-dev.txt",
+		PostStartCommand:  "make dev-services",
+	})
+	if got := custom["postCreateCommand"]; got != "pip install -r requirements-dev.txt" {
+		t.Errorf("postCreateCommand = %v", got)
+	}
+	if got := custom["postStartCommand"]; got != generatedStart+"; make dev-services" {
+		t.Errorf("postStartCommand = %v, want servo's command followed by the project command", got)
+	}
+	if custom["onCreateCommand"] != defaults["onCreateCommand"] {
+		t.Errorf("Expected onCreateCommand to be unaffected")
+	}
+}
+
+func TestDevcontainerGeneration_CustomName(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+
+	writeProject := func(name string) {
+		proj := &project.Project{
+			Clients:        []string{"vscode"},
+			DefaultSession: "test",
+			ActiveSession:  "test",
+			Config:         project.ProjectConfig{DevcontainerName: name},
+		}
+		projectData, _ := yaml.Marshal(proj)
+		os.WriteFile(".servo/project.yaml", projectData, 0644)
+	}
+	readName := func() interface{} {
+		var devcontainer map[string]interface{}
+		data, _ := os.ReadFile(".devcontainer/devcontainer.json")
+		if err := json.Unmarshal(data, &devcontainer); err != nil {
+			t.Fatalf("Failed to parse devcontainer.json: %v", err)
+		}
+		return devcontainer["name"]
+	}
+
+	manager := NewConfigGeneratorManager(".servo")
+	if err := manager.GenerateDevcontainer(); err != nil {
+		t.Fatalf("Failed to generate devcontainer: %v", err)
+	}
+	if got := readName(); got != "Servo Development Environment" {
+		t.Errorf("Expected default name, got %v", got)
+	}
+
+	writeProject("Acme API")
+	if err := manager.GenerateDevcontainer(); err != nil {
+		t.Fatalf("Failed to generate devcontainer: %v", err)
+	}
+	if got := readName(); got != "Acme API" {
+		t.Errorf("Expected configured name, got %v", got)
+	}
+
+	writeProject("Acme\nAPI")
+	if err := manager.GenerateDevcontainer(); err == nil {
+		t.Error("Expected a multi-line devcontainer_name to be rejected")
+	}
+}
+
 // Helper functions
 
+// testManifestPath returns where the config tests' "test" session stores a manifest file
+func testManifestPath(file string) string {
+	return session.NewManager(".servo").ManifestPath("test", file)
+}
+
 func setupDevcontainerTestProject() error {
 	dirs := []string{
 		".servo",
 		".servo/sessions",
 		".servo/sessions/test",
-		".servo/sessions/test/manifests",
+		session.NewManager(".servo").ManifestsDir("test"),
 	}
 
 	for _, dir := range dirs {
@@ -239,7 +358,7 @@ func createBasicDevcontainerManifest() error {
 		return err
 	}
 
-	return os.WriteFile(".servo/sessions/test/manifests/basic-app.servo", data, 0644)
+	return os.WriteFile(testManifestPath("basic-app.servo"), data, 0644)
 }
 
 func createMCPServerManifest() error {
@@ -269,7 +388,7 @@ func createMCPServerManifest() error {
 		return err
 	}
 
-	return os.WriteFile(".servo/sessions/test/manifests/mcp-server.servo", data, 0644)
+	return os.WriteFile(testManifestPath("mcp-server.servo"), data, 0644)
 }
 
 func createPortForwardingManifest() error {
@@ -293,7 +412,7 @@ func createPortForwardingManifest() error {
 		return err
 	}
 
-	return os.WriteFile(".servo/sessions/test/manifests/port-app.servo", data, 0644)
+	return os.WriteFile(testManifestPath("port-app.servo"), data, 0644)
 }
 
 func createFeaturesManifest() error {
@@ -319,7 +438,7 @@ func createFeaturesManifest() error {
 		return err
 	}
 
-	return os.WriteFile(".servo/sessions/test/manifests/features-app.servo", data, 0644)
+	return os.WriteFile(testManifestPath("features-app.servo"), data, 0644)
 }
 
 func verifyBasicDevcontainer(t *testing.T) {
@@ -475,13 +594,16 @@ func verifyMinimalDevcontainer(t *testing.T) {
 		t.Errorf("Expected name 'Servo Development Environment', got %v", config["name"])
 	}
 
-	// Should have minimal required fields
-	requiredFields := []string{"dockerComposeFile", "service", "workspaceFolder"}
+	// Without services the devcontainer is a single container
+	requiredFields := []string{"build", "workspaceMount", "workspaceFolder"}
 	for _, field := range requiredFields {
 		if _, exists := config[field]; !exists {
 			t.Errorf("Required field %s not found", field)
 		}
 	}
+	if _, exists := config["dockerComposeFile"]; exists {
+		t.Error("Expected no dockerComposeFile in single-container mode")
+	}
 
 	// Features should be empty or minimal
 	features, ok := config["features"].(map[string]interface{})
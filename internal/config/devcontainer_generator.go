@@ -4,11 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/servo/servo/internal/override"
+	"github.com/servo/servo/internal/project"
 	"github.com/servo/servo/internal/runtime"
+	"github.com/servo/servo/internal/session"
 	"github.com/servo/servo/pkg"
 )
 
@@ -16,6 +20,10 @@ import (
 type DevcontainerGenerator struct {
 	*BaseGenerator
 	runtimeAnalyzer *runtime.RuntimeAnalyzer
+
+	// containerEnv resolves the workspace container's containerEnv, from servo's
+	// defaults through the override layers
+	containerEnv *Resolver
 }
 
 // NewDevcontainerGenerator creates a new devcontainer generator
@@ -26,29 +34,42 @@ func NewDevcontainerGenerator(servoDir string) *DevcontainerGenerator {
 	}
 }
 
+// Name returns the generator's registration name
+func (g *DevcontainerGenerator) Name() string {
+	return DevcontainerGeneratorName
+}
+
 // Generate generates .devcontainer/devcontainer.json for infrastructure setup
 func (g *DevcontainerGenerator) Generate() error {
+	g.written = nil
+
 	project, activeSession, manifests, err := g.GetActiveSessionData()
 	if err != nil {
 		return err
 	}
 
 	// Validate secrets before generating configuration
-	if err := g.ValidateSecretsBeforeGeneration(project, manifests); err != nil {
+	if err := g.ValidateSecretsBeforeGeneration(project, activeSession.Name, manifests); err != nil {
 		return fmt.Errorf("secrets validation failed: %w", err)
 	}
+	if err := project.Config.ValidateDevcontainerName(); err != nil {
+		return err
+	}
 
 	// Setup override manager
 	g.SetupOverrideManager(activeSession.Name)
 
-	// Generate base devcontainer configuration (infrastructure only)
-	devcontainerConfig := g.buildBaseDevcontainerConfig()
+	devcontainerConfig, profiles, err := g.buildManifestConfig(project, activeSession, manifests)
+	if err != nil {
+		return err
+	}
 
 	// Apply overrides with precedence: session > project > defaults
 	finalConfig := g.processDevcontainerOverrides(devcontainerConfig)
+	g.markIncomplete(finalConfig)
 
 	// Create .devcontainer directory
-	if err := os.MkdirAll(".devcontainer", 0755); err != nil {
+	if err := os.MkdirAll(g.outputPath(".devcontainer"), 0755); err != nil {
 		return fmt.Errorf("failed to create .devcontainer directory: %w", err)
 	}
 
@@ -58,7 +79,143 @@ func (g *DevcontainerGenerator) Generate() error {
 		return fmt.Errorf("failed to marshal devcontainer config: %w", err)
 	}
 
-	return os.WriteFile(".devcontainer/devcontainer.json", data, 0644)
+	devcontainerPath := g.outputPath(filepath.Join(".devcontainer", "devcontainer.json"))
+	if err := os.WriteFile(devcontainerPath, data, 0644); err != nil {
+		return err
+	}
+	g.written = append(g.written, devcontainerPath)
+
+	return g.writeComposeProfilesEnv(profiles)
+}
+
+// Effective returns the devcontainer configuration Generate would write, with the
+// layer that set each value. Nothing is written and secrets are not validated.
+func (g *DevcontainerGenerator) Effective() (*EffectiveConfig, error) {
+	project, activeSession, manifests, err := g.GetActiveSessionData()
+	if err != nil {
+		return nil, err
+	}
+
+	g.SetupOverrideManager(activeSession.Name)
+	layers, err := g.overrideManager.DevcontainerLayers()
+	if err != nil {
+		return nil, err
+	}
+
+	devcontainerConfig, _, err := g.buildManifestConfig(project, activeSession, manifests)
+	if err != nil {
+		return nil, err
+	}
+	effective := newEffectiveConfig(activeSession.Name, g.processDevcontainerOverrides(devcontainerConfig))
+	effective.recordEnvironment([]string{"containerEnv"}, g.containerEnv)
+	for _, layer := range layers {
+		effective.record(layer.Layer, g.convertDevcontainerOverrideToMap(layer.Override))
+	}
+	return effective, nil
+}
+
+// buildManifestConfig builds the devcontainer configuration from the session's
+// manifests, before any override is applied, along with the active compose profiles.
+// A single-container configuration has no compose profiles.
+func (g *DevcontainerGenerator) buildManifestConfig(proj *project.Project, activeSession *session.Session, manifests map[string]*pkg.ServoDefinition) (map[string]interface{}, []string, error) {
+	mode, err := g.ResolveDevcontainerMode(proj, activeSession.Name, manifests)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Generate base devcontainer configuration (infrastructure only)
+	devcontainerConfig := g.buildBaseDevcontainerConfig(mode)
+
+	// A configured workspace mount also moves the folder editors open, so it always
+	// names the mounted directory
+	if proj != nil && proj.Config.WorkspaceMount != "" {
+		_, workspaceTarget, err := g.ResolveWorkspaceMount(proj)
+		if err != nil {
+			return nil, nil, err
+		}
+		devcontainerConfig["workspaceFolder"] = workspaceTarget
+	}
+
+	g.containerEnv = NewResolver()
+	if mode == project.DevcontainerModeSingle {
+		if err := g.addSingleContainerSettings(devcontainerConfig, proj, activeSession.Name); err != nil {
+			return nil, nil, err
+		}
+		g.setContainerEnv(devcontainerConfig, map[string]string{"SERVO_DEV_MODE": "1"})
+		return devcontainerConfig, nil, nil
+	}
+
+	// Pass active compose profiles so only the selected optional services start
+	profiles := g.ResolveActiveProfiles(proj, activeSession)
+	if len(profiles) > 0 {
+		g.setContainerEnv(devcontainerConfig, map[string]string{"COMPOSE_PROFILES": strings.Join(profiles, ",")})
+	}
+	servicePrefix, err := g.ResolveServicePrefix(proj)
+	if err != nil {
+		return nil, nil, err
+	}
+	if runServices := g.buildRunServices(manifests, profiles, servicePrefix); runServices != nil {
+		devcontainerConfig["runServices"] = runServices
+	}
+	return devcontainerConfig, profiles, nil
+}
+
+// setContainerEnv sets servo's own containerEnv variables as the resolver's defaults
+func (g *DevcontainerGenerator) setContainerEnv(config map[string]interface{}, defaults map[string]string) {
+	config["containerEnv"] = g.containerEnv.Set(SourceDefault, defaults).Values()
+}
+
+// mergeContainerEnv sets the override layer of the containerEnv resolver, so an
+// override adds to servo's variables instead of replacing them
+func (g *DevcontainerGenerator) mergeContainerEnv(override interface{}) interface{} {
+	if g.containerEnv == nil {
+		g.containerEnv = NewResolver()
+	}
+	vars := make(map[string]string)
+	switch overrideEnv := override.(type) {
+	case map[string]string:
+		vars = overrideEnv
+	case map[string]interface{}:
+		for key, value := range overrideEnv {
+			vars[key] = fmt.Sprint(value)
+		}
+	}
+	return g.containerEnv.Set(SourceOverride, vars).Values()
+}
+
+// writeComposeProfilesEnv records COMPOSE_PROFILES in .devcontainer/.env, which docker compose
+// reads when the devcontainer starts. Other entries in the file are preserved.
+func (g *DevcontainerGenerator) writeComposeProfilesEnv(profiles []string) error {
+	envPath := g.outputPath(filepath.Join(".devcontainer", ".env"))
+
+	var lines []string
+	existing, err := os.ReadFile(envPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", envPath, err)
+	}
+	for _, line := range strings.Split(string(existing), "\n") {
+		if line == "" || strings.HasPrefix(line, "COMPOSE_PROFILES=") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if len(profiles) > 0 {
+		lines = append(lines, "COMPOSE_PROFILES="+strings.Join(profiles, ","))
+	}
+
+	if len(lines) == 0 {
+		if err := os.Remove(envPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", envPath, err)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(envPath, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		return err
+	}
+	g.written = append(g.written, envPath)
+	return nil
 }
 
 // processDevcontainerOverrides applies override configurations to devcontainer config
@@ -114,6 +271,9 @@ func (g *DevcontainerGenerator) convertDevcontainerOverrideToMap(override *overr
 	if len(override.Mounts) > 0 {
 		result["mounts"] = override.Mounts
 	}
+	if len(override.ContainerEnv) > 0 {
+		result["containerEnv"] = override.ContainerEnv
+	}
 
 	// Add features if specified
 	if len(override.Features) > 0 {
@@ -133,20 +293,36 @@ func (g *DevcontainerGenerator) convertDevcontainerOverrideToMap(override *overr
 	return result
 }
 
-// buildBaseDevcontainerConfig creates the base infrastructure-only devcontainer configuration
-func (g *DevcontainerGenerator) buildBaseDevcontainerConfig() map[string]interface{} {
-	_, _, manifests, err := g.GetActiveSessionData()
+// buildBaseDevcontainerConfig creates the base infrastructure-only devcontainer
+// configuration. Only the compose mode names the compose file and service.
+func (g *DevcontainerGenerator) buildBaseDevcontainerConfig(mode string) map[string]interface{} {
+	proj, _, manifests, err := g.GetActiveSessionData()
 	if err != nil {
 		// Fallback to basic config if we can't get manifests
 		return g.buildFallbackConfig()
 	}
 
 	config := map[string]interface{}{
-		"name":              "Servo Development Environment",
-		"dockerComposeFile": []string{"docker-compose.yml"},
-		"service":           "workspace",
-		"workspaceFolder":   "/workspace",
-		"remoteUser":        "root",
+		"name":            "Servo Development Environment",
+		"workspaceFolder": "/workspace",
+		"remoteUser":      "root",
+	}
+	if mode != project.DevcontainerModeSingle {
+		config["dockerComposeFile"] = []string{"docker-compose.yml"}
+		config["service"] = "workspace"
+	}
+	if proj != nil && proj.Config.DevcontainerName != "" {
+		config["name"] = strings.TrimSpace(proj.Config.DevcontainerName)
+	}
+	if proj != nil {
+		if remoteUser := strings.TrimSpace(proj.Config.RemoteUser); remoteUser != "" {
+			config["remoteUser"] = remoteUser
+		}
+		// The devcontainer CLI remaps a non-root remote user to the host UID/GID on
+		// Linux unless told not to; follow host_user_ids instead of that default
+		if config["remoteUser"] != "root" {
+			config["updateRemoteUserUID"] = proj.Config.HostUserIDs
+		}
 	}
 
 	// Extract runtime requirements and build features
@@ -160,8 +336,22 @@ func (g *DevcontainerGenerator) buildBaseDevcontainerConfig() map[string]interfa
 	config["forwardPorts"] = forwardPorts
 
 	// Add setup commands for infrastructure
-	config["onCreateCommand"] = g.buildOnCreateCommand(manifests)
-	config["postStartCommand"] = g.buildPostStartCommand()
+	projectDir, err := g.WorkspaceProjectDir(proj)
+	if err != nil {
+		return g.buildFallbackConfig()
+	}
+	config["onCreateCommand"] = g.buildOnCreateCommand(manifests, g.ResolveVolumeRoot(proj), projectDir)
+	config["postStartCommand"] = g.buildPostStartCommand(mode)
+
+	// Project lifecycle commands run after servo's own rather than replacing them
+	if proj != nil {
+		if command := strings.TrimSpace(proj.Config.PostCreateCommand); command != "" {
+			config["postCreateCommand"] = command
+		}
+		if command := strings.TrimSpace(proj.Config.PostStartCommand); command != "" {
+			config["postStartCommand"] = config["postStartCommand"].(string) + "; " + command
+		}
+	}
 
 	return config
 }
@@ -177,8 +367,8 @@ func (g *DevcontainerGenerator) buildFallbackConfig() map[string]interface{} {
 		"features":          map[string]interface{}{},
 		"forwardPorts":      []interface{}{},
 		"customizations":    map[string]interface{}{},
-		"onCreateCommand":   g.buildOnCreateCommand(nil),
-		"postStartCommand":  g.buildPostStartCommand(),
+		"onCreateCommand":   g.buildOnCreateCommand(nil, DefaultVolumeRoot, g.defaultProjectDir()),
+		"postStartCommand":  g.buildPostStartCommand(project.DevcontainerModeCompose),
 	}
 }
 
@@ -226,26 +416,8 @@ func (g *DevcontainerGenerator) extractForwardPorts(manifests map[string]*pkg.Se
 			continue
 		}
 
-		// Check both Dependencies.Services and direct Services fields
-		var servicesToCheck map[string]*pkg.ServiceDependency
-		if manifest.Dependencies != nil && manifest.Dependencies.Services != nil {
-			servicesToCheck = make(map[string]*pkg.ServiceDependency)
-			for name, service := range manifest.Dependencies.Services {
-				servicesToCheck[name] = &service
-			}
-		}
-		if manifest.Services != nil {
-			if servicesToCheck == nil {
-				servicesToCheck = manifest.Services
-			} else {
-				// Merge both if present
-				for name, service := range manifest.Services {
-					servicesToCheck[name] = service
-				}
-			}
-		}
-
-		if servicesToCheck != nil {
+		servicesToCheck := manifest.AllServices()
+		if len(servicesToCheck) > 0 {
 			for serviceName, service := range servicesToCheck {
 				if service != nil {
 					for _, portMapping := range service.Ports {
@@ -346,6 +518,8 @@ func (g *DevcontainerGenerator) applyDevcontainerOverrides(base map[string]inter
 			case "forwardPorts":
 				// Merge forward ports arrays
 				result[key] = g.mergeForwardPorts(result[key], value)
+			case "containerEnv":
+				result[key] = g.mergeContainerEnv(value)
 			default:
 				// Direct override for other properties
 				result[key] = value
@@ -356,16 +530,32 @@ func (g *DevcontainerGenerator) applyDevcontainerOverrides(base map[string]inter
 	return result
 }
 
-// buildOnCreateCommand builds the onCreateCommand for devcontainer infrastructure setup
-func (g *DevcontainerGenerator) buildOnCreateCommand(manifests map[string]*pkg.ServoDefinition) string {
+// defaultProjectDir returns the project directory inside a workspace container using
+// DefaultWorkspaceMount, or "" when it cannot be determined
+func (g *DevcontainerGenerator) defaultProjectDir() string {
+	projectDir, err := g.WorkspaceProjectDir(nil)
+	if err != nil {
+		return ""
+	}
+	return projectDir
+}
+
+// buildOnCreateCommand builds the onCreateCommand for devcontainer infrastructure
+// setup. projectDir is the project root inside the container (see
+// WorkspaceProjectDir); directories below it are only created when it is known.
+func (g *DevcontainerGenerator) buildOnCreateCommand(manifests map[string]*pkg.ServoDefinition, volumeRoot, projectDir string) string {
 	commands := []string{
 		"echo '🔧 Setting up development environment...'",
-		"mkdir -p /workspace/.servo/services",
-		"mkdir -p /workspace/.servo/logs",
+	}
+	if servicesDir := workspaceVolumeRoot(projectDir, volumeRoot); servicesDir != "" {
+		commands = append(commands, "mkdir -p "+servicesDir)
+	}
+	if logsDir := workspaceVolumeRoot(projectDir, LogsRoot); logsDir != "" {
+		commands = append(commands, "mkdir -p "+logsDir)
 	}
 
 	// Add persistence directory creation commands
-	persistenceDirs := g.extractPersistenceDirectories(manifests)
+	persistenceDirs := g.extractPersistenceDirectories(manifests, volumeRoot, projectDir)
 	for _, dir := range persistenceDirs {
 		commands = append(commands, dir)
 	}
@@ -373,7 +563,11 @@ func (g *DevcontainerGenerator) buildOnCreateCommand(manifests map[string]*pkg.S
 	commands = append(commands,
 		"echo '⚙️  Installing servo CLI...'",
 		"if ! command -v servo &> /dev/null; then",
-		"  cd /workspace &&",
+	)
+	if projectDir != "" {
+		commands = append(commands, "  cd "+projectDir+" &&")
+	}
+	commands = append(commands,
 		"  go build -o /usr/local/bin/servo ./cmd/servo &&",
 		"  echo '✅ Servo CLI installed successfully'",
 		"else",
@@ -384,10 +578,14 @@ func (g *DevcontainerGenerator) buildOnCreateCommand(manifests map[string]*pkg.S
 	return strings.Join(commands, " && ")
 }
 
-// extractPersistenceDirectories extracts directory creation commands for volume persistence
-func (g *DevcontainerGenerator) extractPersistenceDirectories(manifests map[string]*pkg.ServoDefinition) []string {
+// extractPersistenceDirectories extracts directory creation commands for volume persistence.
+// Volume directories are skipped for an absolute volumeRoot, where Docker creates the
+// bind mount sources on the host, and every directory is skipped when projectDir is "".
+func (g *DevcontainerGenerator) extractPersistenceDirectories(manifests map[string]*pkg.ServoDefinition, volumeRoot, projectDir string) []string {
 	var commands []string
 	seen := make(map[string]bool)
+	servicesDir := workspaceVolumeRoot(projectDir, volumeRoot)
+	logsDir := workspaceVolumeRoot(projectDir, LogsRoot)
 
 	if manifests == nil {
 		return commands
@@ -398,38 +596,23 @@ func (g *DevcontainerGenerator) extractPersistenceDirectories(manifests map[stri
 			continue
 		}
 
-		// Check both Dependencies.Services and direct Services fields
-		var servicesToCheck map[string]*pkg.ServiceDependency
-		if manifest.Dependencies != nil && manifest.Dependencies.Services != nil {
-			servicesToCheck = make(map[string]*pkg.ServiceDependency)
-			for name, service := range manifest.Dependencies.Services {
-				servicesToCheck[name] = &service
-			}
-		}
-		if manifest.Services != nil {
-			if servicesToCheck == nil {
-				servicesToCheck = manifest.Services
-			} else {
-				// Merge both if present
-				for name, service := range manifest.Services {
-					servicesToCheck[name] = service
-				}
-			}
-		}
-
-		if servicesToCheck != nil {
+		servicesToCheck := manifest.AllServices()
+		if len(servicesToCheck) > 0 {
 			for serviceName, service := range servicesToCheck {
 				if service != nil && len(service.Volumes) > 0 {
-					serviceDir := fmt.Sprintf("mkdir -p /workspace/.servo/services/%s/%s", serverName, serviceName)
-					logDir := fmt.Sprintf("mkdir -p /workspace/.servo/logs/%s/%s", serverName, serviceName)
+					serviceDir := fmt.Sprintf("mkdir -p %s/%s/%s", servicesDir, serverName, serviceName)
 
-					if !seen[serviceDir] {
+					if servicesDir != "" && !seen[serviceDir] {
 						commands = append(commands, serviceDir)
 						seen[serviceDir] = true
 					}
-					if !seen[logDir] {
-						commands = append(commands, logDir)
-						seen[logDir] = true
+					// Only log volumes are mounted from the logs root, each from its own directory
+					for _, dir := range logVolumeDirs(service) {
+						logDir := fmt.Sprintf("mkdir -p %s/%s/%s/%s", logsDir, serverName, serviceName, dir)
+						if logsDir != "" && !seen[logDir] {
+							commands = append(commands, logDir)
+							seen[logDir] = true
+						}
 					}
 				}
 			}
@@ -439,9 +622,45 @@ func (g *DevcontainerGenerator) extractPersistenceDirectories(manifests map[stri
 	return commands
 }
 
+// buildRunServices lists the compose services the devcontainer starts: the workspace,
+// every ungated service and those gated by an active profile. It returns nil when no
+// service declares profiles, leaving compose to start everything. Service names
+// follow the servicePrefix template.
+func (g *DevcontainerGenerator) buildRunServices(manifests map[string]*pkg.ServoDefinition, activeProfiles []string, servicePrefix string) []string {
+	active := make(map[string]bool, len(activeProfiles))
+	for _, profile := range activeProfiles {
+		active[profile] = true
+	}
+
+	gated := false
+	runServices := []string{"workspace"}
+	for manifestName, manifest := range manifests {
+		for serviceName, service := range manifest.AllServices() {
+			start := len(service.Profiles) == 0
+			for _, profile := range service.Profiles {
+				gated = true
+				start = start || active[profile]
+			}
+			if start {
+				runServices = append(runServices, ComposeServiceName(servicePrefix, manifestName, serviceName))
+			}
+		}
+	}
+
+	if !gated {
+		return nil
+	}
+	sort.Strings(runServices[1:])
+	return runServices
+}
+
 // buildPostStartCommand builds the postStartCommand for devcontainer
-func (g *DevcontainerGenerator) buildPostStartCommand() string {
-	return "echo 'Development container started. Services should be running via docker-compose.'; servo status || echo 'Servo not yet available - will be after setup completes'"
+func (g *DevcontainerGenerator) buildPostStartCommand(mode string) string {
+	started := "echo 'Development container started. Services should be running via docker-compose.'"
+	if mode == project.DevcontainerModeSingle {
+		started = "echo 'Development container started.'"
+	}
+	return started + "; servo status || echo 'Servo not yet available - will be after setup completes'"
 }
 
 // mergeForwardPorts merges base forward ports with override forward ports
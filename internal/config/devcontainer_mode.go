@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/pkg"
+)
+
+// singleContainerDataVolume is the workspace-data volume of a single-container
+// devcontainer. Compose scopes its volume to the compose project; the devcontainer
+// id does the same here.
+const singleContainerDataVolume = "servo-workspace-data-${devcontainerId}"
+
+// ResolveDevcontainerMode returns the devcontainer layout to generate for a session:
+// project.DevcontainerModeCompose or project.DevcontainerModeSingle. Auto picks
+// single-container mode when no manifest declares services and no docker-compose
+// override layer exists, since overrides can only be applied to a compose file.
+// Forcing single mode while either exists is an error, since nothing would start
+// the services or apply the overrides.
+func (g *BaseGenerator) ResolveDevcontainerMode(proj *project.Project, sessionName string, manifests map[string]*pkg.ServoDefinition) (string, error) {
+	var withServices []string
+	for name, manifest := range manifests {
+		if manifest != nil && len(manifest.AllServices()) > 0 {
+			withServices = append(withServices, name)
+		}
+	}
+	sort.Strings(withServices)
+
+	mode := project.DevcontainerModeAuto
+	if proj != nil && proj.Config.DevcontainerMode != "" {
+		mode = proj.Config.DevcontainerMode
+	}
+	if mode == project.DevcontainerModeCompose {
+		return project.DevcontainerModeCompose, nil
+	}
+
+	overrides, err := g.composeOverrideFiles(sessionName)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case mode == project.DevcontainerModeSingle && len(withServices) > 0:
+		return "", fmt.Errorf("config.devcontainer_mode single cannot run the services declared by %s; use compose or auto", strings.Join(withServices, ", "))
+	case mode == project.DevcontainerModeSingle && len(overrides) > 0:
+		return "", fmt.Errorf("config.devcontainer_mode single cannot apply the docker-compose overrides in %s; use compose or auto", strings.Join(overrides, ", "))
+	case len(withServices) > 0 || len(overrides) > 0:
+		return project.DevcontainerModeCompose, nil
+	default:
+		return project.DevcontainerModeSingle, nil
+	}
+}
+
+// composeOverrideFiles returns the docker-compose override files present for a
+// session, in merge order
+func (g *BaseGenerator) composeOverrideFiles(sessionName string) ([]string, error) {
+	g.SetupOverrideManager(sessionName)
+	layers, err := g.overrideManager.DockerComposeLayers()
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, layer := range layers {
+		if _, err := os.Stat(layer.Path); err == nil {
+			files = append(files, layer.Path)
+		}
+	}
+	return files, nil
+}
+
+// singleContainer reports whether the devcontainer is generated without compose
+func (g *BaseGenerator) singleContainer(proj *project.Project, sessionName string, manifests map[string]*pkg.ServoDefinition) (bool, error) {
+	mode, err := g.ResolveDevcontainerMode(proj, sessionName, manifests)
+	return mode == project.DevcontainerModeSingle, err
+}
+
+// removeStaleComposeFile deletes a docker-compose.yml left by compose mode. A file
+// that does not match the checksum servo recorded when writing it was edited by
+// hand (or never written by servo) and is kept with a warning.
+func (g *BaseGenerator) removeStaleComposeFile() error {
+	composeFile := filepath.Join(".devcontainer", "docker-compose.yml")
+	composePath := g.outputPath(composeFile)
+	if _, err := os.Stat(composePath); os.IsNotExist(err) {
+		return nil
+	}
+
+	unchanged, err := generatedUnchanged(g.servoDir, composeFile, composePath)
+	if err != nil {
+		return err
+	}
+	if !unchanged {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: keeping %s, which servo did not generate as it is; delete it to use the single-container devcontainer\n", composeFile)
+		return nil
+	}
+	if err := os.Remove(composePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", composePath, err)
+	}
+	return nil
+}
+
+// addSingleContainerSettings makes a devcontainer configuration build and run the
+// workspace as one container, with the mounts the compose workspace service would
+// have. The caller sets its environment.
+func (g *BaseGenerator) addSingleContainerSettings(config map[string]interface{}, proj *project.Project, sessionName string) error {
+	workspaceMount, _, err := g.ResolveWorkspaceMount(proj)
+	if err != nil {
+		return err
+	}
+	source, target, mode, err := project.ParseWorkspaceMount(workspaceMount)
+	if err != nil {
+		return err
+	}
+
+	projectDir, err := g.WorkspaceProjectDir(proj)
+	if err != nil {
+		return err
+	}
+
+	mounts := []interface{}{
+		fmt.Sprintf("source=%s,target=%s,type=volume", singleContainerDataVolume, project.WorkspaceDataMount),
+	}
+	if proj != nil {
+		for _, mount := range devMounts(proj, sessionName, devMountRoot(projectDir, target)) {
+			mounts = append(mounts, devcontainerBindMount(mount.source, mount.target, "cached"))
+		}
+	}
+
+	// devcontainer.json resolves dockerfile against .devcontainer/, not the context,
+	// so this names the same project-root Dockerfile the compose build uses
+	config["build"] = map[string]interface{}{
+		"dockerfile": "../Dockerfile",
+		"context":    "..",
+	}
+	config["workspaceMount"] = devcontainerBindMount(source, target, mode)
+	config["mounts"] = mounts
+	return nil
+}
+
+// devcontainerBindMount writes a compose-style bind mount in devcontainer.json mount
+// syntax. A relative source is resolved against .devcontainer/, as compose does.
+func devcontainerBindMount(source, target, mode string) string {
+	if !filepath.IsAbs(source) && !path.IsAbs(filepath.ToSlash(source)) {
+		relative := path.Join(".devcontainer", filepath.ToSlash(source))
+		source = "${localWorkspaceFolder}"
+		if relative != "." {
+			source += "/" + relative
+		}
+	}
+
+	mount := fmt.Sprintf("source=%s,target=%s,type=bind", source, target)
+	switch mode {
+	case "ro":
+		mount += ",readonly"
+	case "cached", "delegated", "consistent":
+		mount += ",consistency=" + mode
+	}
+	return mount
+}
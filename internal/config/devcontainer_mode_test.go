@@ -0,0 +1,161 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/pkg"
+	"gopkg.in/yaml.v3"
+)
+
+func TestResolveDevcontainerMode(t *testing.T) {
+	withServices := map[string]*pkg.ServoDefinition{
+		"api": {Services: map[string]*pkg.ServiceDependency{"db": {Image: "postgres:16"}}},
+	}
+	withoutServices := map[string]*pkg.ServoDefinition{"api": {}}
+
+	tests := []struct {
+		name      string
+		mode      string
+		manifests map[string]*pkg.ServoDefinition
+		override  bool
+		want      string
+		wantErr   string
+	}{
+		{name: "auto without services", manifests: withoutServices, want: project.DevcontainerModeSingle},
+		{name: "auto with services", mode: project.DevcontainerModeAuto, manifests: withServices, want: project.DevcontainerModeCompose},
+		{name: "auto with compose override", manifests: withoutServices, override: true, want: project.DevcontainerModeCompose},
+		{name: "forced compose", mode: project.DevcontainerModeCompose, manifests: withoutServices, want: project.DevcontainerModeCompose},
+		{name: "forced single", mode: project.DevcontainerModeSingle, manifests: withoutServices, want: project.DevcontainerModeSingle},
+		{name: "forced single with services", mode: project.DevcontainerModeSingle, manifests: withServices, wantErr: "services declared by api"},
+		{name: "forced single with compose override", mode: project.DevcontainerModeSingle, manifests: withoutServices, override: true, wantErr: "docker-compose overrides"},
+	}
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Chdir(t.TempDir())
+			if tt.override {
+				os.MkdirAll(".servo/config", 0755)
+				os.WriteFile(".servo/config/docker-compose.yml", []byte("services:\n  workspace:\n    environment:\n      DEBUG: \"1\"\n"), 0644)
+			}
+
+			g := NewBaseGenerator(".servo")
+			proj := &project.Project{Config: project.ProjectConfig{DevcontainerMode: tt.mode}}
+			got, err := g.ResolveDevcontainerMode(proj, "test", tt.manifests)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolveDevcontainerMode() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ResolveDevcontainerMode() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDevcontainerBindMount(t *testing.T) {
+	tests := []struct {
+		source, target, mode string
+		want                 string
+	}{
+		{source: "../..", target: "/workspaces", mode: "cached", want: "source=${localWorkspaceFolder}/..,target=/workspaces,type=bind,consistency=cached"},
+		{source: "..", target: "/src", want: "source=${localWorkspaceFolder},target=/src,type=bind"},
+		{source: "/home/me/repo", target: "/src", mode: "ro", want: "source=/home/me/repo,target=/src,type=bind,readonly"},
+	}
+	for _, tt := range tests {
+		if got := devcontainerBindMount(tt.source, tt.target, tt.mode); got != tt.want {
+			t.Errorf("devcontainerBindMount(%q, %q, %q) = %q, want %q", tt.source, tt.target, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestDevcontainerGeneration_SingleContainer(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+	proj := &project.Project{
+		DefaultSession: "test",
+		ActiveSession:  "test",
+		MCPServers: []project.MCPServer{
+			{Name: "api", Source: "/src/api", Dev: true, Sessions: []string{"test"}},
+			{Name: "tool", Source: "../tool", Dev: true, Sessions: []string{"test"}},
+		},
+	}
+	data, _ := yaml.Marshal(proj)
+	os.WriteFile(".servo/project.yaml", data, 0644)
+	if err := createMCPServerManifest(); err != nil {
+		t.Fatalf("Failed to create manifest: %v", err)
+	}
+
+	// A compose file edited by hand after an earlier compose-mode run is kept
+	composeFile := filepath.Join(".devcontainer", "docker-compose.yml")
+	os.MkdirAll(".devcontainer", 0755)
+	os.WriteFile(composeFile, []byte("services: {}\n"), 0644)
+	RecordChecksums(".servo", []string{composeFile})
+	os.WriteFile(composeFile, []byte("services: {}\n# mine\n"), 0644)
+
+	manager := NewConfigGeneratorManager(".servo")
+	if err := manager.GenerateDockerCompose(); err != nil {
+		t.Fatalf("GenerateDockerCompose() error = %v", err)
+	}
+	if _, err := os.Stat(composeFile); err != nil {
+		t.Error("Expected a hand-edited docker-compose.yml to be kept")
+	}
+
+	// One servo generated unchanged is removed
+	RecordChecksums(".servo", []string{composeFile})
+	if err := manager.GenerateDevcontainer(); err != nil {
+		t.Fatalf("GenerateDevcontainer() error = %v", err)
+	}
+	if err := manager.GenerateDockerCompose(); err != nil {
+		t.Fatalf("GenerateDockerCompose() error = %v", err)
+	}
+	if _, err := os.Stat(composeFile); !os.IsNotExist(err) {
+		t.Error("Expected no docker-compose.yml in single-container mode")
+	}
+
+	var config map[string]interface{}
+	raw, _ := os.ReadFile(".devcontainer/devcontainer.json")
+	if err := json.Unmarshal(raw, &config); err != nil {
+		t.Fatalf("Failed to parse devcontainer.json: %v", err)
+	}
+	for _, key := range []string{"dockerComposeFile", "service", "runServices"} {
+		if _, ok := config[key]; ok {
+			t.Errorf("Expected no %s in single-container mode, got %v", key, config[key])
+		}
+	}
+	if build, _ := config["build"].(map[string]interface{}); build["dockerfile"] != "../Dockerfile" || build["context"] != ".." {
+		t.Errorf("Expected a build of the project-root Dockerfile, got %v", config["build"])
+	}
+	if config["workspaceMount"] != "source=${localWorkspaceFolder}/..,target=/workspaces,type=bind,consistency=cached" {
+		t.Errorf("Unexpected workspaceMount %v", config["workspaceMount"])
+	}
+	// The default mount puts the project one level below /workspaces
+	cwd, _ := os.Getwd()
+	mounts, _ := json.Marshal(config["mounts"])
+	for _, want := range []string{
+		"source=" + singleContainerDataVolume + ",target=" + project.WorkspaceDataMount + ",type=volume",
+		"source=/src/api,target=/workspaces/" + filepath.Base(cwd) + "/.servo/dev/api,type=bind,consistency=cached",
+		"source=${localWorkspaceFolder}/../tool,target=/workspaces/" + filepath.Base(cwd) + "/.servo/dev/tool,type=bind,consistency=cached",
+	} {
+		if !strings.Contains(string(mounts), want) {
+			t.Errorf("Expected mount %q, got %s", want, mounts)
+		}
+	}
+	if env, _ := config["containerEnv"].(map[string]interface{}); env["SERVO_DEV_MODE"] != "1" {
+		t.Errorf("Expected SERVO_DEV_MODE in containerEnv, got %v", config["containerEnv"])
+	}
+}
@@ -1,10 +1,14 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/servo/servo/internal/session"
 	"github.com/servo/servo/pkg"
 	"gopkg.in/yaml.v3"
 )
@@ -91,7 +95,7 @@ env:
 	}
 
 	// Create test manifest
-	manifestFile := filepath.Join(servoDir, "sessions", "default", "manifests", "test-server.servo")
+	manifestFile := session.NewManager(servoDir).ManifestPath("default", "test-server.servo")
 	testManifest := &pkg.ServoDefinition{
 		ServoVersion: "1.0",
 		Name:         "test-server",
@@ -296,3 +300,262 @@ func splitEnvVar(envVar string) []string {
 	}
 	return []string{envVar} // No '=' found
 }
+
+func TestDockerComposeGenerator_HealthCheck(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+
+	manifest := &pkg.ServoDefinition{
+		ServoVersion: "1.0",
+		Name:         "db-app",
+		Services: map[string]*pkg.ServiceDependency{
+			"db": {
+				Image: "postgres:15",
+				HealthCheck: &pkg.HealthCheck{
+					Test:     []string{"CMD", "pg_isready"},
+					Interval: "5s",
+					Retries:  3,
+				},
+			},
+			"cache": {Image: "redis:7"},
+		},
+	}
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("Failed to marshal manifest: %v", err)
+	}
+	if err := os.WriteFile(testManifestPath("db-app.servo"), data, 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	if err := NewDockerComposeGenerator(".servo").Generate(); err != nil {
+		t.Fatalf("Failed to generate docker-compose: %v", err)
+	}
+
+	var compose struct {
+		Services map[string]struct {
+			HealthCheck *struct {
+				Test     []string `yaml:"test"`
+				Interval string   `yaml:"interval"`
+				Timeout  string   `yaml:"timeout"`
+				Retries  int      `yaml:"retries"`
+			} `yaml:"healthcheck"`
+		} `yaml:"services"`
+	}
+	composeData, err := os.ReadFile(".devcontainer/docker-compose.yml")
+	if err != nil {
+		t.Fatalf("Failed to read docker-compose.yml: %v", err)
+	}
+	if err := yaml.Unmarshal(composeData, &compose); err != nil {
+		t.Fatalf("Failed to parse docker-compose.yml: %v", err)
+	}
+
+	hc := compose.Services["db-app-db"].HealthCheck
+	if hc == nil {
+		t.Fatal("Expected healthcheck on db-app-db")
+	}
+	if len(hc.Test) != 2 || hc.Test[1] != "pg_isready" || hc.Interval != "5s" || hc.Retries != 3 || hc.Timeout != "" {
+		t.Errorf("Unexpected healthcheck: %+v", hc)
+	}
+	if compose.Services["db-app-cache"].HealthCheck != nil {
+		t.Error("Service without healthcheck should not get one")
+	}
+}
+
+func TestDockerComposeGenerator_DependsOnConditions(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+
+	manifests := map[string]*pkg.ServoDefinition{
+		"database-server": {
+			ServoVersion: "1.0",
+			Name:         "database-server",
+			Services: map[string]*pkg.ServiceDependency{
+				"database": {
+					Image:       "postgres:15",
+					HealthCheck: &pkg.HealthCheck{Test: []string{"CMD", "pg_isready"}},
+				},
+			},
+		},
+		"api-server": {
+			ServoVersion: "1.0",
+			Name:         "api-server",
+			Services: map[string]*pkg.ServiceDependency{
+				"cache": {Image: "redis:7"},
+				"api": {
+					Image:     "node:18",
+					DependsOn: []string{"database", "cache", "missing"},
+				},
+			},
+		},
+	}
+	for name, manifest := range manifests {
+		data, err := yaml.Marshal(manifest)
+		if err != nil {
+			t.Fatalf("Failed to marshal manifest: %v", err)
+		}
+		if err := os.WriteFile(testManifestPath(name+".servo"), data, 0644); err != nil {
+			t.Fatalf("Failed to write manifest: %v", err)
+		}
+	}
+
+	if err := NewDockerComposeGenerator(".servo").Generate(); err != nil {
+		t.Fatalf("Failed to generate docker-compose: %v", err)
+	}
+
+	var compose struct {
+		Services map[string]struct {
+			DependsOn map[string]struct {
+				Condition string `yaml:"condition"`
+			} `yaml:"depends_on"`
+		} `yaml:"services"`
+	}
+	composeData, err := os.ReadFile(".devcontainer/docker-compose.yml")
+	if err != nil {
+		t.Fatalf("Failed to read docker-compose.yml: %v", err)
+	}
+	if err := yaml.Unmarshal(composeData, &compose); err != nil {
+		t.Fatalf("Failed to parse docker-compose.yml: %v", err)
+	}
+
+	deps := compose.Services["api-server-api"].DependsOn
+	if len(deps) != 2 {
+		t.Fatalf("Expected 2 resolved dependencies, got %v", deps)
+	}
+	if deps["database-server-database"].Condition != "service_healthy" {
+		t.Errorf("Expected service_healthy for healthchecked database, got %q", deps["database-server-database"].Condition)
+	}
+	if deps["api-server-cache"].Condition != "service_started" {
+		t.Errorf("Expected service_started for cache without healthcheck, got %q", deps["api-server-cache"].Condition)
+	}
+}
+
+func TestAddServerDependencies(t *testing.T) {
+	services := map[string]interface{}{
+		"database-server-database": map[string]interface{}{},
+		"api-server-api":           map[string]interface{}{"depends_on": []string{"api-server-cache"}},
+		"api-server-cache":         map[string]interface{}{},
+		"tools-runner":             map[string]interface{}{},
+	}
+	manifests := map[string]*pkg.ServoDefinition{
+		"acme-db":    {Name: "database-server"},
+		"api-server": {Name: "api-server", DependsOn: []string{"database-server", "absent"}},
+		"tools":      {Name: "tools"},
+	}
+	manifestServices := map[string][]string{
+		"acme-db":    {"database-server-database"},
+		"api-server": {"api-server-api", "api-server-cache"},
+		"tools":      {"tools-runner"},
+	}
+
+	if err := addServerDependencies(services, manifests, manifestServices); err != nil {
+		t.Fatalf("addServerDependencies() error = %v", err)
+	}
+
+	api := services["api-server-api"].(map[string]interface{})["depends_on"]
+	if !reflect.DeepEqual(api, []string{"api-server-cache", "database-server-database"}) {
+		t.Errorf("Expected the service dependency kept and the server's services added, got %v", api)
+	}
+	cache := services["api-server-cache"].(map[string]interface{})["depends_on"]
+	if !reflect.DeepEqual(cache, []string{"database-server-database"}) {
+		t.Errorf("Expected every service of the dependent server to wait, got %v", cache)
+	}
+	if _, ok := services["tools-runner"].(map[string]interface{})["depends_on"]; ok {
+		t.Error("Servers without depends_on should be left alone")
+	}
+
+	manifests["acme-db"].DependsOn = []string{"api-server"}
+	err := addServerDependencies(services, manifests, manifestServices)
+	if err == nil || !strings.Contains(err.Error(), "cycle: acme-db -> api-server -> acme-db") {
+		t.Errorf("Expected a depends_on cycle error with its path, got %v", err)
+	}
+}
+
+func TestUpgradeDependsOn_KeepsLongForm(t *testing.T) {
+	config := map[string]interface{}{
+		"services": map[string]interface{}{
+			"web": map[string]interface{}{
+				"depends_on": []interface{}{"db"},
+			},
+			"worker": map[string]interface{}{
+				"depends_on": map[string]interface{}{
+					"db": map[string]interface{}{"condition": "service_completed_successfully"},
+				},
+			},
+			"db": map[string]interface{}{
+				"healthcheck": map[string]interface{}{"test": []string{"CMD", "true"}},
+			},
+		},
+	}
+
+	upgradeDependsOn(config)
+
+	services := config["services"].(map[string]interface{})
+	web := services["web"].(map[string]interface{})["depends_on"].(map[string]interface{})
+	if web["db"].(map[string]interface{})["condition"] != "service_healthy" {
+		t.Errorf("Expected web to wait for healthy db, got %v", web)
+	}
+	worker := services["worker"].(map[string]interface{})["depends_on"].(map[string]interface{})
+	if worker["db"].(map[string]interface{})["condition"] != "service_completed_successfully" {
+		t.Errorf("Long-form depends_on should be preserved, got %v", worker)
+	}
+}
+
+// BenchmarkAddServicesFromManifests benchmarks compose generation for a session
+// with dozens of services sharing project environment variables
+func BenchmarkAddServicesFromManifests(b *testing.B) {
+	servoDir := filepath.Join(b.TempDir(), ".servo")
+	if err := os.MkdirAll(servoDir, 0755); err != nil {
+		b.Fatalf("Failed to create servo directory: %v", err)
+	}
+	envContent := "version: \"1.0\"\nenv:\n  LOG_LEVEL: debug\n  REGION: us-east-1\n  FEATURE_FLAGS: all\n"
+	if err := os.WriteFile(filepath.Join(servoDir, "env.yaml"), []byte(envContent), 0644); err != nil {
+		b.Fatalf("Failed to write env.yaml: %v", err)
+	}
+
+	manifests := make(map[string]*pkg.ServoDefinition)
+	for m := 0; m < 8; m++ {
+		services := make(map[string]*pkg.ServiceDependency)
+		for s := 0; s < 6; s++ {
+			services[fmt.Sprintf("svc%d", s)] = &pkg.ServiceDependency{
+				Image:       "redis:7",
+				Ports:       []string{"6379"},
+				Environment: map[string]string{"SERVICE_INDEX": fmt.Sprint(s)},
+				Volumes:     []string{"data:/data"},
+			}
+		}
+		manifests[fmt.Sprintf("server%d", m)] = &pkg.ServoDefinition{Name: fmt.Sprintf("server%d", m), Services: services}
+	}
+
+	generator := NewDockerComposeGenerator(servoDir)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		config := generator.buildBaseDockerComposeConfig(".", "/workspace")
+		if err := generator.addServicesFromManifests(config, manifests, DefaultVolumeRoot, "", DefaultServicePrefix); err != nil {
+			b.Fatalf("addServicesFromManifests() error = %v", err)
+		}
+	}
+}
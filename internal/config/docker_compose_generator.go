@@ -2,8 +2,14 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
+	"github.com/servo/servo/internal/manifest"
 	"github.com/servo/servo/internal/override"
 	"github.com/servo/servo/internal/project"
 	"github.com/servo/servo/internal/utils"
@@ -13,6 +19,10 @@ import (
 // DockerComposeGenerator handles docker-compose.yml generation
 type DockerComposeGenerator struct {
 	*BaseGenerator
+
+	// envResolvers holds the environment resolver of each compose service, by service
+	// name, from the manifests through the override layers
+	envResolvers map[string]*Resolver
 }
 
 // NewDockerComposeGenerator creates a new docker-compose generator
@@ -22,6 +32,11 @@ func NewDockerComposeGenerator(servoDir string) *DockerComposeGenerator {
 	}
 }
 
+// Name returns the generator's registration name
+func (g *DockerComposeGenerator) Name() string {
+	return DockerComposeGeneratorName
+}
+
 // Generate creates a docker-compose.yml file from active session manifests.
 //
 // This method implements a multi-stage configuration generation process:
@@ -37,39 +52,148 @@ func NewDockerComposeGenerator(servoDir string) *DockerComposeGenerator {
 // The generated configuration supports both development and production deployment
 // scenarios with proper volume mounting, networking, and service dependencies.
 func (g *DockerComposeGenerator) Generate() error {
+	g.written = nil
+
 	project, activeSession, manifests, err := g.GetActiveSessionData()
 	if err != nil {
 		return err
 	}
 
-	if err := g.ValidateSecretsBeforeGeneration(project, manifests); err != nil {
+	single, err := g.singleContainer(project, activeSession.Name, manifests)
+	if err != nil {
+		return err
+	}
+	if single {
+		// A single-container devcontainer has no compose file; drop one left by compose mode
+		return g.removeStaleComposeFile()
+	}
+
+	if err := g.ValidateSecretsBeforeGeneration(project, activeSession.Name, manifests); err != nil {
 		return fmt.Errorf("secrets validation failed: %w", err)
 	}
 
 	g.SetupOverrideManager(activeSession.Name)
 
 	// Build the complete configuration through staged composition
-	dockerComposeConfig := g.buildBaseDockerComposeConfig()
-	if err := g.addServicesFromManifests(dockerComposeConfig, manifests); err != nil {
-		return fmt.Errorf("failed to add services from manifests: %w", err)
+	dockerComposeConfig, err := g.buildManifestConfig(project, activeSession.Name, manifests)
+	if err != nil {
+		return err
 	}
-	finalConfig := g.processDockerComposeOverrides(dockerComposeConfig)
-
-	if err := g.injectSecrets(finalConfig, project); err != nil {
-		return fmt.Errorf("failed to inject secrets: %w", err)
+	finalConfig, err := g.applyOverridesAndSecrets(dockerComposeConfig, project, activeSession.Name)
+	if err != nil {
+		return err
 	}
+	g.markIncomplete(finalConfig)
 
 	// Create .devcontainer directory
-	if err := utils.EnsureDirectoryStructure([]string{".devcontainer"}); err != nil {
+	if err := utils.EnsureDirectoryStructure([]string{g.outputPath(".devcontainer")}); err != nil {
 		return fmt.Errorf("failed to create .devcontainer directory: %w", err)
 	}
 
 	// Write docker-compose.yml
-	return utils.WriteYAMLFile(".devcontainer/docker-compose.yml", finalConfig)
+	composePath := g.outputPath(filepath.Join(".devcontainer", "docker-compose.yml"))
+	if err := utils.WriteYAMLFile(composePath, finalConfig); err != nil {
+		return err
+	}
+	g.written = append(g.written, composePath)
+	return nil
+}
+
+// Effective returns the docker-compose configuration Generate would write, with the
+// layer that set each value. Nothing is written and secrets are not validated; the
+// configuration only ever references secrets, so their values never appear. It is
+// nil for a single-container devcontainer, which has no compose file.
+func (g *DockerComposeGenerator) Effective() (*EffectiveConfig, error) {
+	project, activeSession, manifests, err := g.GetActiveSessionData()
+	if err != nil {
+		return nil, err
+	}
+	single, err := g.singleContainer(project, activeSession.Name, manifests)
+	if err != nil {
+		return nil, err
+	}
+	if single {
+		return nil, nil
+	}
+
+	g.SetupOverrideManager(activeSession.Name)
+	layers, err := g.overrideManager.DockerComposeLayers()
+	if err != nil {
+		return nil, err
+	}
+
+	dockerComposeConfig, err := g.buildManifestConfig(project, activeSession.Name, manifests)
+	if err != nil {
+		return nil, err
+	}
+	finalConfig, err := g.applyOverridesAndSecrets(dockerComposeConfig, project, activeSession.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	effective := newEffectiveConfig(activeSession.Name, finalConfig)
+	for name, resolver := range g.envResolvers {
+		effective.recordEnvironment([]string{"services", name, "environment"}, resolver)
+	}
+	for _, layer := range layers {
+		effective.record(layer.Layer, g.convertOverrideToMap(layer.Override))
+	}
+	return effective, nil
+}
+
+// serviceResolver returns the environment resolver of a compose service, creating
+// an empty one the first time
+func (g *DockerComposeGenerator) serviceResolver(serviceName string) *Resolver {
+	if g.envResolvers == nil {
+		g.envResolvers = make(map[string]*Resolver)
+	}
+	resolver, ok := g.envResolvers[serviceName]
+	if !ok {
+		resolver = NewResolver()
+		g.envResolvers[serviceName] = resolver
+	}
+	return resolver
+}
+
+// buildManifestConfig builds the docker-compose configuration from the session's
+// manifests, before any override is applied
+func (g *DockerComposeGenerator) buildManifestConfig(project *project.Project, sessionName string, manifests map[string]*pkg.ServoDefinition) (map[string]interface{}, error) {
+	g.envResolvers = nil
+	workspaceMount, workspaceTarget, err := g.ResolveWorkspaceMount(project)
+	if err != nil {
+		return nil, err
+	}
+	dockerComposeConfig := g.buildBaseDockerComposeConfig(workspaceMount, workspaceTarget)
+	servicePrefix, err := g.ResolveServicePrefix(project)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.addServicesFromManifests(dockerComposeConfig, manifests, g.ResolveVolumeRoot(project), g.ResolveHostUser(project), servicePrefix); err != nil {
+		return nil, fmt.Errorf("failed to add services from manifests: %w", err)
+	}
+	projectDir, err := g.WorkspaceProjectDir(project)
+	if err != nil {
+		return nil, err
+	}
+	addDevMounts(dockerComposeConfig, project, sessionName, devMountRoot(projectDir, workspaceTarget))
+	return dockerComposeConfig, nil
+}
+
+// applyOverridesAndSecrets layers the override files onto a manifest configuration
+// and adds the secret references its services need
+func (g *DockerComposeGenerator) applyOverridesAndSecrets(dockerComposeConfig map[string]interface{}, project *project.Project, sessionName string) (map[string]interface{}, error) {
+	finalConfig := g.processDockerComposeOverrides(dockerComposeConfig)
+	upgradeDependsOn(finalConfig)
+
+	if err := g.injectSecrets(finalConfig, project, sessionName); err != nil {
+		return nil, fmt.Errorf("failed to inject secrets: %w", err)
+	}
+	return finalConfig, nil
 }
 
-// buildBaseDockerComposeConfig creates the base infrastructure-only docker-compose configuration
-func (g *DockerComposeGenerator) buildBaseDockerComposeConfig() map[string]interface{} {
+// buildBaseDockerComposeConfig creates the base infrastructure-only docker-compose
+// configuration, with the workspace bind-mounted as workspaceMount at workspaceTarget
+func (g *DockerComposeGenerator) buildBaseDockerComposeConfig(workspaceMount, workspaceTarget string) map[string]interface{} {
 	config := map[string]interface{}{
 		"version":  "3.8",
 		"services": map[string]interface{}{},
@@ -81,44 +205,51 @@ func (g *DockerComposeGenerator) buildBaseDockerComposeConfig() map[string]inter
 	services := config["services"].(map[string]interface{})
 
 	// Add workspace service (infrastructure only)
-	workspaceService := g.buildWorkspaceService()
+	workspaceService := g.buildWorkspaceService(workspaceMount, workspaceTarget)
 	services["workspace"] = workspaceService
 
 	return config
 }
 
-// addServicesFromManifests adds services from manifests to docker-compose config
-func (g *DockerComposeGenerator) addServicesFromManifests(config map[string]interface{}, manifests map[string]*pkg.ServoDefinition) error {
+// addServicesFromManifests adds services from manifests to docker-compose config,
+// persisting named volumes under volumeRoot. When hostUser is set, services that
+// persist to those directories and declare no user of their own run as hostUser, so
+// the files they create stay owned by the host user. Service names come from the
+// servicePrefix template; two services expanding to the same name are an error.
+func (g *DockerComposeGenerator) addServicesFromManifests(config map[string]interface{}, manifests map[string]*pkg.ServoDefinition, volumeRoot, hostUser, servicePrefix string) error {
 	services := config["services"].(map[string]interface{})
-
-	for manifestName, manifest := range manifests {
+	hostRoot := composeVolumeRoot(volumeRoot)
+	composeLogsRoot := composeVolumeRoot(LogsRoot)
+	dependencies := make(map[string]serviceDependsOn)
+	manifestServices := make(map[string][]string)
+	serviceNames := make(map[string]map[string]string)
+	owners := map[string]string{"workspace": "the workspace container"}
+	var projectEnv map[string]string
+
+	manifestNames := make([]string, 0, len(manifests))
+	for manifestName := range manifests {
+		manifestNames = append(manifestNames, manifestName)
+	}
+	sort.Strings(manifestNames)
+
+	for _, manifestName := range manifestNames {
+		manifest := manifests[manifestName]
 		if manifest == nil {
 			continue
 		}
 
-		// Check both Dependencies.Services and direct Services fields
-		var servicesToAdd map[string]*pkg.ServiceDependency
-		if manifest.Dependencies != nil && manifest.Dependencies.Services != nil {
-			servicesToAdd = make(map[string]*pkg.ServiceDependency)
-			for name, service := range manifest.Dependencies.Services {
-				servicesToAdd[name] = &service
-			}
-		}
-		if manifest.Services != nil {
-			if servicesToAdd == nil {
-				servicesToAdd = manifest.Services
-			} else {
-				// Merge both if present
-				for name, service := range manifest.Services {
-					servicesToAdd[name] = service
+		servicesToAdd := manifest.AllServices()
+		if len(servicesToAdd) > 0 {
+			serviceNames[manifestName] = make(map[string]string, len(servicesToAdd))
+			for _, serviceName := range sortedServiceNames(servicesToAdd) {
+				service := servicesToAdd[serviceName]
+				prefixedName := ComposeServiceName(servicePrefix, manifestName, serviceName)
+				owner := fmt.Sprintf("service %s of %s", serviceName, manifestName)
+				if existing, taken := owners[prefixedName]; taken {
+					return fmt.Errorf("compose service name '%s' is used by both %s and %s; set config.service_prefix to a template with {manifest} to keep them apart", prefixedName, existing, owner)
 				}
-			}
-		}
-
-		if servicesToAdd != nil {
-			for serviceName, service := range servicesToAdd {
-				// Add service with prefix to avoid conflicts
-				prefixedName := fmt.Sprintf("%s-%s", manifestName, serviceName)
+				owners[prefixedName] = owner
+				serviceNames[manifestName][serviceName] = prefixedName
 				serviceConfig := make(map[string]interface{})
 
 				// Copy service configuration
@@ -129,29 +260,22 @@ func (g *DockerComposeGenerator) addServicesFromManifests(config map[string]inte
 					serviceConfig["ports"] = service.Ports
 				}
 				
-				// Merge environment variables from multiple sources
-				envSlice := []string{}
-				
-				// 1. Add project-level environment variables first
-				projectEnv, err := g.LoadProjectEnvironmentVariables()
-				if err != nil {
-					return fmt.Errorf("failed to load project environment variables: %w", err)
-				}
-				for key, value := range projectEnv {
-					envSlice = append(envSlice, key+"="+value)
-				}
-				
-				// 2. Add service-specific environment variables (these can override project-level)
-				if service.Environment != nil {
-					for key, value := range service.Environment {
-						envSlice = append(envSlice, key+"="+value)
+				// Project environment is loaded once for every service, and only when
+				// there is a service to add it to
+				if projectEnv == nil {
+					var err error
+					projectEnv, err = g.LoadProjectEnvironmentVariables()
+					if err != nil {
+						return fmt.Errorf("failed to load project environment variables: %w", err)
 					}
 				}
-				
-				// Set environment if we have any variables
-				if len(envSlice) > 0 {
+				resolver := g.serviceResolver(prefixedName).
+					Set(SourceProject, projectEnv).
+					Set(SourceService, manifest.TargetEnvironment(serviceName, service.Environment))
+				if envSlice := resolver.Environment(); len(envSlice) > 0 {
 					serviceConfig["environment"] = envSlice
 				}
+				persisted := false
 				if len(service.Volumes) > 0 {
 					// Transform volumes to use host paths for persistence
 					transformedVolumes := make([]string, 0, len(service.Volumes))
@@ -159,20 +283,27 @@ func (g *DockerComposeGenerator) addServicesFromManifests(config map[string]inte
 						if strings.Contains(volume, ":") {
 							parts := strings.Split(volume, ":")
 							volumeName := parts[0]
-							if !strings.HasPrefix(volumeName, "/") && !strings.HasPrefix(volumeName, ".") {
+							if isLogVolume(volume) {
+								// Log volume - its own directory under the service's logs directory
+								hostPath := fmt.Sprintf("%s/%s/%s/%s", composeLogsRoot, manifestName, serviceName, logVolumeDir(volume))
+								transformedVolumes = append(transformedVolumes, hostPath+":"+strings.Join(parts[1:], ":"))
+								persisted = true
+							} else if !strings.HasPrefix(volumeName, "/") && !strings.HasPrefix(volumeName, ".") {
 								// Named volume - transform to host path
-								hostPath := fmt.Sprintf("../.servo/services/%s/%s/%s", manifestName, serviceName, volumeName)
+								hostPath := fmt.Sprintf("%s/%s/%s/%s", hostRoot, manifestName, serviceName, volumeName)
 								transformedVolume := hostPath + ":" + strings.Join(parts[1:], ":")
 								transformedVolumes = append(transformedVolumes, transformedVolume)
+								persisted = true
 							} else {
 								// Already a host path or absolute path - keep as-is
 								transformedVolumes = append(transformedVolumes, volume)
 							}
 						} else {
 							// Simple named volume - transform to host path with default mount point
-							hostPath := fmt.Sprintf("../.servo/services/%s/%s/%s", manifestName, serviceName, volume)
+							hostPath := fmt.Sprintf("%s/%s/%s/%s", hostRoot, manifestName, serviceName, volume)
 							transformedVolume := hostPath + ":/data"
 							transformedVolumes = append(transformedVolumes, transformedVolume)
+							persisted = true
 						}
 					}
 					serviceConfig["volumes"] = transformedVolumes
@@ -180,30 +311,257 @@ func (g *DockerComposeGenerator) addServicesFromManifests(config map[string]inte
 				if len(service.Command) > 0 {
 					serviceConfig["command"] = service.Command
 				}
+				if service.User != "" {
+					serviceConfig["user"] = service.User
+				} else if persisted && hostUser != "" {
+					serviceConfig["user"] = hostUser
+				}
+				entrypoint, err := service.EntrypointValue()
+				if err != nil {
+					return fmt.Errorf("invalid entrypoint for service %s: %w", prefixedName, err)
+				}
+				if entrypoint != nil {
+					serviceConfig["entrypoint"] = entrypoint
+				}
+				if len(service.Profiles) > 0 {
+					serviceConfig["profiles"] = service.Profiles
+				}
+				if service.HealthCheck != nil {
+					serviceConfig["healthcheck"] = buildHealthCheckConfig(service.HealthCheck)
+				}
+				if service.Init {
+					serviceConfig["init"] = true
+				}
+				if service.TTY {
+					serviceConfig["tty"] = true
+				}
+				if service.StdinOpen {
+					serviceConfig["stdin_open"] = true
+				}
+				if len(service.DependsOn) > 0 {
+					dependencies[prefixedName] = serviceDependsOn{manifest: manifestName, targets: service.DependsOn}
+				}
 
 				services[prefixedName] = serviceConfig
+				manifestServices[manifestName] = append(manifestServices[manifestName], prefixedName)
+			}
+		}
+	}
+
+	// Targets are resolved once every manifest's services are known
+	for serviceName, deps := range dependencies {
+		var resolved []string
+		for _, target := range deps.targets {
+			name, ok := resolveDependsOnTarget(services, serviceNames, deps.manifest, target)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "⚠️  Warning: service %s depends on unknown service %s, ignoring\n", serviceName, target)
+				continue
+			}
+			resolved = append(resolved, name)
+		}
+		if len(resolved) > 0 {
+			services[serviceName].(map[string]interface{})["depends_on"] = resolved
+		}
+	}
+
+	return addServerDependencies(services, manifests, manifestServices)
+}
+
+// addServerDependencies makes the services of a manifest with a server-level depends_on
+// wait for every service of the servers it depends on. Servers missing from the session
+// are skipped; install and validate --installed report them. A cycle is an error,
+// since compose could start none of the services on it.
+func addServerDependencies(services map[string]interface{}, manifests map[string]*pkg.ServoDefinition, manifestServices map[string][]string) error {
+	if cycle := manifest.DependencyCycle(manifests); cycle != nil {
+		return fmt.Errorf("server depends_on cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	for manifestName, servoDef := range manifests {
+		if servoDef == nil || len(servoDef.DependsOn) == 0 {
+			continue
+		}
+
+		var upstream []string
+		for _, server := range servoDef.DependsOn {
+			for _, key := range manifest.Providers(manifests, server) {
+				if key != manifestName {
+					upstream = append(upstream, manifestServices[key]...)
+				}
+			}
+		}
+		sort.Strings(upstream)
+
+		for _, serviceName := range manifestServices[manifestName] {
+			serviceMap := services[serviceName].(map[string]interface{})
+			dependsOn, _ := serviceMap["depends_on"].([]string)
+			for _, target := range upstream {
+				if !slices.Contains(dependsOn, target) {
+					dependsOn = append(dependsOn, target)
+				}
+			}
+			if len(dependsOn) > 0 {
+				serviceMap["depends_on"] = dependsOn
 			}
 		}
 	}
 	return nil
 }
 
-// buildWorkspaceService creates the main workspace service
-func (g *DockerComposeGenerator) buildWorkspaceService() map[string]interface{} {
+// serviceDependsOn holds a manifest service's unresolved depends_on targets
+type serviceDependsOn struct {
+	manifest string
+	targets  []string
+}
+
+// resolveDependsOnTarget maps a manifest depends_on entry to a compose service name,
+// given each manifest's service names mapped to compose names. A service of the same
+// manifest wins, then an exact compose service name, then a service with that name
+// in exactly one other manifest.
+func resolveDependsOnTarget(services map[string]interface{}, serviceNames map[string]map[string]string, manifestName, target string) (string, bool) {
+	if name, ok := serviceNames[manifestName][target]; ok {
+		return name, true
+	}
+	if services[target] != nil {
+		return target, true
+	}
+
+	var matches []string
+	for _, names := range serviceNames {
+		if name, ok := names[target]; ok {
+			matches = append(matches, name)
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], true
+	}
+	return "", false
+}
+
+// sortedServiceNames returns the names of services in a stable order, so collisions
+// and generated output do not depend on map iteration
+func sortedServiceNames(services map[string]*pkg.ServiceDependency) []string {
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// upgradeDependsOn rewrites short-form depends_on lists to the long form so dependents
+// wait for readiness: service_healthy when the target has a healthcheck, service_started otherwise.
+// Long-form entries written by overrides are left untouched.
+func upgradeDependsOn(config map[string]interface{}) {
+	services, ok := config["services"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for _, serviceConfig := range services {
+		serviceMap, ok := serviceConfig.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var targets []string
+		switch deps := serviceMap["depends_on"].(type) {
+		case []string:
+			targets = deps
+		case []interface{}:
+			for _, dep := range deps {
+				targets = append(targets, fmt.Sprint(dep))
+			}
+		default:
+			continue
+		}
+
+		longForm := make(map[string]interface{}, len(targets))
+		for _, target := range targets {
+			condition := "service_started"
+			if targetMap, ok := services[target].(map[string]interface{}); ok && targetMap["healthcheck"] != nil {
+				condition = "service_healthy"
+			}
+			longForm[target] = map[string]interface{}{"condition": condition}
+		}
+		serviceMap["depends_on"] = longForm
+	}
+}
+
+// buildHealthCheckConfig converts a manifest healthcheck into its compose form
+func buildHealthCheckConfig(hc *pkg.HealthCheck) map[string]interface{} {
+	healthcheck := map[string]interface{}{
+		"test": hc.Test,
+	}
+	if hc.Interval != "" {
+		healthcheck["interval"] = hc.Interval
+	}
+	if hc.Timeout != "" {
+		healthcheck["timeout"] = hc.Timeout
+	}
+	if hc.Retries > 0 {
+		healthcheck["retries"] = hc.Retries
+	}
+	return healthcheck
+}
+
+// devMount is the local checkout of a --dev server and where the workspace mounts it
+type devMount struct {
+	source string
+	target string
+}
+
+// devMounts lists the checkout of every --dev server in the session, mounted at
+// <root>/<server>. A checkout recorded relative to the project root is returned
+// relative to .devcontainer/, which compose and devcontainer.json resolve it against.
+func devMounts(project *project.Project, sessionName, root string) []devMount {
+	var mounts []devMount
+	for _, server := range project.MCPServers {
+		if !server.Dev || !slices.Contains(server.Sessions, sessionName) {
+			continue
+		}
+		source := filepath.ToSlash(filepath.Clean(server.Source))
+		if !filepath.IsAbs(server.Source) {
+			source = path.Join("..", source)
+		}
+		mounts = append(mounts, devMount{
+			source: source,
+			target: path.Join(root, server.Name),
+		})
+	}
+	return mounts
+}
+
+// addDevMounts bind-mounts the local checkout of every --dev server in the session
+// into the workspace service at <root>/<server>
+func addDevMounts(config map[string]interface{}, project *project.Project, sessionName, root string) {
+	services, _ := config["services"].(map[string]interface{})
+	workspace, _ := services["workspace"].(map[string]interface{})
+	if workspace == nil {
+		return
+	}
+	volumes, _ := workspace["volumes"].([]interface{})
+
+	for _, mount := range devMounts(project, sessionName, root) {
+		volumes = append(volumes, mount.source+":"+mount.target+":cached")
+	}
+	workspace["volumes"] = volumes
+}
+
+// buildWorkspaceService creates the main workspace service, which starts in the
+// directory the workspace is mounted at
+func (g *DockerComposeGenerator) buildWorkspaceService(workspaceMount, workspaceTarget string) map[string]interface{} {
 	return map[string]interface{}{
 		"build": map[string]interface{}{
 			"dockerfile": "Dockerfile",
 			"context":    "..",
 		},
 		"volumes": []interface{}{
-			"../..:/workspaces:cached",
-			"workspace-data:/workspace/.servo",
+			workspaceMount,
+			"workspace-data:" + project.WorkspaceDataMount,
 		},
 		"command": "/bin/sh -c \"while sleep 1000; do :; done\"",
-		"environment": []string{
-			"SERVO_DEV_MODE=1",
-		},
-		"working_dir": "/workspaces",
+		"environment": g.serviceResolver("workspace").Set(SourceDefault, map[string]string{"SERVO_DEV_MODE": "1"}).Environment(),
+		"working_dir": workspaceTarget,
 	}
 }
 
@@ -248,10 +606,10 @@ func (g *DockerComposeGenerator) applyDockerComposeOverrides(base map[string]int
 					for serviceName, serviceConfig := range overrideServices {
 						if existingService, exists := baseServices[serviceName]; exists {
 							// Merge existing service
-							baseServices[serviceName] = g.mergeServiceConfigs(existingService, serviceConfig)
+							baseServices[serviceName] = g.mergeServiceConfigs(serviceName, existingService, serviceConfig)
 						} else {
-							// Add new service
-							baseServices[serviceName] = serviceConfig
+							// Add new service, its environment resolved like every other
+							baseServices[serviceName] = g.mergeServiceConfigs(serviceName, map[string]interface{}{}, serviceConfig)
 						}
 					}
 				}
@@ -278,8 +636,8 @@ func (g *DockerComposeGenerator) applyDockerComposeOverrides(base map[string]int
 	return result
 }
 
-// mergeServiceConfigs merges two service configurations
-func (g *DockerComposeGenerator) mergeServiceConfigs(base, override interface{}) interface{} {
+// mergeServiceConfigs merges two configurations of the named service
+func (g *DockerComposeGenerator) mergeServiceConfigs(serviceName string, base, override interface{}) interface{} {
 	baseMap, baseOk := base.(map[string]interface{})
 	overrideMap, overrideOk := override.(map[string]interface{})
 
@@ -300,7 +658,7 @@ func (g *DockerComposeGenerator) mergeServiceConfigs(base, override interface{})
 		switch key {
 		case "environment":
 			// Merge environment variables
-			result[key] = g.mergeEnvironmentVars(result[key], value)
+			result[key] = g.mergeEnvironmentVars(serviceName, result[key], value)
 		case "volumes":
 			// Merge volume mounts
 			result[key] = g.mergeSlices(result[key], value)
@@ -316,24 +674,16 @@ func (g *DockerComposeGenerator) mergeServiceConfigs(base, override interface{})
 	return result
 }
 
-// mergeEnvironmentVars merges environment variable configurations
-func (g *DockerComposeGenerator) mergeEnvironmentVars(base, override interface{}) interface{} {
-	// Handle both map[string]string and []string formats
-	baseEnv := g.normalizeEnvVars(base)
-	overrideEnv := g.normalizeEnvVars(override)
-
-	// Merge
-	for key, value := range overrideEnv {
-		baseEnv[key] = value
+// mergeEnvironmentVars sets the override layer of the service's resolver. A service
+// the manifests did not build starts from the environment it was written with.
+func (g *DockerComposeGenerator) mergeEnvironmentVars(serviceName string, base, override interface{}) interface{} {
+	if _, ok := g.envResolvers[serviceName]; !ok {
+		g.serviceResolver(serviceName).Set(SourceService, g.normalizeEnvVars(base))
 	}
-
-	// Convert back to slice format (preferred for docker-compose)
-	result := make([]string, 0, len(baseEnv))
-	for key, value := range baseEnv {
-		result = append(result, key+"="+value)
-	}
-
-	return result
+	// Handle both map[string]string and []string formats; the override layer wins
+	return g.serviceResolver(serviceName).
+		Set(SourceOverride, g.normalizeEnvVars(override)).
+		Environment()
 }
 
 // normalizeEnvVars converts environment variables to map[string]string format
@@ -409,9 +759,9 @@ func (g *DockerComposeGenerator) mergeSlices(base, override interface{}) interfa
 }
 
 // injectSecrets injects secrets into docker-compose configuration
-func (g *DockerComposeGenerator) injectSecrets(config map[string]interface{}, project *project.Project) error {
+func (g *DockerComposeGenerator) injectSecrets(config map[string]interface{}, project *project.Project, sessionName string) error {
 	// Get configured secrets
-	configuredSecrets, err := g.projectManager.GetConfiguredSecrets()
+	configuredSecrets, err := g.projectManager.GetConfiguredSecrets(sessionName)
 	if err != nil {
 		return fmt.Errorf("failed to get configured secrets: %w", err)
 	}
@@ -429,6 +779,23 @@ func (g *DockerComposeGenerator) injectSecrets(config map[string]interface{}, pr
 		for serviceName, serviceConfig := range services {
 			if serviceMap, ok := serviceConfig.(map[string]interface{}); ok {
 				neededSecrets := g.serviceNeedsSecrets(serviceMap, configuredSecrets)
+				if resolver, ok := g.envResolvers[serviceName]; ok {
+					// The resolver finds ${...} references anywhere in a value, and only
+					// to configured secrets; labels and /run/secrets paths still count
+					neededSecrets = nil
+					for secretName := range g.labelSecrets(serviceMap) {
+						neededSecrets = append(neededSecrets, secretName)
+					}
+					for secretName := range g.envSecretReferences(serviceMap) {
+						if configuredSecrets[secretName] {
+							neededSecrets = append(neededSecrets, secretName)
+						}
+					}
+					neededSecrets = g.resolveSecretReferences(resolver, configuredSecrets, neededSecrets)
+					if env := resolver.Environment(); len(env) > 0 {
+						serviceMap["environment"] = env
+					}
+				}
 				if len(neededSecrets) > 0 {
 					// Add secrets section to service
 					if serviceMap["secrets"] == nil {
@@ -464,41 +831,39 @@ func (g *DockerComposeGenerator) injectSecrets(config map[string]interface{}, pr
 	return nil
 }
 
-// expandSecrets expands secret placeholders in a string
-func (g *DockerComposeGenerator) expandSecrets(value string, secretProvider func(string) (string, error)) string {
-	if !strings.Contains(value, "${") {
-		return value
-	}
-
-	// Simple secret expansion - replace ${SECRET_NAME} with actual values
-	result := value
-	start := strings.Index(result, "${")
-	for start != -1 {
-		end := strings.Index(result[start:], "}")
-		if end == -1 {
-			break
+// resolveSecretReferences scans a service's resolved environment and adds every
+// configured secret referenced anywhere in a value to needed. No lookup is installed
+// on the resolver: the environment goes to docker compose, which reads the values
+// from the mounted secrets and must still see $${NAME} escapes as written.
+func (g *DockerComposeGenerator) resolveSecretReferences(resolver *Resolver, configuredSecrets map[string]bool, needed []string) []string {
+	seen := make(map[string]bool, len(needed))
+	unique := needed[:0]
+	for _, name := range needed {
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
 		}
-		end += start
-
-		secretName := result[start+2 : end]
-		secretValue, err := secretProvider(secretName)
-		if err != nil || secretValue == "" {
-			// Keep placeholder if secret not found
-			start = strings.Index(result[end+1:], "${")
-			if start != -1 {
-				start += end + 1
-			}
-			continue
-		}
-
-		result = result[:start] + secretValue + result[end+1:]
-		start = strings.Index(result[start+len(secretValue):], "${")
-		if start != -1 {
-			start += len(secretValue)
+	}
+	needed = unique
+	collect := func(name string) (string, error) {
+		secretName := strings.ToLower(name)
+		if configuredSecrets[secretName] && !seen[secretName] {
+			seen[secretName] = true
+			needed = append(needed, secretName)
 		}
+		return "", errLeaveReference
+	}
+	for _, key := range resolver.Keys() {
+		value, _ := resolver.Resolve(key)
+		expandVariables(value, collect)
 	}
+	return needed
+}
 
-	return result
+// expandSecrets expands secret placeholders in a string with the rules of
+// expandVariables
+func (g *DockerComposeGenerator) expandSecrets(value string, secretProvider func(string) (string, error)) string {
+	return expandVariables(value, secretProvider)
 }
 
 // processDockerComposeOverrides applies override configurations to docker-compose config
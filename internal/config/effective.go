@@ -0,0 +1,167 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LayerManifest attributes the values servo generates from the session's manifests,
+// before any override layer is applied
+const LayerManifest = "manifest"
+
+// LayerProjectEnv attributes environment variables taken from .servo/env.yaml
+const LayerProjectEnv = "env.yaml"
+
+// EffectiveConfig is a generated configuration together with the override layer that
+// last set each of its values
+type EffectiveConfig struct {
+	Session string
+	Config  map[string]interface{}
+	origins map[string]string
+}
+
+// newEffectiveConfig wraps a generated configuration whose values all start out
+// attributed to the manifests
+func newEffectiveConfig(sessionName string, cfg map[string]interface{}) *EffectiveConfig {
+	return &EffectiveConfig{
+		Session: sessionName,
+		Config:  cfg,
+		origins: make(map[string]string),
+	}
+}
+
+// record attributes every value an override layer sets to that layer. Layers are
+// recorded in merge order, so a later layer takes over the values it also sets.
+func (e *EffectiveConfig) record(layer string, overrides map[string]interface{}) {
+	walkLeaves(overrides, nil, func(path []string) {
+		e.origins[originKey(path)] = layer
+	})
+}
+
+// recordEnvironment attributes each variable of the environment at path to the
+// resolver layer it came from. Override values are left to record, which knows the
+// override layer that set them.
+func (e *EffectiveConfig) recordEnvironment(path []string, resolver *Resolver) {
+	for _, key := range resolver.Keys() {
+		_, source := resolver.Resolve(key)
+		layer := LayerManifest
+		switch source {
+		case SourceOverride:
+			continue
+		case SourceProject:
+			layer = LayerProjectEnv
+		}
+		e.origins[originKey(append(slices.Clone(path), key))] = layer
+	}
+}
+
+// Origin returns the layer that set the value at path. Values no override touched,
+// and values only reshaped after merging, fall back to their closest recorded parent
+// and then to the manifests.
+func (e *EffectiveConfig) Origin(path ...string) string {
+	for i := len(path); i > 0; i-- {
+		if layer, ok := e.origins[originKey(path[:i])]; ok {
+			return layer
+		}
+	}
+	return LayerManifest
+}
+
+// AnnotatedYAML renders the configuration as YAML with each value commented with the
+// layer that set it. Environment entries are annotated one variable at a time.
+func (e *EffectiveConfig) AnnotatedYAML() ([]byte, error) {
+	var doc yaml.Node
+	if err := doc.Encode(e.Config); err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	e.annotate(&doc, nil)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to render configuration: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to render configuration: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// annotate sets a line comment naming the winning layer on each value under node
+func (e *EffectiveConfig) annotate(node *yaml.Node, path []string) {
+	if node.Kind == yaml.DocumentNode {
+		for _, child := range node.Content {
+			e.annotate(child, path)
+		}
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		valuePath := append(slices.Clone(path), key.Value)
+
+		switch {
+		case value.Kind == yaml.MappingNode && len(value.Content) > 0:
+			e.annotate(value, valuePath)
+		case value.Kind == yaml.SequenceNode && key.Value == "environment":
+			for _, item := range value.Content {
+				name, _, _ := strings.Cut(item.Value, "=")
+				item.LineComment = e.Origin(append(slices.Clone(valuePath), name)...)
+			}
+		case value.Kind == yaml.ScalarNode:
+			value.LineComment = e.Origin(valuePath...)
+		default:
+			key.LineComment = e.Origin(valuePath...)
+		}
+	}
+}
+
+// walkLeaves calls fn with the path of every value in v that is not itself a
+// non-empty map. Environment lists of KEY=VALUE entries are walked per variable.
+func walkLeaves(v interface{}, path []string, fn func(path []string)) {
+	child := func(key string) []string {
+		return append(slices.Clone(path), key)
+	}
+
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if len(value) == 0 && len(path) > 0 {
+			fn(path)
+		}
+		for key, item := range value {
+			walkLeaves(item, child(key), fn)
+		}
+		return
+	case map[string]string:
+		if len(value) == 0 && len(path) > 0 {
+			fn(path)
+		}
+		for key := range value {
+			fn(child(key))
+		}
+		return
+	case []string:
+		if len(path) > 0 && path[len(path)-1] == "environment" {
+			for _, entry := range value {
+				name, _, _ := strings.Cut(entry, "=")
+				fn(child(name))
+			}
+			return
+		}
+	}
+
+	if len(path) > 0 {
+		fn(path)
+	}
+}
+
+// originKey joins a value path into a lookup key. Keys may themselves contain dots,
+// as labels often do, so a separator that cannot appear in YAML keys is used.
+func originKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
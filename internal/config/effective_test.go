@@ -0,0 +1,111 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/override"
+	"github.com/servo/servo/internal/session"
+)
+
+func TestEffectiveConfig_LayerAttribution(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+	t.Setenv("SERVO_ENV", "ci")
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+
+	manifest := `servo_version: "1.0"
+name: app
+services:
+  db:
+    image: postgres:15
+    environment:
+      POSTGRES_DB: app
+      POSTGRES_USER: app
+      POSTGRES_PASSWORD: "${db_password}"
+`
+	if err := os.WriteFile(testManifestPath("app.servo"), []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	sessionConfigDir := filepath.Join(session.NewManager(".servo").GetSessionDir("test"), "config")
+	overrides := map[string]string{
+		filepath.Join(".servo", "config", "docker-compose.yml"):    "services:\n  app-db:\n    image: postgres:16\n",
+		filepath.Join(".servo", "config", "docker-compose.ci.yml"): "services:\n  app-db:\n    environment:\n      POSTGRES_USER: ci\n",
+		filepath.Join(sessionConfigDir, "docker-compose.yml"):      "services:\n  app-db:\n    environment:\n      POSTGRES_DB: scratch\n",
+		filepath.Join(".servo", "config", "devcontainer.json"):     `{"name": "Team Env", "containerEnv": {"EDITOR": "vim"}}`,
+		filepath.Join(".servo", "env.yaml"):                        "version: \"1.0\"\nenv:\n  LOG_LEVEL: debug\n",
+	}
+	for path, content := range overrides {
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write override %s: %v", path, err)
+		}
+	}
+
+	compose := NewDockerComposeGenerator(".servo")
+	compose.SetSession("test")
+	effective, err := compose.Effective()
+	if err != nil {
+		t.Fatalf("Effective() error = %v", err)
+	}
+
+	service := effective.Config["services"].(map[string]interface{})["app-db"].(map[string]interface{})
+	if service["image"] != "postgres:16" {
+		t.Errorf("Expected the project override image, got %v", service["image"])
+	}
+
+	origins := map[string][]string{
+		LayerManifest:             {"services", "app-db", "environment", "POSTGRES_PASSWORD"},
+		LayerProjectEnv:           {"services", "app-db", "environment", "LOG_LEVEL"},
+		override.LayerProject:     {"services", "app-db", "image"},
+		override.LayerEnvironment: {"services", "app-db", "environment", "POSTGRES_USER"},
+		override.LayerSession:     {"services", "app-db", "environment", "POSTGRES_DB"},
+	}
+	for want, path := range origins {
+		if got := effective.Origin(path...); got != want {
+			t.Errorf("Origin(%s) = %s, want %s", strings.Join(path, "."), got, want)
+		}
+	}
+
+	data, err := effective.AnnotatedYAML()
+	if err != nil {
+		t.Fatalf("AnnotatedYAML() error = %v", err)
+	}
+	for _, want := range []string{
+		"image: postgres:16 # project",
+		"- POSTGRES_USER=ci # env",
+		"- POSTGRES_DB=scratch # session",
+		"- POSTGRES_PASSWORD=${db_password} # manifest",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Annotated output missing %q:\n%s", want, data)
+		}
+	}
+
+	devcontainer := NewDevcontainerGenerator(".servo")
+	devcontainer.SetSession("test")
+	effective, err = devcontainer.Effective()
+	if err != nil {
+		t.Fatalf("devcontainer Effective() error = %v", err)
+	}
+	if effective.Config["name"] != "Team Env" || effective.Origin("name") != override.LayerProject {
+		t.Errorf("Expected the project devcontainer name, got %v from %s", effective.Config["name"], effective.Origin("name"))
+	}
+	if env, _ := effective.Config["containerEnv"].(map[string]string); env["EDITOR"] != "vim" || effective.Origin("containerEnv", "EDITOR") != override.LayerProject {
+		t.Errorf("Expected the project override's containerEnv, got %v", effective.Config["containerEnv"])
+	}
+	if effective.Origin("service") != LayerManifest {
+		t.Errorf("Expected untouched values to come from the manifests, got %s", effective.Origin("service"))
+	}
+
+	if _, err := os.Stat(".devcontainer"); !os.IsNotExist(err) {
+		t.Error("Effective() should not write any files")
+	}
+}
@@ -1,43 +1,194 @@
 package config
 
+import (
+	"fmt"
+	"sort"
+
+	"github.com/servo/servo/internal/logging"
+)
+
+// Generator names for the built-in generators
+const (
+	DevcontainerGeneratorName  = "devcontainer"
+	DockerComposeGeneratorName = "docker-compose"
+	BundleGeneratorName        = "bundle"
+)
+
 // Generator defines the interface for configuration generators
 type Generator interface {
+	Name() string
 	Generate() error
 }
 
+// OutputGenerator is implemented by generators that can write under an arbitrary
+// output root and report the files they wrote
+type OutputGenerator interface {
+	Generator
+	SetOutputRoot(root string)
+	WrittenFiles() []string
+}
+
+// IncompleteMarkerKey is the top-level key that marks generated output as incomplete
+// because it was written while required secrets were missing
+const IncompleteMarkerKey = "x-servo-incomplete"
+
+// SecretValidatingGenerator is implemented by generators that refuse to run while
+// required secrets are missing, unless told to skip that check
+type SecretValidatingGenerator interface {
+	Generator
+	SetSkipSecretValidation(skip bool)
+	MissingSecrets() []string
+}
+
 // ConfigGeneratorManager coordinates infrastructure configuration generators
 type ConfigGeneratorManager struct {
-	devcontainerGen  *DevcontainerGenerator
-	dockerComposeGen *DockerComposeGenerator
+	generators           []Generator
+	outputRoot           string
+	skipSecretValidation bool
 }
 
 // NewConfigGeneratorManager creates a new configuration generator manager
+// with the built-in devcontainer, docker-compose and bundle generators registered
 func NewConfigGeneratorManager(servoDir string) *ConfigGeneratorManager {
-	return &ConfigGeneratorManager{
-		devcontainerGen:  NewDevcontainerGenerator(servoDir),
-		dockerComposeGen: NewDockerComposeGenerator(servoDir),
+	m := &ConfigGeneratorManager{}
+	m.Register(NewDevcontainerGenerator(servoDir))
+	m.Register(NewDockerComposeGenerator(servoDir))
+	m.Register(NewBundleGenerator(servoDir))
+	return m
+}
+
+// Register adds a generator, replacing any registered generator with the same name.
+// Generators run in registration order.
+func (m *ConfigGeneratorManager) Register(gen Generator) {
+	if out, ok := gen.(OutputGenerator); ok && m.outputRoot != "" {
+		out.SetOutputRoot(m.outputRoot)
+	}
+	if sv, ok := gen.(SecretValidatingGenerator); ok && m.skipSecretValidation {
+		sv.SetSkipSecretValidation(true)
+	}
+	for i, existing := range m.generators {
+		if existing.Name() == gen.Name() {
+			m.generators[i] = gen
+			return
+		}
+	}
+	m.generators = append(m.generators, gen)
+}
+
+// SetOutputRoot makes every generator that supports it write under root, for example
+// a temp dir for diffing or tests. The default, "", writes into the current project.
+func (m *ConfigGeneratorManager) SetOutputRoot(root string) {
+	m.outputRoot = root
+	for _, gen := range m.generators {
+		if out, ok := gen.(OutputGenerator); ok {
+			out.SetOutputRoot(root)
+		}
+	}
+}
+
+// SetSkipSecretValidation makes every generator that checks secrets generate anyway
+// when required secrets are missing, for inspecting the output before secrets are set.
+// The output is marked incomplete; MissingSecrets reports what was skipped.
+func (m *ConfigGeneratorManager) SetSkipSecretValidation(skip bool) {
+	m.skipSecretValidation = skip
+	for _, gen := range m.generators {
+		if sv, ok := gen.(SecretValidatingGenerator); ok {
+			sv.SetSkipSecretValidation(skip)
+		}
 	}
 }
 
+// MissingSecrets returns, sorted and deduplicated, the required secrets that the
+// generators went ahead without during their most recent run
+func (m *ConfigGeneratorManager) MissingSecrets() []string {
+	seen := make(map[string]bool)
+	var missing []string
+	for _, gen := range m.generators {
+		sv, ok := gen.(SecretValidatingGenerator)
+		if !ok {
+			continue
+		}
+		for _, secret := range sv.MissingSecrets() {
+			if !seen[secret] {
+				seen[secret] = true
+				missing = append(missing, secret)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// Generators returns the registered generators in execution order
+func (m *ConfigGeneratorManager) Generators() []Generator {
+	return append([]Generator(nil), m.generators...)
+}
+
+// Generate runs a single registered generator by name
+func (m *ConfigGeneratorManager) Generate(name string) error {
+	for _, gen := range m.generators {
+		if gen.Name() == name {
+			if err := gen.Generate(); err != nil {
+				return err
+			}
+			logWritten(gen)
+			return nil
+		}
+	}
+	return fmt.Errorf("no generator registered with name '%s'", name)
+}
+
 // GenerateDevcontainer generates devcontainer configuration
 func (m *ConfigGeneratorManager) GenerateDevcontainer() error {
-	return m.devcontainerGen.Generate()
+	return m.Generate(DevcontainerGeneratorName)
 }
 
 // GenerateDockerCompose generates docker-compose configuration
 func (m *ConfigGeneratorManager) GenerateDockerCompose() error {
-	return m.dockerComposeGen.Generate()
+	return m.Generate(DockerComposeGeneratorName)
+}
+
+// GenerateBundle writes servers.json when the project enables config.bundle
+func (m *ConfigGeneratorManager) GenerateBundle() error {
+	return m.Generate(BundleGeneratorName)
 }
 
 // GenerateAll generates all infrastructure configuration files
 func (m *ConfigGeneratorManager) GenerateAll() error {
-	if err := m.GenerateDevcontainer(); err != nil {
-		return err
+	for _, gen := range m.generators {
+		if err := gen.Generate(); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", gen.Name(), err)
+		}
+		logWritten(gen)
 	}
 
-	if err := m.GenerateDockerCompose(); err != nil {
-		return err
+	return nil
+}
+
+// GenerateFiles runs every generator like GenerateAll and returns the paths written,
+// in generation order. Generators that do not report their output contribute nothing.
+func (m *ConfigGeneratorManager) GenerateFiles() ([]string, error) {
+	var files []string
+	for _, gen := range m.generators {
+		if err := gen.Generate(); err != nil {
+			return files, fmt.Errorf("failed to generate %s: %w", gen.Name(), err)
+		}
+		logWritten(gen)
+		if out, ok := gen.(OutputGenerator); ok {
+			files = append(files, out.WrittenFiles()...)
+		}
 	}
 
-	return nil
+	return files, nil
+}
+
+// logWritten logs each file a generator reports writing
+func logWritten(gen Generator) {
+	out, ok := gen.(OutputGenerator)
+	if !ok {
+		return
+	}
+	for _, path := range out.WrittenFiles() {
+		logging.Info("wrote file", "generator", gen.Name(), "path", path)
+	}
 }
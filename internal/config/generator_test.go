@@ -0,0 +1,137 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type fakeGenerator struct {
+	name  string
+	err   error
+	calls *[]string
+}
+
+func (f *fakeGenerator) Name() string {
+	return f.name
+}
+
+func (f *fakeGenerator) Generate() error {
+	*f.calls = append(*f.calls, f.name)
+	return f.err
+}
+
+func TestConfigGeneratorManager_DefaultGenerators(t *testing.T) {
+	manager := NewConfigGeneratorManager(".servo")
+
+	generators := manager.Generators()
+	if len(generators) != 3 {
+		t.Fatalf("Expected 3 built-in generators, got %d", len(generators))
+	}
+	if generators[0].Name() != DevcontainerGeneratorName {
+		t.Errorf("Expected first generator %s, got %s", DevcontainerGeneratorName, generators[0].Name())
+	}
+	if generators[1].Name() != DockerComposeGeneratorName {
+		t.Errorf("Expected second generator %s, got %s", DockerComposeGeneratorName, generators[1].Name())
+	}
+	if generators[2].Name() != BundleGeneratorName {
+		t.Errorf("Expected third generator %s, got %s", BundleGeneratorName, generators[2].Name())
+	}
+}
+
+func TestConfigGeneratorManager_RegisterFakes(t *testing.T) {
+	var calls []string
+	manager := &ConfigGeneratorManager{}
+	manager.Register(&fakeGenerator{name: "first", calls: &calls})
+	manager.Register(&fakeGenerator{name: "second", calls: &calls})
+
+	if err := manager.GenerateAll(); err != nil {
+		t.Fatalf("GenerateAll returned error: %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "first" || calls[1] != "second" {
+		t.Errorf("Expected generators to run in registration order, got %v", calls)
+	}
+
+	// Registering the same name replaces the generator in place
+	failure := errors.New("boom")
+	manager.Register(&fakeGenerator{name: "first", err: failure, calls: &calls})
+	if len(manager.Generators()) != 2 {
+		t.Errorf("Expected replacement rather than append, got %d generators", len(manager.Generators()))
+	}
+
+	calls = nil
+	err := manager.GenerateAll()
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected wrapped generator error, got %v", err)
+	}
+	if len(calls) != 1 {
+		t.Errorf("Expected GenerateAll to stop at the first failure, got %v", calls)
+	}
+}
+
+func TestConfigGeneratorManager_GenerateUnknown(t *testing.T) {
+	manager := &ConfigGeneratorManager{}
+	if err := manager.GenerateDevcontainer(); err == nil {
+		t.Error("Expected error when devcontainer generator is not registered")
+	}
+}
+
+func TestConfigGeneratorManager_GenerateFilesToOutputRoot(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+	if err := createBasicDevcontainerManifest(); err != nil {
+		t.Fatalf("Failed to create test manifest: %v", err)
+	}
+
+	outputRoot := t.TempDir()
+	manager := NewConfigGeneratorManager(".servo")
+	manager.SetOutputRoot(outputRoot)
+
+	files, err := manager.GenerateFiles()
+	if err != nil {
+		t.Fatalf("GenerateFiles() error = %v", err)
+	}
+
+	want := []string{
+		filepath.Join(outputRoot, ".devcontainer", "devcontainer.json"),
+		filepath.Join(outputRoot, ".devcontainer", "docker-compose.yml"),
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("GenerateFiles() = %v, want %v", files, want)
+	}
+	for _, file := range want {
+		if _, err := os.Stat(file); err != nil {
+			t.Errorf("Expected %s to exist: %v", file, err)
+		}
+	}
+	if _, err := os.Stat(".devcontainer"); !os.IsNotExist(err) {
+		t.Error("Generation into an output root should not touch the project tree")
+	}
+}
+
+func TestConfigGeneratorManager_GenerateFilesSkipsUnreportedOutput(t *testing.T) {
+	var calls []string
+	manager := &ConfigGeneratorManager{}
+	manager.Register(&fakeGenerator{name: "plain", calls: &calls})
+	manager.SetOutputRoot(t.TempDir())
+
+	files, err := manager.GenerateFiles()
+	if err != nil {
+		t.Fatalf("GenerateFiles() error = %v", err)
+	}
+	if len(files) != 0 || len(calls) != 1 {
+		t.Errorf("Expected one call and no reported files, got calls=%v files=%v", calls, files)
+	}
+}
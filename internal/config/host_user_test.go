@@ -0,0 +1,110 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/servo/servo/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGeneration_HostUserIDs(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	originalIDs := hostUserIDs
+	defer func() { hostUserIDs = originalIDs }()
+	hostUserIDs = func() (int, int) { return 1000, 1001 }
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+
+	manifest := `servo_version: "1.0"
+name: app
+services:
+  db:
+    image: postgres:16
+    volumes:
+      - data:/var/lib/postgresql/data
+  agent:
+    image: agent:1
+    user: "999"
+    volumes:
+      - cache:/cache
+  proxy:
+    image: nginx:1
+`
+	if err := os.WriteFile(testManifestPath("app.servo"), []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	writeProject := func(cfg project.ProjectConfig) {
+		proj := &project.Project{DefaultSession: "test", ActiveSession: "test", Config: cfg}
+		data, _ := yaml.Marshal(proj)
+		os.WriteFile(".servo/project.yaml", data, 0644)
+	}
+	generate := func() (map[string]map[string]interface{}, map[string]interface{}) {
+		manager := NewConfigGeneratorManager(".servo")
+		if err := manager.GenerateDockerCompose(); err != nil {
+			t.Fatalf("Failed to generate docker-compose: %v", err)
+		}
+		if err := manager.GenerateDevcontainer(); err != nil {
+			t.Fatalf("Failed to generate devcontainer: %v", err)
+		}
+
+		var compose struct {
+			Services map[string]map[string]interface{} `yaml:"services"`
+		}
+		composeData, _ := os.ReadFile(".devcontainer/docker-compose.yml")
+		if err := yaml.Unmarshal(composeData, &compose); err != nil {
+			t.Fatalf("Failed to parse docker-compose.yml: %v", err)
+		}
+		var devcontainer map[string]interface{}
+		devcontainerData, _ := os.ReadFile(".devcontainer/devcontainer.json")
+		if err := json.Unmarshal(devcontainerData, &devcontainer); err != nil {
+			t.Fatalf("Failed to parse devcontainer.json: %v", err)
+		}
+		return compose.Services, devcontainer
+	}
+
+	// Off by default: nothing is injected
+	writeProject(project.ProjectConfig{})
+	services, devcontainer := generate()
+	if _, ok := services["app-db"]["user"]; ok {
+		t.Errorf("Expected no injected user by default, got %v", services["app-db"]["user"])
+	}
+	if _, ok := devcontainer["updateRemoteUserUID"]; ok || devcontainer["remoteUser"] != "root" {
+		t.Errorf("Expected the root remote user untouched, got %v / %v", devcontainer["remoteUser"], devcontainer["updateRemoteUserUID"])
+	}
+
+	writeProject(project.ProjectConfig{RemoteUser: "vscode"})
+	_, devcontainer = generate()
+	if devcontainer["remoteUser"] != "vscode" || devcontainer["updateRemoteUserUID"] != false {
+		t.Errorf("Expected vscode without UID remapping, got %v / %v", devcontainer["remoteUser"], devcontainer["updateRemoteUserUID"])
+	}
+
+	writeProject(project.ProjectConfig{RemoteUser: "vscode", HostUserIDs: true})
+	services, devcontainer = generate()
+	if services["app-db"]["user"] != "1000:1001" {
+		t.Errorf("Expected the volume-backed service to run as the host user, got %v", services["app-db"]["user"])
+	}
+	if services["app-agent"]["user"] != "999" {
+		t.Errorf("Expected the manifest user to win, got %v", services["app-agent"]["user"])
+	}
+	if _, ok := services["app-proxy"]["user"]; ok {
+		t.Errorf("Expected a service without volumes to keep its image user, got %v", services["app-proxy"]["user"])
+	}
+	if devcontainer["updateRemoteUserUID"] != true {
+		t.Errorf("Expected updateRemoteUserUID with host_user_ids, got %v", devcontainer["updateRemoteUserUID"])
+	}
+
+	// Hosts without numeric IDs skip the injection
+	hostUserIDs = func() (int, int) { return -1, -1 }
+	services, _ = generate()
+	if _, ok := services["app-db"]["user"]; ok {
+		t.Errorf("Expected no user without host IDs, got %v", services["app-db"]["user"])
+	}
+}
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ChecksumsFileName is the file under the servo dir recording the sha256 of each
+// generated file as servo last wrote it, in sha256sum format
+const ChecksumsFileName = "generated.sum"
+
+// GeneratedFiles returns the project-relative files servo owns outright and may
+// delete. .devcontainer/.env is excluded because it keeps user entries.
+func GeneratedFiles() []string {
+	return []string{
+		filepath.Join(".devcontainer", "devcontainer.json"),
+		filepath.Join(".devcontainer", "docker-compose.yml"),
+		BundleFileName,
+	}
+}
+
+// RecordChecksums stores the current contents' checksums of those files that are in
+// GeneratedFiles, keeping entries for generated files not in the list
+func RecordChecksums(servoDir string, files []string) error {
+	sums, err := readChecksums(servoDir)
+	if err != nil {
+		return err
+	}
+
+	owned := generatedSet()
+	for _, file := range files {
+		file = filepath.Clean(file)
+		if !owned[file] {
+			continue
+		}
+		sum, err := fileChecksum(file)
+		if err != nil {
+			return err
+		}
+		sums[file] = sum
+	}
+
+	return writeChecksums(servoDir, sums)
+}
+
+// ModifiedFiles returns the generated files whose contents no longer match the
+// recorded checksum, meaning they were edited by hand. Missing files and files with
+// no recorded checksum are not reported.
+func ModifiedFiles(servoDir string) ([]string, error) {
+	sums, err := readChecksums(servoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var modified []string
+	for file, recorded := range sums {
+		sum, err := fileChecksum(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if sum != recorded {
+			modified = append(modified, file)
+		}
+	}
+	sort.Strings(modified)
+	return modified, nil
+}
+
+// generatedUnchanged reports whether the generated file recorded as file, read from
+// path, still has the checksum servo recorded when writing it. A file with no
+// recorded checksum is not known to be servo's and is reported as changed.
+func generatedUnchanged(servoDir, file, path string) (bool, error) {
+	sums, err := readChecksums(servoDir)
+	if err != nil {
+		return false, err
+	}
+	recorded, ok := sums[filepath.Clean(file)]
+	if !ok {
+		return false, nil
+	}
+	sum, err := fileChecksum(path)
+	if err != nil {
+		return false, err
+	}
+	return sum == recorded, nil
+}
+
+// CleanGenerated deletes every generated file and the checksum record, returning the
+// files removed
+func CleanGenerated(servoDir string) ([]string, error) {
+	var removed []string
+	for _, file := range GeneratedFiles() {
+		if err := os.Remove(file); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return removed, fmt.Errorf("failed to remove %s: %w", file, err)
+		}
+		removed = append(removed, file)
+	}
+
+	if err := os.Remove(filepath.Join(servoDir, ChecksumsFileName)); err != nil && !os.IsNotExist(err) {
+		return removed, fmt.Errorf("failed to remove %s: %w", ChecksumsFileName, err)
+	}
+	return removed, nil
+}
+
+// generatedSet returns GeneratedFiles as a lookup set
+func generatedSet() map[string]bool {
+	set := make(map[string]bool)
+	for _, file := range GeneratedFiles() {
+		set[file] = true
+	}
+	return set
+}
+
+// fileChecksum returns the hex sha256 of a file's contents
+func fileChecksum(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// readChecksums loads the checksum record, which is empty when none exists yet
+func readChecksums(servoDir string) (map[string]string, error) {
+	sums := make(map[string]string)
+
+	f, err := os.Open(filepath.Join(servoDir, ChecksumsFileName))
+	if os.IsNotExist(err) {
+		return sums, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ChecksumsFileName, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		sum, file, ok := strings.Cut(scanner.Text(), "  ")
+		if ok && file != "" {
+			sums[filepath.FromSlash(file)] = sum
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ChecksumsFileName, err)
+	}
+	return sums, nil
+}
+
+// writeChecksums saves the checksum record sorted by path
+func writeChecksums(servoDir string, sums map[string]string) error {
+	files := make([]string, 0, len(sums))
+	for file := range sums {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	var b strings.Builder
+	for _, file := range files {
+		fmt.Fprintf(&b, "%s  %s\n", sums[file], filepath.ToSlash(file))
+	}
+
+	if err := os.WriteFile(filepath.Join(servoDir, ChecksumsFileName), []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ChecksumsFileName, err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGeneratedFileChecksums(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+	os.MkdirAll(".servo", 0755)
+	os.MkdirAll(".devcontainer", 0755)
+
+	compose := filepath.Join(".devcontainer", "docker-compose.yml")
+	env := filepath.Join(".devcontainer", ".env")
+	os.WriteFile(compose, []byte("services: {}\n"), 0644)
+	os.WriteFile(BundleFileName, []byte("{}\n"), 0644)
+	os.WriteFile(env, []byte("COMPOSE_PROFILES=gpu\n"), 0644)
+
+	if err := RecordChecksums(".servo", []string{compose, BundleFileName, env}); err != nil {
+		t.Fatalf("RecordChecksums() error = %v", err)
+	}
+
+	modified, err := ModifiedFiles(".servo")
+	if err != nil || len(modified) != 0 {
+		t.Fatalf("ModifiedFiles() = %v, %v; want none", modified, err)
+	}
+
+	os.WriteFile(compose, []byte("services: {edited: {}}\n"), 0644)
+	os.WriteFile(env, []byte("EXTRA=1\n"), 0644)
+	modified, err = ModifiedFiles(".servo")
+	if err != nil || !reflect.DeepEqual(modified, []string{compose}) {
+		t.Errorf("ModifiedFiles() = %v, %v; want [%s]", modified, err, compose)
+	}
+
+	removed, err := CleanGenerated(".servo")
+	if err != nil {
+		t.Fatalf("CleanGenerated() error = %v", err)
+	}
+	if want := []string{compose, BundleFileName}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("CleanGenerated() removed %v, want %v", removed, want)
+	}
+	if _, err := os.Stat(env); err != nil {
+		t.Errorf("Expected %s to be kept: %v", env, err)
+	}
+	if _, err := os.Stat(filepath.Join(".servo", ChecksumsFileName)); !os.IsNotExist(err) {
+		t.Errorf("Expected checksum record to be removed")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
 	"github.com/servo/servo/pkg"
 	"gopkg.in/yaml.v3"
 )
@@ -114,12 +115,22 @@ func TestOverrideGeneration_PrecedenceOrder(t *testing.T) {
 		t.Fatalf("Failed to create multiple overrides: %v", err)
 	}
 
+	// Without an environment selected the env-specific file is ignored
+	t.Setenv("SERVO_ENV", "")
 	manager := NewConfigGeneratorManager(".servo")
 	if err := manager.GenerateDockerCompose(); err != nil {
 		t.Fatalf("Failed to generate docker-compose: %v", err)
 	}
 
-	verifyPrecedenceOrder(t)
+	verifyPrecedenceOrder(t, "info")
+
+	t.Setenv("SERVO_ENV", "ci")
+	manager = NewConfigGeneratorManager(".servo")
+	if err := manager.GenerateDockerCompose(); err != nil {
+		t.Fatalf("Failed to generate docker-compose: %v", err)
+	}
+
+	verifyPrecedenceOrder(t, "warn")
 }
 
 func TestOverrideGeneration_ComplexServiceMerging(t *testing.T) {
@@ -166,7 +177,7 @@ func setupOverrideTestProject() error {
 		".servo",
 		".servo/sessions",
 		".servo/sessions/test",
-		".servo/sessions/test/manifests",
+		session.NewManager(".servo").ManifestsDir("test"),
 		".servo/sessions/test/config",
 		".servo/config",
 	}
@@ -239,7 +250,7 @@ func createBasicDockerComposeManifest() error {
 		return err
 	}
 
-	return os.WriteFile(".servo/sessions/test/manifests/basic-app.servo", data, 0644)
+	return os.WriteFile(testManifestPath("basic-app.servo"), data, 0644)
 }
 
 func createBasicDevcontainerOverrideManifest() error {
@@ -267,7 +278,7 @@ func createBasicDevcontainerOverrideManifest() error {
 		return err
 	}
 
-	return os.WriteFile(".servo/sessions/test/manifests/dev-app.servo", data, 0644)
+	return os.WriteFile(testManifestPath("dev-app.servo"), data, 0644)
 }
 
 func createDockerComposeOverride() error {
@@ -365,7 +376,7 @@ func createComplexDockerComposeManifest() error {
 		return err
 	}
 
-	return os.WriteFile(".servo/sessions/test/manifests/complex-app.servo", data, 0644)
+	return os.WriteFile(testManifestPath("complex-app.servo"), data, 0644)
 }
 
 func createMultipleOverrides() error {
@@ -374,8 +385,9 @@ func createMultipleOverrides() error {
 		"services": map[string]interface{}{
 			"basic-app-web": map[string]interface{}{
 				"environment": map[string]string{
-					"ENV":   "staging",
-					"DEBUG": "false",
+					"ENV":       "staging",
+					"DEBUG":     "false",
+					"LOG_LEVEL": "info",
 				},
 			},
 		},
@@ -390,12 +402,33 @@ func createMultipleOverrides() error {
 		return err
 	}
 
+	// Environment-specific override, applied only when SERVO_ENV=ci
+	envOverride := map[string]interface{}{
+		"services": map[string]interface{}{
+			"basic-app-web": map[string]interface{}{
+				"environment": map[string]string{
+					"ENV":       "ci",
+					"LOG_LEVEL": "warn",
+				},
+			},
+		},
+	}
+
+	envData, err := yaml.Marshal(envOverride)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(".servo/config/docker-compose.ci.yml", envData, 0644); err != nil {
+		return err
+	}
+
 	// Session-level override (higher precedence)
 	sessionOverride := map[string]interface{}{
 		"services": map[string]interface{}{
 			"basic-app-web": map[string]interface{}{
 				"environment": map[string]string{
-					"ENV": "development", // Should override both manifest and project
+					"ENV": "development", // Should override manifest, project and env
 				},
 			},
 		},
@@ -587,7 +620,9 @@ func verifyDevcontainerOverrideMerging(t *testing.T) {
 	}
 }
 
-func verifyPrecedenceOrder(t *testing.T) {
+// verifyPrecedenceOrder checks defaults < project < env < session, where
+// wantLogLevel is the value expected from the env layer (or project without one)
+func verifyPrecedenceOrder(t *testing.T, wantLogLevel string) {
 	data, err := os.ReadFile(".devcontainer/docker-compose.yml")
 	if err != nil {
 		t.Fatalf("Failed to read docker-compose.yml: %v", err)
@@ -621,6 +656,11 @@ func verifyPrecedenceOrder(t *testing.T) {
 	if envMap["DEBUG"] != "false" {
 		t.Errorf("Expected DEBUG=false from project override, got %v", envMap["DEBUG"])
 	}
+
+	// LOG_LEVEL comes from the env override when one is selected, which beats project
+	if envMap["LOG_LEVEL"] != wantLogLevel {
+		t.Errorf("Expected LOG_LEVEL=%s, got %v", wantLogLevel, envMap["LOG_LEVEL"])
+	}
 }
 
 func verifyComplexServiceMerging(t *testing.T) {
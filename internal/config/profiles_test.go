@@ -0,0 +1,163 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/pkg"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGeneration_ComposeProfiles(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+
+	manifest := &pkg.ServoDefinition{
+		ServoVersion: "1.0",
+		Name:         "profiled-app",
+		Services: map[string]*pkg.ServiceDependency{
+			"web": {Image: "nginx:latest"},
+			"db":  {Image: "postgres:15", Profiles: []string{"database"}},
+		},
+	}
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("Failed to marshal manifest: %v", err)
+	}
+	if err := os.WriteFile(testManifestPath("profiled-app.servo"), data, 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	// Without active profiles nothing is passed to the devcontainer
+	manager := NewConfigGeneratorManager(".servo")
+	if err := manager.GenerateAll(); err != nil {
+		t.Fatalf("Failed to generate configs: %v", err)
+	}
+
+	var compose struct {
+		Services map[string]map[string]interface{} `yaml:"services"`
+	}
+	composeData, _ := os.ReadFile(".devcontainer/docker-compose.yml")
+	if err := yaml.Unmarshal(composeData, &compose); err != nil {
+		t.Fatalf("Failed to parse docker-compose.yml: %v", err)
+	}
+	if _, ok := compose.Services["profiled-app-web"]["profiles"]; ok {
+		t.Error("Service without profiles should not be gated")
+	}
+	profiles, ok := compose.Services["profiled-app-db"]["profiles"].([]interface{})
+	if !ok || len(profiles) != 1 || profiles[0] != "database" {
+		t.Errorf("Expected db service profiles [database], got %v", compose.Services["profiled-app-db"]["profiles"])
+	}
+	if _, err := os.Stat(".devcontainer/.env"); !os.IsNotExist(err) {
+		t.Error("Expected no .env file when no profiles are active")
+	}
+	if got := readRunServices(t); strings.Join(got, ",") != "workspace,profiled-app-web" {
+		t.Errorf("Expected gated db to be left out of runServices, got %v", got)
+	}
+
+	// Project-level active profiles
+	proj := &project.Project{
+		Clients:        []string{"vscode"},
+		DefaultSession: "test",
+		ActiveSession:  "test",
+		Config:         project.ProjectConfig{Profiles: []string{"database"}},
+	}
+	projectData, _ := yaml.Marshal(proj)
+	os.WriteFile(".servo/project.yaml", projectData, 0644)
+	os.WriteFile(".devcontainer/.env", []byte("OTHER=1\nCOMPOSE_PROFILES=stale\n"), 0644)
+
+	if err := manager.GenerateDevcontainer(); err != nil {
+		t.Fatalf("Failed to generate devcontainer: %v", err)
+	}
+
+	envData, _ := os.ReadFile(".devcontainer/.env")
+	if string(envData) != "OTHER=1\nCOMPOSE_PROFILES=database\n" {
+		t.Errorf("Unexpected .env content: %q", string(envData))
+	}
+
+	var devcontainer map[string]interface{}
+	devcontainerData, _ := os.ReadFile(".devcontainer/devcontainer.json")
+	if err := json.Unmarshal(devcontainerData, &devcontainer); err != nil {
+		t.Fatalf("Failed to parse devcontainer.json: %v", err)
+	}
+	containerEnv, _ := devcontainer["containerEnv"].(map[string]interface{})
+	if containerEnv["COMPOSE_PROFILES"] != "database" {
+		t.Errorf("Expected containerEnv COMPOSE_PROFILES=database, got %v", devcontainer["containerEnv"])
+	}
+	if got := readRunServices(t); strings.Join(got, ",") != "workspace,profiled-app-db,profiled-app-web" {
+		t.Errorf("Expected active database profile to start db, got %v", got)
+	}
+
+	// Session-level profiles take precedence over the project
+	sessionData := "name: test\nactive: true\nprofiles:\n  - database\n  - cache\n"
+	os.WriteFile(".servo/sessions/test/session.yaml", []byte(sessionData), 0644)
+
+	if err := manager.GenerateDevcontainer(); err != nil {
+		t.Fatalf("Failed to generate devcontainer: %v", err)
+	}
+	envData, _ = os.ReadFile(".devcontainer/.env")
+	if !strings.Contains(string(envData), "COMPOSE_PROFILES=database,cache") {
+		t.Errorf("Expected session profiles in .env, got %q", string(envData))
+	}
+}
+
+func TestGeneration_RunServicesWithoutProfiles(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+
+	manifest := &pkg.ServoDefinition{
+		ServoVersion: "1.0",
+		Name:         "plain-app",
+		Services: map[string]*pkg.ServiceDependency{
+			"web": {Image: "nginx:latest"},
+		},
+	}
+	data, _ := yaml.Marshal(manifest)
+	os.WriteFile(testManifestPath("plain-app.servo"), data, 0644)
+
+	if err := NewConfigGeneratorManager(".servo").GenerateDevcontainer(); err != nil {
+		t.Fatalf("Failed to generate devcontainer: %v", err)
+	}
+	if got := readRunServices(t); got != nil {
+		t.Errorf("Expected runServices to be omitted without profile-gated services, got %v", got)
+	}
+}
+
+// readRunServices returns the generated devcontainer runServices, or nil when absent
+func readRunServices(t *testing.T) []string {
+	t.Helper()
+
+	var devcontainer struct {
+		RunServices []string `json:"runServices"`
+	}
+	data, _ := os.ReadFile(".devcontainer/devcontainer.json")
+	if err := json.Unmarshal(data, &devcontainer); err != nil {
+		t.Fatalf("Failed to parse devcontainer.json: %v", err)
+	}
+	return devcontainer.RunServices
+}
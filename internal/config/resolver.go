@@ -0,0 +1,179 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EnvSource names the layer an environment variable was resolved from
+type EnvSource string
+
+const (
+	// SourceDefault holds values servo sets itself, such as SERVO_DEV_MODE
+	SourceDefault EnvSource = "default"
+	// SourceProject holds the project's .servo/env.yaml
+	SourceProject EnvSource = "project"
+	// SourceService holds a manifest service's environment
+	SourceService EnvSource = "service"
+	// SourceOverride holds the environment of docker-compose override files
+	SourceOverride EnvSource = "override"
+)
+
+// EnvSources lists the resolver layers from lowest to highest precedence
+var EnvSources = []EnvSource{SourceDefault, SourceProject, SourceService, SourceOverride}
+
+// Resolver resolves environment variables across the EnvSources layers, a higher
+// layer replacing the value of a lower one. With a lookup set, ${NAME} and
+// ${NAME:-default} references in resolved values are interpolated through it, which
+// is where secret, host or config values come in; without one, values are returned
+// as written so the references are left for docker compose.
+type Resolver struct {
+	layers map[EnvSource]map[string]string
+	lookup func(string) (string, error)
+}
+
+// NewResolver creates a resolver with every layer empty
+func NewResolver() *Resolver {
+	return &Resolver{layers: make(map[EnvSource]map[string]string)}
+}
+
+// Set replaces the variables of one layer and returns the resolver for chaining
+func (r *Resolver) Set(source EnvSource, vars map[string]string) *Resolver {
+	r.layers[source] = vars
+	return r
+}
+
+// errLeaveReference is returned by a lookup to keep a reference exactly as written,
+// default included, for a later stage such as docker compose to interpolate
+var errLeaveReference = errors.New("reference left for a later stage")
+
+// WithLookup interpolates resolved values through lookup and returns the resolver
+// for chaining. A name lookup fails on, or resolves to "", is left as written
+// unless the reference carries a default; one it returns errLeaveReference for is
+// always left as written.
+func (r *Resolver) WithLookup(lookup func(string) (string, error)) *Resolver {
+	r.lookup = lookup
+	return r
+}
+
+// Resolve returns the value of key from the highest layer that sets it, and that
+// layer. An unset key returns "" and an empty source.
+func (r *Resolver) Resolve(key string) (string, EnvSource) {
+	for i := len(EnvSources) - 1; i >= 0; i-- {
+		source := EnvSources[i]
+		if value, ok := r.layers[source][key]; ok {
+			return r.interpolate(value), source
+		}
+	}
+	return "", ""
+}
+
+// Keys returns every key set in any layer, sorted
+func (r *Resolver) Keys() []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, vars := range r.layers {
+		for key := range vars {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Values returns the resolved variables as a map, the form devcontainer.json takes
+func (r *Resolver) Values() map[string]string {
+	values := make(map[string]string)
+	for _, key := range r.Keys() {
+		values[key], _ = r.Resolve(key)
+	}
+	return values
+}
+
+// Environment returns the resolved variables as KEY=VALUE entries sorted by key, the
+// list form docker compose takes
+func (r *Resolver) Environment() []string {
+	keys := r.Keys()
+	env := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, _ := r.Resolve(key)
+		env = append(env, key+"="+value)
+	}
+	return env
+}
+
+// interpolate expands references in value when the resolver has a lookup
+func (r *Resolver) interpolate(value string) string {
+	if r.lookup == nil {
+		return value
+	}
+	return expandVariables(value, r.lookup)
+}
+
+// expandVariables expands variable references in a string.
+//
+// Supported forms follow compose/shell conventions:
+//   - ${NAME} is replaced with the looked-up value, or left intact when it is not set
+//   - ${NAME:-default} uses default when the value is not set or empty
+//   - $${NAME} is an escape and produces a literal ${NAME}
+//
+// Malformed expressions (unterminated or empty names) are left intact with a warning.
+func expandVariables(value string, lookup func(string) (string, error)) string {
+	if !strings.Contains(value, "${") {
+		return value
+	}
+
+	var result strings.Builder
+	i := 0
+	for i < len(value) {
+		if strings.HasPrefix(value[i:], "$${") {
+			result.WriteString("${")
+			i += 3
+			continue
+		}
+
+		if !strings.HasPrefix(value[i:], "${") {
+			result.WriteByte(value[i])
+			i++
+			continue
+		}
+
+		end := strings.Index(value[i:], "}")
+		if end == -1 {
+			fmt.Printf("⚠️  Warning: unterminated variable reference in %q\n", value)
+			result.WriteString(value[i:])
+			break
+		}
+		end += i
+
+		expr := value[i+2 : end]
+		name, defaultValue, hasDefault := strings.Cut(expr, ":-")
+		if name == "" || strings.ContainsAny(name, "${") {
+			fmt.Printf("⚠️  Warning: malformed variable reference %q\n", value[i:end+1])
+			result.WriteString("${")
+			i += 2
+			continue
+		}
+
+		resolved, err := lookup(name)
+		switch {
+		case errors.Is(err, errLeaveReference):
+			result.WriteString(value[i : end+1])
+		case err == nil && resolved != "":
+			result.WriteString(resolved)
+		case hasDefault:
+			result.WriteString(defaultValue)
+		default:
+			// Keep placeholder if the value is not found
+			result.WriteString(value[i : end+1])
+		}
+		i = end + 1
+	}
+
+	return result.String()
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestResolver_Precedence(t *testing.T) {
+	resolver := NewResolver().
+		Set(SourceOverride, map[string]string{"LOG_LEVEL": "warn"}).
+		Set(SourceDefault, map[string]string{"LOG_LEVEL": "info", "SERVO_DEV_MODE": "1"}).
+		Set(SourceService, map[string]string{"LOG_LEVEL": "debug", "PORT": "8080"}).
+		Set(SourceProject, map[string]string{"LOG_LEVEL": "error", "PORT": "80", "REGION": "us-east-1"})
+
+	tests := []struct {
+		key    string
+		value  string
+		source EnvSource
+	}{
+		{key: "LOG_LEVEL", value: "warn", source: SourceOverride},
+		{key: "PORT", value: "8080", source: SourceService},
+		{key: "REGION", value: "us-east-1", source: SourceProject},
+		{key: "SERVO_DEV_MODE", value: "1", source: SourceDefault},
+		{key: "MISSING", value: "", source: ""},
+	}
+	for _, tt := range tests {
+		value, source := resolver.Resolve(tt.key)
+		if value != tt.value || source != tt.source {
+			t.Errorf("Resolve(%s) = (%q, %q), want (%q, %q)", tt.key, value, source, tt.value, tt.source)
+		}
+	}
+
+	want := "LOG_LEVEL=warn,PORT=8080,REGION=us-east-1,SERVO_DEV_MODE=1"
+	if got := strings.Join(resolver.Environment(), ","); got != want {
+		t.Errorf("Environment() = %s, want %s", got, want)
+	}
+
+	// Setting a layer again replaces it rather than merging into it
+	resolver.Set(SourceOverride, nil)
+	if value, source := resolver.Resolve("LOG_LEVEL"); value != "debug" || source != SourceService {
+		t.Errorf("Expected the service value once the override layer is cleared, got (%q, %q)", value, source)
+	}
+
+	if env := NewResolver().Environment(); len(env) != 0 {
+		t.Errorf("Expected an empty environment, got %v", env)
+	}
+}
+
+func TestResolver_Interpolation(t *testing.T) {
+	vars := map[string]string{
+		"DATABASE_URL": "postgres://${DB_USER}:${DB_PASSWORD}@db/app",
+		"MODE":         "${MODE_SECRET:-dev}",
+		"LITERAL":      "$${NOT_EXPANDED}",
+	}
+
+	resolver := NewResolver().Set(SourceService, vars)
+	if value, _ := resolver.Resolve("DATABASE_URL"); value != vars["DATABASE_URL"] {
+		t.Errorf("Expected references left for compose without a lookup, got %q", value)
+	}
+
+	lookup := func(name string) (string, error) {
+		switch name {
+		case "DB_USER":
+			return "app", nil
+		case "DB_PASSWORD":
+			return "s3cret", nil
+		}
+		return "", fmt.Errorf("%s not set", name)
+	}
+	resolver.WithLookup(lookup)
+
+	tests := map[string]string{
+		"DATABASE_URL": "postgres://app:s3cret@db/app",
+		"MODE":         "dev",
+		"LITERAL":      "${NOT_EXPANDED}",
+	}
+	for key, want := range tests {
+		if value, source := resolver.Resolve(key); value != want || source != SourceService {
+			t.Errorf("Resolve(%s) = (%q, %q), want (%q, %q)", key, value, source, want, SourceService)
+		}
+	}
+}
+
+func TestResolver_LeaveReference(t *testing.T) {
+	resolver := NewResolver().
+		Set(SourceDefault, map[string]string{"MODE": "dev"}).
+		Set(SourceService, map[string]string{"URL": "${HOST:-localhost}:${PORT}"}).
+		WithLookup(func(name string) (string, error) {
+			if name == "PORT" {
+				return "8080", nil
+			}
+			return "", errLeaveReference
+		})
+
+	if value, _ := resolver.Resolve("URL"); value != "${HOST:-localhost}:8080" {
+		t.Errorf("Resolve(URL) = %q, want the default left for a later stage", value)
+	}
+	if values := resolver.Values(); len(values) != 2 || values["MODE"] != "dev" || values["URL"] != "${HOST:-localhost}:8080" {
+		t.Errorf("Values() = %v", values)
+	}
+}
@@ -2,12 +2,15 @@ package config
 
 import (
 	"encoding/base64"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/internal/session"
 	"github.com/servo/servo/pkg"
 )
 
@@ -95,6 +98,52 @@ func TestDockerComposeGeneration_MissingSecretsFixed(t *testing.T) {
 	}
 }
 
+func TestDockerComposeGeneration_SkipSecretValidation(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	if err := setupTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+	if err := createSecretsManifest(); err != nil {
+		t.Fatalf("Failed to create secrets manifest: %v", err)
+	}
+
+	manager := NewConfigGeneratorManager(".servo")
+	manager.SetSkipSecretValidation(true)
+	if err := manager.GenerateDockerCompose(); err != nil {
+		t.Fatalf("Expected generation to proceed without secrets, got: %v", err)
+	}
+	if got := manager.MissingSecrets(); len(got) == 0 {
+		t.Error("Expected the missing secrets to be reported")
+	}
+
+	data, err := os.ReadFile(".devcontainer/docker-compose.yml")
+	if err != nil {
+		t.Fatalf("Failed to read docker-compose.yml: %v", err)
+	}
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatalf("Failed to parse docker-compose.yml: %v", err)
+	}
+	marker, _ := config[IncompleteMarkerKey].(string)
+	if marker == "" {
+		t.Errorf("Expected %s marker in output, got:\n%s", IncompleteMarkerKey, data)
+	}
+	for _, secret := range manager.MissingSecrets() {
+		if !strings.Contains(marker, secret) {
+			t.Errorf("Expected marker to name %s, got %q", secret, marker)
+		}
+	}
+
+	// Turning validation back on enforces the secrets again
+	manager.SetSkipSecretValidation(false)
+	if err := manager.GenerateDockerCompose(); err == nil {
+		t.Error("Expected generation to fail once secret validation is enforced")
+	}
+}
+
 func createBase64Secrets(secrets map[string]string) error {
 	// Encode secrets with base64 for basic obscurity
 	encodedSecrets := make(map[string]string)
@@ -184,7 +233,7 @@ func setupTestProject() error {
 		".servo",
 		".servo/sessions",
 		".servo/sessions/test",
-		".servo/sessions/test/manifests",
+		session.NewManager(".servo").ManifestsDir("test"),
 	}
 
 	for _, dir := range dirs {
@@ -264,5 +313,162 @@ func createSecretsManifest() error {
 		return err
 	}
 
-	return os.WriteFile(".servo/sessions/test/manifests/secure-app.servo", data, 0644)
+	return os.WriteFile(testManifestPath("secure-app.servo"), data, 0644)
+}
+
+func TestDockerComposeGenerator_ExpandSecrets(t *testing.T) {
+	generator := NewDockerComposeGenerator(t.TempDir())
+
+	secrets := map[string]string{
+		"api_key": "sk-123",
+		"empty":   "",
+	}
+	provider := func(name string) (string, error) {
+		value, ok := secrets[name]
+		if !ok {
+			return "", fmt.Errorf("secret '%s' not found", name)
+		}
+		return value, nil
+	}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "no placeholders", value: "plain", expected: "plain"},
+		{name: "configured secret", value: "${api_key}", expected: "sk-123"},
+		{name: "missing secret kept", value: "${missing}", expected: "${missing}"},
+		{name: "multiple secrets", value: "${api_key}:${api_key}", expected: "sk-123:sk-123"},
+		{name: "default for missing secret", value: "${missing:-fallback}", expected: "fallback"},
+		{name: "default for empty secret", value: "${empty:-fallback}", expected: "fallback"},
+		{name: "empty default", value: "x${missing:-}y", expected: "xy"},
+		{name: "default ignored when set", value: "${api_key:-fallback}", expected: "sk-123"},
+		{name: "escaped placeholder", value: "$${api_key}", expected: "${api_key}"},
+		{name: "escaped and expanded", value: "$${HOME}/${api_key}", expected: "${HOME}/sk-123"},
+		{name: "unterminated expression", value: "prefix-${api_key", expected: "prefix-${api_key"},
+		{name: "empty name", value: "${}-${api_key}", expected: "${}-sk-123"},
+		{name: "nested expression", value: "${a${api_key}", expected: "${ask-123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := generator.expandSecrets(tt.value, provider); got != tt.expected {
+				t.Errorf("expandSecrets(%q) = %q, want %q", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDockerComposeGeneration_SecretTargets(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+	manifest := `servo_version: "1.0"
+name: app
+services:
+  web:
+    image: nginx:1
+    environment:
+      API_KEY: ${api_key}
+  worker:
+    image: busybox
+  db:
+    image: postgres:16
+    environment:
+      API_KEY: ${api_key}
+configuration_schema:
+  secrets:
+    api_key:
+      description: API key
+      type: api_key
+      env_var: API_KEY
+      targets:
+        web: WEB_API_KEY
+        worker: WORKER_KEY
+`
+	os.WriteFile(testManifestPath("app.servo"), []byte(manifest), 0644)
+
+	manager := NewConfigGeneratorManager(".servo")
+	if err := manager.GenerateDockerCompose(); err != nil {
+		t.Fatalf("GenerateDockerCompose() error = %v", err)
+	}
+
+	var compose struct {
+		Services map[string]struct {
+			Environment []string `yaml:"environment"`
+		} `yaml:"services"`
+	}
+	data, _ := os.ReadFile(".devcontainer/docker-compose.yml")
+	if err := yaml.Unmarshal(data, &compose); err != nil {
+		t.Fatalf("Failed to parse docker-compose.yml: %v", err)
+	}
+
+	want := map[string]string{
+		"app-web":    "WEB_API_KEY=${api_key}",
+		"app-worker": "WORKER_KEY=${api_key}",
+		"app-db":     "API_KEY=${api_key}",
+	}
+	for service, entry := range want {
+		env := compose.Services[service].Environment
+		if len(env) != 1 || env[0] != entry {
+			t.Errorf("%s environment = %v, want [%s]", service, env, entry)
+		}
+	}
+}
+
+func TestDockerComposeGeneration_EmbeddedSecretReference(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+	manifest := `servo_version: "1.0"
+name: app
+services:
+  web:
+    image: nginx:1
+    environment:
+      DATABASE_URL: postgres://app:${db_password}@db/app
+      LOG_LEVEL: ${LOG_LEVEL:-info}
+      LITERAL: $${HOME}
+`
+	os.WriteFile(testManifestPath("app.servo"), []byte(manifest), 0644)
+	if err := createBase64Secrets(map[string]string{"db_password": "s3cret"}); err != nil {
+		t.Fatalf("Failed to create secrets: %v", err)
+	}
+
+	if err := NewConfigGeneratorManager(".servo").GenerateDockerCompose(); err != nil {
+		t.Fatalf("GenerateDockerCompose() error = %v", err)
+	}
+	data, _ := os.ReadFile(".devcontainer/docker-compose.yml")
+	var compose struct {
+		Services map[string]struct {
+			Environment []string                 `yaml:"environment"`
+			Secrets     []map[string]interface{} `yaml:"secrets"`
+		} `yaml:"services"`
+		Secrets map[string]interface{} `yaml:"secrets"`
+	}
+	if err := yaml.Unmarshal(data, &compose); err != nil {
+		t.Fatalf("Failed to parse docker-compose.yml: %v", err)
+	}
+
+	web := compose.Services["app-web"]
+	// The $$ escape is compose's to undo; stripping it would let compose fill in $HOME
+	want := []string{"DATABASE_URL=postgres://app:${db_password}@db/app", "LITERAL=$${HOME}", "LOG_LEVEL=${LOG_LEVEL:-info}"}
+	if strings.Join(web.Environment, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected references and escapes left for compose, got %v", web.Environment)
+	}
+	if len(web.Secrets) != 1 || web.Secrets[0]["source"] != "db_password" || compose.Secrets["db_password"] == nil {
+		t.Errorf("Expected the secret referenced inside a value to be mounted, got %v and %v", web.Secrets, compose.Secrets)
+	}
+	if strings.Contains(string(data), "s3cret") {
+		t.Error("Secret values must never be written to docker-compose.yml")
+	}
 }
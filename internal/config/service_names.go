@@ -0,0 +1,36 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/servo/servo/internal/project"
+)
+
+// DefaultServicePrefix is the compose service name template used when the project
+// sets no config.service_prefix. Keeping the manifest name in every service name
+// stops services of different manifests from colliding.
+const DefaultServicePrefix = "{manifest}-{service}"
+
+// ResolveServicePrefix returns the project's compose service name template, or
+// DefaultServicePrefix. "none" resolves to the bare {service}.
+func (g *BaseGenerator) ResolveServicePrefix(proj *project.Project) (string, error) {
+	if proj == nil {
+		return DefaultServicePrefix, nil
+	}
+	if err := proj.Config.ValidateServicePrefix(); err != nil {
+		return "", err
+	}
+	switch prefix := strings.TrimSpace(proj.Config.ServicePrefix); prefix {
+	case "":
+		return DefaultServicePrefix, nil
+	case project.ServicePrefixNone:
+		return "{service}", nil
+	default:
+		return prefix, nil
+	}
+}
+
+// ComposeServiceName expands a service name template for one manifest service
+func ComposeServiceName(template, manifestName, serviceName string) string {
+	return strings.NewReplacer("{manifest}", manifestName, "{service}", serviceName).Replace(template)
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGeneration_ServicePrefix(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		extra   string
+		wantWeb string
+		wantDB  string
+		wantErr string
+	}{
+		{name: "default", wantWeb: "app-web", wantDB: "app-db"},
+		{name: "none", prefix: "none", wantWeb: "web", wantDB: "db"},
+		{name: "template", prefix: "mcp_{service}.{manifest}", wantWeb: "mcp_web.app", wantDB: "mcp_db.app"},
+		{
+			name:    "collision",
+			prefix:  "none",
+			extra:   "servo_version: \"1.0\"\nname: other\nservices:\n  db:\n    image: mysql:8\n",
+			wantErr: "compose service name 'db' is used by both service db of app and service db of other",
+		},
+		{
+			name:    "workspace collision",
+			prefix:  "none",
+			extra:   "servo_version: \"1.0\"\nname: other\nservices:\n  workspace:\n    image: busybox\n",
+			wantErr: "is used by both the workspace container and service workspace of other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalDir, _ := os.Getwd()
+			defer os.Chdir(originalDir)
+			os.Chdir(t.TempDir())
+
+			if err := setupDevcontainerTestProject(); err != nil {
+				t.Fatalf("Failed to setup test project: %v", err)
+			}
+			manifest := `servo_version: "1.0"
+name: app
+services:
+  web:
+    image: nginx:1
+    depends_on: [db]
+  db:
+    image: postgres:16
+    profiles: [data]
+`
+			os.WriteFile(testManifestPath("app.servo"), []byte(manifest), 0644)
+			if tt.extra != "" {
+				os.WriteFile(testManifestPath("other.servo"), []byte(tt.extra), 0644)
+			}
+			proj := &project.Project{DefaultSession: "test", ActiveSession: "test", Config: project.ProjectConfig{
+				ServicePrefix: tt.prefix,
+				Profiles:      []string{"data"},
+			}}
+			data, _ := yaml.Marshal(proj)
+			os.WriteFile(".servo/project.yaml", data, 0644)
+
+			manager := NewConfigGeneratorManager(".servo")
+			err := manager.GenerateDockerCompose()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GenerateDockerCompose() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GenerateDockerCompose() error = %v", err)
+			}
+			if err := manager.GenerateDevcontainer(); err != nil {
+				t.Fatalf("GenerateDevcontainer() error = %v", err)
+			}
+
+			var compose struct {
+				Services map[string]map[string]interface{} `yaml:"services"`
+			}
+			composeData, _ := os.ReadFile(".devcontainer/docker-compose.yml")
+			if err := yaml.Unmarshal(composeData, &compose); err != nil {
+				t.Fatalf("Failed to parse docker-compose.yml: %v", err)
+			}
+			web, ok := compose.Services[tt.wantWeb]
+			if !ok || compose.Services[tt.wantDB] == nil {
+				t.Fatalf("Expected services %s and %s, got %v", tt.wantWeb, tt.wantDB, compose.Services)
+			}
+			dependsOn, _ := web["depends_on"].(map[string]interface{})
+			if _, ok := dependsOn[tt.wantDB]; !ok {
+				t.Errorf("Expected %s to depend on %s, got %v", tt.wantWeb, tt.wantDB, web["depends_on"])
+			}
+
+			var devcontainer struct {
+				RunServices []string `json:"runServices"`
+			}
+			devcontainerData, _ := os.ReadFile(".devcontainer/devcontainer.json")
+			if err := json.Unmarshal(devcontainerData, &devcontainer); err != nil {
+				t.Fatalf("Failed to parse devcontainer.json: %v", err)
+			}
+			want := []string{"workspace", tt.wantDB, tt.wantWeb}
+			if tt.wantDB > tt.wantWeb {
+				want = []string{"workspace", tt.wantWeb, tt.wantDB}
+			}
+			if !reflect.DeepEqual(devcontainer.RunServices, want) {
+				t.Errorf("runServices = %v, want %v", devcontainer.RunServices, want)
+			}
+		})
+	}
+}
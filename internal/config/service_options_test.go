@@ -0,0 +1,119 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestGeneration_ServiceUserAndEntrypoint(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+
+	manifest := `servo_version: "1.0"
+name: app
+services:
+  db:
+    image: postgres:15
+    user: "999:999"
+    entrypoint: ["docker-entrypoint.sh", "postgres"]
+  worker:
+    image: python:3.12
+    entrypoint: python -m worker
+  cache:
+    image: redis:7
+`
+	if err := os.WriteFile(testManifestPath("app.servo"), []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	if err := NewConfigGeneratorManager(".servo").GenerateDockerCompose(); err != nil {
+		t.Fatalf("Failed to generate docker-compose: %v", err)
+	}
+
+	var compose struct {
+		Services map[string]map[string]interface{} `yaml:"services"`
+	}
+	composeData, _ := os.ReadFile(".devcontainer/docker-compose.yml")
+	if err := yaml.Unmarshal(composeData, &compose); err != nil {
+		t.Fatalf("Failed to parse docker-compose.yml: %v", err)
+	}
+
+	db := compose.Services["app-db"]
+	if db["user"] != "999:999" {
+		t.Errorf("Expected db user 999:999, got %v", db["user"])
+	}
+	if want := []interface{}{"docker-entrypoint.sh", "postgres"}; !reflect.DeepEqual(db["entrypoint"], want) {
+		t.Errorf("Expected db entrypoint %v, got %v", want, db["entrypoint"])
+	}
+	if entrypoint := compose.Services["app-worker"]["entrypoint"]; entrypoint != "python -m worker" {
+		t.Errorf("Expected string entrypoint to be kept as-is, got %v", entrypoint)
+	}
+
+	cache := compose.Services["app-cache"]
+	for _, key := range []string{"user", "entrypoint"} {
+		if _, ok := cache[key]; ok {
+			t.Errorf("Service without %s should not set it", key)
+		}
+	}
+}
+
+func TestGeneration_ServiceInitAndTTY(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+
+	manifest := `servo_version: "1.0"
+name: app
+services:
+  debug:
+    image: python:3.12
+    init: true
+    tty: true
+    stdin_open: true
+  cache:
+    image: redis:7
+    init: false
+`
+	if err := os.WriteFile(testManifestPath("app.servo"), []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	if err := NewConfigGeneratorManager(".servo").GenerateDockerCompose(); err != nil {
+		t.Fatalf("Failed to generate docker-compose: %v", err)
+	}
+
+	var compose struct {
+		Services map[string]map[string]interface{} `yaml:"services"`
+	}
+	composeData, _ := os.ReadFile(".devcontainer/docker-compose.yml")
+	if err := yaml.Unmarshal(composeData, &compose); err != nil {
+		t.Fatalf("Failed to parse docker-compose.yml: %v", err)
+	}
+
+	for _, key := range []string{"init", "tty", "stdin_open"} {
+		if compose.Services["app-debug"][key] != true {
+			t.Errorf("Expected debug service %s: true, got %v", key, compose.Services["app-debug"][key])
+		}
+		if _, ok := compose.Services["app-cache"][key]; ok {
+			t.Errorf("Service without %s should not set it", key)
+		}
+	}
+}
@@ -243,7 +243,7 @@ type mockProjectManager struct {
 	configuredSecrets map[string]bool
 }
 
-func (m *mockProjectManager) GetConfiguredSecrets() (map[string]bool, error) {
+func (m *mockProjectManager) GetConfiguredSecrets(sessionName string) (map[string]bool, error) {
 	return m.configuredSecrets, nil
 }
 
@@ -260,4 +260,6 @@ func (m *mockProjectManager) RemoveMCPServer(name string) error
 func (m *mockProjectManager) GetServoDir() string                                      { return ".servo" }
 func (m *mockProjectManager) AddRequiredSecret(name, description string) error         { return nil }
 func (m *mockProjectManager) RemoveRequiredSecret(name string) error                   { return nil }
-func (m *mockProjectManager) GetMissingSecrets() ([]project.RequiredSecret, error)     { return nil, nil }
+func (m *mockProjectManager) GetMissingSecrets(sessionName string) ([]project.RequiredSecret, error) {
+	return nil, nil
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/project"
+	"github.com/servo/servo/pkg"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGeneration_VolumeRoot(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	if err := setupDevcontainerTestProject(); err != nil {
+		t.Fatalf("Failed to setup test project: %v", err)
+	}
+
+	manifest := &pkg.ServoDefinition{
+		ServoVersion: "1.0",
+		Name:         "store",
+		Services: map[string]*pkg.ServiceDependency{
+			"db": {Image: "postgres:15", Volumes: []string{"db_data:/var/lib/postgresql/data", "cache", "db_logs:/var/log"}},
+		},
+	}
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("Failed to marshal manifest: %v", err)
+	}
+	if err := os.WriteFile(testManifestPath("store.servo"), data, 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		volumeRoot  string
+		wantVolumes []string
+		wantMkdir   string
+		rejectMkdir string
+	}{
+		{
+			name:        "default",
+			wantVolumes: []string{"../.servo/services/store/db/db_data:/var/lib/postgresql/data", "../.servo/services/store/db/cache:/data", "../.servo/logs/store/db/var-log:/var/log"},
+			wantMkdir:   "mkdir -p /workspace/.servo/services/store/db",
+		},
+		{
+			name:        "relative",
+			volumeRoot:  "scratch/volumes/",
+			wantVolumes: []string{"../scratch/volumes/store/db/db_data:/var/lib/postgresql/data", "../scratch/volumes/store/db/cache:/data", "../.servo/logs/store/db/var-log:/var/log"},
+			wantMkdir:   "mkdir -p /workspace/scratch/volumes/store/db",
+			rejectMkdir: "/workspace/.servo/services",
+		},
+		{
+			name:        "absolute",
+			volumeRoot:  "/mnt/fast/servo",
+			wantVolumes: []string{"/mnt/fast/servo/store/db/db_data:/var/lib/postgresql/data", "/mnt/fast/servo/store/db/cache:/data", "../.servo/logs/store/db/var-log:/var/log"},
+			wantMkdir:   "mkdir -p /workspace/.servo/logs/store/db/var-log",
+			rejectMkdir: "services",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proj := &project.Project{
+				Clients:        []string{"vscode"},
+				DefaultSession: "test",
+				ActiveSession:  "test",
+				Config:         project.ProjectConfig{VolumeRoot: tt.volumeRoot, WorkspaceMount: "..:/workspace"},
+			}
+			projectData, _ := yaml.Marshal(proj)
+			os.WriteFile(".servo/project.yaml", projectData, 0644)
+
+			if err := NewConfigGeneratorManager(".servo").GenerateAll(); err != nil {
+				t.Fatalf("Failed to generate configs: %v", err)
+			}
+
+			var compose struct {
+				Services map[string]struct {
+					Volumes []string `yaml:"volumes"`
+				} `yaml:"services"`
+			}
+			composeData, _ := os.ReadFile(".devcontainer/docker-compose.yml")
+			if err := yaml.Unmarshal(composeData, &compose); err != nil {
+				t.Fatalf("Failed to parse docker-compose.yml: %v", err)
+			}
+			volumes := compose.Services["store-db"].Volumes
+			if strings.Join(volumes, ",") != strings.Join(tt.wantVolumes, ",") {
+				t.Errorf("Volumes = %v, want %v", volumes, tt.wantVolumes)
+			}
+
+			var devcontainer map[string]interface{}
+			devcontainerData, _ := os.ReadFile(".devcontainer/devcontainer.json")
+			if err := json.Unmarshal(devcontainerData, &devcontainer); err != nil {
+				t.Fatalf("Failed to parse devcontainer.json: %v", err)
+			}
+			onCreate, _ := devcontainer["onCreateCommand"].(string)
+			if !strings.Contains(onCreate, tt.wantMkdir) {
+				t.Errorf("onCreateCommand missing %q: %s", tt.wantMkdir, onCreate)
+			}
+			if tt.rejectMkdir != "" && strings.Contains(onCreate, "mkdir -p "+tt.rejectMkdir) {
+				t.Errorf("onCreateCommand should not contain %q: %s", tt.rejectMkdir, onCreate)
+			}
+		})
+	}
+}
+
+func TestLogVolumeDirs(t *testing.T) {
+	service := &pkg.ServiceDependency{Volumes: []string{"db_logs:/var/log", "db_data:/var/lib/postgresql/data", "app_logs:/var/log/nginx/:ro", "output:/srv/app/logs"}}
+	want := []string{"var-log", "var-log-nginx", "srv-app-logs"}
+	if got := logVolumeDirs(service); !reflect.DeepEqual(got, want) {
+		t.Errorf("logVolumeDirs() = %v, want %v", got, want)
+	}
+}
+
+func TestIsLogVolume(t *testing.T) {
+	tests := map[string]bool{
+		"db_logs:/var/log":                 true,
+		"app_logs:/var/log/nginx:ro":       true,
+		"output:/srv/app/logs":             true,
+		"db_data:/var/lib/postgresql/data": false,
+		"/host/logs:/var/log":              false,
+		"./logs:/var/log":                  false,
+		"logs":                             false,
+		"catalog:/var/logbook":             false,
+	}
+	for volume, want := range tests {
+		if got := isLogVolume(volume); got != want {
+			t.Errorf("isLogVolume(%q) = %v, want %v", volume, got, want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/servo/servo/internal/project"
+)
+
+// DefaultWorkspaceMount is the workspace bind mount used when the project sets no
+// config.workspace_mount: the directory two levels above .devcontainer/, mounted at
+// /workspaces
+const DefaultWorkspaceMount = "../..:/workspaces:cached"
+
+// ResolveWorkspaceMount returns the project's workspace bind mount, or
+// DefaultWorkspaceMount, along with the container directory it mounts at
+func (g *BaseGenerator) ResolveWorkspaceMount(proj *project.Project) (mount, target string, err error) {
+	mount = DefaultWorkspaceMount
+	if proj != nil && proj.Config.WorkspaceMount != "" {
+		mount = proj.Config.WorkspaceMount
+	}
+	_, target, _, err = project.ParseWorkspaceMount(mount)
+	if err != nil {
+		return "", "", err
+	}
+	return mount, target, nil
+}
+
+// WorkspaceProjectDir returns where the workspace container sees the project root:
+// the mount target joined with the project's path below the mount source. It is ""
+// when the project root lies outside the mounted directory.
+func (g *BaseGenerator) WorkspaceProjectDir(proj *project.Project) (string, error) {
+	mount, target, err := g.ResolveWorkspaceMount(proj)
+	if err != nil {
+		return "", err
+	}
+	source, _, _, err := project.ParseWorkspaceMount(mount)
+	if err != nil {
+		return "", err
+	}
+
+	root, err := filepath.Abs(".")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve project root: %w", err)
+	}
+	if !filepath.IsAbs(source) {
+		source = filepath.Join(root, ".devcontainer", source)
+	}
+	rel, err := filepath.Rel(source, root)
+	if err != nil || (rel != "." && !filepath.IsLocal(rel)) {
+		return "", nil
+	}
+	return path.Join(target, filepath.ToSlash(rel)), nil
+}
+
+// devMountRoot is where the workspace container mounts checkouts installed with
+// --dev: .servo/dev under the project directory, or under the mount target when the
+// project lies outside the mounted directory
+func devMountRoot(projectDir, workspaceTarget string) string {
+	if projectDir == "" {
+		projectDir = workspaceTarget
+	}
+	return path.Join(projectDir, ".servo", "dev")
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGeneration_WorkspaceMount(t *testing.T) {
+	tests := []struct {
+		name          string
+		mount         string
+		wantVolume    string
+		wantDir       string
+		wantWorkspace string
+		// wantProject is the project directory in the container, given the project
+		// root's parent and base names; "" when the project is outside the mount
+		wantProject func(parent, base string) string
+	}{
+		{name: "default", wantVolume: "../..:/workspaces:cached", wantDir: "/workspaces", wantWorkspace: "/workspace",
+			wantProject: func(parent, base string) string { return "/workspaces/" + base }},
+		{name: "nested project", mount: "../../..:/workspaces/app:cached", wantVolume: "../../..:/workspaces/app:cached", wantDir: "/workspaces/app", wantWorkspace: "/workspaces/app",
+			wantProject: func(parent, base string) string { return "/workspaces/app/" + parent + "/" + base }},
+		{name: "project outside the mount", mount: "/elsewhere:/src", wantVolume: "/elsewhere:/src", wantDir: "/src", wantWorkspace: "/src",
+			wantProject: func(parent, base string) string { return "" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalDir, _ := os.Getwd()
+			defer os.Chdir(originalDir)
+			root := t.TempDir()
+			os.Chdir(root)
+			projectDir := tt.wantProject(filepath.Base(filepath.Dir(root)), filepath.Base(root))
+
+			if err := setupDevcontainerTestProject(); err != nil {
+				t.Fatalf("Failed to setup test project: %v", err)
+			}
+			proj := &project.Project{DefaultSession: "test", ActiveSession: "test", Config: project.ProjectConfig{WorkspaceMount: tt.mount, DevcontainerMode: project.DevcontainerModeCompose}}
+			data, _ := yaml.Marshal(proj)
+			os.WriteFile(".servo/project.yaml", data, 0644)
+
+			manager := NewConfigGeneratorManager(".servo")
+			if err := manager.GenerateDockerCompose(); err != nil {
+				t.Fatalf("GenerateDockerCompose() error = %v", err)
+			}
+			if err := manager.GenerateDevcontainer(); err != nil {
+				t.Fatalf("GenerateDevcontainer() error = %v", err)
+			}
+
+			var compose struct {
+				Services struct {
+					Workspace struct {
+						Volumes    []string `yaml:"volumes"`
+						WorkingDir string   `yaml:"working_dir"`
+					} `yaml:"workspace"`
+				} `yaml:"services"`
+			}
+			composeData, _ := os.ReadFile(".devcontainer/docker-compose.yml")
+			if err := yaml.Unmarshal(composeData, &compose); err != nil {
+				t.Fatalf("Failed to parse docker-compose.yml: %v", err)
+			}
+			workspace := compose.Services.Workspace
+			if !slices.Contains(workspace.Volumes, tt.wantVolume) || !slices.Contains(workspace.Volumes, "workspace-data:/workspace/.servo") {
+				t.Errorf("workspace volumes = %v, want %s and the workspace-data volume", workspace.Volumes, tt.wantVolume)
+			}
+			if workspace.WorkingDir != tt.wantDir {
+				t.Errorf("working_dir = %s, want %s", workspace.WorkingDir, tt.wantDir)
+			}
+
+			var devcontainer struct {
+				WorkspaceFolder string `json:"workspaceFolder"`
+				OnCreateCommand string `json:"onCreateCommand"`
+			}
+			devcontainerData, _ := os.ReadFile(".devcontainer/devcontainer.json")
+			if err := json.Unmarshal(devcontainerData, &devcontainer); err != nil {
+				t.Fatalf("Failed to parse devcontainer.json: %v", err)
+			}
+			if devcontainer.WorkspaceFolder != tt.wantWorkspace {
+				t.Errorf("workspaceFolder = %s, want %s", devcontainer.WorkspaceFolder, tt.wantWorkspace)
+			}
+
+			// Directories inside the project are created below its path in the container
+			if projectDir == "" {
+				if strings.Contains(devcontainer.OnCreateCommand, "mkdir") || strings.Contains(devcontainer.OnCreateCommand, "cd ") {
+					t.Errorf("Expected no project paths for a project outside the mount, got %s", devcontainer.OnCreateCommand)
+				}
+			} else if !strings.Contains(devcontainer.OnCreateCommand, "mkdir -p "+projectDir+"/.servo/logs") {
+				t.Errorf("onCreateCommand missing the logs directory under %s: %s", projectDir, devcontainer.OnCreateCommand)
+			}
+		})
+	}
+}
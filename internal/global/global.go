@@ -0,0 +1,92 @@
+package global
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/servo/servo/internal/manifest"
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDir is where globally installed servers are recorded, relative to the home
+// directory
+const DefaultDir = ".servo/global"
+
+// stateFile is the file inside the global directory that lists installed servers
+const stateFile = "servers.yaml"
+
+// Server records where a global server was installed from and which clients'
+// user-level configs it was merged into
+type Server struct {
+	Source  string   `yaml:"source"`
+	Clients []string `yaml:"clients,omitempty"`
+}
+
+// State lists every globally installed server by name
+type State struct {
+	Servers map[string]Server `yaml:"servers"`
+}
+
+// Dir returns the global directory in the current user's home directory
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, DefaultDir), nil
+}
+
+// ManifestsDir returns the directory global manifests are stored in
+func ManifestsDir(dir string) string {
+	return filepath.Join(dir, "manifests")
+}
+
+// NewStore returns the manifest store over the global manifests directory
+func NewStore(dir string, parser *mcp.Parser) *manifest.Store {
+	return manifest.NewStore(ManifestsDir(dir), parser)
+}
+
+// Load reads the global state in dir. A missing state has no servers.
+func Load(dir string) (*State, error) {
+	path := filepath.Join(dir, stateFile)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &State{Servers: make(map[string]Server)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read global state %s: %w", path, err)
+	}
+
+	var state State
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse global state %s: %w", path, err)
+	}
+	if state.Servers == nil {
+		state.Servers = make(map[string]Server)
+	}
+	return &state, nil
+}
+
+// Save writes the global state to dir
+func (s *State) Save(dir string) error {
+	return utils.WriteYAMLFile(filepath.Join(dir, stateFile), s)
+}
+
+// ServersFor returns, sorted, the servers merged into the given client
+func (s *State) ServersFor(client string) []string {
+	var names []string
+	for name, server := range s.Servers {
+		for _, c := range server.Clients {
+			if c == client {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,32 @@
+package global
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestState(t *testing.T) {
+	dir := t.TempDir()
+
+	state, err := Load(dir)
+	if err != nil || len(state.Servers) != 0 {
+		t.Fatalf("Load() of a missing state = %+v, %v; want empty", state, err)
+	}
+
+	state.Servers["notes"] = Server{Source: "notes.servo", Clients: []string{"cursor", "vscode"}}
+	state.Servers["journal"] = Server{Source: "github:me/journal", Clients: []string{"cursor"}}
+	if err := state.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	state, err = Load(dir)
+	if err != nil || len(state.Servers) != 2 {
+		t.Fatalf("Load() = %+v, %v; want both servers", state, err)
+	}
+	if got := state.ServersFor("cursor"); !reflect.DeepEqual(got, []string{"journal", "notes"}) {
+		t.Errorf("ServersFor(cursor) = %v", got)
+	}
+	if got := state.ServersFor("claude-code"); len(got) != 0 {
+		t.Errorf("ServersFor(claude-code) = %v, want none", got)
+	}
+}
@@ -0,0 +1,78 @@
+// Package logging provides servo's leveled diagnostic logger. Diagnostics go to
+// stderr so the regular stdout output of each command is unaffected.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// LevelEnvVar selects the log level when no --verbose flag is given
+const LevelEnvVar = "SERVO_LOG_LEVEL"
+
+var logger atomic.Pointer[slog.Logger]
+
+func init() {
+	Configure(os.Stderr, slog.LevelWarn)
+}
+
+// Configure sends diagnostics at or above level to w
+func Configure(w io.Writer, level slog.Level) {
+	handler := slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			// Timestamps add noise to short-lived CLI runs
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	logger.Store(slog.New(handler))
+}
+
+// ParseLevel parses a SERVO_LOG_LEVEL value: debug, info, warn or error
+func ParseLevel(value string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return slog.LevelWarn, fmt.Errorf("invalid log level '%s' (valid: debug, info, warn, error)", value)
+}
+
+// VerbosityLevel maps the number of --verbose flags to a level: none keeps the
+// default of warnings only, one adds info and two or more add debug
+func VerbosityLevel(count int) slog.Level {
+	switch {
+	case count >= 2:
+		return slog.LevelDebug
+	case count == 1:
+		return slog.LevelInfo
+	}
+	return slog.LevelWarn
+}
+
+// Debug logs detailed diagnostics, shown with -v -v or SERVO_LOG_LEVEL=debug
+func Debug(msg string, args ...any) {
+	logger.Load().Debug(msg, args...)
+}
+
+// Info logs the main decisions a command makes, shown with -v
+func Info(msg string, args ...any) {
+	logger.Load().Info(msg, args...)
+}
+
+// Warn logs problems that do not stop the command
+func Warn(msg string, args ...any) {
+	logger.Load().Warn(msg, args...)
+}
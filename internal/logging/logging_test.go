@@ -0,0 +1,60 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"debug":   slog.LevelDebug,
+		"INFO":    slog.LevelInfo,
+		"warning": slog.LevelWarn,
+		" error ": slog.LevelError,
+	}
+	for value, want := range tests {
+		got, err := ParseLevel(value)
+		if err != nil {
+			t.Errorf("ParseLevel(%q) error = %v", value, err)
+		}
+		if got != want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", value, got, want)
+		}
+	}
+
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("Expected error for invalid level")
+	}
+}
+
+func TestVerbosityLevel(t *testing.T) {
+	for count, want := range map[int]slog.Level{0: slog.LevelWarn, 1: slog.LevelInfo, 2: slog.LevelDebug, 3: slog.LevelDebug} {
+		if got := VerbosityLevel(count); got != want {
+			t.Errorf("VerbosityLevel(%d) = %v, want %v", count, got, want)
+		}
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	defer Configure(os.Stderr, slog.LevelWarn)
+
+	var buf bytes.Buffer
+	Configure(&buf, slog.LevelInfo)
+
+	Debug("hidden detail")
+	Info("resolved source", "via", "git")
+
+	out := buf.String()
+	if strings.Contains(out, "hidden detail") {
+		t.Errorf("Debug message logged at info level:\n%s", out)
+	}
+	if !strings.Contains(out, "resolved source") || !strings.Contains(out, "via=git") {
+		t.Errorf("Info message missing:\n%s", out)
+	}
+	if strings.Contains(out, "time=") {
+		t.Errorf("Expected no timestamp:\n%s", out)
+	}
+}
@@ -0,0 +1,54 @@
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/servo/servo/pkg"
+)
+
+// capabilityList is one kind of advertised capability and its names
+type capabilityList struct {
+	kind  string
+	names []string
+}
+
+// capabilityLists returns a manifest's capability lists in tools, resources, prompts order
+func capabilityLists(manifest *pkg.ServoDefinition) []capabilityList {
+	if manifest == nil || manifest.Capabilities == nil {
+		return nil
+	}
+	return []capabilityList{
+		{"tool", manifest.Capabilities.Tools},
+		{"resource", manifest.Capabilities.Resources},
+		{"prompt", manifest.Capabilities.Prompts},
+	}
+}
+
+// CapabilityCounts summarizes a manifest's advertised capabilities as counts, e.g.
+// "3 tools, 1 prompt", or returns "" when it advertises none
+func CapabilityCounts(manifest *pkg.ServoDefinition) string {
+	var parts []string
+	for _, list := range capabilityLists(manifest) {
+		switch len(list.names) {
+		case 0:
+		case 1:
+			parts = append(parts, "1 "+list.kind)
+		default:
+			parts = append(parts, fmt.Sprintf("%d %ss", len(list.names), list.kind))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// CapabilityNames lists a manifest's advertised capabilities by name, one line per
+// kind such as "tools: search, fetch"
+func CapabilityNames(manifest *pkg.ServoDefinition) []string {
+	var lines []string
+	for _, list := range capabilityLists(manifest) {
+		if len(list.names) > 0 {
+			lines = append(lines, fmt.Sprintf("%ss: %s", list.kind, strings.Join(list.names, ", ")))
+		}
+	}
+	return lines
+}
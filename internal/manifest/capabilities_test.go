@@ -0,0 +1,44 @@
+package manifest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/pkg"
+)
+
+func TestCapabilities(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities *pkg.Capabilities
+		counts       string
+		names        string
+	}{
+		{name: "none"},
+		{name: "empty lists", capabilities: &pkg.Capabilities{}},
+		{
+			name:         "all kinds",
+			capabilities: &pkg.Capabilities{Tools: []string{"search", "fetch"}, Resources: []string{"docs"}, Prompts: []string{"summarize", "review", "triage"}},
+			counts:       "2 tools, 1 resource, 3 prompts",
+			names:        "tools: search, fetch|resources: docs|prompts: summarize, review, triage",
+		},
+		{
+			name:         "prompts only",
+			capabilities: &pkg.Capabilities{Prompts: []string{"explain"}},
+			counts:       "1 prompt",
+			names:        "prompts: explain",
+		},
+	}
+	for _, tt := range tests {
+		servo := &pkg.ServoDefinition{Capabilities: tt.capabilities}
+		if got := CapabilityCounts(servo); got != tt.counts {
+			t.Errorf("%s: CapabilityCounts() = %q, want %q", tt.name, got, tt.counts)
+		}
+		if got := strings.Join(CapabilityNames(servo), "|"); got != tt.names {
+			t.Errorf("%s: CapabilityNames() = %q, want %q", tt.name, got, tt.names)
+		}
+	}
+	if CapabilityCounts(nil) != "" || CapabilityNames(nil) != nil {
+		t.Error("Expected no capabilities for a nil manifest")
+	}
+}
@@ -0,0 +1,88 @@
+package manifest
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/servo/servo/pkg"
+)
+
+// Providers returns the sorted keys of the manifests that satisfy a depends_on entry:
+// the manifest stored under that key, and any manifest declaring that name
+func Providers(manifests map[string]*pkg.ServoDefinition, server string) []string {
+	var keys []string
+	for key, servoDef := range manifests {
+		if key == server || (servoDef != nil && servoDef.Name == server) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MissingDependencies returns the depends_on entries of servoDef that no manifest in
+// manifests satisfies, in the order they are declared
+func MissingDependencies(servoDef *pkg.ServoDefinition, manifests map[string]*pkg.ServoDefinition) []string {
+	var missing []string
+	for _, server := range servoDef.DependsOn {
+		if len(Providers(manifests, server)) == 0 {
+			missing = append(missing, server)
+		}
+	}
+	return missing
+}
+
+// DependencyCycle returns a server depends_on cycle among manifests as the keys along
+// it, starting and ending with the same key, or nil when there is none
+func DependencyCycle(manifests map[string]*pkg.ServoDefinition) []string {
+	keys := make([]string, 0, len(manifests))
+	for key := range manifests {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(manifests))
+	var path []string
+
+	var visit func(key string) []string
+	visit = func(key string) []string {
+		state[key] = visiting
+		path = append(path, key)
+		if servoDef := manifests[key]; servoDef != nil {
+			for _, server := range servoDef.DependsOn {
+				for _, next := range Providers(manifests, server) {
+					if next == key {
+						continue
+					}
+					switch state[next] {
+					case visiting:
+						start := slices.Index(path, next)
+						return append(slices.Clone(path[start:]), next)
+					case unvisited:
+						if cycle := visit(next); cycle != nil {
+							return cycle
+						}
+					}
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[key] = visited
+		return nil
+	}
+
+	for _, key := range keys {
+		if state[key] != unvisited {
+			continue
+		}
+		if cycle := visit(key); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/servo/servo/pkg"
+)
+
+func TestMissingDependencies(t *testing.T) {
+	manifests := map[string]*pkg.ServoDefinition{
+		"acme-db": {ID: "acme-db", Name: "database-server"},
+		"cache":   {Name: "cache"},
+	}
+
+	if got := Providers(manifests, "database-server"); !reflect.DeepEqual(got, []string{"acme-db"}) {
+		t.Errorf("Providers by declared name = %v, want [acme-db]", got)
+	}
+	if got := Providers(manifests, "acme-db"); !reflect.DeepEqual(got, []string{"acme-db"}) {
+		t.Errorf("Providers by key = %v, want [acme-db]", got)
+	}
+
+	api := &pkg.ServoDefinition{Name: "api", DependsOn: []string{"queue", "database-server", "cache", "search"}}
+	if got := MissingDependencies(api, manifests); !reflect.DeepEqual(got, []string{"queue", "search"}) {
+		t.Errorf("MissingDependencies = %v, want [queue search]", got)
+	}
+}
+
+func TestDependencyCycle(t *testing.T) {
+	manifests := map[string]*pkg.ServoDefinition{
+		"api":     {Name: "api", DependsOn: []string{"acme-db"}},
+		"acme-db": {ID: "acme-db", Name: "database-server", DependsOn: []string{"cache"}},
+		"cache":   {Name: "cache"},
+	}
+	if cycle := DependencyCycle(manifests); cycle != nil {
+		t.Errorf("DependencyCycle() = %v, want none", cycle)
+	}
+
+	// The cycle closes through a declared name rather than the key
+	manifests["cache"].DependsOn = []string{"api"}
+	manifests["api"].DependsOn = []string{"database-server"}
+	want := []string{"acme-db", "cache", "api", "acme-db"}
+	if cycle := DependencyCycle(manifests); !reflect.DeepEqual(cycle, want) {
+		t.Errorf("DependencyCycle() = %v, want %v", cycle, want)
+	}
+}
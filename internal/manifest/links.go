@@ -0,0 +1,23 @@
+package manifest
+
+import (
+	"net/url"
+
+	"github.com/servo/servo/pkg"
+)
+
+// ProjectURL returns the page to send users to for a manifest: metadata.homepage,
+// falling back to metadata.repository. Only http and https URLs are returned, since
+// the link may be handed to a browser; anything else yields an empty string.
+func ProjectURL(manifest *pkg.ServoDefinition) string {
+	if manifest == nil || manifest.Metadata == nil {
+		return ""
+	}
+
+	for _, candidate := range []string{manifest.Metadata.Homepage, manifest.Metadata.Repository} {
+		if u, err := url.Parse(candidate); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+			return candidate
+		}
+	}
+	return ""
+}
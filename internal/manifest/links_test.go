@@ -0,0 +1,30 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/servo/servo/pkg"
+)
+
+func TestProjectURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata *pkg.Metadata
+		want     string
+	}{
+		{name: "no metadata", want: ""},
+		{name: "no links", metadata: &pkg.Metadata{}, want: ""},
+		{name: "homepage wins", metadata: &pkg.Metadata{Homepage: "https://example.com", Repository: "https://github.com/org/repo"}, want: "https://example.com"},
+		{name: "repository fallback", metadata: &pkg.Metadata{Repository: "https://github.com/org/repo"}, want: "https://github.com/org/repo"},
+		{name: "non-web homepage skipped", metadata: &pkg.Metadata{Homepage: "file:///etc/passwd", Repository: "http://git.example.com/repo"}, want: "http://git.example.com/repo"},
+		{name: "only non-web links", metadata: &pkg.Metadata{Homepage: "javascript:alert(1)", Repository: "git@github.com:org/repo.git"}, want: ""},
+	}
+	for _, tt := range tests {
+		if got := ProjectURL(&pkg.ServoDefinition{Metadata: tt.metadata}); got != tt.want {
+			t.Errorf("%s: ProjectURL() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if got := ProjectURL(nil); got != "" {
+		t.Errorf("ProjectURL(nil) = %q, want empty", got)
+	}
+}
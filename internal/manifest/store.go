@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/servo/servo/internal/mcp"
@@ -13,23 +14,28 @@ import (
 
 // Store handles manifest storage and retrieval for sessions
 type Store struct {
-	sessionDir string
-	parser     *mcp.Parser
+	manifestDir string
+	parser      *mcp.Parser
 }
 
-// NewStore creates a new manifest store for the given session directory
-func NewStore(sessionDir string, parser *mcp.Parser) *Store {
+// NewStore creates a new manifest store over a session's manifests directory, as
+// returned by session.Manager.ManifestsDir
+func NewStore(manifestDir string, parser *mcp.Parser) *Store {
 	return &Store{
-		sessionDir: sessionDir,
-		parser:     parser,
+		manifestDir: manifestDir,
+		parser:      parser,
 	}
 }
 
+// manifestFile returns the path a server's manifest is stored at
+func (s *Store) manifestFile(serverName string) string {
+	return filepath.Join(s.manifestDir, serverName+".servo")
+}
+
 // StoreManifest stores a parsed .servo manifest for a server
 func (s *Store) StoreManifest(serverName, source string) error {
 	// Create manifests directory
-	manifestDir := filepath.Join(s.sessionDir, "manifests")
-	if err := utils.EnsureDirectoryStructure([]string{manifestDir}); err != nil {
+	if err := utils.EnsureDirectoryStructure([]string{s.manifestDir}); err != nil {
 		return fmt.Errorf("failed to create manifests directory: %w", err)
 	}
 
@@ -53,13 +59,17 @@ func (s *Store) StoreManifest(serverName, source string) error {
 	}
 
 	// Store the manifest with source metadata
-	manifestFile := filepath.Join(manifestDir, serverName+".servo")
-	return s.writeManifest(manifestFile, manifest, source)
+	return s.writeManifest(s.manifestFile(serverName), manifest, source)
+}
+
+// SaveManifest stores an already-built manifest for a server
+func (s *Store) SaveManifest(serverName string, manifest *pkg.ServoDefinition, source string) error {
+	return s.writeManifest(s.manifestFile(serverName), manifest, source)
 }
 
 // GetManifest retrieves a stored manifest by server name
 func (s *Store) GetManifest(serverName string) (*pkg.ServoDefinition, error) {
-	manifestFile := filepath.Join(s.sessionDir, "manifests", serverName+".servo")
+	manifestFile := s.manifestFile(serverName)
 
 	if _, err := os.Stat(manifestFile); os.IsNotExist(err) {
 		return nil, fmt.Errorf("manifest for server %s not found", serverName)
@@ -68,27 +78,38 @@ func (s *Store) GetManifest(serverName string) (*pkg.ServoDefinition, error) {
 	return s.parser.ParseFromFile(manifestFile)
 }
 
-// ListManifests returns all stored manifests
-func (s *Store) ListManifests() (map[string]*pkg.ServoDefinition, error) {
-	manifestDir := filepath.Join(s.sessionDir, "manifests")
-
-	if _, err := os.Stat(manifestDir); os.IsNotExist(err) {
-		return make(map[string]*pkg.ServoDefinition), nil
+// Names returns, sorted, the keys of every stored manifest file, including ones that
+// no longer parse
+func (s *Store) Names() ([]string, error) {
+	if _, err := os.Stat(s.manifestDir); os.IsNotExist(err) {
+		return nil, nil
 	}
 
-	entries, err := os.ReadDir(manifestDir)
+	entries, err := os.ReadDir(s.manifestDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read manifests directory: %w", err)
 	}
 
-	manifests := make(map[string]*pkg.ServoDefinition)
-
+	var names []string
 	for _, entry := range entries {
-		if !strings.HasSuffix(entry.Name(), ".servo") {
-			continue
+		if strings.HasSuffix(entry.Name(), ".servo") {
+			names = append(names, strings.TrimSuffix(entry.Name(), ".servo"))
 		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ListManifests returns all stored manifests
+func (s *Store) ListManifests() (map[string]*pkg.ServoDefinition, error) {
+	names, err := s.Names()
+	if err != nil {
+		return nil, err
+	}
 
-		serverName := strings.TrimSuffix(entry.Name(), ".servo")
+	manifests := make(map[string]*pkg.ServoDefinition)
+
+	for _, serverName := range names {
 		manifest, err := s.GetManifest(serverName)
 		if err != nil || manifest == nil {
 			// Skip invalid or nil manifests but continue processing others
@@ -101,9 +122,69 @@ func (s *Store) ListManifests() (map[string]*pkg.ServoDefinition, error) {
 	return manifests, nil
 }
 
+// ManifestsNamed returns, sorted, the keys of stored manifests that declare the given
+// server name. A key differs from the declared name when a manifest was stored under
+// a name derived from its source rather than its name field.
+func (s *Store) ManifestsNamed(name string) ([]string, error) {
+	manifests, err := s.ListManifests()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for key, manifest := range manifests {
+		if manifest.Name == name {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// ResolveKey returns the key a server is stored under, accepting either the key
+// itself or the name its manifest declares, which differ when the manifest sets an
+// id. A name no manifest matches is returned as given; a name declared by several
+// manifests is an error naming their keys.
+func (s *Store) ResolveKey(name string) (string, error) {
+	if _, err := os.Stat(s.manifestFile(name)); err == nil {
+		return name, nil
+	}
+
+	keys, err := s.ManifestsNamed(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to list manifests: %w", err)
+	}
+	switch len(keys) {
+	case 0:
+		return name, nil
+	case 1:
+		return keys[0], nil
+	default:
+		return "", fmt.Errorf("server name '%s' matches several installed servers (%s); use one of their keys", name, strings.Join(keys, ", "))
+	}
+}
+
+// DuplicateNames maps each server name declared by more than one manifest to the
+// sorted keys of the manifests declaring it
+func DuplicateNames(manifests map[string]*pkg.ServoDefinition) map[string][]string {
+	byName := make(map[string][]string)
+	for key, manifest := range manifests {
+		byName[manifest.Name] = append(byName[manifest.Name], key)
+	}
+
+	duplicates := make(map[string][]string)
+	for name, keys := range byName {
+		if len(keys) > 1 {
+			sort.Strings(keys)
+			duplicates[name] = keys
+		}
+	}
+	return duplicates
+}
+
 // RemoveManifest removes a stored manifest
 func (s *Store) RemoveManifest(serverName string) error {
-	manifestFile := filepath.Join(s.sessionDir, "manifests", serverName+".servo")
+	manifestFile := s.manifestFile(serverName)
 
 	if err := os.Remove(manifestFile); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to remove manifest for %s: %w", serverName, err)
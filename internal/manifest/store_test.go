@@ -0,0 +1,87 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/internal/mcp"
+	"github.com/servo/servo/pkg"
+)
+
+func TestStore_ManifestsNamed(t *testing.T) {
+	manifestDir := t.TempDir()
+
+	manifests := map[string]string{
+		"api-server": "api-server",
+		"legacy-api": "api-server",
+		"db":         "db",
+	}
+	for key, name := range manifests {
+		content := "servo_version: \"1.0\"\nname: " + name + "\nserver:\n  transport: stdio\n  command: " + key + "\n"
+		if err := os.WriteFile(filepath.Join(manifestDir, key+".servo"), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write manifest: %v", err)
+		}
+	}
+
+	store := NewStore(manifestDir, mcp.NewParser())
+	keys, err := store.ManifestsNamed("api-server")
+	if err != nil {
+		t.Fatalf("ManifestsNamed() error = %v", err)
+	}
+	if want := []string{"api-server", "legacy-api"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("ManifestsNamed() = %v, want %v", keys, want)
+	}
+
+	if keys, _ := store.ManifestsNamed("missing"); len(keys) != 0 {
+		t.Errorf("Expected no manifests for unknown name, got %v", keys)
+	}
+}
+
+func TestStore_ResolveKey(t *testing.T) {
+	manifestDir := t.TempDir()
+
+	manifests := map[string]string{
+		"acme-api": "api-server",
+		"db-v1":    "db",
+		"db-v2":    "db",
+	}
+	for key, name := range manifests {
+		content := "servo_version: \"1.0\"\nname: " + name + "\nserver:\n  transport: stdio\n  command: " + key + "\n"
+		if err := os.WriteFile(filepath.Join(manifestDir, key+".servo"), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write manifest: %v", err)
+		}
+	}
+
+	store := NewStore(manifestDir, mcp.NewParser())
+	for name, want := range map[string]string{
+		"acme-api":   "acme-api",
+		"api-server": "acme-api",
+		"db-v1":      "db-v1",
+		"missing":    "missing",
+	} {
+		if key, err := store.ResolveKey(name); err != nil || key != want {
+			t.Errorf("ResolveKey(%q) = %q, %v, want %q", name, key, err, want)
+		}
+	}
+
+	if _, err := store.ResolveKey("db"); err == nil || !strings.Contains(err.Error(), "db-v1, db-v2") {
+		t.Errorf("Expected an error naming both keys for an ambiguous name, got %v", err)
+	}
+}
+
+func TestDuplicateNames(t *testing.T) {
+	manifests := map[string]*pkg.ServoDefinition{
+		"b":  {Name: "api"},
+		"a":  {Name: "api"},
+		"db": {Name: "db"},
+	}
+
+	got := DuplicateNames(manifests)
+	want := map[string][]string{"api": {"a", "b"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DuplicateNames() = %v, want %v", got, want)
+	}
+}
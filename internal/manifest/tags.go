@@ -0,0 +1,56 @@
+package manifest
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/servo/servo/pkg"
+)
+
+// TagIndex maps each manifest tag to the sorted keys of the manifests carrying it
+type TagIndex map[string][]string
+
+// NewTagIndex indexes the metadata.tags of the given manifests, keyed as returned
+// by Store.ListManifests. Manifests without tags are not indexed.
+func NewTagIndex(manifests map[string]*pkg.ServoDefinition) TagIndex {
+	index := make(TagIndex)
+	for key, manifest := range manifests {
+		for _, tag := range Tags(manifest) {
+			index[tag] = append(index[tag], key)
+		}
+	}
+	for tag := range index {
+		sort.Strings(index[tag])
+	}
+	return index
+}
+
+// Servers returns the keys of manifests tagged with tag. Tags are matched
+// case-insensitively since manifests must declare them in lowercase.
+func (idx TagIndex) Servers(tag string) []string {
+	return idx[normalizeTag(tag)]
+}
+
+// Tags returns a manifest's tags, normalized, deduplicated and sorted
+func Tags(manifest *pkg.ServoDefinition) []string {
+	if manifest == nil || manifest.Metadata == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(manifest.Metadata.Tags))
+	var tags []string
+	for _, tag := range manifest.Metadata.Tags {
+		tag = normalizeTag(tag)
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// normalizeTag trims and lowercases a tag for comparison
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
@@ -0,0 +1,42 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/servo/servo/pkg"
+)
+
+func TestTagIndex(t *testing.T) {
+	tagged := func(tags ...string) *pkg.ServoDefinition {
+		return &pkg.ServoDefinition{Metadata: &pkg.Metadata{Tags: tags}}
+	}
+	manifests := map[string]*pkg.ServoDefinition{
+		"postgres": tagged("database", "storage"),
+		"redis":    tagged("storage", "cache", "storage"),
+		"llm":      tagged("ai"),
+		"plain":    tagged(),
+		"bare":     {},
+	}
+
+	index := NewTagIndex(manifests)
+
+	tests := map[string][]string{
+		"storage":  {"postgres", "redis"},
+		"Database": {"postgres"},
+		"ai":       {"llm"},
+		"missing":  nil,
+	}
+	for tag, want := range tests {
+		if got := index.Servers(tag); !reflect.DeepEqual(got, want) {
+			t.Errorf("Servers(%q) = %v, want %v", tag, got, want)
+		}
+	}
+
+	if got, want := Tags(manifests["redis"]), []string{"cache", "storage"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Tags() = %v, want %v", got, want)
+	}
+	if got := Tags(manifests["plain"]); len(got) != 0 {
+		t.Errorf("Tags() = %v for untagged manifest, want none", got)
+	}
+}
@@ -0,0 +1,137 @@
+package mcp
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/servo/servo/internal/logging"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultCredentialsPath is the per-host credentials file, relative to the home directory
+const DefaultCredentialsPath = ".servo/credentials.yaml"
+
+// HostCredential is the auth the credentials file supplies for one host pattern
+type HostCredential struct {
+	Token       string `yaml:"token,omitempty"`
+	Username    string `yaml:"username,omitempty"`
+	Password    string `yaml:"password,omitempty"`
+	SSHKey      string `yaml:"ssh_key,omitempty"`
+	SSHPassword string `yaml:"ssh_password,omitempty"`
+}
+
+// CredentialsFile maps host patterns such as github.com or *.corp.example.com to auth
+type CredentialsFile struct {
+	Hosts map[string]HostCredential `yaml:"hosts"`
+}
+
+// LoadCredentialsFile reads a credentials file. A missing file yields no credentials.
+func LoadCredentialsFile(filePath string) (*CredentialsFile, error) {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return &CredentialsFile{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file %s: %w", filePath, err)
+	}
+
+	var creds CredentialsFile
+	if err := yaml.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file %s: %w", filePath, err)
+	}
+	return &creds, nil
+}
+
+// Match returns the credential for host. An exact pattern wins over wildcards, and
+// among matching wildcard patterns the longest, most specific one wins. Hosts and
+// patterns compare case-insensitively.
+func (f *CredentialsFile) Match(host string) (*HostCredential, bool) {
+	if f == nil || host == "" {
+		return nil, false
+	}
+	host = strings.ToLower(host)
+
+	patterns := make([]string, 0, len(f.Hosts))
+	for pattern := range f.Hosts {
+		if strings.ToLower(pattern) == host {
+			cred := f.Hosts[pattern]
+			return &cred, true
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+	for _, pattern := range patterns {
+		if ok, err := path.Match(strings.ToLower(pattern), host); err == nil && ok {
+			cred := f.Hosts[pattern]
+			return &cred, true
+		}
+	}
+	return nil, false
+}
+
+// repoHost returns the host of an https://, ssh:// or scp-style git@host:path URL
+func repoHost(repoURL string) string {
+	if !strings.Contains(repoURL, "://") {
+		if at := strings.Index(repoURL, "@"); at >= 0 {
+			if colon := strings.Index(repoURL[at:], ":"); colon >= 0 {
+				return repoURL[at+1 : at+colon]
+			}
+		}
+		return ""
+	}
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// hostCredential returns the credentials file entry for repoURL's host, if any
+func (p *Parser) hostCredential(repoURL string) *HostCredential {
+	credentialsPath := p.CredentialsPath
+	if credentialsPath == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		credentialsPath = filepath.Join(home, DefaultCredentialsPath)
+	}
+
+	creds, err := LoadCredentialsFile(credentialsPath)
+	if err != nil {
+		logging.Warn("ignoring credentials file", "error", err)
+		return nil
+	}
+
+	host := repoHost(repoURL)
+	cred, ok := creds.Match(host)
+	if !ok {
+		return nil
+	}
+	logging.Debug("using credentials file entry", "host", host, "path", credentialsPath)
+	return cred
+}
+
+// expandHome expands a leading ~/ to the home directory
+func expandHome(p string) string {
+	rest, found := strings.CutPrefix(p, "~/")
+	if !found {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return p
+	}
+	return filepath.Join(home, rest)
+}
@@ -0,0 +1,144 @@
+package mcp
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestCredentialsFile_Match(t *testing.T) {
+	creds := &CredentialsFile{Hosts: map[string]HostCredential{
+		"github.com":            {Token: "exact"},
+		"*.example.com":         {Token: "wildcard"},
+		"*.git.example.com":     {Token: "specific-wildcard"},
+		"GitLab.Internal":       {Token: "mixed-case"},
+		"[invalid":              {Token: "bad-pattern"},
+		"git.example.com":       {Token: "exact-beats-wildcard"},
+		"*":                     {Token: "catch-all"},
+		"bitbucket.example.org": {Username: "alice", Password: "secret"},
+	}}
+
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "github.com", want: "exact"},
+		{host: "GITHUB.COM", want: "exact"},
+		{host: "api.example.com", want: "wildcard"},
+		{host: "eu.git.example.com", want: "specific-wildcard"},
+		{host: "git.example.com", want: "exact-beats-wildcard"},
+		{host: "gitlab.internal", want: "mixed-case"},
+		{host: "example.net", want: "catch-all"},
+	}
+	for _, tt := range tests {
+		cred, ok := creds.Match(tt.host)
+		if !ok || cred.Token != tt.want {
+			t.Errorf("Match(%q) = %+v, %v; want token %q", tt.host, cred, ok, tt.want)
+		}
+	}
+
+	if _, ok := (&CredentialsFile{Hosts: map[string]HostCredential{"*.example.com": {}}}).Match("example.com"); ok {
+		t.Error("*.example.com should not match the bare domain")
+	}
+	if _, ok := creds.Match(""); ok {
+		t.Error("empty host should not match")
+	}
+}
+
+func TestRepoHost(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/org/repo.git":          "github.com",
+		"https://user@git.example.com:8443/r.git":  "git.example.com",
+		"ssh://git@gitlab.internal:2222/org/r.git": "gitlab.internal",
+		"git@github.com:org/repo.git":              "github.com",
+		"not a url":                                "",
+	}
+	for repoURL, want := range tests {
+		if got := repoHost(repoURL); got != want {
+			t.Errorf("repoHost(%q) = %q, want %q", repoURL, got, want)
+		}
+	}
+}
+
+func TestParser_AuthPrecedence(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GIT_USERNAME", "")
+	t.Setenv("GIT_PASSWORD", "")
+
+	dir := t.TempDir()
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	keyPath := filepath.Join(dir, "id_ed25519")
+	os.WriteFile(keyPath, pem.EncodeToMemory(block), 0600)
+
+	credentialsPath := filepath.Join(dir, "credentials.yaml")
+	os.WriteFile(credentialsPath, []byte(`hosts:
+  github.com:
+    token: file-token
+  "*.corp.example.com":
+    username: alice
+    password: file-password
+  git.internal:
+    ssh_key: `+keyPath+`
+`), 0600)
+
+	password := func(auth interface{}) string {
+		if basic, ok := auth.(*githttp.BasicAuth); ok {
+			return basic.Password
+		}
+		return ""
+	}
+
+	parser := NewParser()
+	parser.CredentialsPath = credentialsPath
+
+	auth, method := parser.authFor("https://github.com/org/private.git")
+	if method != "credentials-file" || password(auth) != "file-token" {
+		t.Errorf("Expected credentials file token, got %s", method)
+	}
+	auth, method = parser.authFor("https://git.corp.example.com/team/repo.git")
+	if method != "credentials-file" || password(auth) != "file-password" {
+		t.Errorf("Expected credentials file username/password, got %s", method)
+	}
+	if _, method = parser.authFor("git@git.internal:team/repo.git"); method != "credentials-file" {
+		t.Errorf("Expected credentials file SSH key, got %s", method)
+	}
+	if _, method = parser.authFor("https://gitlab.com/org/repo.git"); method != "system default" {
+		t.Errorf("Expected system default for an unlisted host, got %s", method)
+	}
+
+	// Environment variables outrank the file
+	t.Setenv("GITHUB_TOKEN", "env-token")
+	auth, method = parser.authFor("https://github.com/org/private.git")
+	if method != "GITHUB_TOKEN" || password(auth) != "env-token" {
+		t.Errorf("Expected environment token to win, got %s", method)
+	}
+
+	// Explicit flags outrank both
+	parser.HTTPToken = "flag-token"
+	auth, method = parser.authFor("https://github.com/org/private.git")
+	if method != "http-token" || password(auth) != "flag-token" {
+		t.Errorf("Expected explicit token to win, got %s", method)
+	}
+	parser.SSHKeyPath = keyPath
+	if _, method = parser.authFor("git@git.internal:team/repo.git"); method != "ssh-key" {
+		t.Errorf("Expected explicit SSH key to win, got %s", method)
+	}
+
+	// A missing file leaves the system defaults in place
+	parser = NewParser()
+	parser.CredentialsPath = filepath.Join(dir, "missing.yaml")
+	t.Setenv("GITHUB_TOKEN", "")
+	if _, method = parser.authFor("https://github.com/org/private.git"); method != "system default" {
+		t.Errorf("Expected system default without a credentials file, got %s", method)
+	}
+}
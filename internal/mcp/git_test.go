@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -397,3 +398,53 @@ func ExampleParser_authentication() {
 
 	fmt.Println("Successfully parsed .servo file from git repository")
 }
+
+// TestParser_ParseFromGitRepoFile tests reading an exact manifest path from a repository
+func TestParser_ParseFromGitRepoFile(t *testing.T) {
+	repoDir := t.TempDir()
+
+	files := map[string]string{
+		"root.servo":            "servo_version: \"1.0\"\nname: root-server\nserver:\n  transport: stdio\n  command: root\n",
+		"servers/db/db.servo":   "servo_version: \"1.0\"\nname: db-server\nserver:\n  transport: stdio\n  command: db\n",
+		"servers/web/web.servo": "servo_version: \"1.0\"\nname: web-server\nserver:\n  transport: stdio\n  command: web\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(repoDir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	commands := [][]string{
+		{"git", "init", repoDir},
+		{"git", "-C", repoDir, "config", "user.email", "test@example.com"},
+		{"git", "-C", repoDir, "config", "user.name", "Test User"},
+		{"git", "-C", repoDir, "add", "."},
+		{"git", "-C", repoDir, "commit", "-m", "Add servo files"},
+	}
+	for _, cmd := range commands {
+		if output, err := exec.Command(cmd[0], cmd[1:]...).CombinedOutput(); err != nil {
+			t.Fatalf("Failed to run %v: %v\nOutput: %s", cmd, err, output)
+		}
+	}
+
+	parser := NewParser()
+
+	servoDef, err := parser.ParseFromGitRepoFile(repoDir, "servers/db/db.servo")
+	if err != nil {
+		t.Fatalf("ParseFromGitRepoFile failed: %v", err)
+	}
+	if servoDef.Name != "db-server" {
+		t.Errorf("Expected name 'db-server', got %s", servoDef.Name)
+	}
+
+	_, err = parser.ParseFromGitRepoFile(repoDir, "servers/cache/cache.servo")
+	if err == nil || !strings.Contains(err.Error(), "servers/cache/cache.servo") {
+		t.Errorf("Expected missing file error naming the path, got %v", err)
+	}
+
+	if _, err := parser.ParseFromGitRepoFile(repoDir, "../outside.servo"); err == nil {
+		t.Error("Expected paths escaping the repository to be rejected")
+	}
+}
@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LicenseProprietary marks a manifest whose server is not released under an open
+// license
+const LicenseProprietary = "proprietary"
+
+// spdxLicenses are the SPDX license identifiers CheckLicense recognizes: the
+// identifiers MCP servers and their dependencies are commonly released under
+var spdxLicenses = []string{
+	"0BSD", "AFL-3.0", "AGPL-1.0-only", "AGPL-1.0-or-later", "AGPL-3.0-only", "AGPL-3.0-or-later",
+	"Apache-1.1", "Apache-2.0", "Artistic-2.0", "BlueOak-1.0.0",
+	"BSD-1-Clause", "BSD-2-Clause", "BSD-2-Clause-Patent", "BSD-3-Clause", "BSD-3-Clause-Clear", "BSD-4-Clause",
+	"BSL-1.0", "BUSL-1.1", "CC-BY-4.0", "CC-BY-SA-4.0", "CC-BY-NC-4.0", "CC-BY-NC-SA-4.0", "CC-BY-ND-4.0", "CC0-1.0",
+	"CDDL-1.0", "CDDL-1.1", "CECILL-2.1", "ECL-2.0", "EPL-1.0", "EPL-2.0", "EUPL-1.1", "EUPL-1.2",
+	"GPL-2.0-only", "GPL-2.0-or-later", "GPL-3.0-only", "GPL-3.0-or-later",
+	"ISC", "LGPL-2.0-only", "LGPL-2.0-or-later", "LGPL-2.1-only", "LGPL-2.1-or-later", "LGPL-3.0-only", "LGPL-3.0-or-later",
+	"LPPL-1.3c", "MIT", "MIT-0", "MPL-1.1", "MPL-2.0", "MPL-2.0-no-copyleft-exception", "MS-PL", "MS-RL",
+	"MulanPSL-2.0", "NCSA", "ODbL-1.0", "OFL-1.1", "OSL-3.0", "PostgreSQL", "Python-2.0", "Ruby",
+	"SSPL-1.0", "Unlicense", "UPL-1.0", "Vim", "W3C", "WTFPL", "X11", "Zlib", "ZPL-2.1",
+	// Deprecated identifiers that remain common in published manifests
+	"AGPL-3.0", "GPL-2.0", "GPL-3.0", "LGPL-2.1", "LGPL-3.0",
+}
+
+// spdxOperators join license identifiers in an SPDX license expression
+var spdxOperators = map[string]bool{"AND": true, "OR": true, "WITH": true}
+
+// CheckLicense returns a warning when a manifest's license is not a recognized SPDX
+// identifier or expression, or "proprietary", and "" otherwise. Identifiers match
+// case-insensitively, as SPDX specifies, and LicenseRef- identifiers are accepted. An
+// empty license is allowed since the field is optional.
+func (v *Validator) CheckLicense(license string) string {
+	license = strings.TrimSpace(license)
+	if license == "" || strings.EqualFold(license, LicenseProprietary) {
+		return ""
+	}
+
+	fields := strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(license))
+	if !hasOperator(fields) {
+		// Without operators the whole value is one identifier, so "Apache 2.0" is
+		// matched, and corrected, as a unit
+		fields = []string{strings.Join(fields, " ")}
+	}
+	var unknown []string
+	for i, id := range fields {
+		if spdxOperators[strings.ToUpper(id)] {
+			continue
+		}
+		// The identifier after WITH names an exception rather than a license
+		if i > 0 && strings.EqualFold(fields[i-1], "WITH") {
+			continue
+		}
+		if !knownLicense(id) {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) == 0 {
+		return ""
+	}
+
+	warning := fmt.Sprintf("license '%s' is not a recognized SPDX identifier", unknown[0])
+	if suggestion := nearestLicense(unknown[0]); suggestion != "" {
+		warning += fmt.Sprintf(", did you mean '%s'?", suggestion)
+	} else {
+		warning += fmt.Sprintf(" (use an id from https://spdx.org/licenses/ or '%s')", LicenseProprietary)
+	}
+	return warning
+}
+
+// hasOperator reports whether the fields of a license value include an SPDX operator
+func hasOperator(fields []string) bool {
+	for _, field := range fields {
+		if spdxOperators[strings.ToUpper(field)] {
+			return true
+		}
+	}
+	return false
+}
+
+// knownLicense reports whether id is a recognized SPDX identifier. A trailing "+"
+// (or later) and LicenseRef- identifiers are accepted.
+func knownLicense(id string) bool {
+	if strings.HasPrefix(id, "LicenseRef-") {
+		return true
+	}
+	id = strings.TrimSuffix(id, "+")
+	for _, known := range spdxLicenses {
+		if strings.EqualFold(id, known) {
+			return true
+		}
+	}
+	return false
+}
+
+// nearestLicense returns the recognized identifier closest to id by edit distance,
+// or "" when none is close enough to be a likely typo
+func nearestLicense(id string) string {
+	best := ""
+	bestDistance := len(id)/3 + 2
+	for _, known := range spdxLicenses {
+		if d := editDistance(strings.ToLower(id), strings.ToLower(known)); d < bestDistance {
+			best, bestDistance = known, d
+		}
+	}
+	return best
+}
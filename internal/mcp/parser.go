@@ -6,18 +6,43 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
-	"regexp"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/servo/servo/internal/logging"
 	"github.com/servo/servo/pkg"
 	"gopkg.in/yaml.v3"
 )
 
+// DefaultAlternateManifestNames are recognized by directory discovery when no
+// *.servo file exists. Entries starting with "." match as a filename suffix
+// (so ".servo.yaml" also matches "server.servo.yaml"); others match exactly.
+var DefaultAlternateManifestNames = []string{"servo.yaml", ".servo.yaml"}
+
+// DefaultManifestSearchDepth is how many directory levels below a source directory
+// discovery descends when the top level holds no manifest
+const DefaultManifestSearchDepth = 1
+
+// DefaultHTTPTimeout bounds each HTTP fetch of a manifest or catalog index
+const DefaultHTTPTimeout = 30 * time.Second
+
+// ManifestError reports manifest content that is not a valid .servo file, as opposed
+// to a source that could not be read or fetched
+type ManifestError struct {
+	Err error
+}
+
+func (e *ManifestError) Error() string { return e.Err.Error() }
+
+func (e *ManifestError) Unwrap() error { return e.Err }
+
 // Parser handles parsing .servo files from various sources
 type Parser struct {
 	// Authentication options
@@ -26,6 +51,24 @@ type Parser struct {
 	HTTPUsername string
 	HTTPPassword string
 	HTTPToken    string
+
+	// AlternateManifestNames overrides DefaultAlternateManifestNames when non-nil;
+	// an empty slice limits discovery to *.servo files
+	AlternateManifestNames []string
+
+	// SearchDepth overrides DefaultManifestSearchDepth when non-nil; 0 searches only
+	// the given directory
+	SearchDepth *int
+
+	// Strict rejects unknown top-level manifest keys instead of ignoring them
+	Strict bool
+
+	// CredentialsPath is the per-host credentials file consulted when no explicit
+	// option or environment variable supplies auth; "" uses DefaultCredentialsPath
+	CredentialsPath string
+
+	// HTTPTimeout overrides DefaultHTTPTimeout for URL fetches when non-zero
+	HTTPTimeout time.Duration
 }
 
 // NewParser creates a new servo file parser
@@ -45,7 +88,31 @@ func (p *Parser) ParseFromFile(filePath string) (*pkg.ServoDefinition, error) {
 
 // ParseFromURL parses a .servo file from a remote URL
 func (p *Parser) ParseFromURL(urlStr string) (*pkg.ServoDefinition, error) {
-	resp, err := http.Get(urlStr)
+	data, err := p.FetchURL(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.parseYAML(data)
+}
+
+// FetchURL downloads urlStr within the parser's HTTP timeout. Over https the request
+// carries the credentials file entry for the URL's host. The git auth options and
+// environment tokens are left to git clones, so they are never sent to arbitrary hosts.
+func (p *Parser) FetchURL(urlStr string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, urlStr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch URL %s: %w", urlStr, err)
+	}
+	if strings.HasPrefix(urlStr, "https://") {
+		p.setHTTPAuth(req)
+	}
+
+	timeout := p.HTTPTimeout
+	if timeout == 0 {
+		timeout = DefaultHTTPTimeout
+	}
+	resp, err := (&http.Client{Timeout: timeout}).Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch URL %s: %w", urlStr, err)
 	}
@@ -59,105 +126,39 @@ func (p *Parser) ParseFromURL(urlStr string) (*pkg.ServoDefinition, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
-
-	return p.parseYAML(data)
+	return data, nil
 }
 
-// ParseFromGitRepo clones a git repository and parses a .servo file from it
-// Supports SSH key authentication and all git hosting services
-func (p *Parser) ParseFromGitRepo(repoURL string, subdirectory string) (*pkg.ServoDefinition, error) {
-	// Create temporary directory for cloning
-	tempDir, err := os.MkdirTemp("", "servo-clone-*")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+// setHTTPAuth adds the credentials file entry for req's host, if any
+func (p *Parser) setHTTPAuth(req *http.Request) {
+	cred := p.hostCredential(req.URL.String())
+	if cred == nil {
+		return
 	}
-	defer os.RemoveAll(tempDir)
-
-	// Set up authentication with preference: explicit options > environment > system defaults
-	var auth interface{}
 
 	switch {
-	case strings.HasPrefix(repoURL, "git@") || strings.Contains(repoURL, "ssh://"):
-		// SSH authentication - go-git handles most of this automatically
-
-		// 1. Use explicit SSH key if provided
-		if p.SSHKeyPath != "" {
-			if sshAuth, err := ssh.NewPublicKeysFromFile("git", p.SSHKeyPath, p.SSHPassword); err == nil {
-				auth = sshAuth
-			}
-		}
-
-		// 2. Try SSH agent if no explicit key
-		if auth == nil {
-			if sshAuth, err := ssh.NewSSHAgentAuth("git"); err == nil {
-				auth = sshAuth
-			}
-		}
-
-		// 3. If no explicit auth set, go-git automatically handles:
-		//    - Reading ~/.ssh/config for host-specific configurations
-		//    - Trying common SSH key locations (~/.ssh/id_rsa, ~/.ssh/id_ed25519, etc.)
-		//    - Using SSH agent
-		//    - Handling known_hosts verification
-		//    - SSH key passphrases via system prompts or agents
-
-	case strings.HasPrefix(repoURL, "https://"):
-		// HTTPS authentication with multiple options
-
-		// 1. Use explicit credentials if provided
-		switch {
-		case p.HTTPToken != "":
-			auth = &githttp.BasicAuth{
-				Username: "token", // Standard token format for GitHub/GitLab
-				Password: p.HTTPToken,
-			}
-		case p.HTTPUsername != "" && p.HTTPPassword != "":
-			auth = &githttp.BasicAuth{
-				Username: p.HTTPUsername,
-				Password: p.HTTPPassword,
-			}
-		default:
-			// 2. Check environment variables
-			if token := os.Getenv("GITHUB_TOKEN"); token != "" {
-				auth = &githttp.BasicAuth{
-					Username: "token",
-					Password: token,
-				}
-			} else if username := os.Getenv("GIT_USERNAME"); username != "" {
-				if password := os.Getenv("GIT_PASSWORD"); password != "" {
-					auth = &githttp.BasicAuth{
-						Username: username,
-						Password: password,
-					}
-				}
-			}
-		}
-
-		// 3. If no explicit auth, go-git will automatically:
-		//    - Use git credential helpers (credential-manager, etc.)
-		//    - Check ~/.netrc for stored credentials
-		//    - Use system keychain/credential store
+	case cred.Token != "":
+		req.Header.Set("Authorization", "Bearer "+cred.Token)
+	case cred.Username != "" && cred.Password != "":
+		req.SetBasicAuth(cred.Username, cred.Password)
 	}
+}
 
-	// Clone the repository
-	cloneOptions := &git.CloneOptions{
-		URL:      repoURL,
-		Progress: nil, // Silent clone
-		Depth:    1,   // Shallow clone for efficiency
-	}
-	if auth != nil {
-		switch a := auth.(type) {
-		case *githttp.BasicAuth:
-			cloneOptions.Auth = a
-		case ssh.AuthMethod:
-			cloneOptions.Auth = a
-		}
+// ParseFromGitRepo clones a git repository and parses a .servo file from it
+// Supports SSH key authentication and all git hosting services. A github:org/repo
+// shorthand repoURL is expanded first, cloning its ref and searching its subdirectory.
+func (p *Parser) ParseFromGitRepo(repoURL string, subdirectory string) (*pkg.ServoDefinition, error) {
+	var ref string
+	if shorthand, ok := ParseGitHubShorthand(repoURL); ok {
+		repoURL, ref = shorthand.RepoURL, shorthand.Ref
+		subdirectory = path.Join(shorthand.Subdir, subdirectory)
 	}
 
-	_, err = git.PlainClone(tempDir, false, cloneOptions)
+	tempDir, err := p.cloneRepo(repoURL, ref)
 	if err != nil {
-		return nil, fmt.Errorf("failed to clone repository %s: %w", repoURL, err)
+		return nil, err
 	}
+	defer os.RemoveAll(tempDir)
 
 	// Determine the directory to search for .servo files
 	searchDir := tempDir
@@ -172,576 +173,380 @@ func (p *Parser) ParseFromGitRepo(repoURL string, subdirectory string) (*pkg.Ser
 	return p.ParseFromDirectory(searchDir)
 }
 
-// ParseFromDirectory finds and parses a .servo file in a directory
-func (p *Parser) ParseFromDirectory(dirPath string) (*pkg.ServoDefinition, error) {
-	// Look for any .servo files in the directory
-	entries, err := os.ReadDir(dirPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read directory %s: %w", dirPath, err)
+// ParseFromGitRepoFile clones a git repository and parses the manifest at filePath,
+// relative to the repository root, skipping directory discovery
+func (p *Parser) ParseFromGitRepoFile(repoURL string, filePath string) (*pkg.ServoDefinition, error) {
+	var ref string
+	if shorthand, ok := ParseGitHubShorthand(repoURL); ok {
+		repoURL, ref = shorthand.RepoURL, shorthand.Ref
+		filePath = path.Join(shorthand.Subdir, strings.TrimPrefix(filePath, "/"))
 	}
 
-	var servoFiles []string
-	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".servo") {
-			servoFiles = append(servoFiles, filepath.Join(dirPath, entry.Name()))
-		}
+	cleanPath, err := cleanRepoPath(filePath)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(servoFiles) == 0 {
-		return nil, fmt.Errorf("no .servo files found in directory %s", dirPath)
+	tempDir, err := p.cloneRepo(repoURL, ref)
+	if err != nil {
+		return nil, err
 	}
+	defer os.RemoveAll(tempDir)
 
-	if len(servoFiles) > 1 {
-		return nil, fmt.Errorf("multiple .servo files found in directory %s, specify one: %v", dirPath, servoFiles)
+	fullPath := filepath.Join(tempDir, cleanPath)
+	if info, err := os.Stat(fullPath); err != nil || info.IsDir() {
+		return nil, fmt.Errorf("file %s not found in repository %s", cleanPath, repoURL)
 	}
 
-	return p.ParseFromFile(servoFiles[0])
+	return p.ParseFromFile(fullPath)
 }
 
-// parseYAML parses YAML data into ServoDefinition
-func (p *Parser) parseYAML(data []byte) (*pkg.ServoDefinition, error) {
-	// First, try to parse with the new structure
-	var servo pkg.ServoDefinition
-	if err := yaml.Unmarshal(data, &servo); err != nil {
-		return nil, fmt.Errorf("failed to parse YAML: %w", err)
-	}
-
-	// Handle backward compatibility: check if we need to migrate from old metadata structure
-	if servo.Name == "" {
-		// Try parsing with the old structure to extract fields
-		var legacyServo struct {
-			pkg.ServoDefinition
-			Metadata *struct {
-				Name        string   `yaml:"name"`
-				Version     string   `yaml:"version"`
-				Description string   `yaml:"description"`
-				Author      string   `yaml:"author"`
-				License     string   `yaml:"license"`
-				Homepage    string   `yaml:"homepage,omitempty"`
-				Repository  string   `yaml:"repository,omitempty"`
-				Tags        []string `yaml:"tags,omitempty"`
-			} `yaml:"metadata"`
-		}
-
-		if err := yaml.Unmarshal(data, &legacyServo); err == nil && legacyServo.Metadata != nil {
-			// Migrate fields from legacy structure
-			if servo.Name == "" && legacyServo.Metadata.Name != "" {
-				servo.Name = legacyServo.Metadata.Name
-			}
-			if servo.Version == "" && legacyServo.Metadata.Version != "" {
-				servo.Version = legacyServo.Metadata.Version
-			}
-			if servo.Description == "" && legacyServo.Metadata.Description != "" {
-				servo.Description = legacyServo.Metadata.Description
-			}
-			if servo.Author == "" && legacyServo.Metadata.Author != "" {
-				servo.Author = legacyServo.Metadata.Author
-			}
-			if servo.License == "" && legacyServo.Metadata.License != "" {
-				servo.License = legacyServo.Metadata.License
-			}
-
-			// Preserve optional metadata fields in the new structure
-			if servo.Metadata == nil {
-				servo.Metadata = &pkg.Metadata{}
-			}
-			if servo.Metadata.Homepage == "" && legacyServo.Metadata.Homepage != "" {
-				servo.Metadata.Homepage = legacyServo.Metadata.Homepage
-			}
-			if servo.Metadata.Repository == "" && legacyServo.Metadata.Repository != "" {
-				servo.Metadata.Repository = legacyServo.Metadata.Repository
-			}
-			if len(servo.Metadata.Tags) == 0 && len(legacyServo.Metadata.Tags) > 0 {
-				servo.Metadata.Tags = legacyServo.Metadata.Tags
-			}
-		}
+// cleanRepoPath validates a manifest path given relative to a repository root
+func cleanRepoPath(filePath string) (string, error) {
+	cleanPath := filepath.Clean(filepath.FromSlash(strings.TrimPrefix(filePath, "/")))
+	if filePath == "" || !filepath.IsLocal(cleanPath) {
+		return "", fmt.Errorf("invalid manifest path %q: must be relative to the repository root", filePath)
 	}
-
-	return &servo, nil
+	return cleanPath, nil
 }
 
-// convertGitToRawURL converts git repository URLs to raw content URLs
-func (p *Parser) convertGitToRawURL(repoURL string, subdirectory string) (string, error) {
-	// Parse the repository URL
-	parsedURL, err := url.Parse(repoURL)
+// cloneRepo shallow-clones a repository into a temporary directory that the caller
+// must remove. A non-empty ref clones that branch, or that tag when no branch matches.
+func (p *Parser) cloneRepo(repoURL string, ref string) (string, error) {
+	// Create temporary directory for cloning
+	tempDir, err := os.MkdirTemp("", "servo-clone-*")
 	if err != nil {
-		return "", fmt.Errorf("invalid repository URL: %w", err)
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
 	}
 
-	// Handle GitHub URLs
-	if strings.Contains(parsedURL.Host, "github.com") {
-		// Convert github.com/user/repo.git to raw.githubusercontent.com/user/repo/main/name.servo
-		path := strings.TrimSuffix(parsedURL.Path, ".git")
-		path = strings.TrimPrefix(path, "/")
+	auth, authMethod := p.authFor(repoURL)
+	logging.Info("cloning repository", "repo", repoURL, "auth", authMethod)
 
-		// Extract repository name for .servo file name
-		pathParts := strings.Split(path, "/")
-		if len(pathParts) < 2 {
-			return "", fmt.Errorf("invalid GitHub repository path: %s", path)
+	// Clone the repository
+	cloneOptions := &git.CloneOptions{
+		URL:      repoURL,
+		Progress: nil, // Silent clone
+		Depth:    1,   // Shallow clone for efficiency
+	}
+	if auth != nil {
+		cloneOptions.Auth = auth
+	}
+
+	if ref == "" {
+		_, err = git.PlainClone(tempDir, false, cloneOptions)
+	} else {
+		cloneOptions.SingleBranch = true
+		for _, refName := range []plumbing.ReferenceName{plumbing.NewBranchReferenceName(ref), plumbing.NewTagReferenceName(ref)} {
+			// A failed attempt can leave a partial clone behind
+			os.RemoveAll(tempDir)
+			if err = os.MkdirAll(tempDir, 0700); err != nil {
+				break
+			}
+			cloneOptions.ReferenceName = refName
+			if _, err = git.PlainClone(tempDir, false, cloneOptions); err == nil {
+				break
+			}
 		}
-		repoName := pathParts[len(pathParts)-1]
-
-		servoPath := fmt.Sprintf("%s.servo", repoName)
-		if subdirectory != "" {
-			servoPath = filepath.Join(subdirectory, fmt.Sprintf("%s.servo", repoName))
+	}
+	if err != nil {
+		os.RemoveAll(tempDir)
+		if ref != "" {
+			return "", fmt.Errorf("failed to clone repository %s at %s: %w", repoURL, ref, err)
 		}
-
-		rawURL := fmt.Sprintf("https://raw.githubusercontent.com/%s/main/%s", path, servoPath)
-		return rawURL, nil
+		return "", fmt.Errorf("failed to clone repository %s: %w", repoURL, err)
 	}
 
-	// Handle other git hosting services here
-	return "", fmt.Errorf("unsupported git hosting service: %s", parsedURL.Host)
+	return tempDir, nil
 }
 
-// Validator handles validation of .servo files
-type Validator struct {
-}
-
-// NewValidator creates a new servo file validator
-func NewValidator() *Validator {
-	return &Validator{}
-}
-
-// Validate validates a ServoDefinition
-func (v *Validator) Validate(servo *pkg.ServoDefinition) error {
-	if servo == nil {
-		return fmt.Errorf("servo definition cannot be nil")
-	}
-
-	// Validate servo version
-	if err := v.validateServoVersion(servo.ServoVersion); err != nil {
-		return err
-	}
+// authFor picks the authentication for cloning repoURL with preference: explicit
+// options > environment > credentials file > system defaults. It returns nil when
+// go-git should fall back to ~/.ssh, credential helpers or anonymous access, along
+// with a label for the method chosen.
+func (p *Parser) authFor(repoURL string) (transport.AuthMethod, string) {
+	switch {
+	case strings.HasPrefix(repoURL, "git@") || strings.Contains(repoURL, "ssh://"):
+		// SSH authentication - go-git handles most of this automatically
 
-	// Validate required top-level fields
-	if err := v.validateTopLevelFields(servo); err != nil {
-		return err
-	}
+		// 1. Use explicit SSH key if provided
+		if p.SSHKeyPath != "" {
+			sshAuth, err := ssh.NewPublicKeysFromFile("git", p.SSHKeyPath, p.SSHPassword)
+			if err == nil {
+				return sshAuth, "ssh-key"
+			}
+			logging.Debug("ignoring SSH key", "path", p.SSHKeyPath, "error", err)
+		}
 
-	// Validate optional metadata
-	if servo.Metadata != nil {
-		if err := v.validateMetadata(servo.Metadata); err != nil {
-			return err
+		// 2. Use the key the credentials file lists for this host
+		if cred := p.hostCredential(repoURL); cred != nil && cred.SSHKey != "" {
+			sshAuth, err := ssh.NewPublicKeysFromFile("git", expandHome(cred.SSHKey), cred.SSHPassword)
+			if err == nil {
+				return sshAuth, "credentials-file"
+			}
+			logging.Debug("ignoring credentials file SSH key", "path", cred.SSHKey, "error", err)
 		}
-	}
 
-	// Validate requirements
-	if servo.Requirements != nil {
-		if err := v.validateRequirements(servo.Requirements); err != nil {
-			return err
+		// 3. Try SSH agent if no explicit key
+		if sshAuth, err := ssh.NewSSHAgentAuth("git"); err == nil {
+			return sshAuth, "ssh-agent"
 		}
-	}
 
-	// Validate install section
-	if err := v.validateInstall(&servo.Install); err != nil {
-		return err
-	}
+		// 4. If no explicit auth set, go-git automatically handles:
+		//    - Reading ~/.ssh/config for host-specific configurations
+		//    - Trying common SSH key locations (~/.ssh/id_rsa, ~/.ssh/id_ed25519, etc.)
+		//    - Handling known_hosts verification
+		//    - SSH key passphrases via system prompts or agents
 
-	// Validate dependencies
-	if servo.Dependencies != nil {
-		if err := v.validateDependencies(servo.Dependencies); err != nil {
-			return err
-		}
-	}
+	case strings.HasPrefix(repoURL, "https://"):
+		// HTTPS authentication with multiple options
 
-	// Validate configuration schema
-	if servo.ConfigurationSchema != nil {
-		if err := v.validateConfigurationSchema(servo.ConfigurationSchema); err != nil {
-			return err
+		// 1. Use explicit credentials if provided
+		switch {
+		case p.HTTPToken != "":
+			return tokenAuth(p.HTTPToken), "http-token"
+		case p.HTTPUsername != "" && p.HTTPPassword != "":
+			return &githttp.BasicAuth{Username: p.HTTPUsername, Password: p.HTTPPassword}, "http-basic"
 		}
-	}
 
-	// Validate server section
-	if err := v.validateServer(&servo.Server); err != nil {
-		return err
-	}
+		// 2. Check environment variables
+		if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+			return tokenAuth(token), "GITHUB_TOKEN"
+		}
+		if username, password := os.Getenv("GIT_USERNAME"), os.Getenv("GIT_PASSWORD"); username != "" && password != "" {
+			return &githttp.BasicAuth{Username: username, Password: password}, "GIT_USERNAME/GIT_PASSWORD"
+		}
 
-	// Validate clients section
-	if servo.Clients != nil {
-		if err := v.validateClients(servo.Clients); err != nil {
-			return err
+		// 3. Use the credentials file entry for this host
+		if cred := p.hostCredential(repoURL); cred != nil {
+			switch {
+			case cred.Token != "":
+				return tokenAuth(cred.Token), "credentials-file"
+			case cred.Username != "" && cred.Password != "":
+				return &githttp.BasicAuth{Username: cred.Username, Password: cred.Password}, "credentials-file"
+			}
 		}
+
+		// 4. If no explicit auth, go-git will automatically:
+		//    - Use git credential helpers (credential-manager, etc.)
+		//    - Check ~/.netrc for stored credentials
+		//    - Use system keychain/credential store
 	}
 
-	return nil
+	return nil, "system default"
 }
 
-// validateServoVersion validates the servo_version field
-func (v *Validator) validateServoVersion(version string) error {
-	if version == "" {
-		return fmt.Errorf("servo_version is required")
+// tokenAuth authenticates over HTTPS with an access token
+func tokenAuth(token string) *githttp.BasicAuth {
+	return &githttp.BasicAuth{
+		Username: "token", // Standard token format for GitHub/GitLab
+		Password: token,
 	}
-
-	validVersions := []string{"1.0"}
-	for _, validVersion := range validVersions {
-		if version == validVersion {
-			return nil
-		}
-	}
-
-	return fmt.Errorf("unsupported servo_version: %s, supported versions: %v", version, validVersions)
 }
 
-// validateTopLevelFields validates the required and optional top-level fields
-func (v *Validator) validateTopLevelFields(servo *pkg.ServoDefinition) error {
-	// Validate required name field
-	if servo.Name == "" {
-		return fmt.Errorf("name is required")
+// ParseFromDirectory finds and parses a .servo file in a directory, descending up to
+// the search depth into subdirectories when the directory itself holds none.
+// *.servo files are canonical; alternate names such as servo.yaml are only
+// used when no *.servo file exists at the same level.
+func (p *Parser) ParseFromDirectory(dirPath string) (*pkg.ServoDefinition, error) {
+	manifestFile, err := p.FindManifestFile(dirPath)
+	if err != nil {
+		return nil, err
 	}
+	logging.Debug("found manifest", "path", manifestFile)
+	return p.ParseFromFile(manifestFile)
+}
 
-	// Validate name format (lowercase, hyphens only)
-	nameRegex := regexp.MustCompile(`^[a-z][a-z0-9-]*[a-z0-9]$`)
-	if !nameRegex.MatchString(servo.Name) {
-		return fmt.Errorf("name must be lowercase with hyphens only: %s", servo.Name)
+// FindManifestFile returns the path of the single manifest ParseFromDirectory would read
+func (p *Parser) FindManifestFile(dirPath string) (string, error) {
+	manifestFiles, err := p.FindManifestFiles(dirPath)
+	if err != nil {
+		return "", err
 	}
 
-	// Validate optional version field if provided
-	if servo.Version != "" {
-		// Validate semantic version format
-		semverRegex := regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([a-zA-Z0-9\-\.]+))?(?:\+([a-zA-Z0-9\-\.]+))?$`)
-		if !semverRegex.MatchString(servo.Version) {
-			return fmt.Errorf("version must be valid semantic version: %s", servo.Version)
+	if len(manifestFiles) > 1 {
+		candidates := make([]string, len(manifestFiles))
+		for i, manifestFile := range manifestFiles {
+			candidates[i] = manifestFile
+			if rel, err := filepath.Rel(dirPath, manifestFile); err == nil {
+				candidates[i] = filepath.ToSlash(rel)
+			}
 		}
+		return "", fmt.Errorf("multiple .servo files found in directory %s, specify one: %v", dirPath, candidates)
 	}
 
-	// Validate optional description field if provided
-	if servo.Description != "" && len(servo.Description) > 200 {
-		return fmt.Errorf("description must be 200 characters or less")
-	}
-
-	return nil
+	return manifestFiles[0], nil
 }
 
-// validateMetadata validates the optional metadata section
-func (v *Validator) validateMetadata(metadata *pkg.Metadata) error {
-	// Validate URLs if provided
-	if metadata.Homepage != "" {
-		if _, err := url.Parse(metadata.Homepage); err != nil {
-			return fmt.Errorf("metadata.homepage must be valid URL: %w", err)
-		}
-	}
+// FindManifestFiles returns the manifest candidates in the shallowest level of dirPath,
+// searching at most SearchDepth levels of non-hidden subdirectories, that holds any
+func (p *Parser) FindManifestFiles(dirPath string) ([]string, error) {
+	depth := DefaultManifestSearchDepth
+	if p.SearchDepth != nil {
+		depth = *p.SearchDepth
+	}
+
+	dirs := []string{dirPath}
+	for level := 0; level <= depth && len(dirs) > 0; level++ {
+		var servoFiles, alternateFiles, subdirs []string
+		for _, dir := range dirs {
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				if level == 0 {
+					return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+				}
+				logging.Debug("skipping unreadable directory", "path", dir, "error", err)
+				continue
+			}
 
-	if metadata.Repository != "" {
-		if _, err := url.Parse(metadata.Repository); err != nil {
-			return fmt.Errorf("metadata.repository must be valid URL: %w", err)
+			for _, entry := range entries {
+				switch {
+				case entry.IsDir():
+					if !strings.HasPrefix(entry.Name(), ".") {
+						subdirs = append(subdirs, filepath.Join(dir, entry.Name()))
+					}
+				case strings.HasSuffix(entry.Name(), ".servo"):
+					servoFiles = append(servoFiles, filepath.Join(dir, entry.Name()))
+				case p.isAlternateManifestName(entry.Name()):
+					alternateFiles = append(alternateFiles, filepath.Join(dir, entry.Name()))
+				}
+			}
 		}
-	}
 
-	// Validate tags
-	tagRegex := regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
-	for _, tag := range metadata.Tags {
-		if !tagRegex.MatchString(tag) {
-			return fmt.Errorf("invalid tag format: %s", tag)
+		if len(servoFiles) > 0 && len(alternateFiles) > 0 {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: using %v and ignoring %v in %s; *.servo files take precedence\n", servoFiles, alternateFiles, dirPath)
 		}
-	}
-
-	return nil
-}
 
-// validateRequirements validates the requirements section
-func (v *Validator) validateRequirements(req *pkg.Requirements) error {
-	// Validate system requirements
-	for _, sysReq := range req.System {
-		if sysReq.Name == "" {
-			return fmt.Errorf("system requirement name is required")
+		if len(servoFiles) == 0 {
+			servoFiles = alternateFiles
 		}
-		if sysReq.Description == "" {
-			return fmt.Errorf("system requirement description is required")
-		}
-		if sysReq.CheckCommand == "" {
-			return fmt.Errorf("system requirement check_command is required")
+		if len(servoFiles) > 0 {
+			return servoFiles, nil
 		}
+		dirs = subdirs
 	}
 
-	// Validate runtime requirements
-	for _, runtime := range req.Runtimes {
-		if runtime.Name == "" {
-			return fmt.Errorf("runtime requirement name is required")
-		}
-		if runtime.Version == "" {
-			return fmt.Errorf("runtime requirement version is required")
-		}
+	if depth > 0 {
+		return nil, fmt.Errorf("no .servo files found in directory %s or %d level(s) below it", dirPath, depth)
 	}
-
-	return nil
+	return nil, fmt.Errorf("no .servo files found in directory %s", dirPath)
 }
 
-// validateInstall validates the install section
-func (v *Validator) validateInstall(install *pkg.Install) error {
-	if install.Type == "" {
-		return fmt.Errorf("install.type is required")
-	}
-
-	validTypes := []string{"git", "local", "file", "remote"}
-	validType := false
-	for _, vt := range validTypes {
-		if install.Type == vt {
-			validType = true
-			break
-		}
-	}
-	if !validType {
-		return fmt.Errorf("install.type must be one of: %v", validTypes)
-	}
-
-	if install.Method == "" {
-		return fmt.Errorf("install.method is required")
-	}
-
-	if install.Method != install.Type {
-		return fmt.Errorf("install.method must match install.type")
+// isAlternateManifestName reports whether a filename matches a configured alternate manifest name
+func (p *Parser) isAlternateManifestName(name string) bool {
+	names := p.AlternateManifestNames
+	if names == nil {
+		names = DefaultAlternateManifestNames
 	}
 
-	// Type-specific validations
-	if install.Type == "git" {
-		if install.Repository == "" {
-			return fmt.Errorf("install.repository is required for git type")
-		}
-		if _, err := url.Parse(install.Repository); err != nil {
-			return fmt.Errorf("install.repository must be valid URL: %w", err)
+	for _, alternate := range names {
+		if name == alternate || (strings.HasPrefix(alternate, ".") && strings.HasSuffix(name, alternate)) {
+			return true
 		}
 	}
+	return false
+}
 
-	if len(install.SetupCommands) == 0 {
-		return fmt.Errorf("install.setup_commands is required")
-	}
-
-	// Validate commands for safety
-	for _, cmd := range install.SetupCommands {
-		if err := v.validateCommand(cmd); err != nil {
-			return fmt.Errorf("unsafe setup command: %w", err)
+// parseYAML parses YAML data into ServoDefinition, migrating the legacy metadata layout
+func (p *Parser) parseYAML(data []byte) (*pkg.ServoDefinition, error) {
+	// Stores may parse through a nil *Parser, which is always lenient
+	if p != nil && p.Strict {
+		if err := checkTopLevelKeys(data); err != nil {
+			return nil, &ManifestError{Err: err}
 		}
 	}
 
-	for _, cmd := range install.BuildCommands {
-		if err := v.validateCommand(cmd); err != nil {
-			return fmt.Errorf("unsafe build command: %w", err)
-		}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, &ManifestError{Err: fmt.Errorf("failed to parse YAML: %w", err)}
 	}
-
-	for _, cmd := range install.TestCommands {
-		if err := v.validateCommand(cmd); err != nil {
-			return fmt.Errorf("unsafe test command: %w", err)
-		}
+	for _, warning := range canonicalizeKeyCase(&root) {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %s\n", warning)
 	}
 
-	return nil
-}
-
-// validateDependencies validates the dependencies section
-func (v *Validator) validateDependencies(deps *pkg.Dependencies) error {
-	for serviceName, service := range deps.Services {
-		if serviceName == "" {
-			return fmt.Errorf("service name cannot be empty")
-		}
-
-		if service.Image == "" {
-			return fmt.Errorf("service.image is required for service %s", serviceName)
-		}
-
-		// Validate Docker image format
-		if !v.isValidDockerImage(service.Image) {
-			return fmt.Errorf("invalid Docker image format: %s", service.Image)
-		}
-
-		// Validate ports
-		for _, port := range service.Ports {
-			if err := v.validatePort(port); err != nil {
-				return fmt.Errorf("invalid port for service %s: %w", serviceName, err)
-			}
-		}
-
-		// Validate health check
-		if service.HealthCheck != nil {
-			if err := v.validateHealthCheck(service.HealthCheck); err != nil {
-				return fmt.Errorf("invalid health check for service %s: %w", serviceName, err)
-			}
+	var servo pkg.ServoDefinition
+	if root.Kind != 0 {
+		if err := root.Decode(&servo); err != nil {
+			return nil, &ManifestError{Err: fmt.Errorf("failed to parse YAML: %w", err)}
 		}
 	}
 
-	return nil
+	servo.Normalize()
+	return &servo, nil
 }
 
-// validateConfigurationSchema validates the configuration_schema section
-func (v *Validator) validateConfigurationSchema(schema *pkg.ConfigurationSchema) error {
-	// Validate secrets
-	for secretName, secret := range schema.Secrets {
-		if secret.Description == "" {
-			return fmt.Errorf("secret %s: description is required", secretName)
-		}
-		if secret.Type == "" {
-			return fmt.Errorf("secret %s: type is required", secretName)
-		}
-		if secret.EnvVar == "" {
-			return fmt.Errorf("secret %s: env_var is required", secretName)
-		}
-
-		validSecretTypes := []string{"api_key", "password", "certificate", "url"}
-		if !v.contains(validSecretTypes, secret.Type) {
-			return fmt.Errorf("secret %s: invalid type %s", secretName, secret.Type)
-		}
+// convertGitToRawURL converts git repository URLs to raw content URLs
+func (p *Parser) convertGitToRawURL(repoURL string, subdirectory string) (string, error) {
+	// Parse the repository URL
+	parsedURL, err := url.Parse(repoURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository URL: %w", err)
 	}
 
-	// Validate config
-	for configName, config := range schema.Config {
-		if config.Description == "" {
-			return fmt.Errorf("config %s: description is required", configName)
-		}
-		if config.Type == "" {
-			return fmt.Errorf("config %s: type is required", configName)
-		}
-		if config.EnvVar == "" {
-			return fmt.Errorf("config %s: env_var is required", configName)
-		}
+	// Handle GitHub URLs
+	if strings.Contains(parsedURL.Host, "github.com") {
+		// Convert github.com/user/repo.git to raw.githubusercontent.com/user/repo/main/name.servo
+		path := strings.TrimSuffix(parsedURL.Path, ".git")
+		path = strings.TrimPrefix(path, "/")
 
-		validConfigTypes := []string{"string", "integer", "boolean", "select", "multiselect", "file", "url"}
-		if !v.contains(validConfigTypes, config.Type) {
-			return fmt.Errorf("config %s: invalid type %s", configName, config.Type)
+		// Extract repository name for .servo file name
+		pathParts := strings.Split(path, "/")
+		if len(pathParts) < 2 {
+			return "", fmt.Errorf("invalid GitHub repository path: %s", path)
 		}
+		repoName := pathParts[len(pathParts)-1]
 
-		// Validate select type has options
-		if config.Type == "select" || config.Type == "multiselect" {
-			if len(config.Options) == 0 {
-				return fmt.Errorf("config %s: options required for %s type", configName, config.Type)
-			}
+		servoPath := fmt.Sprintf("%s.servo", repoName)
+		if subdirectory != "" {
+			servoPath = filepath.Join(subdirectory, fmt.Sprintf("%s.servo", repoName))
 		}
-	}
-
-	return nil
-}
-
-// validateServer validates the server section
-func (v *Validator) validateServer(server *pkg.Server) error {
-	if server.Transport == "" {
-		return fmt.Errorf("server.transport is required")
-	}
-
-	validTransports := []string{"stdio", "sse", "http"}
-	if !v.contains(validTransports, server.Transport) {
-		return fmt.Errorf("server.transport must be one of: %v", validTransports)
-	}
 
-	if server.Command == "" {
-		return fmt.Errorf("server.command is required")
-	}
-
-	if len(server.Args) == 0 {
-		return fmt.Errorf("server.args is required")
+		rawURL := fmt.Sprintf("https://raw.githubusercontent.com/%s/main/%s", path, servoPath)
+		return rawURL, nil
 	}
 
-	return nil
+	// Handle other git hosting services here
+	return "", fmt.Errorf("unsupported git hosting service: %s", parsedURL.Host)
 }
 
-// validateClients validates the clients section
-func (v *Validator) validateClients(clients *pkg.ClientInfo) error {
-	// No specific validation needed for recommended/tested/excluded lists
-	// They're just informational
-
-	// Validate client requirements
-	for clientName, req := range clients.Requirements {
-		if req.MinimumVersion != "" {
-			// Basic semantic version check
-			semverRegex := regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)`)
-			if !semverRegex.MatchString(req.MinimumVersion) {
-				return fmt.Errorf("client %s: invalid minimum_version format", clientName)
-			}
-		}
-	}
-
-	return nil
+// Validator handles validation of .servo files
+type Validator struct {
 }
 
-// validateCommand validates that a command is safe to execute
-func (v *Validator) validateCommand(cmd string) error {
-	// Basic safety checks - prevent obviously dangerous commands
-	dangerousPatterns := []string{
-		"rm -rf",
-		"sudo",
-		"su ",
-		"chmod 777",
-		"wget http://",
-		"curl http://",
-		"bash -c",
-		"sh -c",
-		"eval",
-		"exec",
-	}
-
-	cmdLower := strings.ToLower(cmd)
-	for _, pattern := range dangerousPatterns {
-		if strings.Contains(cmdLower, pattern) {
-			return fmt.Errorf("potentially dangerous command: %s", cmd)
-		}
-	}
-
-	return nil
+// NewValidator creates a new servo file validator
+func NewValidator() *Validator {
+	return &Validator{}
 }
 
-// validatePort validates a port string
-func (v *Validator) validatePort(port string) error {
-	portNum, err := strconv.Atoi(port)
-	if err != nil {
-		return fmt.Errorf("invalid port number: %s", port)
-	}
-
-	if portNum < 1 || portNum > 65535 {
-		return fmt.Errorf("port must be between 1 and 65535: %d", portNum)
-	}
-
-	return nil
+// Validate validates a ServoDefinition; it is equivalent to servo.Validate()
+func (v *Validator) Validate(servo *pkg.ServoDefinition) error {
+	return servo.Validate()
 }
 
-// validateHealthCheck validates a health check configuration
-func (v *Validator) validateHealthCheck(hc *pkg.HealthCheck) error {
-	if len(hc.Test) == 0 {
-		return fmt.Errorf("healthcheck.test is required")
-	}
-
-	// Validate duration formats
-	if hc.Interval != "" && !v.isValidDuration(hc.Interval) {
-		return fmt.Errorf("invalid healthcheck interval: %s", hc.Interval)
-	}
-
-	if hc.Timeout != "" && !v.isValidDuration(hc.Timeout) {
-		return fmt.Errorf("invalid healthcheck timeout: %s", hc.Timeout)
-	}
-
-	if hc.Retries < 0 {
-		return fmt.Errorf("healthcheck retries cannot be negative: %d", hc.Retries)
-	}
-
-	return nil
+// ValidateAs validates a ServoDefinition under a specific servo_version schema,
+// regardless of the version the file declares
+func (v *Validator) ValidateAs(servo *pkg.ServoDefinition, version string) error {
+	return servo.ValidateAs(version)
 }
 
-// isValidDockerImage checks if a string is a valid Docker image reference
-func (v *Validator) isValidDockerImage(image string) bool {
-	// Basic validation - more comprehensive validation could be added
-	if image == "" {
-		return false
+// CheckFilename returns a warning when a manifest's name differs from the stem of the
+// .servo file it was read from, or "" when they match. The name field stays
+// authoritative, so this is never an error. Pass an empty path for stdin and URL
+// sources; alternate manifest names such as servo.yaml are not checked either.
+func (v *Validator) CheckFilename(name, filePath string) string {
+	base := filepath.Base(filePath)
+	if filePath == "" || !strings.HasSuffix(base, ".servo") {
+		return ""
 	}
 
-	// Check for basic format: [registry/]name[:tag]
-	parts := strings.Split(image, ":")
-	if len(parts) > 2 {
-		return false
+	stem := strings.TrimSuffix(base, ".servo")
+	if stem == name {
+		return ""
 	}
-
-	return true
-}
-
-// isValidDuration checks if a string is a valid duration format
-func (v *Validator) isValidDuration(duration string) bool {
-	durationRegex := regexp.MustCompile(`^\d+[smh]$`)
-	return durationRegex.MatchString(duration)
+	return fmt.Sprintf("name '%s' does not match file name %s; the server is installed as '%s'", name, base, name)
 }
 
-// contains checks if a slice contains a string
-func (v *Validator) contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
-	}
-	return false
+// ValidateCommandSafety applies the setup command safety rules to a command
+// that servo is about to execute, such as a system requirement check_command
+func (v *Validator) ValidateCommandSafety(cmd string) error {
+	return pkg.ValidateCommandSafety(cmd)
 }
@@ -1,8 +1,15 @@
 package mcp
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestParser_ParseFromFile(t *testing.T) {
@@ -179,6 +186,109 @@ func TestParser_ParseFromDirectory_NoFiles(t *testing.T) {
 	}
 }
 
+func TestParser_ParseFromDirectory_AlternateNames(t *testing.T) {
+	manifest := func(name string) []byte {
+		return []byte("servo_version: \"1.0\"\nname: \"" + name + "\"\nversion: \"1.0.0\"\n")
+	}
+
+	tests := []struct {
+		name      string
+		files     []string
+		alternate []string
+		expected  string
+		wantErr   bool
+	}{
+		{name: "servo.yaml", files: []string{"servo.yaml"}, expected: "servo-yaml"},
+		{name: "hidden .servo.yaml", files: []string{".servo.yaml"}, expected: "servo-yaml"},
+		{name: "suffix match", files: []string{"server.servo.yaml"}, expected: "server-servo-yaml"},
+		{name: "canonical preferred", files: []string{"app.servo", "servo.yaml"}, expected: "app-servo"},
+		{name: "unrelated yaml ignored", files: []string{"myservo.yaml", "config.yaml"}, wantErr: true},
+		{name: "multiple alternates", files: []string{"servo.yaml", ".servo.yaml"}, wantErr: true},
+		{name: "alternates disabled", files: []string{"servo.yaml"}, alternate: []string{}, wantErr: true},
+		{name: "custom alternate", files: []string{"mcp.yaml"}, alternate: []string{"mcp.yaml"}, expected: "mcp-yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			for _, file := range tt.files {
+				name := strings.Trim(strings.NewReplacer(".", "-").Replace(file), "-")
+				if err := os.WriteFile(filepath.Join(tempDir, file), manifest(name), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", file, err)
+				}
+			}
+
+			parser := NewParser()
+			parser.AlternateManifestNames = tt.alternate
+			servoDef, err := parser.ParseFromDirectory(tempDir)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, parsed %s", servoDef.Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFromDirectory failed: %v", err)
+			}
+			if servoDef.Name != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, servoDef.Name)
+			}
+		})
+	}
+}
+
+func TestParser_ParseFromDirectory_SearchDepth(t *testing.T) {
+	manifest := func(name string) []byte {
+		return []byte("servo_version: \"1.0\"\nname: \"" + name + "\"\nversion: \"1.0.0\"\n")
+	}
+	depth := func(d int) *int { return &d }
+
+	tests := []struct {
+		name     string
+		files    []string
+		depth    *int
+		expected string
+		wantErr  string
+	}{
+		{name: "top level wins", files: []string{"root.servo", "servers/foo/foo.servo"}, expected: "root"},
+		{name: "one level down by default", files: []string{"foo/foo.servo"}, expected: "foo"},
+		{name: "depth 0 is the directory only", files: []string{"foo/foo.servo"}, depth: depth(0), wantErr: "no .servo files"},
+		{name: "two levels needs depth 2", files: []string{"servers/foo/foo.servo"}, wantErr: "1 level(s) below"},
+		{name: "two levels with depth 2", files: []string{"servers/foo/foo.servo"}, depth: depth(2), expected: "foo"},
+		{name: "hidden directories skipped", files: []string{".git/x.servo"}, wantErr: "no .servo files"},
+		{name: "siblings are ambiguous", files: []string{"servers/a/a.servo", "servers/b/b.servo"}, depth: depth(2), wantErr: "[servers/a/a.servo servers/b/b.servo]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			for _, file := range tt.files {
+				path := filepath.Join(tempDir, filepath.FromSlash(file))
+				os.MkdirAll(filepath.Dir(path), 0755)
+				if err := os.WriteFile(path, manifest(strings.TrimSuffix(filepath.Base(file), ".servo")), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", file, err)
+				}
+			}
+
+			parser := NewParser()
+			parser.SearchDepth = tt.depth
+			servoDef, err := parser.ParseFromDirectory(tempDir)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFromDirectory failed: %v", err)
+			}
+			if servoDef.Name != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, servoDef.Name)
+			}
+		})
+	}
+}
+
 func TestParser_AuthenticationConfiguration(t *testing.T) {
 	parser := NewParser()
 
@@ -212,6 +322,70 @@ func TestParser_AuthenticationConfiguration(t *testing.T) {
 	}
 }
 
+func TestParser_FetchURL(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/index.yaml":
+			w.Write([]byte("- name: notes\n"))
+		case "/slow":
+			<-release
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	parser := NewParser()
+	data, err := parser.FetchURL(server.URL + "/index.yaml")
+	if err != nil || string(data) != "- name: notes\n" {
+		t.Errorf("FetchURL() = %q, %v", data, err)
+	}
+	if _, err := parser.FetchURL(server.URL + "/missing"); err == nil || !strings.Contains(err.Error(), "HTTP error 404") {
+		t.Errorf("FetchURL() of a missing path error = %v, want HTTP 404", err)
+	}
+
+	parser.HTTPTimeout = 20 * time.Millisecond
+	if _, err := parser.FetchURL(server.URL + "/slow"); err == nil {
+		t.Error("FetchURL() should give up after HTTPTimeout")
+	}
+}
+
+func TestParser_SetHTTPAuth(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "env-token")
+	dir := t.TempDir()
+	credentialsPath := filepath.Join(dir, "credentials.yaml")
+	os.WriteFile(credentialsPath, []byte(`hosts:
+  catalog.example.com:
+    token: file-token
+  "*.corp.example.com":
+    username: alice
+    password: file-password
+`), 0600)
+
+	authorization := func(parser *Parser, rawURL string) string {
+		req, _ := http.NewRequest(http.MethodGet, rawURL, nil)
+		parser.setHTTPAuth(req)
+		return req.Header.Get("Authorization")
+	}
+
+	parser := NewParser()
+	parser.CredentialsPath = credentialsPath
+	if got := authorization(parser, "https://catalog.example.com/index.yaml"); got != "Bearer file-token" {
+		t.Errorf("credentials file token: Authorization = %q", got)
+	}
+	if got := authorization(parser, "https://git.corp.example.com/index.yaml"); !strings.HasPrefix(got, "Basic ") {
+		t.Errorf("credentials file username/password: Authorization = %q", got)
+	}
+
+	// Git auth options and environment tokens are never sent to other hosts
+	parser.HTTPToken = "flag-token"
+	if got := authorization(parser, "https://other.example.org/index.yaml"); got != "" {
+		t.Errorf("unlisted host: Authorization = %q, want none", got)
+	}
+}
+
 func TestParser_ParseFromURL_InvalidURL(t *testing.T) {
 	parser := NewParser()
 	_, err := parser.ParseFromURL("not-a-valid-url")
@@ -256,3 +430,100 @@ invalid_yaml_syntax: [
 		t.Error("Expected ParseFromFile to fail with invalid YAML")
 	}
 }
+
+func TestParser_Strict(t *testing.T) {
+	manifest := `servo_version: "1.0"
+name: "typo-server"
+serve:
+  transport: "stdio"
+  command: "python"
+descriptoin: "misspelled"
+zzz_custom: true
+`
+	path := filepath.Join(t.TempDir(), "typo.servo")
+	if err := os.WriteFile(path, []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	parser := NewParser()
+	if _, err := parser.ParseFromFile(path); err != nil {
+		t.Fatalf("Lenient parsing should ignore unknown keys, got: %v", err)
+	}
+
+	parser.Strict = true
+	_, err := parser.ParseFromFile(path)
+	if err == nil {
+		t.Fatal("Expected strict parsing to reject unknown keys")
+	}
+	for _, want := range []string{
+		"unknown top-level key 'serve' (line 3), did you mean 'server'?",
+		"unknown top-level key 'descriptoin' (line 6), did you mean 'description'?",
+		"unknown top-level key 'zzz_custom' (line 7)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got: %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "zzz_custom' (line 7), did you mean") {
+		t.Errorf("Expected no suggestion for an unrelated key, got: %v", err)
+	}
+}
+
+func TestParser_KeyCase(t *testing.T) {
+	manifest := `servo_version: "1.0"
+Name: "cased-server"
+Server:
+  transport: "stdio"
+  command: "python"
+description: "kept"
+Description: "ignored, canonical key present"
+`
+	path := filepath.Join(t.TempDir(), "cased.servo")
+	if err := os.WriteFile(path, []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	parser := NewParser()
+	servo, err := parser.ParseFromFile(path)
+	if err != nil {
+		t.Fatalf("Lenient parsing should accept mis-cased keys, got: %v", err)
+	}
+	if servo.Name != "cased-server" {
+		t.Errorf("Expected Name to be read as name, got %q", servo.Name)
+	}
+	if servo.Server.Command != "python" {
+		t.Errorf("Expected Server to be read as server, got %+v", servo.Server)
+	}
+	if servo.Description != "kept" {
+		t.Errorf("Expected the canonical description to win, got %q", servo.Description)
+	}
+
+	parser.Strict = true
+	_, err = parser.ParseFromFile(path)
+	if err == nil || !strings.Contains(err.Error(), "unknown top-level key 'Name' (line 2), did you mean 'name'?") {
+		t.Errorf("Expected strict parsing to reject mis-cased keys, got: %v", err)
+	}
+}
+
+func TestCanonicalizeKeyCase(t *testing.T) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte("NAME: a\nServo_Version: \"1.0\"\nname_typo: b\n"), &root); err != nil {
+		t.Fatalf("Failed to parse YAML: %v", err)
+	}
+
+	warnings := canonicalizeKeyCase(&root)
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], "top-level key 'NAME' (line 1) is read as 'name'") {
+		t.Errorf("Unexpected warning: %s", warnings[0])
+	}
+	if !strings.Contains(warnings[1], "'Servo_Version' (line 2) is read as 'servo_version'") {
+		t.Errorf("Unexpected warning: %s", warnings[1])
+	}
+
+	var empty yaml.Node
+	if warnings := canonicalizeKeyCase(&empty); warnings != nil {
+		t.Errorf("Expected no warnings for an empty document, got %v", warnings)
+	}
+}
@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// GitHubShorthandPrefix marks a github:org/repo[/subdir][@ref] source
+const GitHubShorthandPrefix = "github:"
+
+// githubPathSegment matches one owner, repository or directory name
+var githubPathSegment = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// GitHubSource is a GitHub shorthand source expanded into what a clone needs
+type GitHubSource struct {
+	RepoURL string // Full clone URL, e.g. https://github.com/org/repo.git
+	Subdir  string // Directory within the repository holding the manifest, if any
+	Ref     string // Branch or tag to clone instead of the default branch, if any
+}
+
+// ParseGitHubShorthand expands a github:org/repo[/subdir][@ref] source. It reports
+// false for any other source, including bare org/repo.
+func ParseGitHubShorthand(source string) (GitHubSource, bool) {
+	path, found := strings.CutPrefix(source, GitHubShorthandPrefix)
+	if !found {
+		return GitHubSource{}, false
+	}
+	return parseGitHubPath(path)
+}
+
+// IsBareGitHubShorthand reports whether source has the org/repo[/subdir][@ref] form
+// without the github: prefix. Such a source may equally be a relative local path, so
+// callers should prefer an existing local path.
+func IsBareGitHubShorthand(source string) bool {
+	if strings.HasPrefix(source, ".") || strings.HasSuffix(source, ".servo") || strings.Contains(source, ":") {
+		return false
+	}
+	_, ok := parseGitHubPath(source)
+	return ok
+}
+
+// parseGitHubPath splits org/repo[/subdir][@ref] into its clone URL, subdirectory and ref
+func parseGitHubPath(path string) (GitHubSource, bool) {
+	var ref string
+	if i := strings.LastIndex(path, "@"); i >= 0 {
+		path, ref = path[:i], path[i+1:]
+		if ref == "" {
+			return GitHubSource{}, false
+		}
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 {
+		return GitHubSource{}, false
+	}
+	for _, part := range parts {
+		if part == "." || part == ".." || !githubPathSegment.MatchString(part) {
+			return GitHubSource{}, false
+		}
+	}
+
+	return GitHubSource{
+		RepoURL: fmt.Sprintf("https://github.com/%s/%s.git", parts[0], strings.TrimSuffix(parts[1], ".git")),
+		Subdir:  strings.Join(parts[2:], "/"),
+		Ref:     ref,
+	}, true
+}
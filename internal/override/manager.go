@@ -5,14 +5,20 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 
+	"github.com/servo/servo/internal/logging"
 	"gopkg.in/yaml.v3"
 )
 
-// Manager handles configuration overrides with precedence: session > project > defaults
+// environmentNameRegex restricts environment names to ones safe to embed in a file name
+var environmentNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Manager handles configuration overrides with precedence: session > env > project > defaults
 type Manager struct {
-	sessionDir string
-	projectDir string
+	sessionDir  string
+	projectDir  string
+	environment string
 }
 
 // NewManager creates a new override manager
@@ -23,6 +29,20 @@ func NewManager(sessionDir, projectDir string) *Manager {
 	}
 }
 
+// SetEnvironment selects the environment whose docker-compose.<env>.yml override
+// is layered between the project and session overrides. Empty disables the layer.
+func (m *Manager) SetEnvironment(env string) {
+	m.environment = env
+}
+
+// ValidateEnvironment checks that an environment name can select an override file
+func ValidateEnvironment(env string) error {
+	if !environmentNameRegex.MatchString(env) {
+		return fmt.Errorf("invalid environment name '%s': use letters, digits, '.', '_' or '-'", env)
+	}
+	return nil
+}
+
 // DockerComposeOverride represents docker-compose configuration overrides
 type DockerComposeOverride struct {
 	Version  string                     `yaml:"version,omitempty"`
@@ -57,65 +77,120 @@ type DevcontainerOverride struct {
 	RemoteUser        string                 `json:"remoteUser,omitempty"`
 	WorkspaceFolder   string                 `json:"workspaceFolder,omitempty"`
 	Mounts            []string               `json:"mounts,omitempty"`
+	ContainerEnv      map[string]string      `json:"containerEnv,omitempty"`
 	Extra             map[string]interface{} `json:"-"` // Handle with custom marshal/unmarshal
 }
 
-// GetDockerComposeOverrides retrieves docker-compose overrides with precedence
-func (m *Manager) GetDockerComposeOverrides() (*DockerComposeOverride, error) {
-	// Load in precedence order: defaults < project < session
-	merged := &DockerComposeOverride{
-		Services: make(map[string]ServiceOverride),
-		Networks: make(map[string]interface{}),
-		Volumes:  make(map[string]interface{}),
-		Secrets:  make(map[string]interface{}),
-	}
+// Override layers, in the order they are merged. Later layers take precedence.
+const (
+	LayerProject     = "project"
+	LayerEnvironment = "env"
+	LayerSession     = "session"
+)
 
-	// 1. Load default overrides (none currently, but structure is ready)
+// DockerComposeLayer is the docker-compose override loaded from one layer
+type DockerComposeLayer struct {
+	Layer    string
+	Path     string
+	Override *DockerComposeOverride
+}
 
-	// 2. Load project-level overrides
-	if m.projectDir != "" {
-		projectOverrides, err := m.loadDockerComposeOverride(filepath.Join(m.projectDir, ".servo", "config", "docker-compose.yml"))
+// DevcontainerLayer is the devcontainer override loaded from one layer
+type DevcontainerLayer struct {
+	Layer    string
+	Path     string
+	Override *DevcontainerOverride
+}
+
+// DockerComposeLayers loads the docker-compose override of each layer in merge
+// order: project < env < session. Unreadable files are logged and left out.
+func (m *Manager) DockerComposeLayers() ([]DockerComposeLayer, error) {
+	var layers []DockerComposeLayer
+	load := func(layer, overridePath string) {
+		loaded, err := m.loadDockerComposeOverride(overridePath)
+		logOverride(layer, overridePath, err)
 		if err == nil {
-			merged = m.mergeDockerComposeOverrides(merged, projectOverrides)
+			layers = append(layers, DockerComposeLayer{Layer: layer, Path: overridePath, Override: loaded})
 		}
 	}
 
-	// 3. Load session-level overrides (highest precedence)
+	// Project-level overrides
+	if m.projectDir != "" {
+		load(LayerProject, filepath.Join(m.projectDir, ".servo", "config", "docker-compose.yml"))
+	}
+
+	// Environment-specific project overrides
+	if m.projectDir != "" && m.environment != "" {
+		if err := ValidateEnvironment(m.environment); err != nil {
+			return nil, err
+		}
+		load(LayerEnvironment, filepath.Join(m.projectDir, ".servo", "config", "docker-compose."+m.environment+".yml"))
+	}
+
+	// Session-level overrides (highest precedence)
 	if m.sessionDir != "" {
-		sessionOverrides, err := m.loadDockerComposeOverride(filepath.Join(m.sessionDir, "config", "docker-compose.yml"))
+		load(LayerSession, filepath.Join(m.sessionDir, "config", "docker-compose.yml"))
+	}
+
+	return layers, nil
+}
+
+// DevcontainerLayers loads the devcontainer override of each layer in merge
+// order: project < session. Unreadable files are logged and left out.
+func (m *Manager) DevcontainerLayers() ([]DevcontainerLayer, error) {
+	var layers []DevcontainerLayer
+	load := func(layer, overridePath string) {
+		loaded, err := m.loadDevcontainerOverride(overridePath)
+		logOverride(layer, overridePath, err)
 		if err == nil {
-			merged = m.mergeDockerComposeOverrides(merged, sessionOverrides)
+			layers = append(layers, DevcontainerLayer{Layer: layer, Path: overridePath, Override: loaded})
 		}
 	}
 
+	if m.projectDir != "" {
+		load(LayerProject, filepath.Join(m.projectDir, ".servo", "config", "devcontainer.json"))
+	}
+	if m.sessionDir != "" {
+		load(LayerSession, filepath.Join(m.sessionDir, "config", "devcontainer.json"))
+	}
+
+	return layers, nil
+}
+
+// GetDockerComposeOverrides retrieves docker-compose overrides with precedence
+func (m *Manager) GetDockerComposeOverrides() (*DockerComposeOverride, error) {
+	layers, err := m.DockerComposeLayers()
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &DockerComposeOverride{
+		Services: make(map[string]ServiceOverride),
+		Networks: make(map[string]interface{}),
+		Volumes:  make(map[string]interface{}),
+		Secrets:  make(map[string]interface{}),
+	}
+	for _, layer := range layers {
+		merged = m.mergeDockerComposeOverrides(merged, layer.Override)
+	}
+
 	return merged, nil
 }
 
 // GetDevcontainerOverrides retrieves devcontainer overrides with precedence
 func (m *Manager) GetDevcontainerOverrides() (*DevcontainerOverride, error) {
-	// Load in precedence order: defaults < project < session
+	layers, err := m.DevcontainerLayers()
+	if err != nil {
+		return nil, err
+	}
+
 	merged := &DevcontainerOverride{
 		Features:       make(map[string]interface{}),
 		Customizations: make(map[string]interface{}),
 		Extra:          make(map[string]interface{}),
 	}
-
-	// 1. Load default overrides (none currently)
-
-	// 2. Load project-level overrides
-	if m.projectDir != "" {
-		projectOverrides, err := m.loadDevcontainerOverride(filepath.Join(m.projectDir, ".servo", "config", "devcontainer.json"))
-		if err == nil {
-			merged = m.mergeDevcontainerOverrides(merged, projectOverrides)
-		}
-	}
-
-	// 3. Load session-level overrides (highest precedence)
-	if m.sessionDir != "" {
-		sessionOverrides, err := m.loadDevcontainerOverride(filepath.Join(m.sessionDir, "config", "devcontainer.json"))
-		if err == nil {
-			merged = m.mergeDevcontainerOverrides(merged, sessionOverrides)
-		}
+	for _, layer := range layers {
+		merged = m.mergeDevcontainerOverrides(merged, layer.Override)
 	}
 
 	return merged, nil
@@ -195,6 +270,25 @@ func (m *Manager) SaveDevcontainerOverride(level string, override *DevcontainerO
 	return os.WriteFile(filePath, data, 0644)
 }
 
+// logOverride records whether an override layer was applied. Layers are logged in
+// the order they are merged, so later entries take precedence.
+func logOverride(layer, filePath string, err error) {
+	switch {
+	case err != nil:
+		logging.Info("skipping unreadable override", "layer", layer, "file", filePath, "error", err)
+	case exists(filePath):
+		logging.Info("applied override", "layer", layer, "file", filePath)
+	default:
+		logging.Debug("no override", "layer", layer, "file", filePath)
+	}
+}
+
+// exists reports whether a file is present
+func exists(filePath string) bool {
+	_, err := os.Stat(filePath)
+	return err == nil
+}
+
 // loadDockerComposeOverride loads docker-compose override from file
 func (m *Manager) loadDockerComposeOverride(filePath string) (*DockerComposeOverride, error) {
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
@@ -412,6 +506,20 @@ func (m *Manager) mergeDevcontainerOverrides(base, override *DevcontainerOverrid
 	result.ForwardPorts = append(result.ForwardPorts, override.ForwardPorts...)
 	result.Mounts = append(result.Mounts, override.Mounts...)
 
+	// Merge container environment
+	for key, value := range base.ContainerEnv {
+		if result.ContainerEnv == nil {
+			result.ContainerEnv = make(map[string]string)
+		}
+		result.ContainerEnv[key] = value
+	}
+	for key, value := range override.ContainerEnv {
+		if result.ContainerEnv == nil {
+			result.ContainerEnv = make(map[string]string)
+		}
+		result.ContainerEnv[key] = value // Override takes precedence
+	}
+
 	// Merge features
 	for key, value := range base.Features {
 		result.Features[key] = value
@@ -0,0 +1,119 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// legacyClientNames maps client names older projects may still carry to the
+// name the client registry uses today
+var legacyClientNames = map[string]string{
+	"claude":      "claude-code",
+	"claude_code": "claude-code",
+	"claudecode":  "claude-code",
+	"vs-code":     "vscode",
+	"vs_code":     "vscode",
+}
+
+// ClientRename records one legacy client name replaced by its canonical name
+type ClientRename struct {
+	From string
+	To   string
+}
+
+var (
+	legacyWarningsMu sync.Mutex
+	legacyWarned     = make(map[string]bool)
+)
+
+// CanonicalClientName returns the current registry name for a client, mapping
+// deprecated names to their replacements
+func CanonicalClientName(name string) string {
+	if canonical, ok := legacyClientNames[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+// normalizeClientNames rewrites legacy client names in the project and its
+// servers in place, dropping duplicates the rewrite creates. It returns each
+// distinct rename applied.
+func normalizeClientNames(project *Project) []ClientRename {
+	var renames []ClientRename
+	recorded := make(map[string]bool)
+
+	normalize := func(names []string) []string {
+		if names == nil {
+			return nil
+		}
+		seen := make(map[string]bool, len(names))
+		result := make([]string, 0, len(names))
+		for _, name := range names {
+			canonical := CanonicalClientName(name)
+			if canonical != name && !recorded[name] {
+				recorded[name] = true
+				renames = append(renames, ClientRename{From: name, To: canonical})
+			}
+			if !seen[canonical] {
+				seen[canonical] = true
+				result = append(result, canonical)
+			}
+		}
+		return result
+	}
+
+	project.Clients = normalize(project.Clients)
+	project.DefaultInstallClients = normalize(project.DefaultInstallClients)
+	for i := range project.MCPServers {
+		project.MCPServers[i].Clients = normalize(project.MCPServers[i].Clients)
+	}
+
+	return renames
+}
+
+// warnLegacyClientNames prints a warning the first time each legacy name is seen
+func warnLegacyClientNames(renames []ClientRename) {
+	legacyWarningsMu.Lock()
+	defer legacyWarningsMu.Unlock()
+
+	for _, rename := range renames {
+		if legacyWarned[rename.From] {
+			continue
+		}
+		legacyWarned[rename.From] = true
+		fmt.Fprintf(os.Stderr, "⚠️  project.yaml uses deprecated client name '%s'; treating it as '%s'. Run 'servo client rename-legacy' to update the file.\n", rename.From, rename.To)
+	}
+}
+
+// RenameLegacyClients rewrites deprecated client names in project.yaml to their
+// canonical names and returns the renames applied. The file is left untouched
+// when nothing needs renaming.
+func (m *Manager) RenameLegacyClients() ([]ClientRename, error) {
+	if !m.IsProject() {
+		return nil, fmt.Errorf("not in a servo project directory")
+	}
+
+	data, err := os.ReadFile(filepath.Join(m.GetServoDir(), "project.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project file: %w", err)
+	}
+
+	var project Project
+	if err := yaml.Unmarshal(data, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse project file: %w", err)
+	}
+
+	renames := normalizeClientNames(&project)
+	if len(renames) == 0 {
+		return nil, nil
+	}
+
+	if err := m.Save(&project); err != nil {
+		return nil, err
+	}
+	return renames, nil
+}
@@ -0,0 +1,94 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalClientName(t *testing.T) {
+	tests := map[string]string{
+		"claude":      "claude-code",
+		"claude_code": "claude-code",
+		"vs-code":     "vscode",
+		"claude-code": "claude-code",
+		"cursor":      "cursor",
+		"unknown":     "unknown",
+	}
+	for name, want := range tests {
+		if got := CanonicalClientName(name); got != want {
+			t.Errorf("CanonicalClientName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestLegacyClientNames(t *testing.T) {
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current working directory: %v", err)
+	}
+	defer os.Chdir(oldWd)
+
+	tmpDir := t.TempDir()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+	if err := os.MkdirAll(".servo", 0755); err != nil {
+		t.Fatalf("Failed to create .servo directory: %v", err)
+	}
+
+	projectYAML := `default_session: default
+clients: [claude, claude-code, vscode]
+mcp_servers:
+  - name: web
+    source: ./web.servo
+    clients: [vs_code]
+`
+	projectFile := filepath.Join(".servo", "project.yaml")
+	if err := os.WriteFile(projectFile, []byte(projectYAML), 0644); err != nil {
+		t.Fatalf("Failed to write project.yaml: %v", err)
+	}
+
+	manager := NewManager()
+
+	project, err := manager.Get()
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if want := []string{"claude-code", "vscode"}; !reflect.DeepEqual(project.Clients, want) {
+		t.Errorf("Clients = %v, want %v", project.Clients, want)
+	}
+	if want := []string{"vscode"}; !reflect.DeepEqual(project.MCPServers[0].Clients, want) {
+		t.Errorf("server clients = %v, want %v", project.MCPServers[0].Clients, want)
+	}
+
+	// Loading normalizes in memory only
+	data, _ := os.ReadFile(projectFile)
+	if !strings.Contains(string(data), "claude,") {
+		t.Error("Get() should not rewrite project.yaml")
+	}
+
+	renames, err := manager.RenameLegacyClients()
+	if err != nil {
+		t.Fatalf("RenameLegacyClients() error = %v", err)
+	}
+	want := []ClientRename{{From: "claude", To: "claude-code"}, {From: "vs_code", To: "vscode"}}
+	if !reflect.DeepEqual(renames, want) {
+		t.Errorf("renames = %v, want %v", renames, want)
+	}
+
+	data, _ = os.ReadFile(projectFile)
+	if strings.Contains(string(data), "vs_code") || strings.Contains(string(data), "- claude\n") {
+		t.Errorf("project.yaml still has legacy names:\n%s", data)
+	}
+
+	renames, err = manager.RenameLegacyClients()
+	if err != nil {
+		t.Fatalf("RenameLegacyClients() second run error = %v", err)
+	}
+	if len(renames) != 0 {
+		t.Errorf("Expected no renames on second run, got %v", renames)
+	}
+}
@@ -0,0 +1,164 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/servo/servo/internal/utils"
+)
+
+const (
+	// gitignoreBegin and gitignoreEnd delimit the part of .servo/.gitignore servo
+	// owns; lines below the block belong to the user and are kept on rewrite
+	gitignoreBegin = "# BEGIN servo managed - regenerate with 'servo project fix-gitignore'"
+	gitignoreEnd   = "# END servo managed"
+)
+
+// gitignoreManaged is the recommended content of the managed block: secrets,
+// per-developer session pointers, persisted service data and logs, and leftovers
+// of interrupted operations
+var gitignoreManaged = []string{
+	"# Secrets",
+	"secrets.yaml",
+	"",
+	"# Active session pointers are per developer",
+	"active_session",
+	"active_session.d/",
+	"",
+	"# Persisted service data and logs",
+	"services/",
+	"logs/",
+	"volumes/",
+	"*.log",
+	"",
+	"# Session-specific files",
+	"sessions/*/config/",
+	"sessions/*/logs/",
+	"",
+	"# Staging directories of interrupted session renames",
+	".rename-*/",
+	"",
+	"# OS files",
+	".DS_Store",
+	"Thumbs.db",
+}
+
+// legacyGitignore is the .gitignore servo init wrote before the managed block
+// existed; its lines are dropped rather than kept as user lines on rewrite
+var legacyGitignore = []string{
+	"# Servo project files",
+	"secrets.yaml",
+	"*.log",
+	"# Legacy structure (for backwards compatibility)",
+	"volumes/",
+	"# Session-specific files",
+	"sessions/*/config/",
+	"sessions/*/logs/",
+	"# OS files",
+	".DS_Store",
+	"Thumbs.db",
+}
+
+// RenderGitignore returns the recommended .servo/.gitignore for a project whose
+// current file holds existing: the managed block followed by the user's own lines.
+// User lines are those below the managed block, or for a file without one, every
+// line not written by servo init or already in the managed block.
+func RenderGitignore(existing string) string {
+	var b strings.Builder
+	b.WriteString(gitignoreBegin + "\n")
+	for _, line := range gitignoreManaged {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(gitignoreEnd + "\n")
+
+	if user := userGitignoreLines(existing); len(user) > 0 {
+		b.WriteString("\n")
+		b.WriteString(strings.Join(user, "\n") + "\n")
+	}
+	return b.String()
+}
+
+// userGitignoreLines returns the lines of existing that servo does not manage,
+// without leading or trailing blank lines
+func userGitignoreLines(existing string) []string {
+	lines := strings.Split(strings.ReplaceAll(existing, "\r\n", "\n"), "\n")
+
+	var user []string
+	if begin, end := markerIndex(lines, gitignoreBegin), markerIndex(lines, gitignoreEnd); begin >= 0 && end > begin {
+		user = append(append(user, lines[:begin]...), lines[end+1:]...)
+	} else {
+		known := make(map[string]bool, len(legacyGitignore)+len(gitignoreManaged))
+		for _, line := range append(append([]string{}, legacyGitignore...), gitignoreManaged...) {
+			if line != "" {
+				known[line] = true
+			}
+		}
+		for _, line := range lines {
+			if !known[strings.TrimSpace(line)] {
+				user = append(user, line)
+			}
+		}
+	}
+
+	for len(user) > 0 && strings.TrimSpace(user[0]) == "" {
+		user = user[1:]
+	}
+	for len(user) > 0 && strings.TrimSpace(user[len(user)-1]) == "" {
+		user = user[:len(user)-1]
+	}
+	return user
+}
+
+// markerIndex returns the index of the line equal to marker, or -1
+func markerIndex(lines []string, marker string) int {
+	for i, line := range lines {
+		if strings.TrimSpace(line) == marker {
+			return i
+		}
+	}
+	return -1
+}
+
+// gitignorePath returns the path of the project's .servo/.gitignore
+func (m *Manager) gitignorePath() string {
+	return filepath.Join(m.GetServoDir(), ".gitignore")
+}
+
+// readGitignore returns the current .servo/.gitignore, or "" when there is none
+func (m *Manager) readGitignore() (string, error) {
+	data, err := os.ReadFile(m.gitignorePath())
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read .gitignore: %w", err)
+	}
+	return string(data), nil
+}
+
+// GitignoreIsCurrent reports whether .servo/.gitignore already matches what
+// FixGitignore would write
+func (m *Manager) GitignoreIsCurrent() (bool, error) {
+	existing, err := m.readGitignore()
+	if err != nil {
+		return false, err
+	}
+	return existing == RenderGitignore(existing), nil
+}
+
+// FixGitignore rewrites .servo/.gitignore to the recommended managed block, keeping
+// the user's own lines below it. It reports whether the file changed.
+func (m *Manager) FixGitignore() (bool, error) {
+	existing, err := m.readGitignore()
+	if err != nil {
+		return false, err
+	}
+
+	content := RenderGitignore(existing)
+	if content == existing {
+		return false, nil
+	}
+	if err := utils.WriteFileWithDir(m.gitignorePath(), []byte(content), 0644); err != nil {
+		return false, fmt.Errorf("failed to write .gitignore: %w", err)
+	}
+	return true, nil
+}
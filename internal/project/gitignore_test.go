@@ -0,0 +1,77 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderGitignore(t *testing.T) {
+	fresh := RenderGitignore("")
+	if !strings.HasPrefix(fresh, gitignoreBegin+"\n") || !strings.HasSuffix(fresh, gitignoreEnd+"\n") {
+		t.Errorf("Expected only the managed block for a new file, got:\n%s", fresh)
+	}
+	for _, entry := range []string{"secrets.yaml", "active_session", "services/", "logs/", "volumes/"} {
+		if !strings.Contains(fresh, "\n"+entry+"\n") {
+			t.Errorf("Expected managed block to ignore %s", entry)
+		}
+	}
+	if RenderGitignore(fresh) != fresh {
+		t.Error("Expected rendering to be stable for a current file")
+	}
+
+	legacy := "# Servo project files\nsecrets.yaml\n*.log\n\n# Legacy structure (for backwards compatibility)\nvolumes/\n\n# Session-specific files\nsessions/*/config/\nsessions/*/logs/\n\n# OS files\n.DS_Store\nThumbs.db\n\n# Mine\nscratch/\n"
+	got := RenderGitignore(legacy)
+	if want := fresh + "\n# Mine\nscratch/\n"; got != want {
+		t.Errorf("Expected legacy entries dropped and user lines kept below the block, got:\n%s", got)
+	}
+
+	// Lines around an outdated managed block are all kept, below the new block
+	outdated := "top.txt\n" + gitignoreBegin + "\nsecrets.yaml\n" + gitignoreEnd + "\n\nbottom.txt\n"
+	got = RenderGitignore(outdated)
+	if want := fresh + "\ntop.txt\n\nbottom.txt\n"; got != want {
+		t.Errorf("Expected user lines preserved around the managed block, got:\n%s", got)
+	}
+}
+
+func TestManager_FixGitignore(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	manager := NewManager()
+	if _, err := manager.Init("default", nil); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if current, err := manager.GitignoreIsCurrent(); err != nil || !current {
+		t.Errorf("Expected a new project's .gitignore to be current, got %v, %v", current, err)
+	}
+
+	path := filepath.Join(".servo", ".gitignore")
+	os.WriteFile(path, []byte("secrets.yaml\nmy-notes.md\n"), 0644)
+	if current, _ := manager.GitignoreIsCurrent(); current {
+		t.Error("Expected a stale .gitignore to be reported")
+	}
+
+	changed, err := manager.FixGitignore()
+	if err != nil || !changed {
+		t.Fatalf("FixGitignore() = %v, %v; want a change", changed, err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasSuffix(string(data), gitignoreEnd+"\n\nmy-notes.md\n") {
+		t.Errorf("Expected the user line below the managed block, got:\n%s", data)
+	}
+
+	if changed, err := manager.FixGitignore(); err != nil || changed {
+		t.Errorf("FixGitignore() on a current file = %v, %v; want no change", changed, err)
+	}
+
+	// Re-initializing keeps the user's lines
+	if _, err := manager.InitWithOptions("default", nil, InitOptions{Force: true}); err != nil {
+		t.Fatalf("InitWithOptions(Force) error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "my-notes.md") {
+		t.Errorf("Expected user lines to survive re-initialization, got:\n%s", data)
+	}
+}
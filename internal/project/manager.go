@@ -1,10 +1,12 @@
 package project
 
 import (
-	"encoding/base64"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/servo/servo/internal/utils"
 	"gopkg.in/yaml.v3"
@@ -20,8 +22,10 @@ type RequiredSecret struct {
 type MCPServer struct {
 	Name     string   `yaml:"name" json:"name"`
 	Source   string   `yaml:"source" json:"source"`
+	Path     string   `yaml:"path,omitempty" json:"path,omitempty"` // Manifest file within Source, when not discovered
 	Clients  []string `yaml:"clients,omitempty" json:"clients,omitempty"`
 	Sessions []string `yaml:"sessions,omitempty" json:"sessions,omitempty"` // Sessions where this server is installed
+	Dev      bool     `yaml:"dev,omitempty" json:"dev,omitempty"`           // Source is a local checkout bind-mounted into the workspace
 }
 
 // ServerAlreadyExistsError indicates a server already exists and no update was requested
@@ -36,11 +40,146 @@ func (e *ServerAlreadyExistsError) Error() string {
 
 // Project represents a servo project configuration
 type Project struct {
-	Clients         []string         `yaml:"clients,omitempty" json:"clients,omitempty"`
-	DefaultSession  string           `yaml:"default_session" json:"default_session"`                   // Default session name
-	ActiveSession   string           `yaml:"active_session,omitempty" json:"active_session,omitempty"` // Currently active session
-	MCPServers      []MCPServer      `yaml:"mcp_servers,omitempty" json:"mcp_servers,omitempty"`
-	RequiredSecrets []RequiredSecret `yaml:"required_secrets,omitempty" json:"required_secrets,omitempty"`
+	Clients               []string         `yaml:"clients,omitempty" json:"clients,omitempty"`
+	DefaultInstallClients []string         `yaml:"default_install_clients,omitempty" json:"default_install_clients,omitempty"` // Install targets without --clients; a subset of Clients, every enabled client when empty
+	DefaultSession        string           `yaml:"default_session" json:"default_session"`                                     // Default session name
+	ActiveSession         string           `yaml:"active_session,omitempty" json:"active_session,omitempty"`                   // Currently active session
+	MCPServers            []MCPServer      `yaml:"mcp_servers,omitempty" json:"mcp_servers,omitempty"`
+	RequiredSecrets       []RequiredSecret `yaml:"required_secrets,omitempty" json:"required_secrets,omitempty"`
+	Config                ProjectConfig    `yaml:"config,omitempty" json:"config,omitempty"`
+	MinServoVersion       string           `yaml:"min_servo_version,omitempty" json:"min_servo_version,omitempty"` // Oldest servo release allowed to operate on the project
+}
+
+// ProjectConfig holds project-wide generation settings
+type ProjectConfig struct {
+	Profiles             []string `yaml:"profiles,omitempty" json:"profiles,omitempty"`                             // Compose profiles to start; sessions may override
+	VolumeRoot           string   `yaml:"volume_root,omitempty" json:"volume_root,omitempty"`                       // Host directory for service volumes, relative to the project root or absolute
+	NoDevcontainer       bool     `yaml:"no_devcontainer,omitempty" json:"no_devcontainer,omitempty"`               // Generate client MCP configs only, without .devcontainer output
+	Bundle               bool     `yaml:"bundle,omitempty" json:"bundle,omitempty"`                                 // Also write a client-neutral servers.json with every active-session server
+	InstallUpdateDefault bool     `yaml:"install_update_default,omitempty" json:"install_update_default,omitempty"` // Install updates an existing server unless --no-update is given
+	PostCreateCommand    string   `yaml:"post_create_command,omitempty" json:"post_create_command,omitempty"`       // Devcontainer postCreateCommand, run after servo's own setup
+	PostStartCommand     string   `yaml:"post_start_command,omitempty" json:"post_start_command,omitempty"`         // Appended to servo's generated devcontainer postStartCommand
+	DevcontainerName     string   `yaml:"devcontainer_name,omitempty" json:"devcontainer_name,omitempty"`           // Devcontainer name shown by editors and docker ps
+	ClientEnvFiles       bool     `yaml:"client_env_files,omitempty" json:"client_env_files,omitempty"`             // Clients that support it load secret env vars from a generated env file
+	RemoteUser           string   `yaml:"remote_user,omitempty" json:"remote_user,omitempty"`                       // Devcontainer remoteUser; root when empty
+	HostUserIDs          bool     `yaml:"host_user_ids,omitempty" json:"host_user_ids,omitempty"`                   // Run the remote user and volume-backed services with the host UID/GID
+	ServicePrefix        string   `yaml:"service_prefix,omitempty" json:"service_prefix,omitempty"`                 // Compose service name template: {manifest}-{service} when empty, none for bare names
+	IncludeGlobalServers bool     `yaml:"include_global_servers,omitempty" json:"include_global_servers,omitempty"` // Client configs also list servers installed with --global, under project servers of the same name
+	WorkspaceMount       string   `yaml:"workspace_mount,omitempty" json:"workspace_mount,omitempty"`               // Workspace bind mount as source:target[:mode]; ../..:/workspaces:cached when empty
+	DevcontainerMode     string   `yaml:"devcontainer_mode,omitempty" json:"devcontainer_mode,omitempty"`           // compose, single, or auto (the default) to pick from the session's services
+}
+
+// ValidateDefaultInstallClients checks that every default install client is also
+// enabled in clients
+func (p *Project) ValidateDefaultInstallClients() error {
+	enabled := make(map[string]bool, len(p.Clients))
+	for _, client := range p.Clients {
+		enabled[client] = true
+	}
+	for _, client := range p.DefaultInstallClients {
+		if !enabled[client] {
+			return fmt.Errorf("default_install_clients lists '%s', which is not an enabled client; run 'servo client enable %s' first", client, client)
+		}
+	}
+	return nil
+}
+
+// ValidateDevcontainerName checks config.devcontainer_name, which must be a
+// non-empty single line when set
+func (c ProjectConfig) ValidateDevcontainerName() error {
+	name := c.DevcontainerName
+	if name == "" {
+		return nil
+	}
+	if strings.TrimSpace(name) == "" || strings.ContainsAny(name, "\r\n") {
+		return fmt.Errorf("config.devcontainer_name must be a non-empty single line, got %q", name)
+	}
+	return nil
+}
+
+// Values of config.devcontainer_mode. Auto, the default, uses single-container mode
+// when no manifest in the session declares services.
+const (
+	DevcontainerModeAuto    = "auto"
+	DevcontainerModeCompose = "compose"
+	DevcontainerModeSingle  = "single"
+)
+
+// ValidateDevcontainerMode checks config.devcontainer_mode: empty, auto, compose or single
+func (c ProjectConfig) ValidateDevcontainerMode() error {
+	switch c.DevcontainerMode {
+	case "", DevcontainerModeAuto, DevcontainerModeCompose, DevcontainerModeSingle:
+		return nil
+	}
+	return fmt.Errorf("config.devcontainer_mode must be one of auto, compose or single, got %q", c.DevcontainerMode)
+}
+
+// ServicePrefixNone as config.service_prefix names compose services after the
+// manifest service alone
+const ServicePrefixNone = "none"
+
+// servicePrefixLiteral matches the text a service_prefix template may hold around its placeholders
+var servicePrefixLiteral = regexp.MustCompile(`^[a-zA-Z0-9._-]*$`)
+
+// ValidateServicePrefix checks config.service_prefix: empty, "none", or a template
+// holding {service} once and optionally {manifest}, with only letters, digits, '.',
+// '_' and '-' around them
+func (c ProjectConfig) ValidateServicePrefix() error {
+	prefix := strings.TrimSpace(c.ServicePrefix)
+	if prefix == "" || prefix == ServicePrefixNone {
+		return nil
+	}
+	if strings.Count(prefix, "{service}") != 1 {
+		return fmt.Errorf("config.service_prefix must contain {service} exactly once, got %q", prefix)
+	}
+	literal := strings.NewReplacer("{service}", "", "{manifest}", "").Replace(prefix)
+	if !servicePrefixLiteral.MatchString(literal) {
+		return fmt.Errorf("config.service_prefix may only add letters, digits, '.', '_' and '-' around {manifest} and {service}, got %q", prefix)
+	}
+	return nil
+}
+
+// WorkspaceDataMount is where the workspace container mounts the workspace-data volume
+const WorkspaceDataMount = "/workspace/.servo"
+
+// workspaceMountModes are the bind mount options config.workspace_mount may end with
+var workspaceMountModes = map[string]bool{"cached": true, "delegated": true, "consistent": true, "ro": true, "rw": true}
+
+// ParseWorkspaceMount splits a config.workspace_mount value of the form
+// source:target[:mode]. The source is a host path, resolved against .devcontainer/
+// when relative; the target must be an absolute, clean container path other than /.
+func ParseWorkspaceMount(mount string) (source, target, mode string, err error) {
+	parts := strings.Split(strings.TrimSpace(mount), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return "", "", "", fmt.Errorf("config.workspace_mount must have the form source:target[:mode], got %q", mount)
+	}
+	source, target = parts[0], parts[1]
+	if len(parts) == 3 {
+		mode = parts[2]
+	}
+
+	if strings.TrimSpace(source) == "" || strings.ContainsAny(mount, "\r\n") {
+		return "", "", "", fmt.Errorf("config.workspace_mount source must be a non-empty single-line path, got %q", mount)
+	}
+	if !strings.HasPrefix(target, "/") || target == "/" || path.Clean(target) != target {
+		return "", "", "", fmt.Errorf("config.workspace_mount target must be an absolute container path such as /workspaces, got %q", mount)
+	}
+	if target == WorkspaceDataMount {
+		return "", "", "", fmt.Errorf("config.workspace_mount target %s is where servo mounts the workspace-data volume", target)
+	}
+	if len(parts) == 3 && !workspaceMountModes[mode] {
+		return "", "", "", fmt.Errorf("config.workspace_mount mode must be one of cached, delegated, consistent, ro or rw, got %q", mode)
+	}
+	return source, target, mode, nil
+}
+
+// ValidateWorkspaceMount checks config.workspace_mount when it is set
+func (c ProjectConfig) ValidateWorkspaceMount() error {
+	if c.WorkspaceMount == "" {
+		return nil
+	}
+	_, _, _, err := ParseWorkspaceMount(c.WorkspaceMount)
+	return err
 }
 
 // Manager handles project operations in the current directory
@@ -79,24 +218,8 @@ func (m *Manager) createProjectDirectories() error {
 		return fmt.Errorf("failed to create project directories: %w", err)
 	}
 
-	// Create .gitignore file
-	gitignorePath := filepath.Join(servoDir, ".gitignore")
-	gitignoreContent := `# Servo project files
-secrets.yaml
-*.log
-
-# Legacy structure (for backwards compatibility)
-volumes/
-
-# Session-specific files
-sessions/*/config/
-sessions/*/logs/
-
-# OS files
-.DS_Store
-Thumbs.db
-`
-	if err := utils.WriteFileWithDir(gitignorePath, []byte(gitignoreContent), 0644); err != nil {
+	// Create .gitignore file, keeping user lines when re-initializing
+	if _, err := m.FixGitignore(); err != nil {
 		return fmt.Errorf("failed to create .gitignore: %w", err)
 	}
 
@@ -119,10 +242,43 @@ func (m *Manager) IsProject() bool {
 	return m.projectFileExists()
 }
 
+// InitOptions controls how Init treats an existing project
+type InitOptions struct {
+	// Force re-creates the project structure, project.yaml and .gitignore in an
+	// existing project while leaving sessions and manifests in place
+	Force bool
+	// Reset removes the entire .servo directory before initializing; it requires
+	// Force, so an existing project is never deleted by Reset alone
+	Reset bool
+	// ServoVersion is stamped into min_servo_version when it is a release version
+	ServoVersion string
+}
+
 // Init initializes a new servo project in the current directory
 func (m *Manager) Init(sessionName string, clients []string) (*Project, error) {
+	return m.InitWithOptions(sessionName, clients, InitOptions{})
+}
+
+// InitWithOptions initializes a servo project, optionally reinitializing an existing one
+func (m *Manager) InitWithOptions(sessionName string, clients []string, opts InitOptions) (*Project, error) {
+	var existing *Project
 	if m.IsProject() {
-		return nil, fmt.Errorf("servo project already exists in current directory")
+		switch {
+		case opts.Reset && !opts.Force:
+			return nil, fmt.Errorf("servo project already exists in current directory; --reset requires --force")
+		case opts.Reset:
+			if err := os.RemoveAll(m.GetServoDir()); err != nil {
+				return nil, fmt.Errorf("failed to reset project directory: %w", err)
+			}
+		case opts.Force:
+			// A corrupt project.yaml is exactly what --force is meant to repair,
+			// so fall back to a fresh configuration when it can't be parsed
+			if project, err := m.Get(); err == nil {
+				existing = project
+			}
+		default:
+			return nil, fmt.Errorf("servo project already exists in current directory")
+		}
 	}
 
 	// Use "default" as session name if none provided
@@ -135,13 +291,22 @@ func (m *Manager) Init(sessionName string, clients []string) (*Project, error) {
 		return nil, fmt.Errorf("failed to create project directories: %w", err)
 	}
 
-	// Create project configuration
-	project := &Project{
-		Clients:        clients,
-		DefaultSession: sessionName,
-		ActiveSession:  sessionName,
+	// Create project configuration. Reinitializing starts from the existing one, so
+	// servers, secrets and settings init does not own stay as they are.
+	project := &Project{ActiveSession: sessionName}
+	if existing != nil {
+		project = existing
+		if project.ActiveSession == "" {
+			project.ActiveSession = sessionName
+		}
+	}
+	project.DefaultSession = sessionName
+	if existing == nil || len(clients) > 0 {
+		project.Clients = clients
 	}
 
+	stampServoVersion(project, opts.ServoVersion)
+
 	if err := m.saveProject(project); err != nil {
 		return nil, fmt.Errorf("failed to save project configuration: %w", err)
 	}
@@ -168,6 +333,9 @@ func (m *Manager) Get() (*Project, error) {
 		return nil, fmt.Errorf("failed to parse project file: %w", err)
 	}
 
+	// Clients renamed upstream would otherwise be silently skipped
+	warnLegacyClientNames(normalizeClientNames(&project))
+
 	return &project, nil
 }
 
@@ -176,6 +344,88 @@ func (m *Manager) Save(project *Project) error {
 	return m.saveProject(project)
 }
 
+// GetProjectFile returns the path of the project configuration file
+func (m *Manager) GetProjectFile() string {
+	return filepath.Join(m.GetServoDir(), "project.yaml")
+}
+
+// SaveRaw validates raw project.yaml content and writes it unchanged, so keys
+// servo does not know about and comments are preserved
+func (m *Manager) SaveRaw(data []byte) error {
+	if _, err := ParseProject(data); err != nil {
+		return err
+	}
+
+	projectFile := m.GetProjectFile()
+	tmpFile := projectFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write project file: %w", err)
+	}
+	if err := os.Rename(tmpFile, projectFile); err != nil {
+		os.Remove(tmpFile)
+		return fmt.Errorf("failed to replace project file: %w", err)
+	}
+	return nil
+}
+
+// ParseProject parses project.yaml content and checks the structure servo relies on
+func ParseProject(data []byte) (*Project, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("project configuration must be a YAML mapping")
+	}
+
+	var project Project
+	if err := root.Decode(&project); err != nil {
+		return nil, fmt.Errorf("invalid project configuration: %w", err)
+	}
+
+	if project.DefaultSession == "" {
+		return nil, fmt.Errorf("default_session is required")
+	}
+
+	seen := make(map[string]bool)
+	for i, server := range project.MCPServers {
+		if server.Name == "" {
+			return nil, fmt.Errorf("mcp_servers[%d].name is required", i)
+		}
+		if server.Source == "" {
+			return nil, fmt.Errorf("mcp_servers[%d].source is required for %s", i, server.Name)
+		}
+		if seen[server.Name] {
+			return nil, fmt.Errorf("mcp_servers has duplicate server %s", server.Name)
+		}
+		seen[server.Name] = true
+	}
+
+	for i, secret := range project.RequiredSecrets {
+		if secret.Name == "" {
+			return nil, fmt.Errorf("required_secrets[%d].name is required", i)
+		}
+	}
+
+	if err := project.ValidateDefaultInstallClients(); err != nil {
+		return nil, err
+	}
+	if err := project.Config.ValidateDevcontainerName(); err != nil {
+		return nil, err
+	}
+	if err := project.Config.ValidateServicePrefix(); err != nil {
+		return nil, err
+	}
+	if err := project.Config.ValidateWorkspaceMount(); err != nil {
+		return nil, err
+	}
+	if err := project.Config.ValidateDevcontainerMode(); err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
 // Delete removes the servo project from current directory
 func (m *Manager) Delete() error {
 	if !m.IsProject() {
@@ -230,6 +480,41 @@ func (m *Manager) RemoveClient(clientName string) error {
 	}
 
 	project.Clients = newClients
+	// A disabled client can no longer be an install default
+	var defaults []string
+	for _, client := range project.DefaultInstallClients {
+		if client != clientName {
+			defaults = append(defaults, client)
+		}
+	}
+	project.DefaultInstallClients = defaults
+	return m.Save(project)
+}
+
+// SetDefaultInstallClients records the clients install targets without --clients.
+// Legacy names are mapped and duplicates dropped; every client must be enabled. An
+// empty list clears the setting, so install targets every enabled client again.
+func (m *Manager) SetDefaultInstallClients(clients []string) error {
+	project, err := m.Get()
+	if err != nil {
+		return err
+	}
+
+	var defaults []string
+	seen := make(map[string]bool)
+	for _, client := range clients {
+		client = CanonicalClientName(strings.TrimSpace(client))
+		if client == "" || seen[client] {
+			continue
+		}
+		seen[client] = true
+		defaults = append(defaults, client)
+	}
+
+	project.DefaultInstallClients = defaults
+	if err := project.ValidateDefaultInstallClients(); err != nil {
+		return err
+	}
 	return m.Save(project)
 }
 
@@ -264,6 +549,33 @@ func (m *Manager) GetProjectPath() (string, error) {
 	return os.Getwd()
 }
 
+// FindProjectRoot walks up from startDir to the nearest directory containing
+// .servo/project.yaml and returns it along with startDir's slash-separated path
+// relative to that root ("" when startDir is the root itself)
+func FindProjectRoot(startDir string) (root string, relPath string, err error) {
+	absStart, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve directory: %w", err)
+	}
+
+	for dir := absStart; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, ".servo", "project.yaml")); err == nil {
+			rel, err := filepath.Rel(dir, absStart)
+			if err != nil {
+				return "", "", fmt.Errorf("failed to resolve relative path: %w", err)
+			}
+			if rel == "." {
+				rel = ""
+			}
+			return dir, filepath.ToSlash(rel), nil
+		}
+
+		if filepath.Dir(dir) == dir {
+			return "", "", fmt.Errorf("not in a servo project directory")
+		}
+	}
+}
+
 // GetServoDir returns the .servo directory path for the current project
 func (m *Manager) GetServoDir() string {
 	return ".servo"
@@ -319,26 +631,21 @@ func (m *Manager) RemoveRequiredSecret(name string) error {
 	return fmt.Errorf("required secret '%s' not found", name)
 }
 
-// GetMissingSecrets checks which required secrets are not set and returns them
-func (m *Manager) GetMissingSecrets() ([]RequiredSecret, error) {
+// GetMissingSecrets checks which required secrets are not set for a session and
+// returns them. Secrets resolve through the session's namespace first, then the
+// project-global secrets; an empty sessionName checks project-global secrets only.
+func (m *Manager) GetMissingSecrets(sessionName string) ([]RequiredSecret, error) {
 	project, err := m.Get()
 	if err != nil {
 		return nil, err
 	}
 
-	// Get configured secrets by trying to decrypt the secrets file
-	configuredSecrets, err := m.getConfiguredSecrets()
+	configuredSet, err := m.resolveSecretNames(sessionName)
 	if err != nil {
-		// If we can't read secrets (file missing, wrong password, etc.), assume all are missing
+		// If we can't read secrets, assume all are missing
 		return project.RequiredSecrets, nil
 	}
 
-	// Build set of configured secret names
-	configuredSet := make(map[string]bool)
-	for secretName := range configuredSecrets {
-		configuredSet[secretName] = true
-	}
-
 	// Return only missing secrets
 	var missing []RequiredSecret
 	for _, required := range project.RequiredSecrets {
@@ -350,13 +657,15 @@ func (m *Manager) GetMissingSecrets() ([]RequiredSecret, error) {
 	return missing, nil
 }
 
-// getConfiguredSecrets returns a map of configured secret names to their values
-func (m *Manager) getConfiguredSecrets() (map[string]string, error) {
+// resolveSecretNames returns the names of the secrets visible to a session: the
+// project-global secrets and the session's namespace. Names are stored in the clear,
+// so an encrypted store needs no key.
+func (m *Manager) resolveSecretNames(sessionName string) (map[string]bool, error) {
 	secretsPath := filepath.Join(m.GetServoDir(), "secrets.yaml")
 
 	// Check if secrets file exists
 	if _, err := os.Stat(secretsPath); os.IsNotExist(err) {
-		return make(map[string]string), nil // No secrets file = no configured secrets
+		return make(map[string]bool), nil // No secrets file = no configured secrets
 	}
 
 	// Read and parse the secrets file
@@ -365,43 +674,27 @@ func (m *Manager) getConfiguredSecrets() (map[string]string, error) {
 		return nil, fmt.Errorf("failed to read secrets file: %w", err)
 	}
 
-	var fileData struct {
-		Version string            `yaml:"version"`
-		Secrets map[string]string `yaml:"secrets"`
-	}
-
+	var fileData SecretsFile
 	if err := yaml.Unmarshal(data, &fileData); err != nil {
 		return nil, fmt.Errorf("failed to parse secrets file: %w", err)
 	}
 
-	// Decode base64 secrets
-	decodedSecrets := make(map[string]string)
-	for key, encodedValue := range fileData.Secrets {
-		decodedValue, err := base64.StdEncoding.DecodeString(encodedValue)
-		if err != nil {
-			// If decoding fails, assume it's plain text (for migration)
-			decodedSecrets[key] = encodedValue
-		} else {
-			decodedSecrets[key] = string(decodedValue)
+	names := make(map[string]bool)
+	for name := range fileData.Secrets {
+		names[name] = true
+	}
+	if sessionName != "" {
+		for name := range fileData.Namespaces[sessionName] {
+			names[name] = true
 		}
 	}
-
-	return decodedSecrets, nil
+	return names, nil
 }
 
-// GetConfiguredSecrets returns a map of configured secret names (for external use)
-func (m *Manager) GetConfiguredSecrets() (map[string]bool, error) {
-	secrets, err := m.getConfiguredSecrets()
-	if err != nil {
-		return nil, err
-	}
-
-	result := make(map[string]bool)
-	for name := range secrets {
-		result[name] = true
-	}
-
-	return result, nil
+// GetConfiguredSecrets returns the names of the secrets configured for a session,
+// resolved like GetMissingSecrets (for external use)
+func (m *Manager) GetConfiguredSecrets(sessionName string) (map[string]bool, error) {
+	return m.resolveSecretNames(sessionName)
 }
 
 // AddMCPServer adds an MCP server to the project, session-aware
@@ -461,6 +754,48 @@ func (m *Manager) AddMCPServerToSession(serverName string, source string, client
 	return m.saveProject(project)
 }
 
+// SetMCPServerPath records the manifest file path used to install an MCP server,
+// clearing it when path is empty
+func (m *Manager) SetMCPServerPath(serverName, path string) error {
+	project, err := m.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	for i, server := range project.MCPServers {
+		if server.Name == serverName {
+			if server.Path == path {
+				return nil
+			}
+			project.MCPServers[i].Path = path
+			return m.saveProject(project)
+		}
+	}
+
+	return fmt.Errorf("MCP server %s not found", serverName)
+}
+
+// SetMCPServerDev records whether a server's source is a local checkout that the
+// generated workspace bind-mounts instead of a cloned or downloaded manifest
+func (m *Manager) SetMCPServerDev(serverName string, dev bool) error {
+	project, err := m.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	for i, server := range project.MCPServers {
+		if server.Name == serverName {
+			if server.Dev == dev {
+				return nil
+			}
+			project.MCPServers[i].Dev = dev
+			return m.saveProject(project)
+		}
+	}
+
+	return fmt.Errorf("MCP server %s not found", serverName)
+}
+
 // RemoveMCPServer removes an MCP server from the project
 func (m *Manager) RemoveMCPServer(serverName string) error {
 	project, err := m.Get()
@@ -478,3 +813,36 @@ func (m *Manager) RemoveMCPServer(serverName string) error {
 
 	return fmt.Errorf("MCP server %s not found", serverName)
 }
+
+// RemoveMCPServerFromSession removes a session from an MCP server's session list,
+// dropping the server from the project once no sessions use it
+func (m *Manager) RemoveMCPServerFromSession(serverName, sessionName string) error {
+	project, err := m.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	for i, server := range project.MCPServers {
+		if server.Name != serverName {
+			continue
+		}
+
+		for j, existingSession := range server.Sessions {
+			if existingSession != sessionName {
+				continue
+			}
+
+			remaining := append(server.Sessions[:j:j], server.Sessions[j+1:]...)
+			if len(remaining) == 0 {
+				project.MCPServers = append(project.MCPServers[:i], project.MCPServers[i+1:]...)
+			} else {
+				project.MCPServers[i].Sessions = remaining
+			}
+			return m.saveProject(project)
+		}
+
+		return fmt.Errorf("MCP server %s is not installed in session %s", serverName, sessionName)
+	}
+
+	return fmt.Errorf("MCP server %s not found", serverName)
+}
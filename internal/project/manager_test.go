@@ -3,6 +3,8 @@ package project
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -442,6 +444,55 @@ func TestRemoveClient(t *testing.T) {
 	}
 }
 
+func TestSetDefaultInstallClients(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	manager := NewManager()
+	if _, err := manager.Init("default", []string{"vscode", "cursor"}); err != nil {
+		t.Fatalf("Failed to initialize project: %v", err)
+	}
+
+	defaults := func() string {
+		project, err := manager.Get()
+		if err != nil {
+			t.Fatalf("Failed to get project: %v", err)
+		}
+		return strings.Join(project.DefaultInstallClients, ",")
+	}
+
+	// Legacy names are mapped and duplicates dropped
+	if err := manager.SetDefaultInstallClients([]string{"vs-code", "vscode", "cursor"}); err != nil {
+		t.Fatalf("SetDefaultInstallClients() error = %v", err)
+	}
+	if got := defaults(); got != "vscode,cursor" {
+		t.Errorf("default install clients = %s, want vscode,cursor", got)
+	}
+
+	if err := manager.SetDefaultInstallClients([]string{"claude-code"}); err == nil || !strings.Contains(err.Error(), "not an enabled client") {
+		t.Errorf("Expected a client that is not enabled to be rejected, got %v", err)
+	}
+	if got := defaults(); got != "vscode,cursor" {
+		t.Errorf("a rejected update changed the defaults to %s", got)
+	}
+
+	// Disabling a client also drops it from the defaults
+	if err := manager.RemoveClient("vscode"); err != nil {
+		t.Fatalf("RemoveClient() error = %v", err)
+	}
+	if got := defaults(); got != "cursor" {
+		t.Errorf("default install clients after disable = %s, want cursor", got)
+	}
+
+	if err := manager.SetDefaultInstallClients(nil); err != nil {
+		t.Fatalf("SetDefaultInstallClients(nil) error = %v", err)
+	}
+	if got := defaults(); got != "" {
+		t.Errorf("default install clients after clear = %s, want none", got)
+	}
+}
+
 func TestSessionMethods(t *testing.T) {
 	// Setup temporary directory with a project
 	tmpDir, err := os.MkdirTemp("", "servo-session-test")
@@ -669,7 +720,7 @@ func TestRequiredSecrets(t *testing.T) {
 	}
 
 	// Test GetMissingSecrets
-	missingSecrets, err := manager.GetMissingSecrets()
+	missingSecrets, err := manager.GetMissingSecrets("")
 	if err != nil {
 		t.Fatalf("GetMissingSecrets() returned error: %v", err)
 	}
@@ -679,3 +730,272 @@ func TestRequiredSecrets(t *testing.T) {
 		t.Errorf("Expected 1 missing secret, got %d", len(missingSecrets))
 	}
 }
+
+func TestInitWithOptions_Force(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current working directory: %v", err)
+	}
+	defer os.Chdir(oldWd)
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	manager := NewManager()
+	if _, err := manager.Init("default", []string{"vscode"}); err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	if err := manager.AddRequiredSecret("api_key", "API key"); err != nil {
+		t.Fatalf("AddRequiredSecret() returned error: %v", err)
+	}
+
+	manifestPath := filepath.Join(".servo", "sessions", "default", "manifests", "server.servo")
+	if err := os.WriteFile(manifestPath, []byte("name: server\n"), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	// Without force the existing project is protected
+	if _, err := manager.Init("default", nil); err == nil {
+		t.Fatal("Expected error when initializing an existing project without force")
+	}
+
+	// Simulate damage that --force should repair
+	if err := os.Remove(filepath.Join(".servo", ".gitignore")); err != nil {
+		t.Fatalf("Failed to remove .gitignore: %v", err)
+	}
+	if err := os.RemoveAll(filepath.Join(".servo", "config")); err != nil {
+		t.Fatalf("Failed to remove config dir: %v", err)
+	}
+
+	project, err := manager.InitWithOptions("default", nil, InitOptions{Force: true})
+	if err != nil {
+		t.Fatalf("InitWithOptions(Force) returned error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(".servo", ".gitignore")); err != nil {
+		t.Errorf("Expected .gitignore to be recreated: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(".servo", "config")); err != nil {
+		t.Errorf("Expected config directory to be recreated: %v", err)
+	}
+	if _, err := os.Stat(manifestPath); err != nil {
+		t.Errorf("Expected manifest to survive reinitialization: %v", err)
+	}
+	if len(project.Clients) != 1 || project.Clients[0] != "vscode" {
+		t.Errorf("Expected existing clients to be kept, got %v", project.Clients)
+	}
+	if len(project.RequiredSecrets) != 1 {
+		t.Errorf("Expected required secrets to be kept, got %v", project.RequiredSecrets)
+	}
+}
+
+func TestInitWithOptions_ForceKeepsSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	manager := NewManager()
+	if _, err := manager.Init("default", []string{"vscode", "cursor"}); err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	existing, _ := manager.Get()
+	existing.Config = ProjectConfig{VolumeRoot: "data", WorkspaceMount: "..:/src", NoDevcontainer: true, ClientEnvFiles: true}
+	existing.DefaultInstallClients = []string{"cursor"}
+	existing.ActiveSession = "feature"
+	if err := manager.Save(existing); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	if _, err := manager.InitWithOptions("default", nil, InitOptions{Force: true}); err != nil {
+		t.Fatalf("InitWithOptions(Force) returned error: %v", err)
+	}
+
+	project, err := manager.Get()
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(project.Config, existing.Config) {
+		t.Errorf("Expected config to survive --force, got %+v", project.Config)
+	}
+	if !reflect.DeepEqual(project.DefaultInstallClients, []string{"cursor"}) || project.ActiveSession != "feature" {
+		t.Errorf("Expected default_install_clients and active_session to be kept, got %v and %q", project.DefaultInstallClients, project.ActiveSession)
+	}
+}
+
+func TestInitWithOptions_Reset(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current working directory: %v", err)
+	}
+	defer os.Chdir(oldWd)
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	manager := NewManager()
+	if _, err := manager.Init("default", []string{"vscode"}); err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+
+	manifestPath := filepath.Join(".servo", "sessions", "default", "manifests", "server.servo")
+	if err := os.WriteFile(manifestPath, []byte("name: server\n"), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	// Reset alone must not delete the existing project
+	if _, err := manager.InitWithOptions("default", nil, InitOptions{Reset: true}); err == nil {
+		t.Fatal("Expected --reset without --force to fail")
+	}
+	if _, err := os.Stat(manifestPath); err != nil {
+		t.Fatalf("Expected manifest to survive --reset without --force: %v", err)
+	}
+
+	project, err := manager.InitWithOptions("default", nil, InitOptions{Reset: true, Force: true})
+	if err != nil {
+		t.Fatalf("InitWithOptions(Reset) returned error: %v", err)
+	}
+
+	if _, err := os.Stat(manifestPath); !os.IsNotExist(err) {
+		t.Errorf("Expected manifest to be removed by reset, stat error: %v", err)
+	}
+	if len(project.Clients) != 0 {
+		t.Errorf("Expected clients to be cleared by reset, got %v", project.Clients)
+	}
+}
+
+func TestFindProjectRoot(t *testing.T) {
+	tmpDir := t.TempDir()
+	root, err := filepath.EvalSymlinks(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to resolve temp dir: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, ".servo"), 0755); err != nil {
+		t.Fatalf("Failed to create .servo directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".servo", "project.yaml"), []byte("name: test\n"), 0644); err != nil {
+		t.Fatalf("Failed to create project.yaml: %v", err)
+	}
+
+	nested := filepath.Join(root, "apps", "frontend", "src")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("Failed to create nested directory: %v", err)
+	}
+
+	foundRoot, relPath, err := FindProjectRoot(nested)
+	if err != nil {
+		t.Fatalf("FindProjectRoot() error = %v", err)
+	}
+	if foundRoot != root {
+		t.Errorf("Expected root %s, got %s", root, foundRoot)
+	}
+	if relPath != "apps/frontend/src" {
+		t.Errorf("Expected relative path 'apps/frontend/src', got '%s'", relPath)
+	}
+
+	_, relPath, err = FindProjectRoot(root)
+	if err != nil || relPath != "" {
+		t.Errorf("Expected empty relative path at root, got '%s' (err %v)", relPath, err)
+	}
+
+	outside := t.TempDir()
+	if _, _, err := FindProjectRoot(outside); err == nil {
+		t.Error("Expected error outside of a project")
+	}
+}
+
+func TestParseProject(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "valid with unknown keys", content: "default_session: dev\nextra:\n  key: value\n"},
+		{name: "invalid yaml", content: "default_session: [dev\n", wantErr: "invalid YAML"},
+		{name: "empty document", content: "", wantErr: "mapping"},
+		{name: "not a mapping", content: "- dev\n", wantErr: "mapping"},
+		{name: "wrong type", content: "default_session: dev\nclients: vscode\n", wantErr: "invalid project configuration"},
+		{name: "missing default session", content: "clients: [vscode]\n", wantErr: "default_session"},
+		{name: "server without source", content: "default_session: dev\nmcp_servers:\n  - name: api\n", wantErr: "source"},
+		{name: "duplicate server", content: "default_session: dev\nmcp_servers:\n  - name: api\n    source: a.servo\n  - name: api\n    source: b.servo\n", wantErr: "duplicate"},
+		{name: "secret without name", content: "default_session: dev\nrequired_secrets:\n  - description: key\n", wantErr: "required_secrets"},
+		{name: "devcontainer name", content: "default_session: dev\nconfig:\n  devcontainer_name: Acme API\n"},
+		{name: "blank devcontainer name", content: "default_session: dev\nconfig:\n  devcontainer_name: \"  \"\n", wantErr: "devcontainer_name"},
+		{name: "multi-line devcontainer name", content: "default_session: dev\nconfig:\n  devcontainer_name: |\n    Acme\n    API\n", wantErr: "devcontainer_name"},
+		{name: "bare service names", content: "default_session: dev\nconfig:\n  service_prefix: none\n"},
+		{name: "service name template", content: "default_session: dev\nconfig:\n  service_prefix: mcp_{manifest}.{service}\n"},
+		{name: "service prefix without service", content: "default_session: dev\nconfig:\n  service_prefix: \"{manifest}\"\n", wantErr: "service_prefix must contain {service}"},
+		{name: "service prefix with spaces", content: "default_session: dev\nconfig:\n  service_prefix: my {service}\n", wantErr: "service_prefix may only add"},
+		{name: "workspace mount", content: "default_session: dev\nconfig:\n  workspace_mount: ../../..:/workspaces/app:cached\n"},
+		{name: "workspace mount without mode", content: "default_session: dev\nconfig:\n  workspace_mount: /home/me/repo:/src\n"},
+		{name: "workspace mount without target", content: "default_session: dev\nconfig:\n  workspace_mount: ../..\n", wantErr: "source:target[:mode]"},
+		{name: "workspace mount relative target", content: "default_session: dev\nconfig:\n  workspace_mount: ../..:workspaces\n", wantErr: "absolute container path"},
+		{name: "workspace mount unclean target", content: "default_session: dev\nconfig:\n  workspace_mount: ../..:/workspaces/\n", wantErr: "absolute container path"},
+		{name: "workspace mount unknown mode", content: "default_session: dev\nconfig:\n  workspace_mount: ../..:/workspaces:fast\n", wantErr: "mode must be one of"},
+		{name: "workspace mount on data volume", content: "default_session: dev\nconfig:\n  workspace_mount: ../..:/workspace/.servo\n", wantErr: "workspace-data volume"},
+		{name: "single devcontainer mode", content: "default_session: dev\nconfig:\n  devcontainer_mode: single\n"},
+		{name: "unknown devcontainer mode", content: "default_session: dev\nconfig:\n  devcontainer_mode: podman\n", wantErr: "devcontainer_mode must be one of"},
+		{name: "default install clients", content: "default_session: dev\nclients: [vscode, cursor]\ndefault_install_clients: [cursor]\n"},
+		{name: "default install client not enabled", content: "default_session: dev\nclients: [vscode]\ndefault_install_clients: [cursor]\n", wantErr: "'cursor', which is not an enabled client"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseProject([]byte(tt.content))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ParseProject() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseProject() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRemoveMCPServerFromSession(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	manager := NewManager()
+	if _, err := manager.Init("dev", []string{"vscode"}); err != nil {
+		t.Fatalf("Failed to initialize project: %v", err)
+	}
+	for _, session := range []string{"dev", "prod"} {
+		if err := manager.AddMCPServerToSession("api", "api.servo", nil, session, false); err != nil {
+			t.Fatalf("Failed to add server: %v", err)
+		}
+	}
+
+	if err := manager.RemoveMCPServerFromSession("api", "staging"); err == nil {
+		t.Error("Expected error for a session without the server")
+	}
+	if err := manager.RemoveMCPServerFromSession("other", "dev"); err == nil {
+		t.Error("Expected error for an unknown server")
+	}
+
+	if err := manager.RemoveMCPServerFromSession("api", "dev"); err != nil {
+		t.Fatalf("RemoveMCPServerFromSession() error = %v", err)
+	}
+	project, _ := manager.Get()
+	if len(project.MCPServers) != 1 || len(project.MCPServers[0].Sessions) != 1 || project.MCPServers[0].Sessions[0] != "prod" {
+		t.Errorf("Expected api to remain in prod only, got %+v", project.MCPServers)
+	}
+
+	// Removing the last session drops the server
+	if err := manager.RemoveMCPServerFromSession("api", "prod"); err != nil {
+		t.Fatalf("RemoveMCPServerFromSession() error = %v", err)
+	}
+	project, _ = manager.Get()
+	if len(project.MCPServers) != 0 {
+		t.Errorf("Expected server to be removed, got %+v", project.MCPServers)
+	}
+}
@@ -0,0 +1,174 @@
+package project
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/servo/servo/internal/utils"
+)
+
+// SecretsKeyEnvVar supplies the key of an encrypted secrets store; without it the
+// key is prompted for
+const SecretsKeyEnvVar = "SERVO_SECRETS_KEY"
+
+// SecretsEncryption is the encryption field of an encrypted secrets store. A store
+// without the field holds base64-encoded values and needs no key.
+const SecretsEncryption = "aes-256-gcm"
+
+// secretsKeyIterations is the PBKDF2-SHA256 work factor that derives the AES key
+const secretsKeyIterations = 200000
+
+// secretsCheckValue is encrypted into every encrypted store so a wrong key is caught
+// even when the store holds no secrets
+const secretsCheckValue = "servo-secrets"
+
+// ErrWrongSecretsKey is returned when a key does not decrypt the secrets store
+var ErrWrongSecretsKey = errors.New("wrong secrets key")
+
+// SecretsHeader is the part of secrets.yaml that says how its values are stored
+type SecretsHeader struct {
+	Encryption string `yaml:"encryption,omitempty"`
+	Salt       string `yaml:"salt,omitempty"`
+	Check      string `yaml:"check,omitempty"`
+}
+
+// SecretsFile is the on-disk layout of secrets.yaml. Secrets are project-global;
+// Namespaces holds per-session secrets that take precedence for that session. Names
+// are stored in the clear and values through the header's codec.
+type SecretsFile struct {
+	Version       string `yaml:"version"`
+	SecretsHeader `yaml:",inline"`
+	Secrets       map[string]string            `yaml:"secrets"`
+	Namespaces    map[string]map[string]string `yaml:"namespaces,omitempty"`
+}
+
+// SecretsCodec turns stored secret values into plaintext and back
+type SecretsCodec interface {
+	Encode(value string) (string, error)
+	Decode(stored string) (string, error)
+}
+
+// base64Codec stores values base64-encoded, as stores without encryption do
+type base64Codec struct{}
+
+func (base64Codec) Encode(value string) (string, error) {
+	return base64.StdEncoding.EncodeToString([]byte(value)), nil
+}
+
+func (base64Codec) Decode(stored string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(stored)
+	if err != nil {
+		// Values that are not base64 are plain text (for migration)
+		return stored, nil
+	}
+	return string(decoded), nil
+}
+
+// gcmCodec stores values as base64 of nonce followed by AES-256-GCM ciphertext
+type gcmCodec struct {
+	aead cipher.AEAD
+}
+
+func (c *gcmCodec) Encode(value string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(value), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *gcmCodec) Decode(stored string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(stored)
+	if err != nil || len(data) < c.aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted secret")
+	}
+	nonce, sealed := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	plain, err := c.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", ErrWrongSecretsKey
+	}
+	return string(plain), nil
+}
+
+// newGCMCodec derives the AES-256 key for a passphrase and salt
+func newGCMCodec(passphrase string, salt []byte) (*gcmCodec, error) {
+	key := pbkdf2.Key([]byte(passphrase), salt, secretsKeyIterations, 32, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return &gcmCodec{aead: aead}, nil
+}
+
+// Codec returns the codec of a store with this header. An encrypted store asks
+// passphrase for its key, which must decrypt the header's check value.
+func (h SecretsHeader) Codec(passphrase func() (string, error)) (SecretsCodec, error) {
+	switch h.Encryption {
+	case "":
+		return base64Codec{}, nil
+	case SecretsEncryption:
+	default:
+		return nil, fmt.Errorf("unsupported secrets encryption %q (supported: %s)", h.Encryption, SecretsEncryption)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(h.Salt)
+	if err != nil || len(salt) == 0 {
+		return nil, fmt.Errorf("encrypted secrets store has no valid salt")
+	}
+	key, err := passphrase()
+	if err != nil {
+		return nil, err
+	}
+	codec, err := newGCMCodec(key, salt)
+	if err != nil {
+		return nil, err
+	}
+	if check, err := codec.Decode(h.Check); err != nil || check != secretsCheckValue {
+		return nil, ErrWrongSecretsKey
+	}
+	return codec, nil
+}
+
+// NewEncryptedSecrets returns the header and codec of a store encrypted with
+// passphrase under a fresh salt
+func NewEncryptedSecrets(passphrase string) (SecretsHeader, SecretsCodec, error) {
+	if passphrase == "" {
+		return SecretsHeader{}, nil, fmt.Errorf("secrets key cannot be empty")
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return SecretsHeader{}, nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	codec, err := newGCMCodec(passphrase, salt)
+	if err != nil {
+		return SecretsHeader{}, nil, err
+	}
+	check, err := codec.Encode(secretsCheckValue)
+	if err != nil {
+		return SecretsHeader{}, nil, err
+	}
+	header := SecretsHeader{
+		Encryption: SecretsEncryption,
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+		Check:      check,
+	}
+	return header, codec, nil
+}
+
+// PromptSecretsKey returns the secrets key from SERVO_SECRETS_KEY, prompting for it
+// when the variable is unset and prompts are allowed
+func PromptSecretsKey() (string, error) {
+	return utils.PromptForPassword(SecretsKeyEnvVar, "Secrets key: ")
+}
@@ -0,0 +1,48 @@
+package project
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSecretsHeader_Codec(t *testing.T) {
+	header, codec, err := NewEncryptedSecrets("correct horse")
+	if err != nil {
+		t.Fatalf("NewEncryptedSecrets() error = %v", err)
+	}
+	stored, err := codec.Encode("s3cret")
+	if err != nil || strings.Contains(stored, "s3cret") {
+		t.Fatalf("Encode() = %q, %v", stored, err)
+	}
+
+	key := func(value string) func() (string, error) {
+		return func() (string, error) { return value, nil }
+	}
+	unlocked, err := header.Codec(key("correct horse"))
+	if err != nil {
+		t.Fatalf("Codec() with the right key error = %v", err)
+	}
+	if value, err := unlocked.Decode(stored); err != nil || value != "s3cret" {
+		t.Errorf("Decode() = %q, %v, want s3cret", value, err)
+	}
+
+	if _, err := header.Codec(key("wrong")); !errors.Is(err, ErrWrongSecretsKey) {
+		t.Errorf("Codec() with the wrong key error = %v, want ErrWrongSecretsKey", err)
+	}
+	if _, err := (SecretsHeader{Encryption: "rot13"}).Codec(key("x")); err == nil || !strings.Contains(err.Error(), "unsupported secrets encryption") {
+		t.Errorf("Expected an unsupported encryption error, got %v", err)
+	}
+	if _, _, err := NewEncryptedSecrets(""); err == nil {
+		t.Error("Expected an empty key to be rejected")
+	}
+
+	// A store without encryption keeps base64 values and needs no key
+	plain, err := (SecretsHeader{}).Codec(func() (string, error) { return "", errors.New("no prompt expected") })
+	if err != nil {
+		t.Fatalf("Codec() of a base64 store error = %v", err)
+	}
+	if value, _ := plain.Decode("czNjcmV0"); value != "s3cret" {
+		t.Errorf("Decode() = %q, want s3cret", value)
+	}
+}
@@ -0,0 +1,82 @@
+package project
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseVersion parses a release version such as "1.4.2" or "v1.4", ignoring any
+// pre-release or build suffix. Missing minor and patch numbers count as 0.
+func parseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	fields := strings.Split(version, ".")
+	if version == "" || len(fields) > 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+// compareVersions returns -1, 0 or 1 as a is older than, equal to or newer than b
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// CheckServoVersion refuses a servo binary older than the project's min_servo_version.
+// Projects without the field, and development builds whose version is not a release
+// number, are never blocked.
+func CheckServoVersion(project *Project, servoVersion string) error {
+	if project == nil || project.MinServoVersion == "" {
+		return nil
+	}
+
+	minimum, ok := parseVersion(project.MinServoVersion)
+	if !ok {
+		return fmt.Errorf("invalid min_servo_version '%s' in project.yaml", project.MinServoVersion)
+	}
+
+	current, ok := parseVersion(servoVersion)
+	if !ok {
+		return nil
+	}
+
+	if compareVersions(current, minimum) < 0 {
+		return fmt.Errorf("this project requires servo >= %s but this is servo %s; please upgrade servo to >= %s",
+			project.MinServoVersion, servoVersion, project.MinServoVersion)
+	}
+	return nil
+}
+
+// stampServoVersion raises the project's min_servo_version to servoVersion, leaving
+// it alone for development builds or when it already names a newer version
+func stampServoVersion(project *Project, servoVersion string) {
+	current, ok := parseVersion(servoVersion)
+	if !ok {
+		return
+	}
+	if minimum, ok := parseVersion(project.MinServoVersion); ok && compareVersions(minimum, current) >= 0 {
+		return
+	}
+	project.MinServoVersion = strings.TrimPrefix(strings.TrimSpace(servoVersion), "v")
+}
@@ -0,0 +1,74 @@
+package project
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCheckServoVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		minimum string
+		version string
+		wantErr string
+	}{
+		{name: "no minimum", minimum: "", version: "0.1.0"},
+		{name: "equal", minimum: "1.4.0", version: "1.4.0"},
+		{name: "newer binary", minimum: "1.4.0", version: "v1.10.0"},
+		{name: "short minimum", minimum: "1.4", version: "1.4.1"},
+		{name: "pre-release suffix ignored", minimum: "1.4.0", version: "1.4.0-rc1"},
+		{name: "development build", minimum: "1.4.0", version: "dev"},
+		{name: "older binary", minimum: "1.4.0", version: "1.3.9", wantErr: "please upgrade servo to >= 1.4.0"},
+		{name: "invalid minimum", minimum: "latest", version: "1.4.0", wantErr: "invalid min_servo_version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckServoVersion(&Project{MinServoVersion: tt.minimum}, tt.version)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckServoVersion() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckServoVersion() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInitWithOptions_StampsServoVersion(t *testing.T) {
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(t.TempDir())
+
+	manager := NewManager()
+	project, err := manager.InitWithOptions("default", nil, InitOptions{ServoVersion: "v1.5.0"})
+	if err != nil {
+		t.Fatalf("InitWithOptions() error = %v", err)
+	}
+	if project.MinServoVersion != "1.5.0" {
+		t.Errorf("MinServoVersion = %q, want 1.5.0", project.MinServoVersion)
+	}
+
+	// Reinitializing with an older binary keeps the newer minimum
+	project, err = manager.InitWithOptions("default", nil, InitOptions{Force: true, ServoVersion: "1.2.0"})
+	if err != nil {
+		t.Fatalf("InitWithOptions(Force) error = %v", err)
+	}
+	if project.MinServoVersion != "1.5.0" {
+		t.Errorf("MinServoVersion = %q after older reinit, want 1.5.0", project.MinServoVersion)
+	}
+
+	// Development builds do not stamp a version
+	os.RemoveAll(".servo")
+	project, err = manager.InitWithOptions("default", nil, InitOptions{ServoVersion: "dev"})
+	if err != nil {
+		t.Fatalf("InitWithOptions() error = %v", err)
+	}
+	if project.MinServoVersion != "" {
+		t.Errorf("MinServoVersion = %q for a dev build, want none", project.MinServoVersion)
+	}
+}
@@ -0,0 +1,106 @@
+package registry
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/servo/servo/pkg"
+)
+
+func TestDefaultRegistry_CommandRendering(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	manifests := []pkg.ServoDefinition{
+		{Name: "split", Server: pkg.Server{Transport: "stdio", Command: "go run main.go"}},
+		{Name: "argv", Server: pkg.Server{Transport: "stdio", Command: "go", Args: []string{"run", "main.go", "--title", "my server"}}},
+	}
+	want := map[string][]string{
+		"split": {"go", "run", "main.go"},
+		"argv":  {"go", "run", "main.go", "--title", "my server"},
+	}
+
+	// Every built-in client takes an argv array under its own servers key
+	outputs := map[string]struct {
+		path string
+		key  string
+	}{
+		"vscode":      {path: ".vscode/mcp.json", key: "servers"},
+		"claude-code": {path: ".mcp.json", key: "mcpServers"},
+		"cursor":      {path: ".cursor/mcp.json", key: "mcpServers"},
+	}
+
+	noSecrets := func(string) (string, error) { return "", nil }
+	for _, c := range GetDefaultRegistry().List() {
+		output, ok := outputs[c.Name()]
+		if !ok {
+			t.Errorf("No expected output for client %s", c.Name())
+			continue
+		}
+
+		if err := c.GenerateConfig(manifests, noSecrets); err != nil {
+			t.Fatalf("%s: GenerateConfig() error = %v", c.Name(), err)
+		}
+
+		data, err := os.ReadFile(output.path)
+		if err != nil {
+			t.Fatalf("%s: failed to read %s: %v", c.Name(), output.path, err)
+		}
+		var config map[string]map[string]pkg.MCPServerConfig
+		if err := json.Unmarshal(data, &config); err != nil {
+			t.Fatalf("%s: failed to parse %s: %v", c.Name(), output.path, err)
+		}
+
+		for name, argv := range want {
+			server := config[output.key][name]
+			got := append([]string{server.Command}, server.Args...)
+			if !reflect.DeepEqual(got, argv) {
+				t.Errorf("%s: server %s rendered as %q, want %q", c.Name(), name, got, argv)
+			}
+		}
+	}
+}
+
+func TestDefaultRegistry_RenderConfigMatchesGenerated(t *testing.T) {
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(t.TempDir())
+
+	manifests := []pkg.ServoDefinition{
+		{Name: "search", Server: pkg.Server{Transport: "stdio", Command: "search-mcp", Environment: map[string]string{"API_KEY": "${SEARCH_API_KEY}"}}},
+	}
+	paths := map[string]string{
+		"vscode":      ".vscode/mcp.json",
+		"claude-code": ".mcp.json",
+		"cursor":      ".cursor/mcp.json",
+	}
+
+	noSecrets := func(string) (string, error) { return "", nil }
+	for _, c := range GetDefaultRegistry().List() {
+		rendered, err := c.RenderConfig(manifests, noSecrets)
+		if err != nil {
+			t.Fatalf("%s: RenderConfig() error = %v", c.Name(), err)
+		}
+		if _, err := os.Stat(paths[c.Name()]); !os.IsNotExist(err) {
+			t.Errorf("%s: RenderConfig() wrote %s", c.Name(), paths[c.Name()])
+		}
+		if !strings.Contains(string(rendered), "${SEARCH_API_KEY}") {
+			t.Errorf("%s: RenderConfig() expanded the secret placeholder: %s", c.Name(), rendered)
+		}
+
+		if err := c.GenerateConfig(manifests, noSecrets); err != nil {
+			t.Fatalf("%s: GenerateConfig() error = %v", c.Name(), err)
+		}
+		written, err := os.ReadFile(paths[c.Name()])
+		if err != nil {
+			t.Fatalf("%s: failed to read %s: %v", c.Name(), paths[c.Name()], err)
+		}
+		if string(rendered) != string(written) {
+			t.Errorf("%s: RenderConfig() = %s, GenerateConfig() wrote %s", c.Name(), rendered, written)
+		}
+	}
+}
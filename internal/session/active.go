@@ -0,0 +1,52 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoActiveSession matches, through errors.Is, the error returned when a command
+// needs the active session and none is set
+var ErrNoActiveSession = errors.New("no active session")
+
+// NoActiveSessionError reports that no session is active. Available lists the
+// project's sessions so the message can suggest one to activate.
+type NoActiveSessionError struct {
+	Available []string
+}
+
+func (e *NoActiveSessionError) Error() string {
+	msg := "no active session; run `servo session activate <name>`"
+	if len(e.Available) == 0 {
+		return msg + " after creating one with `servo session create <name>`"
+	}
+	return fmt.Sprintf("%s (available: %s)", msg, strings.Join(e.Available, ", "))
+}
+
+// Is makes errors.Is(err, ErrNoActiveSession) hold for a NoActiveSessionError
+func (e *NoActiveSessionError) Is(target error) bool {
+	return target == ErrNoActiveSession
+}
+
+// RequireActive returns the active session, or a NoActiveSessionError listing the
+// existing sessions when none is active
+func (m *Manager) RequireActive() (*Session, error) {
+	active, err := m.GetActive()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active session: %w", err)
+	}
+	if active != nil {
+		return active, nil
+	}
+
+	sessions, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	noActive := &NoActiveSessionError{}
+	for _, session := range sessions {
+		noActive.Available = append(noActive.Available, session.Name)
+	}
+	return nil, noActive
+}
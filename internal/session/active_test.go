@@ -0,0 +1,41 @@
+package session
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestManager_RequireActive(t *testing.T) {
+	manager := NewManager(t.TempDir())
+
+	_, err := manager.RequireActive()
+	if !errors.Is(err, ErrNoActiveSession) {
+		t.Fatalf("Expected ErrNoActiveSession, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "servo session create") {
+		t.Errorf("Expected a hint to create a session when none exist, got %q", err)
+	}
+
+	for _, name := range []string{"prod", "dev"} {
+		if _, err := manager.Create(name, "", ""); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+	}
+	_, err = manager.RequireActive()
+	var noActive *NoActiveSessionError
+	if !errors.As(err, &noActive) || strings.Join(noActive.Available, ",") != "dev,prod" {
+		t.Fatalf("Expected the available sessions dev, prod, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "servo session activate <name>` (available: dev, prod)") {
+		t.Errorf("Unexpected message %q", err)
+	}
+
+	if err := manager.Activate("dev"); err != nil {
+		t.Fatalf("Failed to activate session: %v", err)
+	}
+	active, err := manager.RequireActive()
+	if err != nil || active.Name != "dev" {
+		t.Errorf("RequireActive() = %v, %v, want dev", active, err)
+	}
+}
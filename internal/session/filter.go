@@ -0,0 +1,127 @@
+package session
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SortBy orders the sessions returned by ListSorted
+type SortBy string
+
+const (
+	// SortByName orders sessions alphabetically by name
+	SortByName SortBy = "name"
+	// SortByCreated orders sessions newest first by creation time
+	SortByCreated SortBy = "created"
+	// SortByLastUsed orders sessions most recently used first, counting a session
+	// that was never used from its creation
+	SortByLastUsed SortBy = "last-used"
+)
+
+// SortOrders are the values accepted by session list --sort
+var SortOrders = []string{string(SortByName), string(SortByCreated), string(SortByLastUsed)}
+
+// ParseSortBy parses a --sort value. An empty value sorts by name.
+func ParseSortBy(value string) (SortBy, error) {
+	switch by := SortBy(strings.TrimSpace(value)); by {
+	case "":
+		return SortByName, nil
+	case SortByName, SortByCreated, SortByLastUsed:
+		return by, nil
+	default:
+		return "", fmt.Errorf("unsupported sort '%s' (supported: %s)", value, strings.Join(SortOrders, ", "))
+	}
+}
+
+// SortSessions orders sessions in place. Sessions with equal times are ordered by name,
+// so the result is the same on every run.
+func SortSessions(sessions []*Session, by SortBy) {
+	sort.SliceStable(sessions, func(i, j int) bool {
+		a, b := sessions[i], sessions[j]
+		switch by {
+		case SortByCreated:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		case SortByLastUsed:
+			if last, other := a.lastActivity(), b.lastActivity(); !last.Equal(other) {
+				return last.After(other)
+			}
+		}
+		return a.Name < b.Name
+	})
+}
+
+// ListFilter narrows the sessions shown by session list
+type ListFilter struct {
+	Since      time.Duration // Keep sessions created or used within this window; zero keeps all
+	ActiveOnly bool          // Keep only the active session
+}
+
+// ParseSince parses a --since window. Besides Go duration units it accepts whole
+// or fractional days and weeks, such as "7d", "2w" or "1.5d".
+func ParseSince(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("duration cannot be empty")
+	}
+
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(value, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+
+	var d time.Duration
+	if unit != 0 {
+		n, err := strconv.ParseFloat(value[:len(value)-1], 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return 0, fmt.Errorf("invalid duration '%s' (use e.g. 12h, 7d or 2w)", value)
+		}
+		// Converting a float past the int64 range is undefined, so bound it first
+		if n*float64(unit) >= math.MaxInt64 {
+			return 0, fmt.Errorf("duration '%s' is too long", value)
+		}
+		d = time.Duration(n * float64(unit))
+	} else {
+		var err error
+		d, err = time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration '%s' (use e.g. 12h, 7d or 2w)", value)
+		}
+	}
+
+	if d <= 0 {
+		return 0, fmt.Errorf("duration '%s' must be positive", value)
+	}
+	return d, nil
+}
+
+// Filter returns the sessions matching the filter, keeping their order
+func (f ListFilter) Filter(sessions []*Session, now time.Time) []*Session {
+	var matched []*Session
+	for _, s := range sessions {
+		if f.ActiveOnly && !s.Active {
+			continue
+		}
+		if f.Since > 0 && s.lastActivity().Before(now.Add(-f.Since)) {
+			continue
+		}
+		matched = append(matched, s)
+	}
+	return matched
+}
+
+// lastActivity is when the session was last used, or created if it never was
+func (s *Session) lastActivity() time.Time {
+	if s.LastUsedAt.After(s.CreatedAt) {
+		return s.LastUsedAt
+	}
+	return s.CreatedAt
+}
@@ -0,0 +1,128 @@
+package session
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "12h", want: 12 * time.Hour},
+		{value: "7d", want: 7 * 24 * time.Hour},
+		{value: "2w", want: 14 * 24 * time.Hour},
+		{value: "1.5d", want: 36 * time.Hour},
+		{value: "90m", want: 90 * time.Minute},
+		{value: "", wantErr: true},
+		{value: "soon", wantErr: true},
+		{value: "xd", wantErr: true},
+		{value: "-1d", wantErr: true},
+		{value: "0h", wantErr: true},
+		{value: "NaNd", wantErr: true},
+		{value: "infw", wantErr: true},
+		{value: "1e300d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSince(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSince(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSince(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListFilter_Filter(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	sessions := []*Session{
+		{Name: "old", CreatedAt: now.Add(-30 * 24 * time.Hour)},
+		{Name: "recently-used", CreatedAt: now.Add(-30 * 24 * time.Hour), LastUsedAt: now.Add(-time.Hour)},
+		{Name: "new", CreatedAt: now.Add(-2 * 24 * time.Hour), Active: true},
+	}
+
+	names := func(filtered []*Session) []string {
+		var out []string
+		for _, s := range filtered {
+			out = append(out, s.Name)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		filter ListFilter
+		want   []string
+	}{
+		{name: "no filter", filter: ListFilter{}, want: []string{"old", "recently-used", "new"}},
+		{name: "since", filter: ListFilter{Since: 7 * 24 * time.Hour}, want: []string{"recently-used", "new"}},
+		{name: "active only", filter: ListFilter{ActiveOnly: true}, want: []string{"new"}},
+		{name: "combined", filter: ListFilter{Since: 24 * time.Hour, ActiveOnly: true}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := names(tt.filter.Filter(sessions, now))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Filter() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Filter() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSortSessions(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	sessions := []*Session{
+		{Name: "beta", CreatedAt: now.Add(-30 * 24 * time.Hour), LastUsedAt: now.Add(-time.Hour)},
+		{Name: "gamma", CreatedAt: now.Add(-2 * 24 * time.Hour)},
+		{Name: "alpha", CreatedAt: now.Add(-2 * 24 * time.Hour)},
+		{Name: "delta", CreatedAt: now.Add(-10 * 24 * time.Hour)},
+	}
+
+	tests := []struct {
+		by   SortBy
+		want []string
+	}{
+		{by: SortByName, want: []string{"alpha", "beta", "delta", "gamma"}},
+		// Equal creation times fall back to name order
+		{by: SortByCreated, want: []string{"alpha", "gamma", "delta", "beta"}},
+		{by: SortByLastUsed, want: []string{"beta", "alpha", "gamma", "delta"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.by), func(t *testing.T) {
+			sorted := append([]*Session(nil), sessions...)
+			SortSessions(sorted, tt.by)
+			var got []string
+			for _, s := range sorted {
+				got = append(got, s.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("SortSessions(%s) = %v, want %v", tt.by, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSortBy(t *testing.T) {
+	for value, want := range map[string]SortBy{"": SortByName, "name": SortByName, "created": SortByCreated, "last-used": SortByLastUsed} {
+		if got, err := ParseSortBy(value); err != nil || got != want {
+			t.Errorf("ParseSortBy(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	if _, err := ParseSortBy("size"); err == nil || !strings.Contains(err.Error(), "supported: name, created, last-used") {
+		t.Errorf("ParseSortBy(size) error = %v, want the supported orders", err)
+	}
+}
@@ -1,9 +1,12 @@
 package session
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/servo/servo/internal/utils"
@@ -17,22 +20,57 @@ type Session struct {
 	CreatedAt   time.Time `yaml:"created_at" json:"created_at"`
 	VolumePath  string    `yaml:"volume_path" json:"volume_path"`
 	Active      bool      `yaml:"active" json:"active"`
+	Profiles    []string  `yaml:"profiles,omitempty" json:"profiles,omitempty"` // Overrides the project's active compose profiles
+	LastUsedAt  time.Time `yaml:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	Clients     []string  `yaml:"clients,omitempty" json:"clients,omitempty"`           // MCP clients the session is meant for
+	Tags        []string  `yaml:"tags,omitempty" json:"tags,omitempty"`                 // Free-form labels for grouping sessions
+	AdoptedFrom string    `yaml:"adopted_from,omitempty" json:"adopted_from,omitempty"` // Session whose volumes this session shares
+}
+
+// MarshalJSON omits last_used_at for a session that has never been used, since
+// omitempty does not apply to a zero time.Time
+func (s Session) MarshalJSON() ([]byte, error) {
+	type plain Session
+	out := struct {
+		plain
+		LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	}{plain: plain(s)}
+	if !s.LastUsedAt.IsZero() {
+		out.LastUsedAt = &s.LastUsedAt
+	}
+	return json.Marshal(out)
+}
+
+// CreateOptions holds the settings recorded in a new session's session.yaml
+type CreateOptions struct {
+	Description string
+	VolumePath  string // Defaults to the session's volumes directory when empty
+	Clients     []string
+	Tags        []string
 }
 
 // Manager handles session operations
 type Manager struct {
 	servoDir string
+	scope    string // Directory relative to the project root, used for scoped active sessions
 }
 
-// NewManager creates a new session manager
+// NewManager creates a new session manager scoped to the directory in ScopeEnvVar, if any
 func NewManager(servoDir string) *Manager {
 	return &Manager{
 		servoDir: servoDir,
+		scope:    normalizeScope(os.Getenv(ScopeEnvVar)),
 	}
 }
 
 // Create creates a new named global session
 func (m *Manager) Create(name, description, volumePath string) (*Session, error) {
+	return m.CreateWithOptions(name, CreateOptions{Description: description, VolumePath: volumePath})
+}
+
+// CreateWithOptions creates a new session, persisting its clients and tags alongside
+// the description and volume path
+func (m *Manager) CreateWithOptions(name string, opts CreateOptions) (*Session, error) {
 	if name == "" {
 		return nil, fmt.Errorf("session name cannot be empty")
 	}
@@ -43,16 +81,19 @@ func (m *Manager) Create(name, description, volumePath string) (*Session, error)
 		return nil, fmt.Errorf("session '%s' already exists", name)
 	}
 
+	volumePath := opts.VolumePath
 	if volumePath == "" {
 		volumePath = filepath.Join(m.getSessionDir(name), "volumes")
 	}
 
 	session := &Session{
 		Name:        name,
-		Description: description,
+		Description: opts.Description,
 		CreatedAt:   time.Now(),
 		VolumePath:  volumePath,
 		Active:      false,
+		Clients:     append([]string(nil), opts.Clients...),
+		Tags:        append([]string(nil), opts.Tags...),
 	}
 
 	if err := m.createSessionDirectories(name); err != nil {
@@ -66,7 +107,24 @@ func (m *Manager) Create(name, description, volumePath string) (*Session, error)
 	return session, nil
 }
 
-// List returns all available sessions
+// CreateIfMissing creates the named session unless it already exists, reporting
+// whether it was created. An existing session is left untouched.
+func (m *Manager) CreateIfMissing(name, description string) (bool, error) {
+	exists, err := m.Exists(name)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if session exists: %w", err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	if _, err := m.Create(name, description, ""); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// List returns all available sessions, sorted by name
 func (m *Manager) List() ([]*Session, error) {
 	sessionsDir := filepath.Join(m.servoDir, "sessions")
 	if _, err := os.Stat(sessionsDir); os.IsNotExist(err) {
@@ -94,9 +152,44 @@ func (m *Manager) List() ([]*Session, error) {
 		sessions = append(sessions, session)
 	}
 
+	SortSessions(sessions, SortByName)
+	return sessions, nil
+}
+
+// ListSorted returns all sessions in the given order
+func (m *Manager) ListSorted(by SortBy) ([]*Session, error) {
+	sessions, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	SortSessions(sessions, by)
 	return sessions, nil
 }
 
+// ListNames returns the names of all sessions, sorted, without parsing their
+// session files. A session whose session.yaml is corrupt is still listed.
+func (m *Manager) ListNames() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(m.servoDir, "sessions"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to read sessions directory: %w", err)
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if exists, err := m.Exists(entry.Name()); err == nil && exists {
+			names = append(names, entry.Name())
+		}
+	}
+
+	return names, nil
+}
+
 // Get retrieves a session by name
 func (m *Manager) Get(name string) (*Session, error) {
 	if name == "" {
@@ -135,13 +228,25 @@ func (m *Manager) Delete(name string) error {
 		return fmt.Errorf("session '%s' does not exist", name)
 	}
 
-	activeSession, err := m.GetActive()
-	if err == nil && activeSession != nil && activeSession.Name == name {
+	// Scoped overrides go first so GetActive below reports the project-wide session
+	if err := m.updateScopedReferences(name, ""); err != nil {
+		return fmt.Errorf("failed to clear scoped active sessions: %w", err)
+	}
+
+	activeName, err := m.GetActiveName()
+	if err == nil && activeName == name {
 		if err := m.ClearActive(); err != nil {
 			return fmt.Errorf("failed to clear active session: %w", err)
 		}
 	}
 
+	// Sessions adopting this one's volumes must not keep pointing at it
+	if deleted, err := m.Get(name); err == nil {
+		if err := m.releaseAdopters(deleted); err != nil {
+			return fmt.Errorf("failed to update sessions adopting its volumes: %w", err)
+		}
+	}
+
 	// Remove session directory
 	sessionDir := m.getSessionDir(name)
 	if err := os.RemoveAll(sessionDir); err != nil {
@@ -171,6 +276,7 @@ func (m *Manager) Activate(name string) error {
 
 	// Mark session as active and save
 	session.Active = true
+	session.LastUsedAt = time.Now()
 	if err := m.saveSession(session); err != nil {
 		return fmt.Errorf("failed to save session: %w", err)
 	}
@@ -194,8 +300,57 @@ func (m *Manager) Activate(name string) error {
 	return nil
 }
 
-// GetActive returns the currently active session
+// Touch records that a session was used and points the active session at it
+// without the full sweep Activate does over every session. Only the named
+// session's file and the active pointer are written, so other sessions keep
+// their stale Active flags until the next Activate. When a directory-scoped
+// override is in effect the override is updated instead of the project-wide pointer.
+func (m *Manager) Touch(name string) error {
+	if name == "" {
+		return fmt.Errorf("session name cannot be empty")
+	}
+
+	session, err := m.Get(name)
+	if err != nil {
+		return fmt.Errorf("session '%s' does not exist: %w", name, err)
+	}
+
+	session.LastUsedAt = time.Now()
+	if err := m.saveSession(session); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	activeFile := filepath.Join(m.servoDir, "active_session")
+	if scopedName, scope, err := m.scopedActiveSession(); err != nil {
+		return err
+	} else if scopedName != "" {
+		activeFile = m.scopedActiveFile(scope)
+	}
+
+	if current, err := os.ReadFile(activeFile); err == nil && string(current) == name {
+		return nil
+	}
+	if err := os.WriteFile(activeFile, []byte(name), 0644); err != nil {
+		return fmt.Errorf("failed to write active session: %w", err)
+	}
+	return nil
+}
+
+// GetActive returns the currently active session. A directory-scoped override
+// for the manager's scope takes precedence over the project-wide active session.
 func (m *Manager) GetActive() (*Session, error) {
+	scopedName, _, err := m.scopedActiveSession()
+	if err != nil {
+		return nil, err
+	}
+	if scopedName != "" {
+		if session, err := m.Get(scopedName); err == nil {
+			session.Active = true
+			return session, nil
+		}
+		// Stale overrides fall back to the project-wide active session
+	}
+
 	activeFile := filepath.Join(m.servoDir, "active_session")
 	data, err := os.ReadFile(activeFile)
 	if err != nil {
@@ -213,6 +368,41 @@ func (m *Manager) GetActive() (*Session, error) {
 	return m.Get(sessionName)
 }
 
+// GetActiveName returns the name of the active session without parsing its
+// session file, honoring directory-scoped overrides like GetActive. It returns
+// "" when no session is active or the active pointer names a session that no
+// longer exists.
+func (m *Manager) GetActiveName() (string, error) {
+	scopedName, _, err := m.scopedActiveSession()
+	if err != nil {
+		return "", err
+	}
+	if scopedName != "" {
+		if exists, err := m.Exists(scopedName); err == nil && exists {
+			return scopedName, nil
+		}
+		// Stale overrides fall back to the project-wide active session
+	}
+
+	data, err := os.ReadFile(filepath.Join(m.servoDir, "active_session"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read active session: %w", err)
+	}
+
+	name := strings.TrimSpace(string(data))
+	exists, err := m.Exists(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to check active session: %w", err)
+	}
+	if !exists {
+		return "", nil
+	}
+	return name, nil
+}
+
 // ClearActive clears the active session
 func (m *Manager) ClearActive() error {
 	activeFile := filepath.Join(m.servoDir, "active_session")
@@ -256,6 +446,50 @@ func (m *Manager) Exists(name string) (bool, error) {
 	return true, nil
 }
 
+// ExistsAll checks several sessions at once, returning whether each name exists.
+// Each distinct session is stat'ed once; empty names map to false, as with Exists.
+func (m *Manager) ExistsAll(names []string) (map[string]bool, error) {
+	result := make(map[string]bool, len(names))
+	for _, name := range names {
+		if _, checked := result[name]; checked {
+			continue
+		}
+		exists, err := m.Exists(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check session '%s': %w", name, err)
+		}
+		result[name] = exists
+	}
+	return result, nil
+}
+
+// RequireSessions returns a single error naming every session in names that does
+// not exist, so commands taking several sessions can validate them all up front
+func (m *Manager) RequireSessions(names ...string) error {
+	exists, err := m.ExistsAll(names)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if !exists[name] && !seen[name] {
+			missing = append(missing, fmt.Sprintf("'%s'", name))
+			seen[name] = true
+		}
+	}
+
+	switch len(missing) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("session %s does not exist", missing[0])
+	default:
+		return fmt.Errorf("sessions %s do not exist", strings.Join(missing, ", "))
+	}
+}
+
 // Clone creates a new session by copying configuration from an existing session.
 //
 // This operation performs a selective copy of session data, including:
@@ -307,9 +541,9 @@ func (m *Manager) Clone(sourceName, targetName, description string) (*Session, e
 	}
 
 	// Copy manifests directory if it exists
-	sourceManifests := filepath.Join(sourceDir, "manifests")
+	sourceManifests := m.ManifestsDir(sourceName)
 	if info, err := os.Stat(sourceManifests); err == nil && info.IsDir() {
-		targetManifests := filepath.Join(targetDir, "manifests")
+		targetManifests := m.ManifestsDir(targetName)
 		if err := copyDir(sourceManifests, targetManifests); err != nil {
 			fmt.Printf("Warning: failed to copy manifests: %v\n", err)
 		}
@@ -320,7 +554,72 @@ func (m *Manager) Clone(sourceName, targetName, description string) (*Session, e
 	return targetSession, nil
 }
 
-// AdoptVolumes configures a session to use volumes from another session
+// ManifestExistsError indicates the target session of a manifest copy already has
+// the server and overwriting was not requested
+type ManifestExistsError struct {
+	ServerName  string
+	SessionName string
+}
+
+func (e *ManifestExistsError) Error() string {
+	return fmt.Sprintf("server '%s' already exists in session '%s'", e.ServerName, e.SessionName)
+}
+
+// CopyManifest copies a single server's .servo file from one session's manifests
+// directory to another's. Unlike Clone, nothing else in either session is touched.
+func (m *Manager) CopyManifest(serverName, sourceName, targetName string, overwrite bool) error {
+	if serverName == "" || sourceName == "" || targetName == "" {
+		return fmt.Errorf("server, source and target session names cannot be empty")
+	}
+	if sourceName == targetName {
+		return fmt.Errorf("source and target session are both '%s'", sourceName)
+	}
+	if err := ValidateServerName(serverName); err != nil {
+		return err
+	}
+
+	if err := m.RequireSessions(sourceName, targetName); err != nil {
+		return err
+	}
+
+	sourceFile := m.ManifestPath(sourceName, serverName+".servo")
+	if _, err := os.Stat(sourceFile); os.IsNotExist(err) {
+		return fmt.Errorf("server '%s' is not installed in session '%s'", serverName, sourceName)
+	} else if err != nil {
+		return fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	targetManifests := m.ManifestsDir(targetName)
+	targetFile := m.ManifestPath(targetName, serverName+".servo")
+	if _, err := os.Stat(targetFile); err == nil && !overwrite {
+		return &ManifestExistsError{ServerName: serverName, SessionName: targetName}
+	}
+
+	if err := os.MkdirAll(targetManifests, 0755); err != nil {
+		return fmt.Errorf("failed to create manifests directory: %w", err)
+	}
+	if err := copyFile(sourceFile, targetFile); err != nil {
+		return fmt.Errorf("failed to copy manifest: %w", err)
+	}
+	return nil
+}
+
+// ValidateServerName rejects server names that would escape a manifests directory
+// once joined into a path
+func ValidateServerName(name string) error {
+	if name == "" {
+		return fmt.Errorf("server name cannot be empty")
+	}
+	if name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid server name '%s'", name)
+	}
+	return nil
+}
+
+// AdoptVolumes configures a session to use volumes from another session. When the
+// source itself adopts volumes, the chain is followed to the session that owns them,
+// and sessions already adopting from sessionName move along with it. Adoption that
+// would lead back to sessionName is rejected as a cycle.
 func (m *Manager) AdoptVolumes(sessionName, sourceSessionName string) error {
 	if sessionName == "" || sourceSessionName == "" {
 		return fmt.Errorf("session names cannot be empty")
@@ -337,14 +636,145 @@ func (m *Manager) AdoptVolumes(sessionName, sourceSessionName string) error {
 		return fmt.Errorf("source session '%s' does not exist: %w", sourceSessionName, err)
 	}
 
-	// Update volume path to point to source session's volumes
-	session.VolumePath = sourceSession.VolumePath
+	owner, err := m.volumeOwner(sessionName, sourceSession)
+	if err != nil {
+		return err
+	}
+
+	// Update volume path to point to the owning session's volumes
+	session.VolumePath = owner.VolumePath
+	session.AdoptedFrom = sourceSessionName
 
 	// Save updated session
 	if err := m.saveSession(session); err != nil {
 		return fmt.Errorf("failed to save session: %w", err)
 	}
 
+	return m.syncAdopters(sessionName, session.VolumePath)
+}
+
+// volumeOwner follows source's adoption chain to the session that owns its volumes.
+// Reaching sessionName, or any session twice, means adopting would create a cycle.
+// A chain that names a deleted session ends at the last session that exists.
+func (m *Manager) volumeOwner(sessionName string, source *Session) (*Session, error) {
+	chain := []string{sessionName}
+	seen := map[string]bool{sessionName: true}
+	owner := source
+	for {
+		chain = append(chain, owner.Name)
+		if seen[owner.Name] {
+			return nil, fmt.Errorf("adopting volumes from '%s' would create a cycle: %s", source.Name, strings.Join(chain, " -> "))
+		}
+		seen[owner.Name] = true
+
+		if owner.AdoptedFrom == "" {
+			return owner, nil
+		}
+		next, err := m.Get(owner.AdoptedFrom)
+		if err != nil {
+			return owner, nil
+		}
+		owner = next
+	}
+}
+
+// Adopters returns, sorted, the sessions that adopt name's volumes directly
+func (m *Manager) Adopters(name string) ([]string, error) {
+	sessions, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	var adopters []string
+	for _, session := range sessions {
+		if session.AdoptedFrom == name && session.Name != name {
+			adopters = append(adopters, session.Name)
+		}
+	}
+	sort.Strings(adopters)
+	return adopters, nil
+}
+
+// releaseAdopters re-points the sessions adopting a session that is being deleted.
+// When it adopted its volumes itself, they adopt from its source instead. Otherwise
+// they stop adopting: volumes kept outside the session directory stay in use, and
+// ones inside it go with the session, so its adopters return to their own.
+func (m *Manager) releaseAdopters(deleted *Session) error {
+	sessions, err := m.List()
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if session.AdoptedFrom != deleted.Name || session.Name == deleted.Name {
+			continue
+		}
+
+		session.AdoptedFrom = deleted.AdoptedFrom
+		volumesDeleted := deleted.AdoptedFrom == "" && pathWithin(session.VolumePath, m.getSessionDir(deleted.Name))
+		if volumesDeleted {
+			session.VolumePath = filepath.Join(m.getSessionDir(session.Name), "volumes")
+		}
+		if err := m.saveSession(session); err != nil {
+			return fmt.Errorf("failed to save session '%s': %w", session.Name, err)
+		}
+		if volumesDeleted {
+			if err := m.syncAdopters(session.Name, session.VolumePath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pathWithin reports whether path is dir or lies below it
+func pathWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// rebasePath moves path from below oldDir to the same place below newDir, leaving
+// paths outside oldDir as they are
+func rebasePath(path, oldDir, newDir string) string {
+	if !pathWithin(path, oldDir) {
+		return path
+	}
+	rel, _ := filepath.Rel(oldDir, path)
+	return filepath.Join(newDir, rel)
+}
+
+// syncAdopters points every session that adopts sessionName's volumes, directly or
+// through a chain, at volumePath
+func (m *Manager) syncAdopters(sessionName, volumePath string) error {
+	sessions, err := m.List()
+	if err != nil {
+		return err
+	}
+	adopters := make(map[string][]*Session)
+	for _, session := range sessions {
+		if session.AdoptedFrom != "" {
+			adopters[session.AdoptedFrom] = append(adopters[session.AdoptedFrom], session)
+		}
+	}
+
+	visited := map[string]bool{sessionName: true}
+	queue := []string{sessionName}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, adopter := range adopters[name] {
+			if visited[adopter.Name] {
+				continue
+			}
+			visited[adopter.Name] = true
+			queue = append(queue, adopter.Name)
+			if adopter.VolumePath == volumePath {
+				continue
+			}
+			adopter.VolumePath = volumePath
+			if err := m.saveSession(adopter); err != nil {
+				return fmt.Errorf("failed to save session '%s': %w", adopter.Name, err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -374,13 +804,15 @@ func (m *Manager) SetVolumePath(sessionName, volumePath string) error {
 		// Reset to default
 		session.VolumePath = filepath.Join(m.getSessionDir(sessionName), "volumes")
 	}
+	// An explicit path ends the session's own adoption; its adopters follow the new path
+	session.AdoptedFrom = ""
 
 	// Save updated session
 	if err := m.saveSession(session); err != nil {
 		return fmt.Errorf("failed to save session: %w", err)
 	}
 
-	return nil
+	return m.syncAdopters(sessionName, session.VolumePath)
 }
 
 // GetSessionDir returns the directory path for a session
@@ -388,6 +820,16 @@ func (m *Manager) GetSessionDir(name string) string {
 	return m.getSessionDir(name)
 }
 
+// ManifestsDir returns the directory holding a session's installed .servo manifests
+func (m *Manager) ManifestsDir(name string) string {
+	return filepath.Join(m.getSessionDir(name), "manifests")
+}
+
+// ManifestPath returns the path of file within a session's manifests directory
+func (m *Manager) ManifestPath(name, file string) string {
+	return filepath.Join(m.ManifestsDir(name), file)
+}
+
 // Helper methods
 
 func (m *Manager) getSessionDir(name string) string {
@@ -400,7 +842,7 @@ func (m *Manager) createSessionDirectories(name string) error {
 	// Create all required directories
 	dirs := []string{
 		sessionDir,
-		filepath.Join(sessionDir, "manifests"), // Server manifests (.servo files)
+		m.ManifestsDir(name),                   // Server manifests (.servo files)
 		filepath.Join(sessionDir, "config"),    // User configuration overrides
 		filepath.Join(sessionDir, "volumes"),   // Default Docker volumes
 		filepath.Join(sessionDir, "logs"),      // Session-specific logs
@@ -426,7 +868,19 @@ func (m *Manager) saveSession(session *Session) error {
 	return nil
 }
 
-// Rename renames a session and updates all references
+// renameFailpoint runs after each step of Rename, letting tests fail a rename part way through
+var renameFailpoint = func(step string) error { return nil }
+
+// renameStep is one state change made by Rename and how to undo it
+type renameStep struct {
+	name string
+	do   func() error
+	undo func()
+}
+
+// Rename renames a session and updates all references. The new session and every
+// pointer to it are written before the old directory is removed; if any step fails,
+// the steps taken so far are undone so the old session is left as it was.
 func (m *Manager) Rename(oldName, newName string) error {
 	if oldName == "" || newName == "" {
 		return fmt.Errorf("session names cannot be empty")
@@ -453,42 +907,136 @@ func (m *Manager) Rename(oldName, newName string) error {
 	oldSessionDir := m.getSessionDir(oldName)
 	newSessionDir := m.getSessionDir(newName)
 
-	// Create new session directory
-	if err := os.MkdirAll(newSessionDir, 0755); err != nil {
-		return fmt.Errorf("failed to create new session directory: %w", err)
-	}
-
-	// Copy all contents to new directory
-	if err := copyDir(oldSessionDir, newSessionDir); err != nil {
-		// Clean up on failure
-		os.RemoveAll(newSessionDir)
-		return fmt.Errorf("failed to copy session data: %w", err)
-	}
-
-	// Update session metadata
-	oldSession.Name = newName
-	newSessionFile := filepath.Join(newSessionDir, "session.yaml")
-
-	if err := utils.WriteYAMLFile(newSessionFile, oldSession); err != nil {
-		os.RemoveAll(newSessionDir)
-		return fmt.Errorf("failed to write updated session file: %w", err)
-	}
+	// The copy is staged outside the sessions directory and moved into place in one
+	// step, so an interrupted copy never appears as a half-written session
+	stagingDir := filepath.Join(m.servoDir, ".rename-"+newName)
+	moved := false
 
-	// Update active session reference if this was the active session
-	isActive := oldSession.Active
-	if isActive {
-		activeFile := filepath.Join(m.servoDir, "active_session")
-		if err := os.WriteFile(activeFile, []byte(newName), 0644); err != nil {
-			// Try to rollback
-			os.RemoveAll(newSessionDir)
-			return fmt.Errorf("failed to update active session reference: %w", err)
+	activeFile := filepath.Join(m.servoDir, "active_session")
+	projectFile := filepath.Join(m.servoDir, "project.yaml")
+	projectData, projectReadErr := os.ReadFile(projectFile)
+	// adopters holds, as they were, the sessions the adopters step rewrote
+	var adopters []Session
+
+	steps := []renameStep{
+		{
+			name: "copy",
+			do: func() error {
+				// Clear any staging left by an interrupted rename
+				os.RemoveAll(stagingDir)
+				if err := copyDir(oldSessionDir, stagingDir); err != nil {
+					return fmt.Errorf("failed to copy session data: %w", err)
+				}
+				renamed := *oldSession
+				renamed.Name = newName
+				renamed.VolumePath = rebasePath(oldSession.VolumePath, oldSessionDir, newSessionDir)
+				if err := utils.WriteYAMLFile(filepath.Join(stagingDir, "session.yaml"), &renamed); err != nil {
+					return fmt.Errorf("failed to write updated session file: %w", err)
+				}
+				if err := os.Rename(stagingDir, newSessionDir); err != nil {
+					return fmt.Errorf("failed to move session into place: %w", err)
+				}
+				moved = true
+				return nil
+			},
+			undo: func() {
+				os.RemoveAll(stagingDir)
+				if moved {
+					os.RemoveAll(newSessionDir)
+				}
+			},
+		},
+		{
+			name: "active",
+			do: func() error {
+				if !oldSession.Active {
+					return nil
+				}
+				if err := os.WriteFile(activeFile, []byte(newName), 0644); err != nil {
+					return fmt.Errorf("failed to update active session reference: %w", err)
+				}
+				return nil
+			},
+			undo: func() {
+				if oldSession.Active {
+					os.WriteFile(activeFile, []byte(oldName), 0644)
+				}
+			},
+		},
+		{
+			name: "adopters",
+			do: func() error {
+				sessions, err := m.List()
+				if err != nil {
+					return fmt.Errorf("failed to list sessions: %w", err)
+				}
+				for _, session := range sessions {
+					if session.Name == oldName || session.Name == newName {
+						continue
+					}
+					original := *session
+					session.VolumePath = rebasePath(session.VolumePath, oldSessionDir, newSessionDir)
+					if session.AdoptedFrom == oldName {
+						session.AdoptedFrom = newName
+					}
+					if session.VolumePath == original.VolumePath && session.AdoptedFrom == original.AdoptedFrom {
+						continue
+					}
+					if err := m.saveSession(session); err != nil {
+						return fmt.Errorf("failed to update session '%s': %w", session.Name, err)
+					}
+					adopters = append(adopters, original)
+				}
+				return nil
+			},
+			undo: func() {
+				for i := range adopters {
+					m.saveSession(&adopters[i])
+				}
+			},
+		},
+		{
+			name: "scoped",
+			do: func() error {
+				if err := m.updateScopedReferences(oldName, newName); err != nil {
+					return fmt.Errorf("failed to update scoped active sessions: %w", err)
+				}
+				return nil
+			},
+			undo: func() { m.updateScopedReferences(newName, oldName) },
+		},
+		{
+			name: "project",
+			do: func() error {
+				if err := m.updateProjectConfigForRename(oldName, newName); err != nil {
+					return fmt.Errorf("failed to update project configuration: %w", err)
+				}
+				return nil
+			},
+			undo: func() {
+				// Restore the exact original bytes, comments and all
+				if projectReadErr == nil {
+					os.WriteFile(projectFile, projectData, 0644)
+				}
+			},
+		},
+	}
+
+	// A failed step may have half-applied, so its own undo runs along with the
+	// undos of the steps before it
+	var taken []renameStep
+	for _, step := range steps {
+		taken = append(taken, step)
+		err := step.do()
+		if err == nil {
+			err = renameFailpoint(step.name)
+		}
+		if err != nil {
+			for i := len(taken) - 1; i >= 0; i-- {
+				taken[i].undo()
+			}
+			return err
 		}
-	}
-
-	// Update project configuration if this session is referenced
-	if err := m.updateProjectConfigForRename(oldName, newName); err != nil {
-		// Log warning but don't fail the rename
-		fmt.Printf("Warning: failed to update project configuration: %v\n", err)
 	}
 
 	// Remove old session directory only after everything else succeeds
@@ -499,12 +1047,18 @@ func (m *Manager) Rename(oldName, newName string) error {
 	return nil
 }
 
-// updateProjectConfigForRename updates project configuration when a session is renamed
-func (m *Manager) updateProjectConfigForRename(oldName, newName string) error {
-	// This is a simplified integration - in a full implementation this would
-	// need to import the project package, but that could create circular imports.
-	// For now, we'll directly manipulate the project.yaml file
+// sessionReferenceKeys are the project.yaml keys whose values name sessions,
+// either as a single string or a list of strings, at any nesting level
+var sessionReferenceKeys = map[string]bool{
+	"default_session": true,
+	"active_session":  true,
+	"sessions":        true,
+}
 
+// updateProjectConfigForRename rewrites every session reference in project.yaml when a session is renamed
+func (m *Manager) updateProjectConfigForRename(oldName, newName string) error {
+	// The project package imports session, so project.yaml is edited as a YAML
+	// node tree here. Walking nodes also preserves key order and comments.
 	projectFile := filepath.Join(m.servoDir, "project.yaml")
 	if _, err := os.Stat(projectFile); os.IsNotExist(err) {
 		return nil // No project file, nothing to update
@@ -516,38 +1070,89 @@ func (m *Manager) updateProjectConfigForRename(oldName, newName string) error {
 		return fmt.Errorf("failed to read project file: %w", err)
 	}
 
-	// Parse as generic map to avoid circular imports
-	var projectData map[string]interface{}
-	if err := yaml.Unmarshal(data, &projectData); err != nil {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
 		return fmt.Errorf("failed to parse project file: %w", err)
 	}
 
-	// Update references to the old session name
-	updated := false
+	// Write back only if references were rewritten
+	count, err := renameSessionReferences(&root, oldName, newName)
+	if err != nil {
+		return fmt.Errorf("failed to update session references: %w", err)
+	}
+	if count == 0 {
+		return nil
+	}
 
-	if defaultSession, ok := projectData["default_session"].(string); ok && defaultSession == oldName {
-		projectData["default_session"] = newName
-		updated = true
+	newData, err := yaml.Marshal(&root)
+	if err != nil {
+		return fmt.Errorf("failed to marshal updated project: %w", err)
 	}
 
-	if activeSession, ok := projectData["active_session"].(string); ok && activeSession == oldName {
-		projectData["active_session"] = newName
-		updated = true
+	if err := os.WriteFile(projectFile, newData, 0644); err != nil {
+		return fmt.Errorf("failed to write updated project file: %w", err)
 	}
 
-	// Write back if we made changes
-	if updated {
-		newData, err := yaml.Marshal(projectData)
-		if err != nil {
-			return fmt.Errorf("failed to marshal updated project: %w", err)
-		}
+	return nil
+}
 
-		if err := os.WriteFile(projectFile, newData, 0644); err != nil {
-			return fmt.Errorf("failed to write updated project file: %w", err)
+// renameSessionReferences walks a YAML node tree and rewrites session references,
+// returning the number of values changed
+func renameSessionReferences(node *yaml.Node, oldName, newName string) (int, error) {
+	count := 0
+
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			n, err := renameSessionReferences(child, oldName, newName)
+			if err != nil {
+				return 0, err
+			}
+			count += n
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			var n int
+			var err error
+			if sessionReferenceKeys[key.Value] {
+				n, err = replaceSessionName(key.Value, value, oldName, newName)
+			} else {
+				n, err = renameSessionReferences(value, oldName, newName)
+			}
+			if err != nil {
+				return 0, err
+			}
+			count += n
 		}
 	}
 
-	return nil
+	return count, nil
+}
+
+// replaceSessionName rewrites a scalar or list of scalars naming a session.
+// Any other shape under a session reference key is an error, so a reference is
+// never silently left pointing at the old name
+func replaceSessionName(key string, node *yaml.Node, oldName, newName string) (int, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Value == oldName {
+			node.Value = newName
+			return 1, nil
+		}
+		return 0, nil
+	case yaml.SequenceNode:
+		count := 0
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return 0, fmt.Errorf("cannot rewrite '%s' at line %d: expected a list of session names", key, item.Line)
+			}
+			n, _ := replaceSessionName(key, item, oldName, newName)
+			count += n
+		}
+		return count, nil
+	}
+	return 0, fmt.Errorf("cannot rewrite '%s' at line %d: expected a session name or list of session names", key, node.Line)
 }
 
 // Utility functions for file operations
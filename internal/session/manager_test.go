@@ -1,11 +1,15 @@
 package session
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 func setupTestManager(t *testing.T) (*Manager, string) {
@@ -116,6 +120,32 @@ func TestManager_CreateDuplicate(t *testing.T) {
 	}
 }
 
+func TestManager_CreateIfMissing(t *testing.T) {
+	manager, _ := setupTestManager(t)
+
+	created, err := manager.CreateIfMissing("staging", "Staging session")
+	if err != nil || !created {
+		t.Fatalf("CreateIfMissing() = %v, %v; want created", created, err)
+	}
+
+	// An existing session is kept as-is
+	created, err = manager.CreateIfMissing("staging", "Other description")
+	if err != nil || created {
+		t.Fatalf("CreateIfMissing() on existing session = %v, %v; want no-op", created, err)
+	}
+	session, err := manager.Get("staging")
+	if err != nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+	if session.Description != "Staging session" {
+		t.Errorf("expected original description to be kept, got %q", session.Description)
+	}
+
+	if _, err := manager.CreateIfMissing("", ""); err == nil {
+		t.Error("expected error for empty session name")
+	}
+}
+
 func TestManager_Get(t *testing.T) {
 	manager, _ := setupTestManager(t)
 
@@ -194,6 +224,72 @@ func TestManager_List(t *testing.T) {
 	}
 }
 
+func TestManager_ListSorted(t *testing.T) {
+	manager, _ := setupTestManager(t)
+
+	for _, name := range []string{"charlie", "alpha", "bravo"} {
+		if _, err := manager.Create(name, "Test session "+name, ""); err != nil {
+			t.Fatalf("failed to create session %s: %v", name, err)
+		}
+	}
+	if err := manager.Activate("charlie"); err != nil {
+		t.Fatalf("failed to activate session: %v", err)
+	}
+
+	names := func(by SortBy) string {
+		sessions, err := manager.ListSorted(by)
+		if err != nil {
+			t.Fatalf("ListSorted(%s) error = %v", by, err)
+		}
+		var out []string
+		for _, s := range sessions {
+			out = append(out, s.Name)
+		}
+		return strings.Join(out, ",")
+	}
+
+	if got := names(SortByName); got != "alpha,bravo,charlie" {
+		t.Errorf("ListSorted(name) = %s, want alpha,bravo,charlie", got)
+	}
+	if got := names(SortByLastUsed); !strings.HasPrefix(got, "charlie,") {
+		t.Errorf("ListSorted(last-used) = %s, want the activated session first", got)
+	}
+}
+
+func TestManager_ListNames(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+
+	names, err := manager.ListNames()
+	if err != nil || len(names) != 0 {
+		t.Fatalf("ListNames() without sessions = %v, %v; want empty", names, err)
+	}
+
+	for _, name := range []string{"beta", "alpha"} {
+		if _, err := manager.Create(name, "", ""); err != nil {
+			t.Fatalf("failed to create session %s: %v", name, err)
+		}
+	}
+
+	// A corrupt session file still counts; a directory without one does not
+	corruptDir := filepath.Join(tmpDir, "sessions", "corrupt")
+	os.MkdirAll(corruptDir, 0755)
+	os.WriteFile(filepath.Join(corruptDir, "session.yaml"), []byte("name: [unclosed"), 0644)
+	os.MkdirAll(filepath.Join(tmpDir, "sessions", "stray"), 0755)
+
+	names, err = manager.ListNames()
+	if err != nil {
+		t.Fatalf("ListNames() error = %v", err)
+	}
+	if strings.Join(names, ",") != "alpha,beta,corrupt" {
+		t.Errorf("ListNames() = %v, want [alpha beta corrupt]", names)
+	}
+
+	sessions, _ := manager.List()
+	if len(sessions) != 2 {
+		t.Errorf("List() should still skip the corrupt session, got %d sessions", len(sessions))
+	}
+}
+
 func TestManager_Delete(t *testing.T) {
 	manager, _ := setupTestManager(t)
 
@@ -356,6 +452,51 @@ func TestManager_ClearActive(t *testing.T) {
 	}
 }
 
+func TestManager_GetActiveName(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+
+	// No active session
+	name, err := manager.GetActiveName()
+	if err != nil {
+		t.Fatalf("unexpected error with no active session: %v", err)
+	}
+	if name != "" {
+		t.Errorf("expected no active session name, got %q", name)
+	}
+
+	if _, err := manager.Create("named", "Named session", ""); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	if err := manager.Activate("named"); err != nil {
+		t.Fatalf("failed to activate session: %v", err)
+	}
+
+	// The session file is never parsed, so a corrupt one still resolves
+	sessionFile := filepath.Join(tmpDir, "sessions", "named", "session.yaml")
+	if err := os.WriteFile(sessionFile, []byte("{not yaml"), 0644); err != nil {
+		t.Fatalf("failed to corrupt session file: %v", err)
+	}
+	name, err = manager.GetActiveName()
+	if err != nil {
+		t.Fatalf("unexpected error getting active name: %v", err)
+	}
+	if name != "named" {
+		t.Errorf("expected active name 'named', got %q", name)
+	}
+
+	// Stale pointer to a deleted session
+	if err := os.WriteFile(filepath.Join(tmpDir, "active_session"), []byte("deleted"), 0644); err != nil {
+		t.Fatalf("failed to write active_session: %v", err)
+	}
+	name, err = manager.GetActiveName()
+	if err != nil {
+		t.Fatalf("unexpected error with stale active session: %v", err)
+	}
+	if name != "" {
+		t.Errorf("expected no name for stale active session, got %q", name)
+	}
+}
+
 func TestManager_Clone(t *testing.T) {
 	manager, _ := setupTestManager(t)
 
@@ -420,6 +561,90 @@ func TestManager_Clone(t *testing.T) {
 	}
 }
 
+func TestManager_ExistsAll(t *testing.T) {
+	manager, _ := setupTestManager(t)
+
+	for _, name := range []string{"dev", "staging"} {
+		if _, err := manager.Create(name, "", ""); err != nil {
+			t.Fatalf("failed to create session %s: %v", name, err)
+		}
+	}
+
+	exists, err := manager.ExistsAll([]string{"dev", "staging", "prod", "", "dev"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]bool{"dev": true, "staging": true, "prod": false, "": false}
+	if !reflect.DeepEqual(exists, want) {
+		t.Errorf("ExistsAll() = %v, want %v", exists, want)
+	}
+
+	if err := manager.RequireSessions("dev", "staging"); err != nil {
+		t.Errorf("expected existing sessions to pass, got %v", err)
+	}
+	if err := manager.RequireSessions("dev", "prod"); err == nil || err.Error() != "session 'prod' does not exist" {
+		t.Errorf("expected single missing session error, got %v", err)
+	}
+	if err := manager.RequireSessions("prod", "dev", "qa", "prod"); err == nil || err.Error() != "sessions 'prod', 'qa' do not exist" {
+		t.Errorf("expected every missing session listed once, got %v", err)
+	}
+}
+
+func TestManager_CopyManifest(t *testing.T) {
+	manager, _ := setupTestManager(t)
+
+	for _, name := range []string{"source", "target"} {
+		if _, err := manager.Create(name, "", ""); err != nil {
+			t.Fatalf("failed to create session %s: %v", name, err)
+		}
+	}
+
+	sourceManifests := filepath.Join(manager.getSessionDir("source"), "manifests")
+	os.MkdirAll(sourceManifests, 0755)
+	os.WriteFile(filepath.Join(sourceManifests, "search.servo"), []byte("name: search\n"), 0644)
+	os.WriteFile(filepath.Join(sourceManifests, "other.servo"), []byte("name: other\n"), 0644)
+
+	if err := manager.CopyManifest("search", "source", "target", false); err != nil {
+		t.Fatalf("unexpected error copying manifest: %v", err)
+	}
+
+	targetManifests := filepath.Join(manager.getSessionDir("target"), "manifests")
+	if data, err := os.ReadFile(filepath.Join(targetManifests, "search.servo")); err != nil || string(data) != "name: search\n" {
+		t.Errorf("expected search.servo to be copied, got %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(targetManifests, "other.servo")); !os.IsNotExist(err) {
+		t.Errorf("expected only the requested manifest to be copied")
+	}
+
+	// An existing target is protected unless overwrite is requested
+	var exists *ManifestExistsError
+	if err := manager.CopyManifest("search", "source", "target", false); !errors.As(err, &exists) {
+		t.Errorf("expected ManifestExistsError, got %v", err)
+	}
+	os.WriteFile(filepath.Join(sourceManifests, "search.servo"), []byte("name: search\nversion: 2.0.0\n"), 0644)
+	if err := manager.CopyManifest("search", "source", "target", true); err != nil {
+		t.Errorf("unexpected error overwriting manifest: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(targetManifests, "search.servo")); !strings.Contains(string(data), "2.0.0") {
+		t.Errorf("expected overwrite to replace the manifest, got %q", data)
+	}
+
+	if err := manager.CopyManifest("missing", "source", "target", false); err == nil || !strings.Contains(err.Error(), "not installed in session 'source'") {
+		t.Errorf("expected missing server error, got %v", err)
+	}
+	if err := manager.CopyManifest("search", "source", "nowhere", false); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("expected missing session error, got %v", err)
+	}
+	for _, name := range []string{"..", "../source/manifests/search", `..\search`} {
+		if err := manager.CopyManifest(name, "source", "target", true); err == nil || !strings.Contains(err.Error(), "invalid server name") {
+			t.Errorf("CopyManifest(%q) error = %v, want an invalid server name", name, err)
+		}
+	}
+	if err := manager.CopyManifest("search", "source", "source", false); err == nil {
+		t.Errorf("expected error copying a manifest onto its own session")
+	}
+}
+
 func TestManager_AdoptVolumes(t *testing.T) {
 	manager, _ := setupTestManager(t)
 
@@ -459,6 +684,160 @@ func TestManager_AdoptVolumes(t *testing.T) {
 	}
 }
 
+func TestManager_AdoptVolumesChain(t *testing.T) {
+	volumePath := func(manager *Manager, name string) string {
+		session, err := manager.Get(name)
+		if err != nil {
+			t.Fatalf("failed to get session %s: %v", name, err)
+		}
+		return session.VolumePath
+	}
+
+	tests := []struct {
+		name  string
+		order [][2]string // adopter, source
+	}{
+		{name: "source adopts first", order: [][2]string{{"b", "c"}, {"a", "b"}}},
+		{name: "adopter adopts first", order: [][2]string{{"a", "b"}, {"b", "c"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, _ := setupTestManager(t)
+			for _, name := range []string{"a", "b"} {
+				if _, err := manager.Create(name, "", ""); err != nil {
+					t.Fatalf("failed to create session %s: %v", name, err)
+				}
+			}
+			if _, err := manager.Create("c", "", "/custom/c/volumes"); err != nil {
+				t.Fatalf("failed to create session c: %v", err)
+			}
+
+			for _, step := range tt.order {
+				if err := manager.AdoptVolumes(step[0], step[1]); err != nil {
+					t.Fatalf("AdoptVolumes(%s, %s) error = %v", step[0], step[1], err)
+				}
+			}
+			for _, name := range []string{"a", "b"} {
+				if got := volumePath(manager, name); got != "/custom/c/volumes" {
+					t.Errorf("expected %s to share c's volumes, got %s", name, got)
+				}
+			}
+
+			// Giving the middle session its own path moves its adopters with it
+			if err := manager.SetVolumePath("b", "/custom/b/volumes"); err != nil {
+				t.Fatalf("SetVolumePath() error = %v", err)
+			}
+			if got := volumePath(manager, "a"); got != "/custom/b/volumes" {
+				t.Errorf("expected a to follow b's new path, got %s", got)
+			}
+			if got := volumePath(manager, "c"); got != "/custom/c/volumes" {
+				t.Errorf("expected c to keep its volumes, got %s", got)
+			}
+		})
+	}
+}
+
+func TestManager_AdoptVolumesCycle(t *testing.T) {
+	manager, _ := setupTestManager(t)
+	for _, name := range []string{"a", "b"} {
+		if _, err := manager.Create(name, "", ""); err != nil {
+			t.Fatalf("failed to create session %s: %v", name, err)
+		}
+	}
+
+	if err := manager.AdoptVolumes("a", "b"); err != nil {
+		t.Fatalf("AdoptVolumes(a, b) error = %v", err)
+	}
+	before, _ := manager.Get("b")
+
+	err := manager.AdoptVolumes("b", "a")
+	if err == nil || !strings.Contains(err.Error(), "cycle: b -> a -> b") {
+		t.Fatalf("expected a cycle error, got %v", err)
+	}
+	after, _ := manager.Get("b")
+	if after.AdoptedFrom != "" || after.VolumePath != before.VolumePath {
+		t.Errorf("expected b unchanged after a rejected adoption, got %+v", after)
+	}
+
+	if err := manager.AdoptVolumes("a", "a"); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected adopting a session's own volumes to be rejected, got %v", err)
+	}
+}
+
+func TestManager_DeleteReleasesAdopters(t *testing.T) {
+	manager, tempDir := setupTestManager(t)
+	for _, name := range []string{"owner", "middle", "adopter", "custom", "follower"} {
+		volumePath := ""
+		if name == "custom" {
+			volumePath = "/custom/volumes"
+		}
+		if _, err := manager.Create(name, "", volumePath); err != nil {
+			t.Fatalf("failed to create session %s: %v", name, err)
+		}
+	}
+	for _, step := range [][2]string{{"middle", "owner"}, {"adopter", "middle"}, {"follower", "custom"}} {
+		if err := manager.AdoptVolumes(step[0], step[1]); err != nil {
+			t.Fatalf("AdoptVolumes(%s, %s) error = %v", step[0], step[1], err)
+		}
+	}
+	if adopters, err := manager.Adopters("middle"); err != nil || !reflect.DeepEqual(adopters, []string{"adopter"}) {
+		t.Errorf("Adopters(middle) = %v, %v, want [adopter]", adopters, err)
+	}
+
+	// Deleting a session that adopted its volumes hands its adopters to its source
+	if err := manager.Delete("middle"); err != nil {
+		t.Fatalf("Delete(middle) error = %v", err)
+	}
+	adopter, _ := manager.Get("adopter")
+	owner, _ := manager.Get("owner")
+	if adopter.AdoptedFrom != "owner" || adopter.VolumePath != owner.VolumePath {
+		t.Errorf("expected adopter to adopt from owner, got %+v", adopter)
+	}
+
+	// Volumes inside a deleted session's directory go with it
+	if err := manager.Delete("owner"); err != nil {
+		t.Fatalf("Delete(owner) error = %v", err)
+	}
+	adopter, _ = manager.Get("adopter")
+	if want := filepath.Join(tempDir, "sessions", "adopter", "volumes"); adopter.AdoptedFrom != "" || adopter.VolumePath != want {
+		t.Errorf("expected adopter back on its own volumes %s, got %+v", want, adopter)
+	}
+
+	// Volumes kept elsewhere stay in use
+	if err := manager.Delete("custom"); err != nil {
+		t.Fatalf("Delete(custom) error = %v", err)
+	}
+	follower, _ := manager.Get("follower")
+	if follower.AdoptedFrom != "" || follower.VolumePath != "/custom/volumes" {
+		t.Errorf("expected follower to keep /custom/volumes without adopting, got %+v", follower)
+	}
+}
+
+func TestManager_RenameUpdatesAdopters(t *testing.T) {
+	manager, tempDir := setupTestManager(t)
+	for _, name := range []string{"old-name", "adopter"} {
+		if _, err := manager.Create(name, "", ""); err != nil {
+			t.Fatalf("failed to create session %s: %v", name, err)
+		}
+	}
+	if err := manager.AdoptVolumes("adopter", "old-name"); err != nil {
+		t.Fatalf("AdoptVolumes() error = %v", err)
+	}
+
+	if err := manager.Rename("old-name", "new-name"); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	want := filepath.Join(tempDir, "sessions", "new-name", "volumes")
+	renamed, _ := manager.Get("new-name")
+	if renamed.VolumePath != want {
+		t.Errorf("expected the renamed session's volumes at %s, got %s", want, renamed.VolumePath)
+	}
+	adopter, _ := manager.Get("adopter")
+	if adopter.AdoptedFrom != "new-name" || adopter.VolumePath != want {
+		t.Errorf("expected adopter to follow the rename to %s, got %+v", want, adopter)
+	}
+}
+
 func TestManager_SetVolumePath(t *testing.T) {
 	manager, _ := setupTestManager(t)
 
@@ -600,50 +979,75 @@ func TestManager_GetSessionDir(t *testing.T) {
 	}
 }
 
+func TestManager_ManifestPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	manager := NewManager(tmpDir)
+
+	expectedDir := filepath.Join(tmpDir, "sessions", "dev", "manifests")
+	if got := manager.ManifestsDir("dev"); got != expectedDir {
+		t.Errorf("expected manifests dir '%s', got '%s'", expectedDir, got)
+	}
+	if got := manager.ManifestPath("dev", "api.servo"); got != filepath.Join(expectedDir, "api.servo") {
+		t.Errorf("expected manifest path under '%s', got '%s'", expectedDir, got)
+	}
+
+	// Newly created sessions get the manifests directory the helpers point at
+	if _, err := manager.Create("dev", "", ""); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	if info, err := os.Stat(expectedDir); err != nil || !info.IsDir() {
+		t.Errorf("expected %s to be created with the session: %v", expectedDir, err)
+	}
+}
+
 func TestManager_CreateWithOptions(t *testing.T) {
 	manager, _ := setupTestManager(t)
 
 	tests := []struct {
-		name         string
-		sessionName  string
-		description  string
-		volumePath   string
-		devcontainer bool
-		clients      []string
-		expectError  bool
+		name        string
+		sessionName string
+		description string
+		volumePath  string
+		clients     []string
+		tags        []string
+		expectError bool
 	}{
 		{
-			name:         "create with devcontainer enabled",
-			sessionName:  "test-devcontainer",
-			description:  "Test devcontainer session",
-			volumePath:   "",
-			devcontainer: true,
-			clients:      []string{"vscode", "claude-code"},
-			expectError:  false,
+			name:        "create with clients and tags",
+			sessionName: "test-clients-tags",
+			description: "Test clients and tags session",
+			clients:     []string{"vscode", "claude-code"},
+			tags:        []string{"frontend", "ci"},
+		},
+		{
+			name:        "create with clients only",
+			sessionName: "test-clients",
+			description: "Test clients session",
+			clients:     []string{"cursor"},
 		},
 		{
-			name:         "create with clients only",
-			sessionName:  "test-clients",
-			description:  "Test clients session",
-			volumePath:   "",
-			devcontainer: false,
-			clients:      []string{"cursor"},
-			expectError:  false,
+			name:        "create with all options",
+			sessionName: "test-all-options",
+			description: "Test all options",
+			volumePath:  "/custom/path",
+			clients:     []string{"vscode", "claude-code", "cursor"},
+			tags:        []string{"staging"},
 		},
 		{
-			name:         "create with all options",
-			sessionName:  "test-all-options",
-			description:  "Test all options",
-			volumePath:   "/custom/path",
-			devcontainer: true,
-			clients:      []string{"vscode", "claude-code", "cursor"},
-			expectError:  false,
+			name:        "empty name",
+			sessionName: "",
+			expectError: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			session, err := manager.Create(tt.sessionName, tt.description, tt.volumePath)
+			session, err := manager.CreateWithOptions(tt.sessionName, CreateOptions{
+				Description: tt.description,
+				VolumePath:  tt.volumePath,
+				Clients:     tt.clients,
+				Tags:        tt.tags,
+			})
 
 			if tt.expectError {
 				if err == nil {
@@ -661,16 +1065,42 @@ func TestManager_CreateWithOptions(t *testing.T) {
 				t.Fatal("session is nil")
 			}
 
-			if session.Name != tt.sessionName {
-				t.Errorf("expected name %s, got %s", tt.sessionName, session.Name)
+			// Read back from session.yaml so the options are known to be persisted
+			stored, err := manager.Get(tt.sessionName)
+			if err != nil {
+				t.Fatalf("failed to get session: %v", err)
 			}
 
-			if session.Description != tt.description {
-				t.Errorf("expected description %s, got %s", tt.description, session.Description)
+			if stored.Name != tt.sessionName {
+				t.Errorf("expected name %s, got %s", tt.sessionName, stored.Name)
+			}
+
+			if stored.Description != tt.description {
+				t.Errorf("expected description %s, got %s", tt.description, stored.Description)
 			}
 
+			if tt.volumePath != "" && stored.VolumePath != tt.volumePath {
+				t.Errorf("expected volume path %s, got %s", tt.volumePath, stored.VolumePath)
+			}
+
+			if !reflect.DeepEqual(stored.Clients, tt.clients) {
+				t.Errorf("expected clients %v, got %v", tt.clients, stored.Clients)
+			}
+
+			if !reflect.DeepEqual(stored.Tags, tt.tags) {
+				t.Errorf("expected tags %v, got %v", tt.tags, stored.Tags)
+			}
 		})
 	}
+
+	// The three-argument Create records no clients or tags
+	session, err := manager.Create("plain", "Plain session", "")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if session.Clients != nil || session.Tags != nil {
+		t.Errorf("expected no clients or tags, got %v and %v", session.Clients, session.Tags)
+	}
 }
 
 func TestManager_CreateProjectSession(t *testing.T) {
@@ -952,3 +1382,286 @@ func TestManager_RenameWithProjectConfig(t *testing.T) {
 		t.Error("renamed session should exist")
 	}
 }
+
+func TestManager_RenameUpdatesAllProjectReferences(t *testing.T) {
+	manager, tempDir := setupTestManager(t)
+
+	if _, err := manager.Create("staging", "Staging session", ""); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	if err := manager.Activate("staging"); err != nil {
+		t.Fatalf("failed to activate session: %v", err)
+	}
+
+	projectYAML := `clients:
+    - vscode
+default_session: staging
+active_session: staging
+mcp_servers:
+    - name: staging
+      source: ./staging.servo
+      sessions:
+        - default
+        - staging
+    - name: other
+      source: ./other.servo
+      sessions:
+        - staging
+`
+	projectFile := filepath.Join(tempDir, "project.yaml")
+	if err := os.WriteFile(projectFile, []byte(projectYAML), 0644); err != nil {
+		t.Fatalf("failed to write project file: %v", err)
+	}
+
+	if err := manager.Rename("staging", "qa"); err != nil {
+		t.Fatalf("unexpected error renaming session: %v", err)
+	}
+
+	data, err := os.ReadFile(projectFile)
+	if err != nil {
+		t.Fatalf("failed to read project file: %v", err)
+	}
+
+	var project struct {
+		DefaultSession string `yaml:"default_session"`
+		ActiveSession  string `yaml:"active_session"`
+		MCPServers     []struct {
+			Name     string   `yaml:"name"`
+			Sessions []string `yaml:"sessions"`
+		} `yaml:"mcp_servers"`
+	}
+	if err := yaml.Unmarshal(data, &project); err != nil {
+		t.Fatalf("failed to parse project file: %v", err)
+	}
+
+	if project.DefaultSession != "qa" {
+		t.Errorf("expected default_session 'qa', got '%s'", project.DefaultSession)
+	}
+	if project.ActiveSession != "qa" {
+		t.Errorf("expected active_session 'qa', got '%s'", project.ActiveSession)
+	}
+	if len(project.MCPServers) != 2 {
+		t.Fatalf("expected 2 servers, got %d", len(project.MCPServers))
+	}
+
+	// Server names that happen to match the session name are not session references
+	if project.MCPServers[0].Name != "staging" {
+		t.Errorf("expected server name to be left untouched, got '%s'", project.MCPServers[0].Name)
+	}
+	if strings.Join(project.MCPServers[0].Sessions, ",") != "default,qa" {
+		t.Errorf("expected sessions [default qa], got %v", project.MCPServers[0].Sessions)
+	}
+	if strings.Join(project.MCPServers[1].Sessions, ",") != "qa" {
+		t.Errorf("expected sessions [qa], got %v", project.MCPServers[1].Sessions)
+	}
+}
+
+func TestManager_RenameFailsOnProjectUpdateError(t *testing.T) {
+	manager, tempDir := setupTestManager(t)
+
+	if _, err := manager.Create("old-name", "Session", ""); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	// An unparseable project file must abort the rename rather than leave stale references
+	projectFile := filepath.Join(tempDir, "project.yaml")
+	if err := os.WriteFile(projectFile, []byte("default_session: [old-name\n"), 0644); err != nil {
+		t.Fatalf("failed to write project file: %v", err)
+	}
+
+	if err := manager.Rename("old-name", "new-name"); err == nil {
+		t.Fatal("expected rename to fail when project configuration cannot be updated")
+	}
+
+	if exists, _ := manager.Exists("old-name"); !exists {
+		t.Error("original session should be intact after failed rename")
+	}
+	if exists, _ := manager.Exists("new-name"); exists {
+		t.Error("partially renamed session should be rolled back")
+	}
+}
+
+func TestManager_RenameRejectsUnrewritableReference(t *testing.T) {
+	manager, tempDir := setupTestManager(t)
+
+	if _, err := manager.Create("old-name", "Session", ""); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	// A mapping under a session reference key cannot be rewritten and must not be skipped
+	projectYAML := `default_session:
+    name: old-name
+mcp_servers:
+    - name: db
+      source: ./db.servo
+      sessions:
+        - old-name
+`
+	projectFile := filepath.Join(tempDir, "project.yaml")
+	if err := os.WriteFile(projectFile, []byte(projectYAML), 0644); err != nil {
+		t.Fatalf("failed to write project file: %v", err)
+	}
+
+	err := manager.Rename("old-name", "new-name")
+	if err == nil {
+		t.Fatal("expected rename to fail on a mapping session reference")
+	}
+	if !strings.Contains(err.Error(), "default_session") {
+		t.Errorf("expected error to name the reference key, got: %v", err)
+	}
+
+	if exists, _ := manager.Exists("old-name"); !exists {
+		t.Error("original session should be intact after failed rename")
+	}
+	data, err := os.ReadFile(projectFile)
+	if err != nil {
+		t.Fatalf("failed to read project file: %v", err)
+	}
+	if string(data) != projectYAML {
+		t.Errorf("project file should be unchanged, got:\n%s", data)
+	}
+}
+
+func TestManager_RenameRollsBackEachStep(t *testing.T) {
+	for _, failAt := range []string{"copy", "adopters", "active", "scoped", "project"} {
+		t.Run(failAt, func(t *testing.T) {
+			manager, tempDir := setupTestManager(t)
+			if _, err := manager.Create("old-name", "Session", ""); err != nil {
+				t.Fatalf("failed to create session: %v", err)
+			}
+			if _, err := manager.Create("adopter", "", ""); err != nil {
+				t.Fatalf("failed to create session: %v", err)
+			}
+			if err := manager.AdoptVolumes("adopter", "old-name"); err != nil {
+				t.Fatalf("AdoptVolumes() error = %v", err)
+			}
+			adopterBefore, _ := manager.Get("adopter")
+			if err := manager.Activate("old-name"); err != nil {
+				t.Fatalf("failed to activate session: %v", err)
+			}
+			scoped := NewManager(tempDir)
+			scoped.SetScope("services/api")
+			if err := scoped.ActivateHere("old-name"); err != nil {
+				t.Fatalf("ActivateHere() error = %v", err)
+			}
+			projectFile := filepath.Join(tempDir, "project.yaml")
+			projectYAML := []byte("# keep this comment\ndefault_session: old-name\nsessions:\n  - old-name\n")
+			if err := os.WriteFile(projectFile, projectYAML, 0644); err != nil {
+				t.Fatalf("failed to write project file: %v", err)
+			}
+
+			oldFailpoint := renameFailpoint
+			defer func() { renameFailpoint = oldFailpoint }()
+			renameFailpoint = func(step string) error {
+				if step == failAt {
+					return errors.New("injected failure after " + step)
+				}
+				return nil
+			}
+
+			if err := manager.Rename("old-name", "new-name"); err == nil || !strings.Contains(err.Error(), "injected failure") {
+				t.Fatalf("Rename() error = %v, want injected failure", err)
+			}
+
+			session, err := manager.Get("old-name")
+			if err != nil || session.Name != "old-name" {
+				t.Fatalf("original session should be intact, got %v (err %v)", session, err)
+			}
+			for _, dir := range []string{
+				filepath.Join(tempDir, "sessions", "new-name"),
+				filepath.Join(tempDir, ".rename-new-name"),
+			} {
+				if _, err := os.Stat(dir); !os.IsNotExist(err) {
+					t.Errorf("%s should be rolled back", dir)
+				}
+			}
+			if active, err := manager.GetActive(); err != nil || active == nil || active.Name != "old-name" {
+				t.Errorf("active session should still be 'old-name', got %v (err %v)", active, err)
+			}
+			if active, err := scoped.GetActive(); err != nil || active == nil || active.Name != "old-name" {
+				t.Errorf("scoped active session should still be 'old-name', got %v (err %v)", active, err)
+			}
+			if data, _ := os.ReadFile(projectFile); string(data) != string(projectYAML) {
+				t.Errorf("project.yaml should be restored, got:\n%s", data)
+			}
+			if adopter, _ := manager.Get("adopter"); !reflect.DeepEqual(adopter, adopterBefore) {
+				t.Errorf("adopter should be restored, got %+v", adopter)
+			}
+		})
+	}
+}
+
+func TestManager_Touch(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+
+	for _, name := range []string{"alpha", "beta"} {
+		if _, err := manager.Create(name, "", ""); err != nil {
+			t.Fatalf("Failed to create session %s: %v", name, err)
+		}
+	}
+	if err := manager.Activate("alpha"); err != nil {
+		t.Fatalf("Failed to activate session: %v", err)
+	}
+
+	alphaFile := filepath.Join(tmpDir, "sessions", "alpha", "session.yaml")
+	alphaBefore, err := os.ReadFile(alphaFile)
+	if err != nil {
+		t.Fatalf("Failed to read session file: %v", err)
+	}
+
+	before := time.Now()
+	if err := manager.Touch("beta"); err != nil {
+		t.Fatalf("Touch() error = %v", err)
+	}
+
+	beta, err := manager.Get("beta")
+	if err != nil {
+		t.Fatalf("Failed to get session: %v", err)
+	}
+	if beta.LastUsedAt.Before(before) {
+		t.Errorf("Expected LastUsedAt to be updated, got %v", beta.LastUsedAt)
+	}
+
+	active, err := manager.GetActive()
+	if err != nil || active == nil || active.Name != "beta" {
+		t.Errorf("Expected active pointer to move to 'beta', got %v (err %v)", active, err)
+	}
+
+	// Other sessions are not rewritten
+	alphaAfter, _ := os.ReadFile(alphaFile)
+	if string(alphaBefore) != string(alphaAfter) {
+		t.Error("Touch should not rewrite other sessions")
+	}
+
+	if err := manager.Touch("missing"); err == nil {
+		t.Error("Expected error touching a missing session")
+	}
+}
+
+func TestManager_TouchScopedOverride(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+
+	for _, name := range []string{"default", "frontend"} {
+		if _, err := manager.Create(name, "", ""); err != nil {
+			t.Fatalf("Failed to create session %s: %v", name, err)
+		}
+	}
+	if err := manager.Activate("default"); err != nil {
+		t.Fatalf("Failed to activate session: %v", err)
+	}
+
+	scoped := NewManager(tmpDir)
+	scoped.SetScope("apps/frontend")
+	if err := scoped.ActivateHere("frontend"); err != nil {
+		t.Fatalf("ActivateHere() error = %v", err)
+	}
+	if err := scoped.Touch("frontend"); err != nil {
+		t.Fatalf("Touch() error = %v", err)
+	}
+
+	// The project-wide pointer is left alone when a scoped override applies
+	active, err := manager.GetActive()
+	if err != nil || active == nil || active.Name != "default" {
+		t.Errorf("Expected project-wide active session 'default', got %v (err %v)", active, err)
+	}
+}
@@ -0,0 +1,130 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ScopeEnvVar holds the working directory's path relative to the project root
+// when servo is run from a subdirectory of a project
+const ScopeEnvVar = "SERVO_SCOPE"
+
+// scopedActiveDir holds per-directory active session overrides, one
+// <relpath>/active_session file per scoped directory
+const scopedActiveDir = "active_session.d"
+
+// normalizeScope cleans a relative scope path, returning "" for the project root
+func normalizeScope(scope string) string {
+	scope = path.Clean(filepath.ToSlash(scope))
+	if scope == "." || scope == "/" || strings.HasPrefix(scope, "../") || scope == ".." {
+		return ""
+	}
+	return strings.TrimPrefix(scope, "/")
+}
+
+// Scope returns the directory scope used to resolve the active session
+func (m *Manager) Scope() string {
+	return m.scope
+}
+
+// SetScope sets the directory scope, relative to the project root, used to resolve the active session
+func (m *Manager) SetScope(scope string) {
+	m.scope = normalizeScope(scope)
+}
+
+// ActivateHere makes a session active for the current directory scope only.
+// At the project root this is the same as Activate.
+func (m *Manager) ActivateHere(name string) error {
+	if m.scope == "" {
+		return m.Activate(name)
+	}
+
+	if name == "" {
+		return fmt.Errorf("session name cannot be empty")
+	}
+
+	if _, err := m.Get(name); err != nil {
+		return fmt.Errorf("session '%s' does not exist: %w", name, err)
+	}
+
+	scopeFile := m.scopedActiveFile(m.scope)
+	if err := os.MkdirAll(filepath.Dir(scopeFile), 0755); err != nil {
+		return fmt.Errorf("failed to create scoped session directory: %w", err)
+	}
+	if err := os.WriteFile(scopeFile, []byte(name), 0644); err != nil {
+		return fmt.Errorf("failed to write scoped active session: %w", err)
+	}
+
+	return nil
+}
+
+// ClearHere removes the active session override for the current directory scope
+func (m *Manager) ClearHere() error {
+	if m.scope == "" {
+		return nil
+	}
+
+	if err := os.Remove(m.scopedActiveFile(m.scope)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to clear scoped active session: %w", err)
+	}
+	return nil
+}
+
+// scopedActiveSession returns the nearest directory override for the current
+// scope, walking up towards the project root, and the scope that set it.
+// The name is "" when no override applies.
+func (m *Manager) scopedActiveSession() (name string, scope string, err error) {
+	for scope := m.scope; scope != "" && scope != "."; scope = path.Dir(scope) {
+		data, err := os.ReadFile(m.scopedActiveFile(scope))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", "", fmt.Errorf("failed to read scoped active session: %w", err)
+		}
+
+		if name := strings.TrimSpace(string(data)); name != "" {
+			return name, scope, nil
+		}
+	}
+	return "", "", nil
+}
+
+// scopedActiveFile returns the override file for a directory scope
+func (m *Manager) scopedActiveFile(scope string) string {
+	return filepath.Join(m.servoDir, scopedActiveDir, filepath.FromSlash(scope), "active_session")
+}
+
+// updateScopedReferences rewrites directory overrides naming oldName to newName,
+// or removes them when newName is empty
+func (m *Manager) updateScopedReferences(oldName, newName string) error {
+	root := filepath.Join(m.servoDir, scopedActiveDir)
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || info.Name() != "active_session" {
+			return nil
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(string(data)) != oldName {
+			return nil
+		}
+
+		if newName == "" {
+			return os.Remove(p)
+		}
+		return os.WriteFile(p, []byte(newName), 0644)
+	})
+}
@@ -0,0 +1,126 @@
+package session
+
+import (
+	"testing"
+)
+
+func TestManager_ScopedActiveSession(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+
+	for _, name := range []string{"default", "frontend", "backend"} {
+		if _, err := manager.Create(name, "", ""); err != nil {
+			t.Fatalf("Failed to create session %s: %v", name, err)
+		}
+	}
+	if err := manager.Activate("default"); err != nil {
+		t.Fatalf("Failed to activate session: %v", err)
+	}
+
+	frontend := NewManager(tmpDir)
+	frontend.SetScope("apps/frontend")
+	if err := frontend.ActivateHere("frontend"); err != nil {
+		t.Fatalf("ActivateHere() error = %v", err)
+	}
+
+	backend := NewManager(tmpDir)
+	backend.SetScope("apps/backend/")
+	if err := backend.ActivateHere("backend"); err != nil {
+		t.Fatalf("ActivateHere() error = %v", err)
+	}
+
+	tests := []struct {
+		scope    string
+		expected string
+	}{
+		{scope: "", expected: "default"},
+		{scope: "apps", expected: "default"},
+		{scope: "apps/frontend", expected: "frontend"},
+		{scope: "apps/frontend/src/components", expected: "frontend"},
+		{scope: "./apps/backend", expected: "backend"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			m := NewManager(tmpDir)
+			m.SetScope(tt.scope)
+			active, err := m.GetActive()
+			if err != nil {
+				t.Fatalf("GetActive() error = %v", err)
+			}
+			if active == nil || active.Name != tt.expected {
+				t.Errorf("Expected active session '%s', got %v", tt.expected, active)
+			}
+		})
+	}
+
+	// Scoped activation leaves the project-wide session untouched
+	global, err := manager.Get("default")
+	if err != nil || !global.Active {
+		t.Errorf("Expected 'default' to remain the project-wide active session")
+	}
+
+	if err := frontend.ClearHere(); err != nil {
+		t.Fatalf("ClearHere() error = %v", err)
+	}
+	active, _ := frontend.GetActive()
+	if active == nil || active.Name != "default" {
+		t.Errorf("Expected fallback to 'default' after ClearHere, got %v", active)
+	}
+}
+
+func TestManager_ScopedActiveSessionRenameAndDelete(t *testing.T) {
+	manager, tmpDir := setupTestManager(t)
+
+	for _, name := range []string{"default", "backend"} {
+		if _, err := manager.Create(name, "", ""); err != nil {
+			t.Fatalf("Failed to create session %s: %v", name, err)
+		}
+	}
+	if err := manager.Activate("default"); err != nil {
+		t.Fatalf("Failed to activate session: %v", err)
+	}
+
+	scoped := NewManager(tmpDir)
+	scoped.SetScope("services/api")
+	if err := scoped.ActivateHere("backend"); err != nil {
+		t.Fatalf("ActivateHere() error = %v", err)
+	}
+
+	if err := manager.Rename("backend", "api"); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	active, err := scoped.GetActive()
+	if err != nil || active == nil || active.Name != "api" {
+		t.Fatalf("Expected scoped session to follow rename to 'api', got %v (err %v)", active, err)
+	}
+
+	if err := scoped.Delete("api"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	active, err = scoped.GetActive()
+	if err != nil || active == nil || active.Name != "default" {
+		t.Errorf("Expected fallback to 'default' after delete, got %v (err %v)", active, err)
+	}
+}
+
+func TestManager_ActivateHereAtRoot(t *testing.T) {
+	manager, _ := setupTestManager(t)
+
+	if _, err := manager.Create("dev", "", ""); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := manager.ActivateHere("dev"); err != nil {
+		t.Fatalf("ActivateHere() error = %v", err)
+	}
+
+	active, err := manager.GetActive()
+	if err != nil || active == nil || active.Name != "dev" {
+		t.Errorf("Expected 'dev' active project-wide, got %v (err %v)", active, err)
+	}
+
+	scoped := NewManager(manager.servoDir)
+	scoped.SetScope("pkg")
+	if err := scoped.ActivateHere("missing"); err == nil {
+		t.Error("Expected error activating a missing session")
+	}
+}
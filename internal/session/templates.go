@@ -0,0 +1,187 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/servo/servo/internal/utils"
+)
+
+// templateFileName is the metadata file stored at the root of each template
+const templateFileName = "template.yaml"
+
+// templateContents lists the session entries captured in a template.
+// Secrets, volumes and logs are intentionally excluded.
+var templateContents = []string{"manifests", "config", "config.yaml"}
+
+// Template describes a reusable session layout saved under .servo/templates/
+type Template struct {
+	Name          string    `yaml:"name" json:"name"`
+	Description   string    `yaml:"description,omitempty" json:"description,omitempty"`
+	SourceSession string    `yaml:"source_session,omitempty" json:"source_session,omitempty"`
+	CreatedAt     time.Time `yaml:"created_at" json:"created_at"`
+	Profiles      []string  `yaml:"profiles,omitempty" json:"profiles,omitempty"`
+}
+
+// SaveTemplate captures a session's manifests and configuration as a named template
+func (m *Manager) SaveTemplate(sessionName, templateName string) (*Template, error) {
+	if err := validateTemplateName(templateName); err != nil {
+		return nil, err
+	}
+
+	source, err := m.Get(sessionName)
+	if err != nil {
+		return nil, fmt.Errorf("source session '%s' does not exist: %w", sessionName, err)
+	}
+
+	templateDir := m.getTemplateDir(templateName)
+	if _, err := os.Stat(templateDir); err == nil {
+		return nil, fmt.Errorf("template '%s' already exists", templateName)
+	}
+
+	if err := os.MkdirAll(templateDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create template directory: %w", err)
+	}
+
+	if err := copySessionLayout(m.getSessionDir(sessionName), templateDir); err != nil {
+		os.RemoveAll(templateDir)
+		return nil, fmt.Errorf("failed to copy session layout: %w", err)
+	}
+
+	template := &Template{
+		Name:          templateName,
+		Description:   source.Description,
+		SourceSession: sessionName,
+		CreatedAt:     time.Now(),
+		Profiles:      source.Profiles,
+	}
+
+	if err := utils.WriteYAMLFile(filepath.Join(templateDir, templateFileName), template); err != nil {
+		os.RemoveAll(templateDir)
+		return nil, fmt.Errorf("failed to save template: %w", err)
+	}
+
+	return template, nil
+}
+
+// GetTemplate retrieves a template by name
+func (m *Manager) GetTemplate(templateName string) (*Template, error) {
+	if err := validateTemplateName(templateName); err != nil {
+		return nil, err
+	}
+
+	templateFile := filepath.Join(m.getTemplateDir(templateName), templateFileName)
+	if _, err := os.Stat(templateFile); os.IsNotExist(err) {
+		return nil, fmt.Errorf("template '%s' does not exist", templateName)
+	}
+
+	var template Template
+	if err := utils.ReadYAMLFile(templateFile, &template); err != nil {
+		return nil, fmt.Errorf("failed to read template '%s': %w", templateName, err)
+	}
+
+	return &template, nil
+}
+
+// ListTemplates returns all saved templates sorted by name
+func (m *Manager) ListTemplates() ([]*Template, error) {
+	entries, err := os.ReadDir(filepath.Join(m.servoDir, "templates"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Template{}, nil
+		}
+		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+	}
+
+	var templates []*Template
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		template, err := m.GetTemplate(entry.Name())
+		if err != nil {
+			continue // Skip directories that are not valid templates
+		}
+		templates = append(templates, template)
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+
+	return templates, nil
+}
+
+// CreateFromTemplate creates a new session populated with a template's manifests and configuration
+func (m *Manager) CreateFromTemplate(templateName, sessionName, description string) (*Session, error) {
+	template, err := m.GetTemplate(templateName)
+	if err != nil {
+		return nil, err
+	}
+
+	if description == "" {
+		description = template.Description
+	}
+
+	session, err := m.Create(sessionName, description, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	if err := copySessionLayout(m.getTemplateDir(templateName), m.getSessionDir(sessionName)); err != nil {
+		m.Delete(sessionName)
+		return nil, fmt.Errorf("failed to copy template layout: %w", err)
+	}
+
+	if len(template.Profiles) > 0 {
+		session.Profiles = append([]string(nil), template.Profiles...)
+		if err := m.saveSession(session); err != nil {
+			return nil, fmt.Errorf("failed to save session: %w", err)
+		}
+	}
+
+	return session, nil
+}
+
+// getTemplateDir returns the directory holding a named template
+func (m *Manager) getTemplateDir(name string) string {
+	return filepath.Join(m.servoDir, "templates", name)
+}
+
+// copySessionLayout copies the template-able entries of a session layout between directories
+func copySessionLayout(srcDir, dstDir string) error {
+	for _, entry := range templateContents {
+		src := filepath.Join(srcDir, entry)
+		info, err := os.Stat(src)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		dst := filepath.Join(dstDir, entry)
+		if info.IsDir() {
+			err = copyDir(src, dst)
+		} else {
+			err = copyFile(src, dst)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to copy %s: %w", entry, err)
+		}
+	}
+	return nil
+}
+
+// validateTemplateName rejects names that would escape the templates directory
+func validateTemplateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("template name cannot be empty")
+	}
+	if name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid template name '%s'", name)
+	}
+	return nil
+}
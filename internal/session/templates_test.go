@@ -0,0 +1,122 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestManager_SaveTemplateAndCreateFromTemplate(t *testing.T) {
+	manager, _ := setupTestManager(t)
+
+	source, err := manager.Create("dev", "Development session", "")
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	source.Profiles = []string{"debug"}
+	if err := manager.SaveSession(source); err != nil {
+		t.Fatalf("Failed to save session: %v", err)
+	}
+
+	sourceDir := manager.GetSessionDir("dev")
+	files := map[string]string{
+		"manifests/graphiti.servo": "name: graphiti\n",
+		"config/overrides.yaml":    "services: {}\n",
+		"volumes/neo4j/data.db":    "volume-data",
+		"logs/server.log":          "log line",
+		"secrets.yaml":             "secret: c2VjcmV0",
+	}
+	for path, content := range files {
+		full := filepath.Join(sourceDir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	template, err := manager.SaveTemplate("dev", "baseline")
+	if err != nil {
+		t.Fatalf("SaveTemplate() error = %v", err)
+	}
+	if template.SourceSession != "dev" {
+		t.Errorf("Expected source session 'dev', got '%s'", template.SourceSession)
+	}
+
+	if _, err := manager.SaveTemplate("dev", "baseline"); err == nil {
+		t.Error("Expected error when saving a duplicate template")
+	}
+
+	session, err := manager.CreateFromTemplate("baseline", "feature", "")
+	if err != nil {
+		t.Fatalf("CreateFromTemplate() error = %v", err)
+	}
+	if session.Description != "Development session" {
+		t.Errorf("Expected description from template, got '%s'", session.Description)
+	}
+	if len(session.Profiles) != 1 || session.Profiles[0] != "debug" {
+		t.Errorf("Expected profiles [debug], got %v", session.Profiles)
+	}
+
+	targetDir := manager.GetSessionDir("feature")
+	for _, path := range []string{"manifests/graphiti.servo", "config/overrides.yaml"} {
+		if _, err := os.Stat(filepath.Join(targetDir, path)); err != nil {
+			t.Errorf("Expected %s to be copied: %v", path, err)
+		}
+	}
+	for _, path := range []string{"volumes/neo4j/data.db", "logs/server.log", "secrets.yaml"} {
+		if _, err := os.Stat(filepath.Join(targetDir, path)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s not to be copied", path)
+		}
+	}
+
+	if _, err := manager.CreateFromTemplate("baseline", "feature", ""); err == nil {
+		t.Error("Expected error when target session already exists")
+	}
+}
+
+func TestManager_ListTemplates(t *testing.T) {
+	manager, _ := setupTestManager(t)
+
+	templates, err := manager.ListTemplates()
+	if err != nil {
+		t.Fatalf("ListTemplates() error = %v", err)
+	}
+	if len(templates) != 0 {
+		t.Errorf("Expected no templates, got %d", len(templates))
+	}
+
+	if _, err := manager.Create("dev", "", ""); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	for _, name := range []string{"zeta", "alpha"} {
+		if _, err := manager.SaveTemplate("dev", name); err != nil {
+			t.Fatalf("SaveTemplate(%s) error = %v", name, err)
+		}
+	}
+
+	templates, err = manager.ListTemplates()
+	if err != nil {
+		t.Fatalf("ListTemplates() error = %v", err)
+	}
+	if len(templates) != 2 || templates[0].Name != "alpha" || templates[1].Name != "zeta" {
+		t.Errorf("Expected sorted templates [alpha zeta], got %v", templates)
+	}
+}
+
+func TestManager_TemplateErrors(t *testing.T) {
+	manager, _ := setupTestManager(t)
+
+	if _, err := manager.SaveTemplate("missing", "baseline"); err == nil {
+		t.Error("Expected error saving template from missing session")
+	}
+	if _, err := manager.CreateFromTemplate("missing", "feature", ""); err == nil {
+		t.Error("Expected error creating session from missing template")
+	}
+	for _, name := range []string{"", "..", "a/b"} {
+		if _, err := manager.SaveTemplate("dev", name); err == nil {
+			t.Errorf("Expected error for template name '%s'", name)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/servo/servo/internal/mcp"
+)
+
+// Verify checks that a session is structurally sound: its session.yaml parses and
+// names the session, its volume path is an accessible directory, and every manifest
+// in manifests/ parses and validates. It returns the first problem found, naming the
+// file or directory to fix.
+func (m *Manager) Verify(name string) error {
+	session, err := m.Get(name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", filepath.Join(m.getSessionDir(name), "session.yaml"), err)
+	}
+	if session.Name != name {
+		return fmt.Errorf("%s names session '%s' but is stored under '%s'; set name: %s",
+			filepath.Join(m.getSessionDir(name), "session.yaml"), session.Name, name, name)
+	}
+
+	if session.VolumePath != "" {
+		info, err := os.Stat(session.VolumePath)
+		switch {
+		case os.IsNotExist(err):
+			return fmt.Errorf("volume path %s does not exist; create it or change volume_path in session.yaml", session.VolumePath)
+		case err != nil:
+			return fmt.Errorf("volume path %s is not accessible: %w", session.VolumePath, err)
+		case !info.IsDir():
+			return fmt.Errorf("volume path %s is not a directory", session.VolumePath)
+		}
+		if _, err := os.ReadDir(session.VolumePath); err != nil {
+			return fmt.Errorf("volume path %s is not accessible: %w", session.VolumePath, err)
+		}
+	}
+
+	manifestDir := m.ManifestsDir(name)
+	entries, err := os.ReadDir(manifestDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read manifests directory %s: %w", manifestDir, err)
+	}
+
+	var manifestFiles []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".servo") {
+			manifestFiles = append(manifestFiles, filepath.Join(manifestDir, entry.Name()))
+		}
+	}
+	sort.Strings(manifestFiles)
+
+	parser := mcp.NewParser()
+	validator := mcp.NewValidator()
+	for _, manifestFile := range manifestFiles {
+		servoFile, err := parser.ParseFromFile(manifestFile)
+		if err != nil {
+			return fmt.Errorf("manifest %s does not parse: %w", manifestFile, err)
+		}
+		if err := validator.Validate(servoFile); err != nil {
+			return fmt.Errorf("manifest %s is invalid: %w", manifestFile, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestManager_Verify(t *testing.T) {
+	manager, _ := setupTestManager(t)
+
+	if _, err := manager.Create("dev", "", ""); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	if err := manager.Verify("dev"); err != nil {
+		t.Fatalf("expected a fresh session to verify, got %v", err)
+	}
+
+	manifestDir := filepath.Join(manager.getSessionDir("dev"), "manifests")
+	valid := `servo_version: "1.0"
+name: search
+install:
+  type: local
+  method: local
+  setup_commands:
+    - "true"
+server:
+  transport: stdio
+  command: search-mcp
+  args: ["--stdio"]
+`
+	os.WriteFile(filepath.Join(manifestDir, "search.servo"), []byte(valid), 0644)
+	if err := manager.Verify("dev"); err != nil {
+		t.Errorf("expected a valid manifest to verify, got %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		corrupt func()
+		restore func()
+		want    string
+	}{
+		{
+			name: "invalid manifest",
+			corrupt: func() {
+				os.WriteFile(filepath.Join(manifestDir, "broken.servo"), []byte("servo_version: \"1.0\"\nname: broken\n"), 0644)
+			},
+			restore: func() { os.Remove(filepath.Join(manifestDir, "broken.servo")) },
+			want:    "broken.servo is invalid",
+		},
+		{
+			name:    "unparseable manifest",
+			corrupt: func() { os.WriteFile(filepath.Join(manifestDir, "garbled.servo"), []byte("name: [unclosed\n"), 0644) },
+			restore: func() { os.Remove(filepath.Join(manifestDir, "garbled.servo")) },
+			want:    "garbled.servo does not parse",
+		},
+		{
+			name:    "missing volume path",
+			corrupt: func() { os.RemoveAll(filepath.Join(manager.getSessionDir("dev"), "volumes")) },
+			restore: func() { os.MkdirAll(filepath.Join(manager.getSessionDir("dev"), "volumes"), 0755) },
+			want:    "volumes does not exist",
+		},
+		{
+			name: "corrupt session file",
+			corrupt: func() {
+				os.WriteFile(filepath.Join(manager.getSessionDir("dev"), "session.yaml"), []byte("name: [unclosed\n"), 0644)
+			},
+			want: "session.yaml: failed to parse session file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.corrupt()
+			err := manager.Verify("dev")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Verify() = %v, want error containing %q", err, tt.want)
+			}
+			if tt.restore != nil {
+				tt.restore()
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/servo/servo/internal/constants"
 	"github.com/servo/servo/internal/errors"
+	"github.com/servo/servo/pkg"
 )
 
 // Validator interface for consistent validation across the codebase
@@ -152,7 +153,7 @@ func ValidateServoVersion(version string) *errors.ServoError {
 		return errors.RequiredFieldError("servo_version")
 	}
 	
-	supportedVersions := []string{constants.DefaultServoVersion}
+	supportedVersions := pkg.SupportedServoVersions
 	for _, supported := range supportedVersions {
 		if version == supported {
 			return nil
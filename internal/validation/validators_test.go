@@ -175,6 +175,7 @@ func TestValidateServoVersion(t *testing.T) {
 		wantError bool
 	}{
 		{"supported version", constants.DefaultServoVersion, false},
+		{"newer supported version", "1.1", false},
 		{"empty version", "", true},
 		{"unsupported version", "2.0", true},
 		{"invalid format", "1.0.0", true},
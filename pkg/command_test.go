@@ -0,0 +1,67 @@
+package pkg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "simple", input: "go run main.go", want: []string{"go", "run", "main.go"}},
+		{name: "extra whitespace", input: "  go\trun   main.go ", want: []string{"go", "run", "main.go"}},
+		{name: "single quotes", input: "node server.js --title 'my server'", want: []string{"node", "server.js", "--title", "my server"}},
+		{name: "double quotes with escape", input: `echo "say \"hi\""`, want: []string{"echo", `say "hi"`}},
+		{name: "backslash space", input: `/opt/my\ tools/server --flag`, want: []string{"/opt/my tools/server", "--flag"}},
+		{name: "empty quoted arg", input: `cmd ''`, want: []string{"cmd", ""}},
+		{name: "unterminated quote", input: `cmd "open`, wantErr: true},
+		{name: "trailing backslash", input: `cmd \`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitCommandLine(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("SplitCommandLine(%q) expected error, got %v", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SplitCommandLine(%q) error = %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitCommandLine(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_Argv(t *testing.T) {
+	tests := []struct {
+		name   string
+		server Server
+		want   []string
+	}{
+		{name: "command and args", server: Server{Command: "go", Args: []string{"run", "main.go"}}, want: []string{"go", "run", "main.go"}},
+		{name: "command string", server: Server{Command: "go run main.go"}, want: []string{"go", "run", "main.go"}},
+		{name: "args keep spaces", server: Server{Command: "node", Args: []string{"--title", "my server"}}, want: []string{"node", "--title", "my server"}},
+		{name: "single word", server: Server{Command: "server"}, want: []string{"server"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.server.Argv()
+			if err != nil {
+				t.Fatalf("Argv() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Argv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
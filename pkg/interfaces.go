@@ -59,6 +59,10 @@ type Client interface {
 	// Configuration is written directly to the client's config files
 	GenerateConfig(manifests []ServoDefinition, secretsProvider func(string) (string, error)) error
 
+	// RenderConfig returns the configuration GenerateConfig would write, without touching disk
+	// Unresolved secret placeholders are kept as ${NAME}, as in the written file
+	RenderConfig(manifests []ServoDefinition, secretsProvider func(string) (string, error)) ([]byte, error)
+
 	// RequiresRestart returns true if the client must be restarted to apply config changes
 	RequiresRestart() bool
 
@@ -74,6 +78,28 @@ type Client interface {
 	SupportsDevcontainers() bool
 }
 
+// EnvFileClient is implemented by clients whose server entries can load environment
+// variables from a file next to the client config. Servo uses it when the project
+// sets config.client_env_files.
+type EnvFileClient interface {
+	// GenerateConfigWithEnvFile writes the client config like GenerateConfig, but moves
+	// each server's secret-referencing environment variables into the env file. The
+	// env file holds secret references only, never secret values.
+	GenerateConfigWithEnvFile(manifests []ServoDefinition, secretsProvider func(string) (string, error)) error
+}
+
+// GlobalConfigClient is implemented by clients with a user-level config file shared by
+// every project, such as ~/.cursor/mcp.json. Servo merges globally installed servers
+// into it.
+type GlobalConfigClient interface {
+	// GlobalConfigPath returns the user-level config file
+	GlobalConfigPath() (string, error)
+
+	// MergeGlobalConfig writes the servers built from manifests into the user-level
+	// config, replacing entries of the same name and keeping every other entry and key
+	MergeGlobalConfig(manifests []ServoDefinition, secretsProvider func(string) (string, error)) error
+}
+
 // ClientRegistry manages available client plugins and provides discovery capabilities.
 //
 // The registry maintains a collection of registered MCP clients and supports
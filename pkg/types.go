@@ -2,6 +2,8 @@ package pkg
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -16,6 +18,7 @@ var (
 // ServoDefinition represents a complete .servo file
 type ServoDefinition struct {
 	ServoVersion        string                        `yaml:"servo_version" json:"servo_version"`
+	ID                  string                        `yaml:"id,omitempty" json:"id,omitempty"`
 	Name                string                        `yaml:"name" json:"name"`
 	Version             string                        `yaml:"version,omitempty" json:"version,omitempty"`
 	Description         string                        `yaml:"description,omitempty" json:"description,omitempty"`
@@ -25,11 +28,14 @@ type ServoDefinition struct {
 	Requirements        *Requirements                 `yaml:"requirements,omitempty" json:"requirements,omitempty"`
 	Install             Install                       `yaml:"install" json:"install"`
 	Dependencies        *Dependencies                 `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
+	DependsOn           []string                      `yaml:"depends_on,omitempty" json:"depends_on,omitempty"` // Servers that must be installed in the same session
 	ConfigurationSchema *ConfigurationSchema          `yaml:"configuration_schema,omitempty" json:"configuration_schema,omitempty"`
 	Server              Server                        `yaml:"server" json:"server"`
 	Services            map[string]*ServiceDependency `yaml:"services,omitempty" json:"services,omitempty"`
 	Clients             *ClientInfo                   `yaml:"clients,omitempty" json:"clients,omitempty"`
 	Documentation       *Documentation                `yaml:"documentation,omitempty" json:"documentation,omitempty"`
+	Capabilities        *Capabilities                 `yaml:"capabilities,omitempty" json:"capabilities,omitempty"` // What the server offers, for browsing; descriptive only
+	PostInstallMessage  string                        `yaml:"post_install_message,omitempty" json:"post_install_message,omitempty"`
 }
 
 // Metadata contains optional package metadata
@@ -37,6 +43,23 @@ type Metadata struct {
 	Homepage   string   `yaml:"homepage,omitempty" json:"homepage,omitempty"`
 	Repository string   `yaml:"repository,omitempty" json:"repository,omitempty"`
 	Tags       []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+
+	// Deprecated: older manifests kept these under metadata. Normalize moves them
+	// to the top-level fields.
+	LegacyName        string `yaml:"name,omitempty" json:"name,omitempty"`
+	LegacyVersion     string `yaml:"version,omitempty" json:"version,omitempty"`
+	LegacyDescription string `yaml:"description,omitempty" json:"description,omitempty"`
+	LegacyAuthor      string `yaml:"author,omitempty" json:"author,omitempty"`
+	LegacyLicense     string `yaml:"license,omitempty" json:"license,omitempty"`
+}
+
+// Capabilities advertises the tools, resources and prompts a server provides. The
+// names are free-form and only shown to users; servo does not check them against
+// the running server.
+type Capabilities struct {
+	Tools     []string `yaml:"tools,omitempty" json:"tools,omitempty"`
+	Resources []string `yaml:"resources,omitempty" json:"resources,omitempty"`
+	Prompts   []string `yaml:"prompts,omitempty" json:"prompts,omitempty"`
 }
 
 // Requirements defines system and runtime requirements
@@ -83,9 +106,50 @@ type ServiceDependency struct {
 	Environment          map[string]string `yaml:"environment,omitempty" json:"environment,omitempty"`
 	Volumes              []string          `yaml:"volumes,omitempty" json:"volumes,omitempty"`
 	Command              []string          `yaml:"command,omitempty" json:"command,omitempty"`
+	User                 string            `yaml:"user,omitempty" json:"user,omitempty"`             // Container user as uid[:gid] or name[:group]
+	Entrypoint           interface{}       `yaml:"entrypoint,omitempty" json:"entrypoint,omitempty"` // Entrypoint override as a command string or argv list
 	HealthCheck          *HealthCheck      `yaml:"healthcheck,omitempty" json:"healthcheck,omitempty"`
 	AutoGeneratePassword bool              `yaml:"auto_generate_password,omitempty" json:"auto_generate_password,omitempty"`
 	Shared               bool              `yaml:"shared,omitempty" json:"shared,omitempty"`
+	Profiles             []string          `yaml:"profiles,omitempty" json:"profiles,omitempty"`     // Compose profiles gating this service; empty means always started
+	DependsOn            []string          `yaml:"depends_on,omitempty" json:"depends_on,omitempty"` // Services that must be started (or healthy) first
+	Init                 bool              `yaml:"init,omitempty" json:"init,omitempty"`             // Run an init process as PID 1 to forward signals and reap zombies
+	TTY                  bool              `yaml:"tty,omitempty" json:"tty,omitempty"`               // Allocate a pseudo-TTY
+	StdinOpen            bool              `yaml:"stdin_open,omitempty" json:"stdin_open,omitempty"` // Keep stdin open for interactive debugging
+}
+
+// EntrypointValue returns the entrypoint as a string or []string for the compose file,
+// or nil when none is set. Any other shape, or an empty string or list, is an error.
+func (s *ServiceDependency) EntrypointValue() (interface{}, error) {
+	switch entrypoint := s.Entrypoint.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if strings.TrimSpace(entrypoint) == "" {
+			return nil, fmt.Errorf("entrypoint cannot be empty")
+		}
+		return entrypoint, nil
+	case []string:
+		if len(entrypoint) == 0 {
+			return nil, fmt.Errorf("entrypoint cannot be empty")
+		}
+		return entrypoint, nil
+	case []interface{}:
+		if len(entrypoint) == 0 {
+			return nil, fmt.Errorf("entrypoint cannot be empty")
+		}
+		argv := make([]string, len(entrypoint))
+		for i, arg := range entrypoint {
+			str, ok := arg.(string)
+			if !ok {
+				return nil, fmt.Errorf("entrypoint entries must be strings, got %T", arg)
+			}
+			argv[i] = str
+		}
+		return argv, nil
+	default:
+		return nil, fmt.Errorf("entrypoint must be a string or a list of strings, got %T", s.Entrypoint)
+	}
 }
 
 // HealthCheck defines service health check configuration
@@ -110,6 +174,18 @@ type SecretSchema struct {
 	Validation  string `yaml:"validation,omitempty" json:"validation,omitempty"`
 	Prompt      string `yaml:"prompt,omitempty" json:"prompt,omitempty"`
 	EnvVar      string `yaml:"env_var" json:"env_var"`
+	// Targets maps a client or service name to the env var that target reads the
+	// secret from, for targets that expect a name other than EnvVar
+	Targets map[string]string `yaml:"targets,omitempty" json:"targets,omitempty"`
+}
+
+// EnvVarFor returns the env var a target reads the secret from: its Targets entry,
+// or EnvVar
+func (s SecretSchema) EnvVarFor(target string) string {
+	if envVar, ok := s.Targets[target]; ok && envVar != "" {
+		return envVar
+	}
+	return s.EnvVar
 }
 
 // ConfigSchema defines a configuration field
@@ -123,6 +199,37 @@ type ConfigSchema struct {
 	EnvVar      string        `yaml:"env_var" json:"env_var"`
 }
 
+// ValidateValue checks a value for a select or multiselect field against the declared
+// options. Multiselect values are comma-separated and each element is checked; other
+// field types accept any value.
+func (c *ConfigSchema) ValidateValue(value string) error {
+	var values []string
+	switch c.Type {
+	case "select":
+		values = []string{value}
+	case "multiselect":
+		for _, v := range strings.Split(value, ",") {
+			values = append(values, strings.TrimSpace(v))
+		}
+	default:
+		return nil
+	}
+
+	allowed := make(map[string]bool, len(c.Options))
+	choices := make([]string, len(c.Options))
+	for i, option := range c.Options {
+		choices[i] = fmt.Sprint(option)
+		allowed[choices[i]] = true
+	}
+
+	for _, v := range values {
+		if !allowed[v] {
+			return fmt.Errorf("invalid value '%s' (valid options: %s)", v, strings.Join(choices, ", "))
+		}
+	}
+	return nil
+}
+
 // Server defines server execution configuration
 type Server struct {
 	Transport        string            `yaml:"transport" json:"transport"`
@@ -131,6 +238,31 @@ type Server struct {
 	Environment      map[string]string `yaml:"environment,omitempty" json:"environment,omitempty"`
 	WorkingDirectory string            `yaml:"working_directory,omitempty" json:"working_directory,omitempty"`
 	Timeout          string            `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	URL              string            `yaml:"url,omitempty" json:"url,omitempty"`         // Endpoint for http/sse transports
+	Headers          map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"` // Request headers for http/sse transports
+}
+
+// IsRemote reports whether the server is reached over the network rather than launched locally
+func (s *Server) IsRemote() bool {
+	return s.Transport == "http" || s.Transport == "sse"
+}
+
+// Argv returns the canonical argv for a stdio server: the command followed by its args.
+// A command given as a single shell-style string with no args, such as
+// "go run main.go", is split into separate arguments.
+func (s *Server) Argv() ([]string, error) {
+	if len(s.Args) > 0 || !strings.ContainsAny(strings.TrimSpace(s.Command), " \t\n'\"\\") {
+		return append([]string{s.Command}, s.Args...), nil
+	}
+
+	argv, err := SplitCommandLine(s.Command)
+	if err != nil {
+		return nil, err
+	}
+	if len(argv) == 0 {
+		return nil, fmt.Errorf("server command is empty")
+	}
+	return argv, nil
 }
 
 // ClientInfo contains client compatibility information
@@ -219,10 +351,14 @@ type MCPConfig struct {
 
 // MCPServerConfig represents an individual MCP server configuration
 type MCPServerConfig struct {
-	Command          string            `json:"command" yaml:"command"`
+	Type             string            `json:"type,omitempty" yaml:"type,omitempty"`
+	Command          string            `json:"command,omitempty" yaml:"command,omitempty"`
 	Args             []string          `json:"args,omitempty" yaml:"args,omitempty"`
 	Environment      map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
 	WorkingDirectory string            `json:"cwd,omitempty" yaml:"cwd,omitempty"`
+	URL              string            `json:"url,omitempty" yaml:"url,omitempty"`
+	Headers          map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	EnvFile          string            `json:"envFile,omitempty" yaml:"envFile,omitempty"` // File the client loads further environment from
 }
 
 // ClientScope represents a configuration scope for a client
@@ -295,6 +431,114 @@ type TemplateContext struct {
 	ConfigPath  string                       `json:"config_path"`
 }
 
+// Normalize migrates a manifest written with the legacy layout, where name, version,
+// description, author and license lived under metadata. When the top-level name is
+// missing, each legacy value fills its empty top-level field; the legacy fields are
+// cleared either way.
+func (s *ServoDefinition) Normalize() {
+	if s.Metadata == nil {
+		return
+	}
+
+	m := s.Metadata
+	if s.Name == "" {
+		migrate := func(field *string, legacy string) {
+			if *field == "" && legacy != "" {
+				*field = legacy
+			}
+		}
+		migrate(&s.Name, m.LegacyName)
+		migrate(&s.Version, m.LegacyVersion)
+		migrate(&s.Description, m.LegacyDescription)
+		migrate(&s.Author, m.LegacyAuthor)
+		migrate(&s.License, m.LegacyLicense)
+	}
+
+	m.LegacyName, m.LegacyVersion, m.LegacyDescription, m.LegacyAuthor, m.LegacyLicense = "", "", "", "", ""
+}
+
+// Key returns the key an installed manifest is stored and tracked under: the stable
+// id when the manifest declares one, so renaming the server keeps its identity, and
+// the name otherwise
+func (s *ServoDefinition) Key() string {
+	if s.ID != "" {
+		return s.ID
+	}
+	return s.Name
+}
+
+// AllServices returns the manifest's backing services from dependencies.services and
+// the top-level services as one map. When both declare the same name, the top-level
+// services entry wins; a nil top-level entry is ignored rather than hiding the other.
+// Entries from dependencies.services are copies, so changing them leaves the manifest
+// untouched.
+func (s *ServoDefinition) AllServices() map[string]*ServiceDependency {
+	services := make(map[string]*ServiceDependency)
+	if s == nil {
+		return services
+	}
+	if s.Dependencies != nil {
+		for name, service := range s.Dependencies.Services {
+			service := service
+			services[name] = &service
+		}
+	}
+	for name, service := range s.Services {
+		if service != nil {
+			services[name] = service
+		}
+	}
+	return services
+}
+
+// TargetEnvironment returns env as a client or service named target sees it. Each
+// secret whose targets map names target has its env_var entry moved to the mapped
+// name, or gains a ${secret} reference there when env has no env_var entry. env
+// itself is not modified, and is returned as is when no secret maps target.
+func (s *ServoDefinition) TargetEnvironment(target string, env map[string]string) map[string]string {
+	if s == nil || s.ConfigurationSchema == nil {
+		return env
+	}
+
+	renamed := make(map[string]string)
+	var moved []string
+	for name, secret := range s.ConfigurationSchema.Secrets {
+		envVar := secret.EnvVarFor(target)
+		if envVar == secret.EnvVar {
+			continue
+		}
+		value, ok := env[secret.EnvVar]
+		if !ok {
+			value = "${" + name + "}"
+		}
+		renamed[envVar] = value
+		moved = append(moved, secret.EnvVar)
+	}
+	if len(renamed) == 0 {
+		return env
+	}
+
+	result := make(map[string]string, len(env)+len(renamed))
+	for key, value := range env {
+		result[key] = value
+	}
+	for _, key := range moved {
+		delete(result, key)
+	}
+	for key, value := range renamed {
+		result[key] = value
+	}
+	return result
+}
+
+// ForClient returns a copy of the manifest whose server environment uses the env var
+// names its secrets map for the named client
+func (s *ServoDefinition) ForClient(client string) ServoDefinition {
+	def := *s
+	def.Server.Environment = s.TargetEnvironment(client, s.Server.Environment)
+	return def
+}
+
 // ToYAML converts a ServoDefinition to YAML format
 func (s *ServoDefinition) ToYAML() (string, error) {
 	data, err := yaml.Marshal(s)
@@ -1,8 +1,11 @@
 package pkg
 
 import (
+	"reflect"
 	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestClientScope_String(t *testing.T) {
@@ -222,3 +225,206 @@ func TestInstall_Fields(t *testing.T) {
 		t.Errorf("expected 2 test commands, got %d", len(install.TestCommands))
 	}
 }
+
+func TestConfigSchema_ValidateValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  ConfigSchema
+		value   string
+		wantErr bool
+	}{
+		{name: "select option", schema: ConfigSchema{Type: "select", Options: []interface{}{"debug", "info"}}, value: "info"},
+		{name: "select unknown", schema: ConfigSchema{Type: "select", Options: []interface{}{"debug", "info"}}, value: "trace", wantErr: true},
+		{name: "select numeric option", schema: ConfigSchema{Type: "select", Options: []interface{}{1, 2}}, value: "2"},
+		{name: "multiselect elements", schema: ConfigSchema{Type: "multiselect", Options: []interface{}{"a", "b", "c"}}, value: "a, c"},
+		{name: "multiselect unknown element", schema: ConfigSchema{Type: "multiselect", Options: []interface{}{"a", "b"}}, value: "a,z", wantErr: true},
+		{name: "string unrestricted", schema: ConfigSchema{Type: "string"}, value: "anything"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schema.ValidateValue(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateValue(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+
+	err := (&ConfigSchema{Type: "select", Options: []interface{}{"debug", "info"}}).ValidateValue("trace")
+	if err == nil || !strings.Contains(err.Error(), "debug, info") {
+		t.Errorf("Expected error to list valid options, got %v", err)
+	}
+}
+
+func TestServoDefinition_Normalize(t *testing.T) {
+	var legacy ServoDefinition
+	data := `servo_version: "1.0"
+metadata:
+  name: legacy-server
+  version: 1.2.0
+  author: Someone
+  homepage: https://example.com
+`
+	if err := yaml.Unmarshal([]byte(data), &legacy); err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	legacy.Normalize()
+	if legacy.Name != "legacy-server" || legacy.Version != "1.2.0" || legacy.Author != "Someone" {
+		t.Errorf("Legacy fields not migrated: %+v", legacy)
+	}
+	if legacy.Metadata.Homepage != "https://example.com" || legacy.Metadata.LegacyName != "" {
+		t.Errorf("Unexpected metadata after normalize: %+v", legacy.Metadata)
+	}
+
+	out, err := legacy.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	if strings.Contains(out, "  name:") {
+		t.Errorf("Legacy metadata should not be written back:\n%s", out)
+	}
+
+	// A top-level name wins and the legacy values are dropped
+	current := ServoDefinition{Name: "current", Metadata: &Metadata{LegacyName: "old", LegacyVersion: "0.1.0"}}
+	current.Normalize()
+	if current.Name != "current" || current.Version != "" || current.Metadata.LegacyName != "" {
+		t.Errorf("Unexpected normalize result: %+v", current)
+	}
+}
+
+func TestServoDefinition_Validate(t *testing.T) {
+	def := &ServoDefinition{
+		ServoVersion: "1.0",
+		Name:         "test-server",
+		Server:       Server{Transport: "stdio", Command: "node", Args: []string{"index.js"}},
+		Install:      Install{Type: "local", Method: "local", SetupCommands: []string{"npm install"}},
+	}
+	if err := def.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	def.Name = "Not Valid"
+	if err := def.Validate(); err == nil {
+		t.Error("Expected invalid name to fail validation")
+	}
+
+	var missing *ServoDefinition
+	if err := missing.Validate(); err == nil {
+		t.Error("Expected nil definition to fail validation")
+	}
+}
+
+func TestServoDefinition_AllServices(t *testing.T) {
+	var def ServoDefinition
+	data := `servo_version: "1.0"
+name: test-server
+server:
+  transport: stdio
+  command: node
+  args: [index.js]
+install:
+  type: local
+  method: local
+  setup_commands: ["npm install"]
+dependencies:
+  services:
+    db:
+      image: postgres:15
+    cache:
+      image: redis:7
+services:
+  db:
+    image: postgres:16
+  search:
+    image: elasticsearch:8
+`
+	if err := yaml.Unmarshal([]byte(data), &def); err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	services := def.AllServices()
+	if len(services) != 3 || services["cache"] == nil || services["search"] == nil {
+		t.Fatalf("AllServices() = %v, want db, cache and search", services)
+	}
+	// The top-level services entry wins a name both sections declare
+	if services["db"].Image != "postgres:16" {
+		t.Errorf("db image = %s, want the top-level postgres:16", services["db"].Image)
+	}
+
+	// Dependency entries are copies, so the manifest is left untouched
+	services["cache"].Image = "redis:8"
+	if def.Dependencies.Services["cache"].Image != "redis:7" {
+		t.Error("AllServices() should copy dependencies.services entries")
+	}
+
+	// Both sections are validated
+	if err := def.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	def.Services["search"].Image = ""
+	if err := def.Validate(); err == nil || !strings.Contains(err.Error(), "service search") {
+		t.Errorf("Validate() error = %v, want the top-level service checked", err)
+	}
+
+	var missing *ServoDefinition
+	if got := missing.AllServices(); len(got) != 0 {
+		t.Errorf("AllServices() of nil = %v", got)
+	}
+}
+
+func TestServoDefinition_TargetEnvironment(t *testing.T) {
+	def := &ServoDefinition{
+		Server: Server{Environment: map[string]string{"API_KEY": "${api_key}", "MODE": "fast"}},
+		ConfigurationSchema: &ConfigurationSchema{Secrets: map[string]SecretSchema{
+			"api_key": {EnvVar: "API_KEY", Targets: map[string]string{"vscode": "VSCODE_API_KEY", "db": "DB_API_KEY"}},
+			"token":   {EnvVar: "TOKEN", Targets: map[string]string{"vscode": "GH_TOKEN"}},
+		}},
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		want   map[string]string
+	}{
+		{name: "unmapped target", target: "cursor", want: map[string]string{"API_KEY": "${api_key}", "MODE": "fast"}},
+		{
+			name:   "mapped target",
+			target: "vscode",
+			want:   map[string]string{"VSCODE_API_KEY": "${api_key}", "GH_TOKEN": "${token}", "MODE": "fast"},
+		},
+		{name: "one secret mapped", target: "db", want: map[string]string{"DB_API_KEY": "${api_key}", "MODE": "fast"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := def.TargetEnvironment(tt.target, def.Server.Environment); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TargetEnvironment(%s) = %v, want %v", tt.target, got, tt.want)
+			}
+		})
+	}
+
+	client := def.ForClient("vscode")
+	if _, ok := client.Server.Environment["GH_TOKEN"]; !ok {
+		t.Errorf("ForClient() environment = %v, want GH_TOKEN", client.Server.Environment)
+	}
+	if _, ok := def.Server.Environment["API_KEY"]; !ok || len(def.Server.Environment) != 2 {
+		t.Errorf("ForClient() modified the manifest: %v", def.Server.Environment)
+	}
+}
+
+func TestServiceDependency_BooleanFlags(t *testing.T) {
+	var service ServiceDependency
+	if err := yaml.Unmarshal([]byte("image: redis:7\ninit: true\ntty: true\nstdin_open: true\n"), &service); err != nil {
+		t.Fatalf("Failed to parse service: %v", err)
+	}
+	if !service.Init || !service.TTY || !service.StdinOpen {
+		t.Errorf("Expected init, tty and stdin_open to be set, got %+v", service)
+	}
+
+	for _, field := range []string{"init: 1", "tty: [true]", "stdin_open: {}"} {
+		if err := yaml.Unmarshal([]byte("image: redis:7\n"+field+"\n"), &ServiceDependency{}); err == nil {
+			t.Errorf("Expected %q to be rejected as a non-boolean", field)
+		}
+	}
+}
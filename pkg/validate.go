@@ -0,0 +1,768 @@
+package pkg
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Validate checks the definition against the .servo file specification and returns
+// the first problem found. Definitions using the legacy metadata layout should be
+// normalized first.
+func (s *ServoDefinition) Validate() error {
+	if s == nil {
+		return fmt.Errorf("servo definition cannot be nil")
+	}
+
+	// Validate servo version, and that the file only uses fields that version has
+	if err := validateServoVersion(s.ServoVersion); err != nil {
+		return err
+	}
+	if err := validateVersionedFields(s, s.ServoVersion); err != nil {
+		return err
+	}
+
+	// Validate required top-level fields
+	if err := validateTopLevelFields(s); err != nil {
+		return err
+	}
+
+	// Validate optional metadata
+	if s.Metadata != nil {
+		if err := validateMetadata(s.Metadata); err != nil {
+			return err
+		}
+	}
+
+	// Validate requirements
+	if s.Requirements != nil {
+		if err := validateRequirements(s.Requirements); err != nil {
+			return err
+		}
+	}
+
+	// Validate install section
+	if err := validateInstall(&s.Install); err != nil {
+		return err
+	}
+
+	// Validate services from dependencies.services and the top-level services alike
+	if err := validateServices(s.AllServices()); err != nil {
+		return err
+	}
+
+	// Validate configuration schema
+	if s.ConfigurationSchema != nil {
+		if err := validateConfigurationSchema(s.ConfigurationSchema); err != nil {
+			return err
+		}
+	}
+
+	// Validate server section
+	if err := validateServer(&s.Server); err != nil {
+		return err
+	}
+
+	// Validate clients section
+	if s.Clients != nil {
+		if err := validateClients(s.Clients); err != nil {
+			return err
+		}
+	}
+
+	// Validate capabilities advertisement
+	if s.Capabilities != nil {
+		if err := validateCapabilities(s.Capabilities); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateAs validates the definition under the given servo_version schema instead of
+// the version the file declares. The version must itself be supported.
+func (s *ServoDefinition) ValidateAs(version string) error {
+	if s == nil {
+		return fmt.Errorf("servo definition cannot be nil")
+	}
+	if err := validateServoVersion(version); err != nil {
+		return err
+	}
+
+	forced := *s
+	forced.ServoVersion = version
+	return forced.Validate()
+}
+
+// MaxPostInstallMessageLength bounds the message printed after a successful install
+const MaxPostInstallMessageLength = 1000
+
+// SupportedServoVersions are the servo_version schemas this release validates, oldest
+// first
+var SupportedServoVersions = []string{"1.0", "1.1"}
+
+// versionedField is a manifest field added after servo_version 1.0. Used returns the
+// paths in a definition that set it.
+type versionedField struct {
+	since string
+	field string
+	used  func(s *ServoDefinition) []string
+}
+
+// versionedFields lists, by the servo_version that introduced them, the fields a
+// manifest declaring an older version may not use
+var versionedFields = []versionedField{
+	{since: "1.1", field: "id", used: func(s *ServoDefinition) []string {
+		return pathIf(s.ID != "", "id")
+	}},
+	{since: "1.1", field: "depends_on", used: func(s *ServoDefinition) []string {
+		return pathIf(len(s.DependsOn) > 0, "depends_on")
+	}},
+	{since: "1.1", field: "capabilities", used: func(s *ServoDefinition) []string {
+		return pathIf(s.Capabilities != nil, "capabilities")
+	}},
+	{since: "1.1", field: "services.*.init", used: func(s *ServoDefinition) []string {
+		return servicePaths(s, "init", func(service *ServiceDependency) bool { return service.Init })
+	}},
+	{since: "1.1", field: "services.*.tty", used: func(s *ServoDefinition) []string {
+		return servicePaths(s, "tty", func(service *ServiceDependency) bool { return service.TTY })
+	}},
+	{since: "1.1", field: "services.*.stdin_open", used: func(s *ServoDefinition) []string {
+		return servicePaths(s, "stdin_open", func(service *ServiceDependency) bool { return service.StdinOpen })
+	}},
+	{since: "1.1", field: "configuration_schema.secrets.*.targets", used: func(s *ServoDefinition) []string {
+		if s.ConfigurationSchema == nil {
+			return nil
+		}
+		var paths []string
+		for name, secret := range s.ConfigurationSchema.Secrets {
+			if len(secret.Targets) > 0 {
+				paths = append(paths, "configuration_schema.secrets."+name+".targets")
+			}
+		}
+		sort.Strings(paths)
+		return paths
+	}},
+}
+
+// pathIf returns path as a one-element list when set is true
+func pathIf(set bool, path string) []string {
+	if !set {
+		return nil
+	}
+	return []string{path}
+}
+
+// servicePaths returns, sorted, services.<name>.<key> for each service where set holds
+func servicePaths(s *ServoDefinition, key string, set func(*ServiceDependency) bool) []string {
+	var paths []string
+	for name, service := range s.AllServices() {
+		if set(service) {
+			paths = append(paths, "services."+name+"."+key)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// servoVersionIndex returns a supported version's position in SupportedServoVersions
+func servoVersionIndex(version string) int {
+	for i, supported := range SupportedServoVersions {
+		if supported == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// validateVersionedFields rejects fields introduced after the given servo_version
+func validateVersionedFields(s *ServoDefinition, version string) error {
+	current := servoVersionIndex(version)
+	for _, f := range versionedFields {
+		if servoVersionIndex(f.since) <= current {
+			continue
+		}
+		if paths := f.used(s); len(paths) > 0 {
+			return fmt.Errorf("%s requires servo_version %s or later (validating under %s)", paths[0], f.since, version)
+		}
+	}
+	return nil
+}
+
+// validateServoVersion validates the servo_version field
+func validateServoVersion(version string) error {
+	if version == "" {
+		return fmt.Errorf("servo_version is required")
+	}
+
+	for _, validVersion := range SupportedServoVersions {
+		if version == validVersion {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported servo_version: %s, supported versions: %v", version, SupportedServoVersions)
+}
+
+// validateTopLevelFields validates the required and optional top-level fields
+func validateTopLevelFields(servo *ServoDefinition) error {
+	// Validate required name field
+	if servo.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	// Validate name format (lowercase, hyphens only)
+	nameRegex := regexp.MustCompile(`^[a-z][a-z0-9-]*[a-z0-9]$`)
+	if !nameRegex.MatchString(servo.Name) {
+		return fmt.Errorf("name must be lowercase with hyphens only: %s", servo.Name)
+	}
+
+	// Validate optional id field, which keys the installed manifest in place of the name
+	if servo.ID != "" && !nameRegex.MatchString(servo.ID) {
+		return fmt.Errorf("id must be lowercase with hyphens only: %s", servo.ID)
+	}
+
+	// Validate optional server dependencies, which name other servers by id or name
+	seen := make(map[string]bool, len(servo.DependsOn))
+	for _, server := range servo.DependsOn {
+		if !nameRegex.MatchString(server) {
+			return fmt.Errorf("depends_on entries must be server names in lowercase with hyphens only: %q", server)
+		}
+		if server == servo.Name || server == servo.ID {
+			return fmt.Errorf("depends_on cannot list the server itself: %s", server)
+		}
+		if seen[server] {
+			return fmt.Errorf("depends_on lists %s more than once", server)
+		}
+		seen[server] = true
+	}
+
+	// Validate optional version field if provided
+	if servo.Version != "" {
+		// Validate semantic version format
+		semverRegex := regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([a-zA-Z0-9\-\.]+))?(?:\+([a-zA-Z0-9\-\.]+))?$`)
+		if !semverRegex.MatchString(servo.Version) {
+			return fmt.Errorf("version must be valid semantic version: %s", servo.Version)
+		}
+	}
+
+	// Validate optional description field if provided
+	if servo.Description != "" && len(servo.Description) > 200 {
+		return fmt.Errorf("description must be 200 characters or less")
+	}
+
+	// Validate optional post-install message if provided
+	if servo.PostInstallMessage != "" {
+		if utf8.RuneCountInString(servo.PostInstallMessage) > MaxPostInstallMessageLength {
+			return fmt.Errorf("post_install_message must be %d characters or less", MaxPostInstallMessageLength)
+		}
+		for _, r := range servo.PostInstallMessage {
+			if unicode.IsControl(r) && r != '\n' && r != '\t' {
+				return fmt.Errorf("post_install_message must be plain text without control characters")
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateMetadata validates the optional metadata section
+func validateMetadata(metadata *Metadata) error {
+	// Validate URLs if provided
+	if metadata.Homepage != "" {
+		if _, err := url.Parse(metadata.Homepage); err != nil {
+			return fmt.Errorf("metadata.homepage must be valid URL: %w", err)
+		}
+	}
+
+	if metadata.Repository != "" {
+		if _, err := url.Parse(metadata.Repository); err != nil {
+			return fmt.Errorf("metadata.repository must be valid URL: %w", err)
+		}
+	}
+
+	// Validate tags
+	tagRegex := regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	for _, tag := range metadata.Tags {
+		if !tagRegex.MatchString(tag) {
+			return fmt.Errorf("invalid tag format: %s", tag)
+		}
+	}
+
+	return nil
+}
+
+// validateRequirements validates the requirements section
+func validateRequirements(req *Requirements) error {
+	// Validate system requirements
+	for _, sysReq := range req.System {
+		if sysReq.Name == "" {
+			return fmt.Errorf("system requirement name is required")
+		}
+		if sysReq.Description == "" {
+			return fmt.Errorf("system requirement description is required")
+		}
+		if sysReq.CheckCommand == "" {
+			return fmt.Errorf("system requirement check_command is required")
+		}
+		if err := validateCommand(sysReq.CheckCommand); err != nil {
+			return fmt.Errorf("unsafe check command for %s: %w", sysReq.Name, err)
+		}
+	}
+
+	// Validate runtime requirements
+	for _, runtime := range req.Runtimes {
+		if runtime.Name == "" {
+			return fmt.Errorf("runtime requirement name is required")
+		}
+		if runtime.Version == "" {
+			return fmt.Errorf("runtime requirement version is required")
+		}
+	}
+
+	return nil
+}
+
+// validateInstall validates the install section
+func validateInstall(install *Install) error {
+	if install.Type == "" {
+		return fmt.Errorf("install.type is required")
+	}
+
+	validTypes := []string{"git", "local", "file", "remote"}
+	validType := false
+	for _, vt := range validTypes {
+		if install.Type == vt {
+			validType = true
+			break
+		}
+	}
+	if !validType {
+		return fmt.Errorf("install.type must be one of: %v", validTypes)
+	}
+
+	if install.Method == "" {
+		return fmt.Errorf("install.method is required")
+	}
+
+	if install.Method != install.Type {
+		return fmt.Errorf("install.method must match install.type")
+	}
+
+	// Type-specific validations
+	if install.Type == "git" {
+		if install.Repository == "" {
+			return fmt.Errorf("install.repository is required for git type")
+		}
+		if _, err := url.Parse(install.Repository); err != nil {
+			return fmt.Errorf("install.repository must be valid URL: %w", err)
+		}
+	}
+
+	// A local or remote server may already be runnable; git and file sources
+	// always need installing
+	if len(install.SetupCommands) == 0 && (install.Type == "git" || install.Type == "file") {
+		return fmt.Errorf("install.setup_commands is required for %s type", install.Type)
+	}
+
+	// Validate commands for safety
+	for _, cmd := range install.SetupCommands {
+		if err := validateCommand(cmd); err != nil {
+			return fmt.Errorf("unsafe setup command: %w", err)
+		}
+	}
+
+	for _, cmd := range install.BuildCommands {
+		if err := validateCommand(cmd); err != nil {
+			return fmt.Errorf("unsafe build command: %w", err)
+		}
+	}
+
+	for _, cmd := range install.TestCommands {
+		if err := validateCommand(cmd); err != nil {
+			return fmt.Errorf("unsafe test command: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateDependencies validates the dependencies section
+func validateDependencies(deps *Dependencies) error {
+	return validateServices((&ServoDefinition{Dependencies: deps}).AllServices())
+}
+
+// validateServices validates a manifest's merged services, as returned by AllServices
+func validateServices(services map[string]*ServiceDependency) error {
+	for serviceName, service := range services {
+		if serviceName == "" {
+			return fmt.Errorf("service name cannot be empty")
+		}
+
+		if service.Image == "" {
+			return fmt.Errorf("service.image is required for service %s", serviceName)
+		}
+
+		// Validate Docker image format
+		if !isValidDockerImage(service.Image) {
+			return fmt.Errorf("invalid Docker image format: %s", service.Image)
+		}
+
+		// Validate ports
+		for _, port := range service.Ports {
+			if err := validatePort(port); err != nil {
+				return fmt.Errorf("invalid port for service %s: %w", serviceName, err)
+			}
+		}
+
+		// Validate health check
+		if service.HealthCheck != nil {
+			if err := validateHealthCheck(service.HealthCheck); err != nil {
+				return fmt.Errorf("invalid health check for service %s: %w", serviceName, err)
+			}
+		}
+
+		// Validate compose profile names
+		for _, profile := range service.Profiles {
+			if !profileNameRegex.MatchString(profile) {
+				return fmt.Errorf("invalid profile '%s' for service %s", profile, serviceName)
+			}
+		}
+
+		if service.User != "" && !serviceUserRegex.MatchString(service.User) {
+			return fmt.Errorf("invalid user '%s' for service %s: must be uid[:gid] or name[:group]", service.User, serviceName)
+		}
+
+		if entrypoint, err := service.EntrypointValue(); err != nil {
+			return fmt.Errorf("invalid entrypoint for service %s: %w", serviceName, err)
+		} else if command, ok := entrypoint.(string); ok {
+			if _, err := SplitCommandLine(command); err != nil {
+				return fmt.Errorf("invalid entrypoint for service %s: %w", serviceName, err)
+			}
+		}
+
+		for _, dep := range service.DependsOn {
+			if dep == "" {
+				return fmt.Errorf("depends_on entries cannot be empty for service %s", serviceName)
+			}
+			if dep == serviceName {
+				return fmt.Errorf("service %s cannot depend on itself", serviceName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateSecretTargets checks that each target of a secret is named and maps to a
+// valid environment variable name
+func validateSecretTargets(targets map[string]string) error {
+	names := make([]string, 0, len(targets))
+	for target := range targets {
+		names = append(names, target)
+	}
+	sort.Strings(names)
+
+	for _, target := range names {
+		if strings.TrimSpace(target) == "" {
+			return fmt.Errorf("targets: target name cannot be empty")
+		}
+		if !envVarNameRegex.MatchString(targets[target]) {
+			return fmt.Errorf("targets: %s: invalid env var name '%s' (use letters, digits and underscores, not starting with a digit)", target, targets[target])
+		}
+	}
+	return nil
+}
+
+// validateSecretTargetCollisions rejects two secrets that a target would read from
+// the same env var
+func validateSecretTargetCollisions(secrets map[string]SecretSchema) error {
+	secretNames := make([]string, 0, len(secrets))
+	seen := make(map[string]bool)
+	var targets []string
+	for name, secret := range secrets {
+		secretNames = append(secretNames, name)
+		for target := range secret.Targets {
+			if !seen[target] {
+				seen[target] = true
+				targets = append(targets, target)
+			}
+		}
+	}
+	sort.Strings(secretNames)
+	sort.Strings(targets)
+
+	for _, target := range targets {
+		owners := make(map[string]string)
+		for _, name := range secretNames {
+			envVar := secrets[name].EnvVarFor(target)
+			if other, taken := owners[envVar]; taken {
+				return fmt.Errorf("secrets %s and %s both map to env var %s for target %s", other, name, envVar, target)
+			}
+			owners[envVar] = name
+		}
+	}
+	return nil
+}
+
+// serviceUserRegex matches a container user as uid[:gid] or name[:group]
+var serviceUserRegex = regexp.MustCompile(`^([0-9]+|[a-zA-Z_][a-zA-Z0-9_.-]*)(:([0-9]+|[a-zA-Z_][a-zA-Z0-9_.-]*))?$`)
+
+// profileNameRegex matches the profile names docker compose accepts
+var profileNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// envVarNameRegex matches a portable environment variable name
+var envVarNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateConfigurationSchema validates the configuration_schema section
+func validateConfigurationSchema(schema *ConfigurationSchema) error {
+	// Validate secrets
+	for secretName, secret := range schema.Secrets {
+		if secret.Description == "" {
+			return fmt.Errorf("secret %s: description is required", secretName)
+		}
+		if secret.Type == "" {
+			return fmt.Errorf("secret %s: type is required", secretName)
+		}
+		if secret.EnvVar == "" {
+			return fmt.Errorf("secret %s: env_var is required", secretName)
+		}
+
+		validSecretTypes := []string{"api_key", "password", "certificate", "url"}
+		if !contains(validSecretTypes, secret.Type) {
+			return fmt.Errorf("secret %s: invalid type %s", secretName, secret.Type)
+		}
+
+		if err := validateSecretTargets(secret.Targets); err != nil {
+			return fmt.Errorf("secret %s: %w", secretName, err)
+		}
+	}
+	if err := validateSecretTargetCollisions(schema.Secrets); err != nil {
+		return err
+	}
+
+	// Validate config
+	for configName, config := range schema.Config {
+		if config.Description == "" {
+			return fmt.Errorf("config %s: description is required", configName)
+		}
+		if config.Type == "" {
+			return fmt.Errorf("config %s: type is required", configName)
+		}
+		if config.EnvVar == "" {
+			return fmt.Errorf("config %s: env_var is required", configName)
+		}
+
+		validConfigTypes := []string{"string", "integer", "boolean", "select", "multiselect", "file", "url"}
+		if !contains(validConfigTypes, config.Type) {
+			return fmt.Errorf("config %s: invalid type %s", configName, config.Type)
+		}
+
+		// Validate select type has options
+		if config.Type == "select" || config.Type == "multiselect" {
+			if len(config.Options) == 0 {
+				return fmt.Errorf("config %s: options required for %s type", configName, config.Type)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateServer validates the server section
+func validateServer(server *Server) error {
+	if server.Transport == "" {
+		return fmt.Errorf("server.transport is required")
+	}
+
+	validTransports := []string{"stdio", "sse", "http"}
+	if !contains(validTransports, server.Transport) {
+		return fmt.Errorf("server.transport must be one of: %v", validTransports)
+	}
+
+	// Remote transports connect to an endpoint instead of launching a process
+	if server.IsRemote() {
+		if server.URL == "" {
+			return fmt.Errorf("server.url is required for %s transport", server.Transport)
+		}
+
+		parsed, err := url.Parse(server.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("server.url must be a valid http(s) URL: %s", server.URL)
+		}
+
+		return nil
+	}
+
+	if server.Command == "" {
+		return fmt.Errorf("server.command is required")
+	}
+
+	// A shell-style command string such as "go run main.go" carries its own args
+	argv, err := server.Argv()
+	if err != nil {
+		return fmt.Errorf("server.command is not a valid command line: %w", err)
+	}
+	if len(argv) < 2 {
+		return fmt.Errorf("server.args is required")
+	}
+
+	if len(server.Headers) > 0 {
+		return fmt.Errorf("server.headers is only supported for http and sse transports")
+	}
+
+	return nil
+}
+
+// validateClients validates the clients section
+func validateClients(clients *ClientInfo) error {
+	// No specific validation needed for recommended/tested/excluded lists
+	// They're just informational
+
+	// Validate client requirements
+	for clientName, req := range clients.Requirements {
+		if req.MinimumVersion != "" {
+			// Basic semantic version check
+			semverRegex := regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)`)
+			if !semverRegex.MatchString(req.MinimumVersion) {
+				return fmt.Errorf("client %s: invalid minimum_version format", clientName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateCapabilities checks that each advertised capability list holds distinct,
+// non-empty names
+func validateCapabilities(capabilities *Capabilities) error {
+	lists := []struct {
+		field string
+		names []string
+	}{
+		{"tools", capabilities.Tools},
+		{"resources", capabilities.Resources},
+		{"prompts", capabilities.Prompts},
+	}
+
+	for _, list := range lists {
+		seen := make(map[string]bool, len(list.names))
+		for i, name := range list.names {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				return fmt.Errorf("capabilities.%s[%d] must not be empty", list.field, i)
+			}
+			if seen[name] {
+				return fmt.Errorf("capabilities.%s lists '%s' more than once", list.field, name)
+			}
+			seen[name] = true
+		}
+	}
+	return nil
+}
+
+// validateCommand validates that a command is safe to execute
+func validateCommand(cmd string) error {
+	// Basic safety checks - prevent obviously dangerous commands
+	dangerousPatterns := []string{
+		"rm -rf",
+		"sudo",
+		"su ",
+		"chmod 777",
+		"wget http://",
+		"curl http://",
+		"bash -c",
+		"sh -c",
+		"eval",
+		"exec",
+	}
+
+	cmdLower := strings.ToLower(cmd)
+	for _, pattern := range dangerousPatterns {
+		if strings.Contains(cmdLower, pattern) {
+			return fmt.Errorf("potentially dangerous command: %s", cmd)
+		}
+	}
+
+	return nil
+}
+
+// validatePort validates a port string
+func validatePort(port string) error {
+	portNum, err := strconv.Atoi(port)
+	if err != nil {
+		return fmt.Errorf("invalid port number: %s", port)
+	}
+
+	if portNum < 1 || portNum > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535: %d", portNum)
+	}
+
+	return nil
+}
+
+// validateHealthCheck validates a health check configuration
+func validateHealthCheck(hc *HealthCheck) error {
+	if len(hc.Test) == 0 {
+		return fmt.Errorf("healthcheck.test is required")
+	}
+
+	// Validate duration formats
+	if hc.Interval != "" && !isValidDuration(hc.Interval) {
+		return fmt.Errorf("invalid healthcheck interval: %s", hc.Interval)
+	}
+
+	if hc.Timeout != "" && !isValidDuration(hc.Timeout) {
+		return fmt.Errorf("invalid healthcheck timeout: %s", hc.Timeout)
+	}
+
+	if hc.Retries < 0 {
+		return fmt.Errorf("healthcheck retries cannot be negative: %d", hc.Retries)
+	}
+
+	return nil
+}
+
+// isValidDockerImage checks if a string is a valid Docker image reference
+func isValidDockerImage(image string) bool {
+	// Basic validation - more comprehensive validation could be added
+	if image == "" {
+		return false
+	}
+
+	// Check for basic format: [registry/]name[:tag]
+	parts := strings.Split(image, ":")
+	if len(parts) > 2 {
+		return false
+	}
+
+	return true
+}
+
+// isValidDuration checks if a string is a valid duration format
+func isValidDuration(duration string) bool {
+	durationRegex := regexp.MustCompile(`^\d+[smh]$`)
+	return durationRegex.MatchString(duration)
+}
+
+// contains checks if a slice contains a string
+func contains(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateCommandSafety applies the setup command safety rules to a command
+// that servo is about to execute, such as a system requirement check_command
+func ValidateCommandSafety(cmd string) error {
+	return validateCommand(cmd)
+}
@@ -458,8 +458,7 @@ services:
 				expectedCommands := []string{
 					"mkdir -p /workspace/.servo/services/persistent-test/database",
 					"mkdir -p /workspace/.servo/services/persistent-test/cache",
-					"mkdir -p /workspace/.servo/logs/persistent-test/database",
-					"mkdir -p /workspace/.servo/logs/persistent-test/cache",
+					"mkdir -p /workspace/.servo/logs/persistent-test/database/var-log",
 				}
 
 				for _, expectedCmd := range expectedCommands {
@@ -469,6 +468,11 @@ services:
 						t.Logf("✅ Persistence directory command found: %s", expectedCmd)
 					}
 				}
+
+				// cache has no log volume, so no log directory is created for it
+				if strings.Contains(onCreateCmdStr, "mkdir -p /workspace/.servo/logs/persistent-test/cache") {
+					t.Error("Unexpected log directory command for service without a log volume")
+				}
 			} else {
 				t.Error("onCreateCommand not found in devcontainer.json")
 			}
@@ -487,7 +491,7 @@ services:
 	// Check volume mappings point to .servo directories
 	expectedMappings := []string{
 		"../.servo/services/persistent-test/database/db_data:/var/lib/postgresql/data",
-		"../.servo/services/persistent-test/database/db_logs:/var/log",
+		"../.servo/logs/persistent-test/database/var-log:/var/log",
 		"../.servo/services/persistent-test/cache/redis_data:/data",
 	}
 
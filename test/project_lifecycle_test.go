@@ -321,6 +321,7 @@ clients:
 func validateSecretHandling(t *testing.T, tempDir string) {
 	configFiles := []string{
 		".vscode/mcp.json",
+		".vscode/mcp.env",
 		".mcp.json",
 		".devcontainer/docker-compose.yml",
 	}